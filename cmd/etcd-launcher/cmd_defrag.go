@@ -17,17 +17,21 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"k8c.io/kubermatic/v2/cmd/etcd-launcher/pkg/etcd"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 )
 
 type defragOptions struct {
 	options
+
+	fragmentationThreshold int
+	minimumDBSize          int64
 }
 
 func DefragCommand(log *zap.SugaredLogger) *cobra.Command {
@@ -35,16 +39,23 @@ func DefragCommand(log *zap.SugaredLogger) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:          "defrag",
-		Short:        "Run defragmentation on all etcds in a etcd cluster in sequence",
+		Short:        "Run defragmentation on all fragmented etcds in a etcd cluster in sequence",
 		RunE:         DefragFunc(log, &opt),
 		SilenceUsage: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.CopyInto(&opt.options)
 
+			if opt.fragmentationThreshold < 0 || opt.fragmentationThreshold > 100 {
+				return errors.New("--fragmentation-threshold must be between 0 and 100")
+			}
+
 			return nil
 		},
 	}
 
+	cmd.PersistentFlags().IntVar(&opt.fragmentationThreshold, "fragmentation-threshold", kubermaticv1.DefaultEtcdDefragmentationThreshold, "minimum percentage of unused database space for a member to be defragmented")
+	cmd.PersistentFlags().Int64Var(&opt.minimumDBSize, "minimum-db-size", 0, "database size in bytes below which members are not defragmented")
+
 	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
 		if err := c.Usage(); err != nil {
 			return err
@@ -79,23 +90,14 @@ func DefragFunc(log *zap.SugaredLogger, opt *defragOptions) cobraFuncE {
 			return fmt.Errorf("failed to set expected cluster size: %w", err)
 		}
 
-		client, err := e.GetEtcdClient(ctx, log)
-		if err != nil {
-			return fmt.Errorf("failed to get etcd cluster client: %w", err)
-		}
-
-		for _, endpoint := range client.Endpoints() {
-			_, err := client.Defragment(ctx, endpoint)
-			if err != nil {
-				return fmt.Errorf("failed to defragment %s: %w", endpoint, err)
-			}
-
-			log.Infow("defragmented etcd member", "endpoint", endpoint)
-
-			time.Sleep(5 * time.Second)
+		if err := e.Defragment(ctx, log, etcd.DefragmentationOptions{
+			FragmentationThreshold: opt.fragmentationThreshold,
+			MinimumDBSize:          opt.minimumDBSize,
+		}); err != nil {
+			return err
 		}
 
-		log.Info("finished defragmentation on all members")
+		log.Info("finished defragmentation")
 
 		return nil
 	})
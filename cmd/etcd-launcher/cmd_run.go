@@ -42,7 +42,9 @@ type runOptions struct {
 	token   string
 	dataDir string
 
-	enableCorruptionCheck bool
+	enableCorruptionCheck   bool
+	learnerPromotionTimeout time.Duration
+	quotaBackendBytes       int64
 }
 
 func RunCommand(logger *zap.SugaredLogger) *cobra.Command {
@@ -87,6 +89,8 @@ func RunCommand(logger *zap.SugaredLogger) *cobra.Command {
 	cmd.PersistentFlags().StringVar(&opt.podIP, "pod-ip", "", "IP address of this etcd pod")
 	cmd.PersistentFlags().StringVar(&opt.token, "token", "", "etcd database token")
 	cmd.PersistentFlags().BoolVar(&opt.enableCorruptionCheck, "enable-corruption-check", false, "enable experimental corruption check")
+	cmd.PersistentFlags().Int64Var(&opt.quotaBackendBytes, "quota-backend-bytes", 0, "maximum size of the etcd database in bytes, 0 uses etcd's default")
+	cmd.PersistentFlags().DurationVar(&opt.learnerPromotionTimeout, "learner-promotion-timeout", 10*time.Minute, "time as a Go duration after which a learner that has not been promoted to a voting member is reported as failed")
 
 	return cmd
 }
@@ -108,6 +112,7 @@ func RunFunc(log *zap.SugaredLogger, opt *runOptions) cobraFuncE {
 			DataDir:               opt.dataDir,
 			Token:                 opt.token,
 			EnableCorruptionCheck: opt.enableCorruptionCheck,
+			QuotaBackendBytes:     opt.quotaBackendBytes,
 		}
 
 		ctx := cmd.Context()
@@ -133,6 +138,15 @@ func RunFunc(log *zap.SugaredLogger, opt *runOptions) cobraFuncE {
 		e.SetInitialMembers(ctx, log)
 		e.LogInitialState(log)
 
+		// a member that has been removed to scale down must not rejoin the cluster
+		if removed, err := e.IsRemoved(ctx, log); err != nil {
+			log.Warnw("failed to check if member has been removed", zap.Error(err))
+		} else if removed {
+			log.Info("member has been removed from the cluster to scale down, waiting for the Pod to be deleted")
+			<-ctx.Done()
+			return nil
+		}
+
 		if e.Exists() {
 			// if the cluster already exists, try to connect and update peer URLs that might be out of sync.
 			// etcd might fail to start if peer URLs in the etcd member state and the flags passed to it are different.
@@ -187,6 +201,19 @@ func RunFunc(log *zap.SugaredLogger, opt *runOptions) cobraFuncE {
 				} else if _, err := e.DeleteUnwantedDeadMembers(ctx, log); err != nil {
 					log.Warnw("failed to remove dead members", zap.Error(err))
 				}
+
+				// members join the cluster as learners and are promoted once they have caught up
+				if err := e.PromoteLearner(ctx, log, opt.learnerPromotionTimeout); err != nil {
+					log.Warnw("failed to promote learner", zap.Error(err))
+				}
+
+				if err := e.RemoveScaledDownMember(ctx, log); err != nil {
+					log.Warnw("failed to remove member to scale down", zap.Error(err))
+				}
+
+				if err := e.RecoverFromNoSpaceAlarm(ctx, log); err != nil {
+					log.Warnw("failed to recover from NOSPACE alarm", zap.Error(err))
+				}
 			}, 30*time.Second)
 		}()
 
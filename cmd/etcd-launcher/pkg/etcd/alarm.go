@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	client "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+)
+
+const (
+	// defaultQuotaBackendBytes is etcd's built-in space quota, used when no quota is configured.
+	defaultQuotaBackendBytes = 2 * 1024 * 1024 * 1024
+
+	// noSpaceRecoveryRetention is how long a successful NOSPACE recovery is reported on the
+	// Pod before the report is cleared.
+	noSpaceRecoveryRetention = 10 * time.Minute
+)
+
+// RecoverFromNoSpaceAlarm checks whether etcd has raised a NOSPACE alarm because the database
+// exceeded its space quota. If so, the keyspace is compacted, all members are defragmented
+// and the alarm is disarmed, so etcd accepts writes again. Only the leader performs the
+// recovery; the outcome is reported on its Pod. Once no alarm is active anymore, every
+// member clears its report, a successful recovery after noSpaceRecoveryRetention.
+func (e *Cluster) RecoverFromNoSpaceAlarm(ctx context.Context, log *zap.SugaredLogger) error {
+	client, err := e.GetEtcdClient(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to get etcd cluster client: %w", err)
+	}
+	defer closeClient(client, log)
+
+	resp, err := client.AlarmList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list alarms: %w", err)
+	}
+
+	alarms := noSpaceAlarms(resp.Alarms)
+	if len(alarms) == 0 {
+		if !e.noSpaceRecoveredAt.IsZero() && time.Since(e.noSpaceRecoveredAt) < noSpaceRecoveryRetention {
+			return nil
+		}
+
+		e.noSpaceRecoveredAt = time.Time{}
+		if err := e.removePodAnnotation(ctx, resources.EtcdNoSpaceRecoveryAnnotation); err != nil {
+			return fmt.Errorf("failed to clear NOSPACE recovery report: %w", err)
+		}
+
+		return nil
+	}
+
+	leader, err := e.isLeader(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to check if member is leader: %w", err)
+	}
+
+	if !leader {
+		return nil
+	}
+
+	log.Warnw("etcd database exceeded its space quota, recovering", "members", len(alarms))
+
+	if err := e.recoverFromNoSpace(ctx, log, client, alarms); err != nil {
+		e.reportNoSpaceRecovery(ctx, log, resources.EtcdNoSpaceRecoveryFailed)
+		return err
+	}
+
+	log.Info("recovered etcd from NOSPACE alarm")
+	e.noSpaceRecoveredAt = time.Now()
+	e.reportNoSpaceRecovery(ctx, log, resources.EtcdNoSpaceRecoverySucceeded)
+
+	return nil
+}
+
+func (e *Cluster) recoverFromNoSpace(ctx context.Context, log *zap.SugaredLogger, c *client.Client, alarms []*etcdserverpb.AlarmMember) error {
+	status, err := c.Status(ctx, e.ownEndpoint())
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	// compacting to the current revision drops all superseded key revisions
+	if _, err := c.Compact(ctx, status.Header.Revision, client.WithCompactPhysical()); err != nil {
+		return fmt.Errorf("failed to compact revision %d: %w", status.Header.Revision, err)
+	}
+
+	quota := e.QuotaBackendBytes
+	if quota <= 0 {
+		quota = defaultQuotaBackendBytes
+	}
+
+	// the regular health checks would fail as long as the alarm is active, so members are
+	// defragmented back to back, the leader (this member) last
+	for _, endpoint := range e.defragmentationOrder(c.Endpoints()) {
+		if _, err := c.Defragment(ctx, endpoint); err != nil {
+			return fmt.Errorf("failed to defragment %s: %w", endpoint, err)
+		}
+
+		status, err := c.Status(ctx, endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to get status of %s: %w", endpoint, err)
+		}
+
+		log.Infow("defragmented etcd member", "endpoint", endpoint, "db-size", status.DbSize, "quota", quota)
+
+		// disarming the alarm would be pointless, as etcd would immediately raise it again
+		if status.DbSize >= quota {
+			return fmt.Errorf("database size of %s (%d bytes) still exceeds the quota (%d bytes) after defragmentation", endpoint, status.DbSize, quota)
+		}
+	}
+
+	for _, alarm := range alarms {
+		if _, err := c.AlarmDisarm(ctx, (*client.AlarmMember)(alarm)); err != nil {
+			return fmt.Errorf("failed to disarm alarm of member %x: %w", alarm.MemberID, err)
+		}
+	}
+
+	return nil
+}
+
+// defragmentationOrder moves this member's endpoint to the end of the list.
+func (e *Cluster) defragmentationOrder(endpoints []string) []string {
+	own := e.ownEndpoint()
+	ordered := []string{}
+
+	for _, endpoint := range endpoints {
+		if endpoint != own {
+			ordered = append(ordered, endpoint)
+		}
+	}
+
+	if len(ordered) < len(endpoints) {
+		ordered = append(ordered, own)
+	}
+
+	return ordered
+}
+
+func (e *Cluster) ownEndpoint() string {
+	return fmt.Sprintf("https://%s.etcd.%s.svc.cluster.local:2379", e.PodName, e.namespace)
+}
+
+// reportNoSpaceRecovery annotates this member's Pod with the outcome of a NOSPACE recovery.
+// Failures are only logged, as the outcome is purely informational.
+func (e *Cluster) reportNoSpaceRecovery(ctx context.Context, log *zap.SugaredLogger, outcome string) {
+	if err := e.setPodAnnotation(ctx, resources.EtcdNoSpaceRecoveryAnnotation, outcome); err != nil {
+		log.Warnw("failed to report NOSPACE recovery", "outcome", outcome, zap.Error(err))
+	}
+}
+
+func noSpaceAlarms(alarms []*etcdserverpb.AlarmMember) []*etcdserverpb.AlarmMember {
+	result := []*etcdserverpb.AlarmMember{}
+
+	for _, alarm := range alarms {
+		if alarm.Alarm == etcdserverpb.AlarmType_NOSPACE {
+			result = append(result, alarm)
+		}
+	}
+
+	return result
+}
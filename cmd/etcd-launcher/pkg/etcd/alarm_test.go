@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestNoSpaceAlarms(t *testing.T) {
+	alarms := []*etcdserverpb.AlarmMember{
+		{MemberID: 1, Alarm: etcdserverpb.AlarmType_NOSPACE},
+		{MemberID: 2, Alarm: etcdserverpb.AlarmType_CORRUPT},
+		{MemberID: 3, Alarm: etcdserverpb.AlarmType_NOSPACE},
+	}
+
+	result := noSpaceAlarms(alarms)
+	if len(result) != 2 || result[0].MemberID != 1 || result[1].MemberID != 3 {
+		t.Fatalf("Expected NOSPACE alarms of members 1 and 3, got %v", result)
+	}
+
+	if result := noSpaceAlarms(nil); len(result) != 0 {
+		t.Fatalf("Expected no alarms, got %v", result)
+	}
+}
+
+func TestDefragmentationOrder(t *testing.T) {
+	e := &Cluster{PodName: "etcd-1", namespace: "cluster-test"}
+
+	endpoints := clientEndpoints(3, "cluster-test")
+	expected := []string{endpoints[0], endpoints[2], endpoints[1]}
+
+	result := e.defragmentationOrder(endpoints)
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+	}
+}
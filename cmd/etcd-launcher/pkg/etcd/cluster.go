@@ -72,6 +72,7 @@ type Cluster struct {
 	DataDir               string
 	Token                 string
 	EnableCorruptionCheck bool
+	QuotaBackendBytes     int64
 
 	clusterClient ctrlruntimeclient.Client
 	namespace     string // filled in later during init()
@@ -80,6 +81,11 @@ type Cluster struct {
 	initialMembers []string
 	usePeerTLSOnly bool
 	clusterSize    int
+
+	// learnerSince is the time this member was first seen as a learner.
+	learnerSince time.Time
+	// noSpaceRecoveredAt is the time this member recovered etcd from a NOSPACE alarm.
+	noSpaceRecoveredAt time.Time
 }
 
 func (e *Cluster) Init(ctx context.Context) (*kubermaticv1.Cluster, error) {
@@ -160,6 +166,18 @@ func (e *Cluster) SetClusterSize(ctx context.Context) error {
 	if sts.Spec.Replicas != nil {
 		e.clusterSize = int(*sts.Spec.Replicas)
 	}
+
+	// while scaling down, the removed member is no longer part of the cluster,
+	// even though its Pod might still be shutting down
+	cluster, err := e.KubermaticCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	if status := cluster.Status.Etcd; status != nil && status.ResizePhase == kubermaticv1.EtcdResizePhaseScalingDown && int(status.Size) < e.clusterSize {
+		e.clusterSize = int(status.Size)
+	}
+
 	return nil
 }
 
@@ -231,14 +249,16 @@ func (e *Cluster) JoinCluster(ctx context.Context, log *zap.SugaredLogger) error
 	ctx, cancelFunc := context.WithTimeout(ctx, timeoutAddMember)
 	defer cancelFunc()
 
-	if _, err := client.MemberAdd(ctx, peerURLs); err != nil {
+	// join as a non-voting learner, so that a slowly syncing member does not count
+	// towards the quorum; the learner is promoted once it has caught up with the leader.
+	if _, err := client.MemberAddAsLearner(ctx, peerURLs); err != nil {
 		closeClient(client, log)
-		return fmt.Errorf("add itself as a member: %w", err)
+		return fmt.Errorf("add itself as a learner member: %w", err)
 	}
 
 	defer closeClient(client, log)
 
-	log.Info("joined etcd cluster successfully.")
+	log.Info("joined etcd cluster successfully as learner.")
 	return nil
 }
 
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	client "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/util/wait"
+)
+
+const (
+	timeoutMemberStatus   = time.Second * 5
+	timeoutHealthyMembers = time.Minute
+)
+
+// DefragmentationOptions controls which members are defragmented.
+type DefragmentationOptions struct {
+	// FragmentationThreshold is the percentage of a member's database that must be
+	// unused before the member is defragmented.
+	FragmentationThreshold int
+	// MinimumDBSize is the database size in bytes below which members are never
+	// defragmented.
+	MinimumDBSize int64
+}
+
+type memberStatus struct {
+	endpoint    string
+	leader      bool
+	dbSize      int64
+	dbSizeInUse int64
+}
+
+// fragmentation returns the percentage of the database that is allocated, but not in use.
+func (m memberStatus) fragmentation() float64 {
+	if m.dbSize <= 0 {
+		return 0
+	}
+
+	return float64(m.dbSize-m.dbSizeInUse) / float64(m.dbSize) * 100
+}
+
+// Defragment defragments all members whose fragmentation exceeds the configured threshold,
+// one member at a time and the leader last. Before every member, the cluster's health is
+// checked and defragmentation is aborted if any member is unhealthy.
+func (e *Cluster) Defragment(ctx context.Context, log *zap.SugaredLogger, opts DefragmentationOptions) error {
+	client, err := e.GetEtcdClient(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to get etcd cluster client: %w", err)
+	}
+	defer closeClient(client, log)
+
+	statuses, err := memberStatuses(ctx, client)
+	if err != nil {
+		return fmt.Errorf("cluster is not healthy: %w", err)
+	}
+
+	for _, member := range statuses {
+		log.Infow("etcd member status",
+			"endpoint", member.endpoint,
+			"leader", member.leader,
+			"db-size", member.dbSize,
+			"db-size-in-use", member.dbSizeInUse,
+			"fragmentation", fmt.Sprintf("%.1f%%", member.fragmentation()),
+		)
+	}
+
+	candidates := defragmentationCandidates(statuses, opts)
+	if len(candidates) == 0 {
+		log.Infow("no member exceeds the fragmentation threshold", "threshold", opts.FragmentationThreshold)
+		return nil
+	}
+
+	for i, member := range candidates {
+		// the previous member was blocked while being defragmented, so give it some time to
+		// catch up before putting the next member out of service
+		if i > 0 {
+			if err := waitForHealthyMembers(ctx, log, client); err != nil {
+				return fmt.Errorf("aborting defragmentation before %s, cluster is not healthy: %w", member.endpoint, err)
+			}
+		}
+
+		if _, err := client.Defragment(ctx, member.endpoint); err != nil {
+			return fmt.Errorf("failed to defragment %s: %w", member.endpoint, err)
+		}
+
+		log.Infow("defragmented etcd member", "endpoint", member.endpoint, "leader", member.leader)
+	}
+
+	if err := waitForHealthyMembers(ctx, log, client); err != nil {
+		return fmt.Errorf("cluster is not healthy after defragmentation: %w", err)
+	}
+
+	return nil
+}
+
+// defragmentationCandidates returns the members that need to be defragmented, in the order
+// in which they should be defragmented; the leader always comes last to avoid unnecessary
+// leader elections while other members are blocked.
+func defragmentationCandidates(statuses []memberStatus, opts DefragmentationOptions) []memberStatus {
+	candidates := []memberStatus{}
+
+	for _, member := range statuses {
+		if member.dbSize < opts.MinimumDBSize {
+			continue
+		}
+
+		if member.fragmentation() < float64(opts.FragmentationThreshold) {
+			continue
+		}
+
+		candidates = append(candidates, member)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return !candidates[i].leader && candidates[j].leader
+	})
+
+	return candidates
+}
+
+// memberStatuses fetches the status of every member and returns an error if any member
+// is unreachable, has no leader or reports errors. Active alarms are reported as errors
+// by etcd as well, but are ignored here, as defragmentation is required to recover from
+// a NOSPACE alarm.
+func memberStatuses(ctx context.Context, client *client.Client) ([]memberStatus, error) {
+	statuses := []memberStatus{}
+
+	for _, endpoint := range client.Endpoints() {
+		ctx, cancel := context.WithTimeout(ctx, timeoutMemberStatus)
+		resp, err := client.Status(ctx, endpoint)
+		cancel()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of %s: %w", endpoint, err)
+		}
+
+		if resp.Leader == 0 {
+			return nil, fmt.Errorf("%s has no leader", endpoint)
+		}
+
+		if errs := nonAlarmErrors(resp.Errors); len(errs) > 0 {
+			return nil, fmt.Errorf("%s reports errors: %v", endpoint, errs)
+		}
+
+		statuses = append(statuses, memberStatus{
+			endpoint:    endpoint,
+			leader:      resp.Header.MemberId == resp.Leader,
+			dbSize:      resp.DbSize,
+			dbSizeInUse: resp.DbSizeInUse,
+		})
+	}
+
+	if len(statuses) == 0 {
+		return nil, errors.New("no etcd endpoints found")
+	}
+
+	return statuses, nil
+}
+
+// nonAlarmErrors filters out the alarms (e.g. "memberID:123 alarm:NOSPACE") from the
+// errors of a status response.
+func nonAlarmErrors(errs []string) []string {
+	result := []string{}
+
+	for _, err := range errs {
+		if !strings.Contains(err, "alarm:") {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+func waitForHealthyMembers(ctx context.Context, log *zap.SugaredLogger, client *client.Client) error {
+	return wait.PollImmediateLog(ctx, log, 5*time.Second, timeoutHealthyMembers, func(ctx context.Context) (error, error) {
+		_, err := memberStatuses(ctx, client)
+		return err, nil
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+)
+
+func TestDefragmentationCandidates(t *testing.T) {
+	statuses := []memberStatus{
+		{endpoint: "etcd-0", leader: true, dbSize: 1000, dbSizeInUse: 400},
+		{endpoint: "etcd-1", dbSize: 1000, dbSizeInUse: 900},
+		{endpoint: "etcd-2", dbSize: 1000, dbSizeInUse: 500},
+		{endpoint: "etcd-3", dbSize: 100, dbSizeInUse: 10},
+	}
+
+	testCases := []struct {
+		name     string
+		opts     DefragmentationOptions
+		expected []string
+	}{
+		{
+			name:     "leader is defragmented last",
+			opts:     DefragmentationOptions{FragmentationThreshold: 50},
+			expected: []string{"etcd-2", "etcd-3", "etcd-0"},
+		},
+		{
+			name:     "members below the threshold are skipped",
+			opts:     DefragmentationOptions{FragmentationThreshold: 70},
+			expected: []string{"etcd-3"},
+		},
+		{
+			name:     "small databases are skipped",
+			opts:     DefragmentationOptions{FragmentationThreshold: 50, MinimumDBSize: 500},
+			expected: []string{"etcd-2", "etcd-0"},
+		},
+		{
+			name:     "a threshold of zero defragments all members",
+			opts:     DefragmentationOptions{},
+			expected: []string{"etcd-1", "etcd-2", "etcd-3", "etcd-0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates := defragmentationCandidates(statuses, tc.opts)
+
+			endpoints := []string{}
+			for _, member := range candidates {
+				endpoints = append(endpoints, member.endpoint)
+			}
+
+			if len(endpoints) != len(tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, endpoints)
+			}
+
+			for i := range endpoints {
+				if endpoints[i] != tc.expected[i] {
+					t.Fatalf("Expected %v, got %v", tc.expected, endpoints)
+				}
+			}
+		})
+	}
+}
+
+func TestNonAlarmErrors(t *testing.T) {
+	errs := nonAlarmErrors([]string{
+		"memberID:8211f1d0f64f3269 alarm:NOSPACE ",
+		"etcdserver: no leader",
+		"memberID:91bc3c398fb3c146 alarm:CORRUPT ",
+	})
+
+	if len(errs) != 1 || errs[0] != "etcdserver: no leader" {
+		t.Fatalf("Expected only the non-alarm error, got %v", errs)
+	}
+
+	if errs := nonAlarmErrors(nil); len(errs) != 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+}
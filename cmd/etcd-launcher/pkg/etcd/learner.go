@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxLearnerRaftIndexLag is the number of raft entries a learner may lag behind
+	// the leader to be considered caught up.
+	maxLearnerRaftIndexLag = 1000
+
+	timeoutPromoteMember = time.Second * 15
+)
+
+// PromoteLearner promotes this member to a voting member if it is a learner that has
+// caught up with the leader. It only performs a single check and is meant to be called
+// periodically. The progress is reported on the member's Pod, from where it is picked up
+// into the Cluster's etcd health condition; a learner that has not been promoted within
+// the given timeout is reported as PromotionFailed, but promotion is still attempted.
+func (e *Cluster) PromoteLearner(ctx context.Context, log *zap.SugaredLogger, timeout time.Duration) error {
+	member, err := e.GetMemberByName(ctx, log, e.PodName)
+	if err != nil {
+		return fmt.Errorf("failed to get own member: %w", err)
+	}
+
+	if member == nil {
+		return errors.New("pod is not a cluster member")
+	}
+
+	if !member.IsLearner {
+		e.learnerSince = time.Time{}
+		e.reportMemberState(ctx, log, resources.EtcdMemberStateVoter)
+		return nil
+	}
+
+	log = log.With("member-id", member.ID)
+
+	if e.learnerSince.IsZero() {
+		log.Info("member is a learner, waiting for it to catch up with the leader")
+		e.learnerSince = time.Now()
+	}
+
+	e.reportMemberState(ctx, log, learnerState(e.learnerSince, time.Now(), timeout))
+
+	learnerIndex, leaderIndex, err := e.raftIndexes(ctx, log)
+	if err != nil {
+		return err
+	}
+
+	if !caughtUp(learnerIndex, leaderIndex) {
+		log.Debugw("learner has not caught up yet", "learner-index", learnerIndex, "leader-index", leaderIndex)
+		return nil
+	}
+
+	client, err := e.GetEtcdClient(ctx, log)
+	if err != nil {
+		return err
+	}
+	defer closeClient(client, log)
+
+	promoteCtx, cancel := context.WithTimeout(ctx, timeoutPromoteMember)
+	defer cancel()
+
+	// etcd itself refuses to promote learners that are not yet in sync with the leader
+	// (rpctypes.ErrMemberLearnerNotReady), which is retried during the next check
+	if _, err := client.MemberPromote(promoteCtx, member.ID); err != nil {
+		return fmt.Errorf("failed to promote learner: %w", err)
+	}
+
+	log.Info("learner has been promoted to a voting member")
+	e.learnerSince = time.Time{}
+	e.reportMemberState(ctx, log, resources.EtcdMemberStateVoter)
+
+	return nil
+}
+
+// caughtUp returns whether a learner at the given raft index is close enough to the
+// leader to be promoted.
+func caughtUp(learnerIndex, leaderIndex uint64) bool {
+	return learnerIndex+maxLearnerRaftIndexLag >= leaderIndex
+}
+
+// learnerState returns the state to report for a learner that has not been promoted yet.
+func learnerState(learnerSince, now time.Time, timeout time.Duration) string {
+	if now.Sub(learnerSince) > timeout {
+		return resources.EtcdMemberStatePromotionFailed
+	}
+
+	return resources.EtcdMemberStateLearner
+}
+
+// raftIndexes returns the raft index of this member and of the current leader.
+func (e *Cluster) raftIndexes(ctx context.Context, log *zap.SugaredLogger) (uint64, uint64, error) {
+	localClient, err := e.getLocalClient(ctx, log)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer closeClient(localClient, log)
+
+	statusCtx, cancel := context.WithTimeout(ctx, timeoutMemberStatus)
+	defer cancel()
+
+	local, err := localClient.Status(statusCtx, e.endpoint())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get member status: %w", err)
+	}
+
+	if local.Leader == 0 {
+		return 0, 0, errors.New("cluster has no leader")
+	}
+
+	members, err := e.listMembers(ctx, log)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	for _, member := range members {
+		if member.ID != local.Leader || len(member.ClientURLs) == 0 {
+			continue
+		}
+
+		// use the cluster FQDN endpoint, as the certificates do not include Pod IPs
+		leader, err := localClient.Status(statusCtx, member.ClientURLs[len(member.ClientURLs)-1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get leader status: %w", err)
+		}
+
+		return local.RaftAppliedIndex, leader.RaftIndex, nil
+	}
+
+	return 0, 0, fmt.Errorf("leader %d not found in member list", local.Leader)
+}
+
+// reportMemberState annotates this member's Pod with the given state. Failures are only
+// logged, as the state is purely informational.
+func (e *Cluster) reportMemberState(ctx context.Context, log *zap.SugaredLogger, state string) {
+	if err := e.setMemberState(ctx, state); err != nil {
+		log.Warnw("failed to report member state", "state", state, zap.Error(err))
+	}
+}
+
+func (e *Cluster) setMemberState(ctx context.Context, state string) error {
+	return e.setPodAnnotation(ctx, resources.EtcdMemberStateAnnotation, state)
+}
+
+func (e *Cluster) setPodAnnotation(ctx context.Context, key, value string) error {
+	pod, err := e.ownPod(ctx)
+	if err != nil {
+		return err
+	}
+
+	if pod.Annotations[key] == value {
+		return nil
+	}
+
+	oldPod := pod.DeepCopy()
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[key] = value
+
+	return e.clusterClient.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod))
+}
+
+func (e *Cluster) removePodAnnotation(ctx context.Context, key string) error {
+	pod, err := e.ownPod(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := pod.Annotations[key]; !ok {
+		return nil
+	}
+
+	oldPod := pod.DeepCopy()
+	delete(pod.Annotations, key)
+
+	return e.clusterClient.Patch(ctx, pod, ctrlruntimeclient.MergeFrom(oldPod))
+}
+
+func (e *Cluster) ownPod(ctx context.Context) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := e.clusterClient.Get(ctx, types.NamespacedName{Name: e.PodName, Namespace: e.namespace}, pod); err != nil {
+		return nil, fmt.Errorf("failed to get Pod: %w", err)
+	}
+
+	return pod, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+	"time"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+)
+
+func TestCaughtUp(t *testing.T) {
+	testCases := []struct {
+		name         string
+		learnerIndex uint64
+		leaderIndex  uint64
+		expected     bool
+	}{
+		{
+			name:         "learner is in sync",
+			learnerIndex: 5000,
+			leaderIndex:  5000,
+			expected:     true,
+		},
+		{
+			name:         "learner lags within the tolerance",
+			learnerIndex: 4000,
+			leaderIndex:  5000,
+			expected:     true,
+		},
+		{
+			name:         "learner lags too far behind",
+			learnerIndex: 3999,
+			leaderIndex:  5000,
+			expected:     false,
+		},
+		{
+			name:         "new learner has not received any entries",
+			learnerIndex: 0,
+			leaderIndex:  50000,
+			expected:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := caughtUp(tc.learnerIndex, tc.leaderIndex); result != tc.expected {
+				t.Fatalf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestLearnerState(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name         string
+		learnerSince time.Time
+		expected     string
+	}{
+		{
+			name:         "new learner",
+			learnerSince: now,
+			expected:     resources.EtcdMemberStateLearner,
+		},
+		{
+			name:         "learner within the timeout",
+			learnerSince: now.Add(-9 * time.Minute),
+			expected:     resources.EtcdMemberStateLearner,
+		},
+		{
+			name:         "learner exceeded the timeout",
+			learnerSince: now.Add(-11 * time.Minute),
+			expected:     resources.EtcdMemberStatePromotionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := learnerState(tc.learnerSince, now, 10*time.Minute); result != tc.expected {
+				t.Fatalf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+)
+
+// RemoveScaledDownMember removes this member from the etcd cluster if the cluster is being
+// scaled down and this is the member to be removed. This happens before the StatefulSet shrinks,
+// so that the etcd cluster never contains a member whose Pod is gone. Once removed, the member
+// is marked on its Pod, which signals the cluster controller to shrink the StatefulSet. If
+// marking fails (e.g. because etcd shuts down right after the removal), the member is marked
+// by IsRemoved when etcd-launcher restarts.
+func (e *Cluster) RemoveScaledDownMember(ctx context.Context, log *zap.SugaredLogger) error {
+	cluster, err := e.KubermaticCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	if !isScaleDownTarget(cluster, e.PodName) {
+		return nil
+	}
+
+	healthy, err := e.IsClusterHealthy(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to check cluster health: %w", err)
+	}
+
+	if !healthy {
+		return errors.New("cluster is not healthy, not removing member")
+	}
+
+	member, err := e.GetMemberByName(ctx, log, e.PodName)
+	if err != nil {
+		return fmt.Errorf("failed to get own member: %w", err)
+	}
+
+	if member == nil {
+		return e.markRemoved(ctx)
+	}
+
+	client, err := e.GetEtcdClient(ctx, log)
+	if err != nil {
+		return fmt.Errorf("can't find cluster client: %w", err)
+	}
+	defer closeClient(client, log)
+
+	removeCtx, cancelFunc := context.WithTimeout(ctx, timeoutRemoveMember)
+	defer cancelFunc()
+
+	if _, err := client.MemberRemove(removeCtx, member.ID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	log.Infow("removed member from the etcd cluster to scale down", "member-id", member.ID)
+
+	return e.markRemoved(ctx)
+}
+
+// IsRemoved returns true if this member has been removed from the etcd cluster
+// by scaling down. A member that is to be removed, but is no longer part of the
+// etcd cluster, is marked as removed.
+func (e *Cluster) IsRemoved(ctx context.Context, log *zap.SugaredLogger) (bool, error) {
+	pod, err := e.ownPod(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if pod.Annotations[resources.EtcdMemberStateAnnotation] == resources.EtcdMemberStateRemoved {
+		return true, nil
+	}
+
+	cluster, err := e.KubermaticCluster(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	if !isScaleDownTarget(cluster, e.PodName) {
+		return false, nil
+	}
+
+	member, err := e.GetMemberByName(ctx, log, e.PodName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get own member: %w", err)
+	}
+
+	if member != nil {
+		return false, nil
+	}
+
+	return true, e.markRemoved(ctx)
+}
+
+func (e *Cluster) markRemoved(ctx context.Context) error {
+	if err := e.setMemberState(ctx, resources.EtcdMemberStateRemoved); err != nil {
+		return fmt.Errorf("failed to mark member as removed: %w", err)
+	}
+
+	return nil
+}
+
+// isScaleDownTarget returns true if the cluster's etcd is being scaled down and the
+// given Pod's member is the one to be removed.
+func isScaleDownTarget(cluster *kubermaticv1.Cluster, podName string) bool {
+	status := cluster.Status.Etcd
+	if status == nil || status.ResizePhase != kubermaticv1.EtcdResizePhaseRemovingMember {
+		return false
+	}
+
+	return podName == fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, status.Size-1)
+}
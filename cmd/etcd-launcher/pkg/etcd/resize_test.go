@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+func TestIsScaleDownTarget(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   *kubermaticv1.ClusterEtcdStatus
+		podName  string
+		expected bool
+	}{
+		{
+			name:     "no etcd status",
+			podName:  "etcd-2",
+			expected: false,
+		},
+		{
+			name:     "cluster is scaling up",
+			status:   &kubermaticv1.ClusterEtcdStatus{Size: 3, TargetSize: 5, ResizePhase: kubermaticv1.EtcdResizePhaseScalingUp},
+			podName:  "etcd-2",
+			expected: false,
+		},
+		{
+			name:     "last member is removed",
+			status:   &kubermaticv1.ClusterEtcdStatus{Size: 3, TargetSize: 1, ResizePhase: kubermaticv1.EtcdResizePhaseRemovingMember},
+			podName:  "etcd-2",
+			expected: true,
+		},
+		{
+			name:     "other members are kept",
+			status:   &kubermaticv1.ClusterEtcdStatus{Size: 3, TargetSize: 1, ResizePhase: kubermaticv1.EtcdResizePhaseRemovingMember},
+			podName:  "etcd-1",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			cluster.Status.Etcd = tc.status
+
+			if result := isScaleDownTarget(cluster, tc.podName); result != tc.expected {
+				t.Fatalf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
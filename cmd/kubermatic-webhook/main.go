@@ -44,6 +44,7 @@ import (
 	kubermaticconfigurationvalidation "k8c.io/kubermatic/v2/pkg/webhook/kubermaticconfiguration/validation"
 	mlaadminsettingmutation "k8c.io/kubermatic/v2/pkg/webhook/mlaadminsetting/mutation"
 	policieswebhook "k8c.io/kubermatic/v2/pkg/webhook/policies"
+	projectvalidation "k8c.io/kubermatic/v2/pkg/webhook/project/validation"
 	resourcequotavalidation "k8c.io/kubermatic/v2/pkg/webhook/resourcequota/validation"
 	seedwebhook "k8c.io/kubermatic/v2/pkg/webhook/seed"
 	uservalidation "k8c.io/kubermatic/v2/pkg/webhook/user/validation"
@@ -196,6 +197,14 @@ func main() {
 		log.Fatalw("Failed to setup user validation webhook", zap.Error(err))
 	}
 
+	// /////////////////////////////////////////
+	// setup Project webhooks
+
+	projectValidator := projectvalidation.NewValidator()
+	if err := builder.WebhookManagedBy(mgr).For(&kubermaticv1.Project{}).WithValidator(projectValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup project validation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// setup Resource Quota webhooks
 
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/log"
+)
+
+// options mirror the flags of the metering tool, so that the cost report can be
+// generated by the same CronJob as the other metering reports.
+type options struct {
+	caBundleFile     string
+	prometheusAPI    string
+	outputDir        string
+	outputPrefix     string
+	outputFormat     string
+	lastMonth        bool
+	lastNumberOfDays int
+
+	s3Endpoint      string
+	s3Bucket        string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+func main() {
+	opts := options{}
+
+	logOpts := log.NewDefaultOptions()
+	logOpts.AddFlags(flag.CommandLine)
+
+	flag.StringVar(&opts.caBundleFile, "ca-bundle", "", "Filename of the CA bundle to use (if not given, default system certificates are used)")
+	flag.StringVar(&opts.prometheusAPI, "prometheus-api", "", "URL of the metering Prometheus")
+	flag.StringVar(&opts.outputDir, "output-dir", "", "Directory in the S3 bucket to write the report to")
+	flag.StringVar(&opts.outputPrefix, "output-prefix", "", "Prefix of the report file name")
+	flag.StringVar(&opts.outputFormat, "output-format", "csv", "Format of the report, csv or json")
+	flag.BoolVar(&opts.lastMonth, "last-month", false, "Report the previous calendar month")
+	flag.IntVar(&opts.lastNumberOfDays, "last-number-of-days", 7, "Number of days to report, ignored if --last-month is set")
+	flag.Parse()
+
+	opts.s3Endpoint = os.Getenv("S3_ENDPOINT")
+	opts.s3Bucket = os.Getenv("S3_BUCKET")
+	opts.accessKeyID = os.Getenv("ACCESS_KEY_ID")
+	opts.secretAccessKey = os.Getenv("SECRET_ACCESS_KEY")
+
+	rawLog := log.New(logOpts.Debug, logOpts.Format)
+	logger := rawLog.Sugar()
+
+	if opts.prometheusAPI == "" || opts.outputDir == "" {
+		logger.Fatal("Both --prometheus-api and --output-dir must be set.")
+	}
+
+	if opts.s3Endpoint == "" || opts.s3Bucket == "" || opts.accessKeyID == "" || opts.secretAccessKey == "" {
+		logger.Fatal("All of S3_ENDPOINT, S3_BUCKET, ACCESS_KEY_ID and SECRET_ACCESS_KEY must be set.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if err := run(ctx, logger, opts); err != nil {
+		logger.Fatalw("Failed to create cost report", zap.Error(err))
+	}
+}
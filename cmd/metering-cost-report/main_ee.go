@@ -0,0 +1,81 @@
+//go:build ee
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/ee/metering/cost"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/util/s3"
+)
+
+func run(ctx context.Context, logger *zap.SugaredLogger, opts options) error {
+	format := kubermaticv1.MeteringReportFormat(opts.outputFormat)
+	from, to := cost.Period(time.Now().UTC(), opts.lastMonth, opts.lastNumberOfDays)
+
+	promClient, err := promapi.NewClient(promapi.Config{Address: opts.prometheusAPI})
+	if err != nil {
+		return fmt.Errorf("failed to create Prometheus client: %w", err)
+	}
+
+	entries, err := cost.Query(ctx, promv1.NewAPI(promClient), from, to)
+	if err != nil {
+		return err
+	}
+
+	report := &bytes.Buffer{}
+	if err := cost.Write(report, format, entries); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	var certPool *x509.CertPool
+	if opts.caBundleFile != "" {
+		bundle, err := certificates.NewCABundleFromFile(opts.caBundleFile)
+		if err != nil {
+			return fmt.Errorf("failed to load CA bundle: %w", err)
+		}
+
+		certPool = bundle.CertPool()
+	}
+
+	minioClient, err := s3.NewClient(opts.s3Endpoint, opts.accessKeyID, opts.secretAccessKey, certPool)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	name := cost.ObjectName(opts.outputDir, opts.outputPrefix, format, from, to)
+	if _, err := minioClient.PutObject(ctx, opts.s3Bucket, name, report, int64(report.Len()), minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to upload report: %w", err)
+	}
+
+	logger.Infow("Uploaded cost report", "object", name, "clusters", len(entries))
+
+	return nil
+}
@@ -53,9 +53,10 @@ const (
 )
 
 var (
-	lbName      string
-	lbNamespace string
-	namespaced  bool
+	lbName        string
+	privateLBName string
+	lbNamespace   string
+	namespaced    bool
 )
 
 func main() {
@@ -64,6 +65,7 @@ func main() {
 	logOpts.AddFlags(flag.CommandLine)
 
 	flag.StringVar(&lbName, "lb-name", "nodeport-lb", "name of the LoadBalancer service to manage.")
+	flag.StringVar(&privateLBName, "private-lb-name", "", "name of the LoadBalancer service to manage for services of private clusters. If empty, these services are not exposed.")
 	flag.StringVar(&lbNamespace, "lb-namespace", "nodeport-proxy", "namespace of the LoadBalancer service to manage. Needs to exist")
 	flag.BoolVar(&namespaced, "namespaced", false, "Whether this controller should only watch services in the lbNamespace")
 	flag.StringVar(&opts.ExposeAnnotationKey, "expose-annotation-key", nodeportproxy.DefaultExposeAnnotationKey, "The annotation key used to determine if a Service should be exposed")
@@ -112,12 +114,13 @@ func main() {
 		}).
 		Watches(&corev1.Service{}, controllerutil.EnqueueConst("")).
 		Build(&LBUpdater{
-			client:      mgr.GetClient(),
-			lbNamespace: lbNamespace,
-			lbName:      lbName,
-			namespace:   namespace,
-			log:         log,
-			opts:        opts,
+			client:        mgr.GetClient(),
+			lbNamespace:   lbNamespace,
+			lbName:        lbName,
+			privateLBName: privateLBName,
+			namespace:     namespace,
+			log:           log,
+			opts:          opts,
 		})
 	if err != nil {
 		log.Fatalw("Failed to construct controller", zap.Error(err))
@@ -132,11 +135,12 @@ func main() {
 type LBUpdater struct {
 	client ctrlruntimeclient.Client
 
-	lbNamespace string
-	lbName      string
-	namespace   string
-	log         *zap.SugaredLogger
-	opts        envoymanager.Options
+	lbNamespace   string
+	lbName        string
+	privateLBName string
+	namespace     string
+	log           *zap.SugaredLogger
+	opts          envoymanager.Options
 }
 
 func (u *LBUpdater) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -156,13 +160,14 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 		return fmt.Errorf("failed to list services: %w", err)
 	}
 
-	var wantLBPorts []corev1.ServicePort
-	wantLBPorts = append(wantLBPorts, corev1.ServicePort{
+	healthCheck := corev1.ServicePort{
 		Name:       "healthz",
 		Port:       healthCheckPort,
 		TargetPort: intstr.FromInt(healthCheckPort),
 		Protocol:   corev1.ProtocolTCP,
-	})
+	}
+
+	wantLBPorts := []corev1.ServicePort{healthCheck}
 	if u.opts.IsSNIEnabled() {
 		wantLBPorts = append(wantLBPorts, corev1.ServicePort{
 			Name:       "sni-listener",
@@ -180,6 +185,9 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 		})
 	}
 
+	// services of private clusters are only exposed on the private LoadBalancer
+	wantPrivateLBPorts := []corev1.ServicePort{healthCheck}
+
 	for _, service := range services.Items {
 		serviceLog := u.log.With("namespace", service.Namespace).With("name", service.Name)
 
@@ -193,13 +201,22 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 			continue
 		}
 
+		ports := &wantLBPorts
+		if service.Annotations[nodeportproxy.PrivateAnnotationKey] == "true" {
+			if u.privateLBName == "" {
+				serviceLog.Debug("Skipping service of private cluster as no private LoadBalancer is configured")
+				continue
+			}
+			ports = &wantPrivateLBPorts
+		}
+
 		// We require a NodePort because we abuse it as allocation mechanism for a unique port
 		for _, servicePort := range service.Spec.Ports {
 			if servicePort.NodePort == 0 {
 				serviceLog.Debugw("Skipping service port as it has no nodePort set", "port", servicePort.NodePort)
 				continue
 			}
-			wantLBPorts = append(wantLBPorts, corev1.ServicePort{
+			*ports = append(*ports, corev1.ServicePort{
 				Name:       fmt.Sprintf("%s-%s", service.Namespace, service.Name),
 				Port:       servicePort.NodePort,
 				TargetPort: intstr.FromInt(int(servicePort.NodePort)),
@@ -211,9 +228,23 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 		}
 	}
 
+	if err := u.updateLB(ctx, u.lbName, wantLBPorts); err != nil {
+		return err
+	}
+
+	if u.privateLBName != "" {
+		if err := u.updateLB(ctx, u.privateLBName, wantPrivateLBPorts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *LBUpdater) updateLB(ctx context.Context, name string, wantLBPorts []corev1.ServicePort) error {
 	lb := &corev1.Service{}
-	if err := u.client.Get(ctx, types.NamespacedName{Namespace: u.lbNamespace, Name: u.lbName}, lb); err != nil {
-		return fmt.Errorf("failed to get service %s/%s from lister: %w", u.lbNamespace, u.lbName, err)
+	if err := u.client.Get(ctx, types.NamespacedName{Namespace: u.lbNamespace, Name: name}, lb); err != nil {
+		return fmt.Errorf("failed to get service %s/%s from lister: %w", u.lbNamespace, name, err)
 	}
 
 	// We need to sort both port list to be able to compare them for equality
@@ -228,20 +259,20 @@ func (u *LBUpdater) syncLB(ctx context.Context, s string) error {
 	wantLBPorts = fillNodePortsAndNames(wantLBPorts, lb.Spec.Ports)
 
 	if equality.Semantic.DeepEqual(wantLBPorts, lb.Spec.Ports) {
-		u.log.Debug("LB service already up to date, nothing to do")
+		u.log.Debugw("LB service already up to date, nothing to do", "service", name)
 		return nil
 	}
 
 	diff := deep.Equal(wantLBPorts, lb.Spec.Ports)
-	u.log.Debugw("Updating LB ports", "diff", diff)
+	u.log.Debugw("Updating LB ports", "service", name, "diff", diff)
 	lb.Spec.Ports = wantLBPorts
 	if err := u.client.Update(ctx, lb); err != nil {
-		return fmt.Errorf("failed to update LB service %s/%s: %w", u.lbNamespace, u.lbName, err)
+		return fmt.Errorf("failed to update LB service %s/%s: %w", u.lbNamespace, name, err)
 	}
 
 	buf := &bytes.Buffer{}
 	buf.WriteString("======================\n")
-	buf.WriteString("Updated LB Ports:\n")
+	buf.WriteString(fmt.Sprintf("Updated LB Ports of %s:\n", name))
 	for _, p := range lb.Spec.Ports {
 		buf.WriteString(fmt.Sprintf("Name: %s\n", p.Name))
 		buf.WriteString(fmt.Sprintf("Port: %d\n", p.Port))
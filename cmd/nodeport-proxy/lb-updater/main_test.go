@@ -735,6 +735,127 @@ func TestReconciliation(t *testing.T) {
 	}
 }
 
+func TestPrivateLoadBalancer(t *testing.T) {
+	exposedService := func(namespace string, nodePort int32, private bool) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "apiserver",
+				Annotations: map[string]string{
+					nodeportproxy.DefaultExposeAnnotationKey: nodeportproxy.NodePortType.String(),
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "1.2.3.4",
+				Ports: []corev1.ServicePort{{
+					Port:     443,
+					NodePort: nodePort,
+				}},
+			},
+		}
+		if private {
+			svc.Annotations[nodeportproxy.PrivateAnnotationKey] = "true"
+		}
+		return svc
+	}
+
+	lb := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "lb-ns",
+				Name:      name,
+			},
+		}
+	}
+
+	healthz := corev1.ServicePort{
+		Name:       "healthz",
+		Port:       8002,
+		TargetPort: intstr.FromInt(8002),
+		Protocol:   corev1.ProtocolTCP,
+	}
+
+	testCases := []struct {
+		name                string
+		privateLBName       string
+		expectedPorts       []corev1.ServicePort
+		expectedPrivatePort []corev1.ServicePort
+	}{
+		{
+			name:          "private services are exposed on the private LoadBalancer only",
+			privateLBName: "lb-private",
+			expectedPorts: []corev1.ServicePort{
+				healthz,
+				{
+					Name:       "public-apiserver-30443",
+					Port:       30443,
+					TargetPort: intstr.FromInt(30443),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			expectedPrivatePort: []corev1.ServicePort{
+				healthz,
+				{
+					Name:       "private-apiserver-30444",
+					Port:       30444,
+					TargetPort: intstr.FromInt(30444),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+		{
+			name: "private services are not exposed without a private LoadBalancer",
+			expectedPorts: []corev1.ServicePort{
+				healthz,
+				{
+					Name:       "public-apiserver-30443",
+					Port:       30443,
+					TargetPort: intstr.FromInt(30443),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fake.NewClientBuilder().WithObjects(
+				exposedService("public", 30443, false),
+				exposedService("private", 30444, true),
+				lb("lb"),
+				lb("lb-private"),
+			).Build()
+
+			updater := &LBUpdater{
+				lbNamespace:   "lb-ns",
+				lbName:        "lb",
+				privateLBName: tc.privateLBName,
+				client:        client,
+				log:           zap.NewNop().Sugar(),
+				opts: envoymanager.Options{
+					ExposeAnnotationKey: nodeportproxy.DefaultExposeAnnotationKey,
+				},
+			}
+
+			if _, err := updater.Reconcile(ctx, reconcile.Request{}); err != nil {
+				t.Fatalf("error reconciling: %v", err)
+			}
+
+			for name, expected := range map[string][]corev1.ServicePort{"lb": tc.expectedPorts, "lb-private": tc.expectedPrivatePort} {
+				svc := &corev1.Service{}
+				if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: "lb-ns", Name: name}, svc); err != nil {
+					t.Fatalf("failed to get service %s: %v", name, err)
+				}
+
+				if !diff.SemanticallyEqual(expected, svc.Spec.Ports) {
+					t.Errorf("ports of service %s differ from expected ports:\n%s", name, diff.ObjectDiff(expected, svc.Spec.Ports))
+				}
+			}
+		})
+	}
+}
+
 // These tests are here for good reasons. Do not change them and make sure
 // they continue to pass.
 //
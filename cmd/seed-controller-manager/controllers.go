@@ -27,6 +27,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addoninstaller"
 	applicationsecretclustercontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/application-secret-cluster-controller"
 	autoupdatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/auto-update-controller"
+	certificaterotationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/certificate-rotation-controller"
 	cloudcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
 	clustercredentialscontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-credentials-controller"
 	clusterphasecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-phase-controller"
@@ -35,6 +36,8 @@ import (
 	cniapplicationinstallationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cni-application-installation-controller"
 	seedconstraintsynchronizer "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/constraint-controller"
 	constrainttemplatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/constraint-template-controller"
+	controlplanegatewaycontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/control-plane-gateway"
+	controlplanesizingcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/control-plane-sizing-controller"
 	defaultapplicationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/default-application-controller"
 	encryptionatrestcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/encryption-at-rest-controller"
 	etcdbackupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/etcdbackup"
@@ -47,11 +50,15 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/monitoring"
 	operatingsystemmanagermigrator "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/operating-system-manager-migrator"
 	operatingsystemprofilesynchronizer "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/operating-system-profile-synchronizer"
+	orphanedcloudresources "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/orphaned-cloud-resources"
 	presetcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/preset-controller"
 	projectcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/project"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/pvwatcher"
+	retainedvolumescontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/retained-volumes-controller"
+	rootcarotationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/root-ca-rotation-controller"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/seedresourcesuptodatecondition"
 	updatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/update-controller"
+	usersshkeycacontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/user-ssh-key-ca-controller"
 	"k8c.io/kubermatic/v2/pkg/features"
 )
 
@@ -88,6 +95,13 @@ var AllControllers = map[string]controllerCreator{
 	defaultapplicationcontroller.ControllerName:             createDefaultApplicationController,
 	clustercredentialscontroller.ControllerName:             createClusterCredentialsController,
 	applicationsecretclustercontroller.ControllerName:       createApplicationSecretClusterController,
+	orphanedcloudresources.ControllerName:                   createOrphanedCloudResourcesController,
+	certificaterotationcontroller.ControllerName:            createCertificateRotationController,
+	rootcarotationcontroller.ControllerName:                 createRootCARotationController,
+	retainedvolumescontroller.ControllerName:                createRetainedVolumesController,
+	controlplanegatewaycontroller.ControllerName:            createControlPlaneGatewayController,
+	controlplanesizingcontroller.ControllerName:             createControlPlaneSizingController,
+	usersshkeycacontroller.ControllerName:                   createUserSSHKeyCAController,
 }
 
 type controllerCreator func(*controllerContext) error
@@ -354,6 +368,7 @@ func createInitialMachineDeploymentController(ctrlCtx *controllerContext) error
 		ctrlCtx.clientProvider,
 		ctrlCtx.log,
 		ctrlCtx.versions,
+		ctrlCtx.runOptions.caBundle.CertPool(),
 	)
 }
 
@@ -367,6 +382,7 @@ func createMLAController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerCount,
 		ctrlCtx.runOptions.workerName,
 		ctrlCtx.versions,
+		ctrlCtx.seedGetter,
 		ctrlCtx.runOptions.mlaNamespace,
 		ctrlCtx.runOptions.grafanaURL,
 		ctrlCtx.runOptions.grafanaHeaderName,
@@ -491,6 +507,8 @@ func createClusterCredentialsController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerName,
 		ctrlCtx.log,
 		ctrlCtx.versions,
+		ctrlCtx.seedGetter,
+		ctrlCtx.runOptions.caBundle.CertPool(),
 	)
 }
 
@@ -504,3 +522,78 @@ func createApplicationSecretClusterController(ctrlCtx *controllerContext) error
 		ctrlCtx.runOptions.namespace,
 	)
 }
+
+func createOrphanedCloudResourcesController(ctrlCtx *controllerContext) error {
+	orphanedcloudresources.MustRegisterMetrics(prometheus.DefaultRegisterer)
+
+	return orphanedcloudresources.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.namespace,
+		ctrlCtx.runOptions.seedName,
+		ctrlCtx.seedGetter,
+		ctrlCtx.runOptions.caBundle.CertPool(),
+	)
+}
+
+func createCertificateRotationController(ctrlCtx *controllerContext) error {
+	return certificaterotationcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.versions,
+		ctrlCtx.runOptions.certificateRenewalThreshold,
+	)
+}
+
+func createRootCARotationController(ctrlCtx *controllerContext) error {
+	return rootcarotationcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.versions,
+	)
+}
+
+func createRetainedVolumesController(ctrlCtx *controllerContext) error {
+	return retainedvolumescontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.namespace,
+	)
+}
+
+func createControlPlaneGatewayController(ctrlCtx *controllerContext) error {
+	return controlplanegatewaycontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.namespace,
+		ctrlCtx.runOptions.seedName,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.seedGetter,
+	)
+}
+
+func createControlPlaneSizingController(ctrlCtx *controllerContext) error {
+	return controlplanesizingcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.configGetter,
+		ctrlCtx.versions,
+	)
+}
+
+func createUserSSHKeyCAController(ctrlCtx *controllerContext) error {
+	return usersshkeycacontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.log,
+		ctrlCtx.versions,
+	)
+}
@@ -59,6 +59,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
@@ -158,6 +160,12 @@ func main() {
 	if err := kubelbv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Fatalw("Failed to register scheme", zap.Stringer("api", kubelbv1alpha1.SchemeGroupVersion), zap.Error(err))
 	}
+	if err := gatewayapiv1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Fatalw("Failed to register scheme", zap.Stringer("api", gatewayapiv1.SchemeGroupVersion), zap.Error(err))
+	}
+	if err := gatewayapiv1alpha2.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Fatalw("Failed to register scheme", zap.Stringer("api", gatewayapiv1alpha2.SchemeGroupVersion), zap.Error(err))
+	}
 
 	// Check if the CRD for the VerticalPodAutoscaler is registered by allocating an informer
 	if err := mgr.GetAPIReader().List(rootCtx, &autoscalingv1.VerticalPodAutoscalerList{}); err != nil {
@@ -244,6 +252,13 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 		log.Debug("Starting addons collector")
 		collectors.MustRegisterAddonCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
 	}
+	if !slices.Contains(disabledCollectors, string(kubermaticv1.CertificateCollector)) {
+		// Unlike the other collectors, this one uses the cache, as listing and parsing every
+		// Secret of every cluster on each scrape would put too much load on the apiserver.
+		// Secrets are cached for the cluster controllers anyway.
+		log.Debug("Starting certificates collector")
+		collectors.MustRegisterCertificateCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetClient())
+	}
 	if !slices.Contains(disabledCollectors, string(kubermaticv1.ProjectCollector)) {
 		// The canonical source of projects is the master cluster, but since they are replicated onto
 		// seeds, we start the project collctor on seed clusters as well, just for convenience for the admin.
@@ -97,6 +97,10 @@ type controllerRunOptions struct {
 
 	// disabledCollectors is a list of comma-separated collectors that should be disabled
 	disabledCollectors string
+
+	// certificateRenewalThreshold is the percentage of their lifetime after which control plane
+	// certificates are renewed
+	certificateRenewalThreshold int
 }
 
 func newControllerRunOptions() (controllerRunOptions, error) {
@@ -149,6 +153,7 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.StringVar(&c.machineControllerImageTag, "machine-controller-image-tag", "", "The Machine Controller image tag.")
 	flag.StringVar(&c.machineControllerImageRepository, "machine-controller-image-repository", "", "The Machine Controller image repository.")
 	flag.StringVar(&configFile, "kubermatic-configuration-file", "", "(for development only) path to a KubermaticConfiguration YAML file")
+	flag.IntVar(&c.certificateRenewalThreshold, "certificate-renewal-threshold", defaulting.DefaultCertificateRenewalThreshold, "Percentage of their lifetime after which control plane certificates are renewed.")
 	flag.StringVar(&c.disabledCollectors, "disabled-collectors", "", "Disables metrics collectors in the seed. The value should be a comma-separated list of collector names.")
 	addFlags(flag.CommandLine)
 	flag.Parse()
@@ -205,6 +210,10 @@ func (o controllerRunOptions) validate() error {
 		return fmt.Errorf("seed-name is undefined")
 	}
 
+	if o.certificateRenewalThreshold < 1 || o.certificateRenewalThreshold > 100 {
+		return fmt.Errorf("certificate-renewal-threshold must be between 1 and 100")
+	}
+
 	return nil
 }
 
@@ -45,6 +45,7 @@ import (
 	envoyagent "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/envoy-agent"
 	machinecontrollerresources "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/machine-controller"
 	roleclonercontroller "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/role-cloner-controller"
+	spotfallbackcontroller "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/spot-fallback-controller"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
@@ -98,6 +99,7 @@ type controllerRunOptions struct {
 	mlaGatewayURL                     string
 	userClusterLogging                bool
 	userClusterMonitoring             bool
+	userClusterTracing                bool
 	monitoringAgentScrapeConfigPrefix string
 	ccmMigration                      bool
 	ccmMigrationCompleted             bool
@@ -148,6 +150,7 @@ func main() {
 	flag.StringVar(&runOp.mlaGatewayURL, "mla-gateway-url", "", "The URL of MLA (Monitoring, Logging, and Alerting) gateway endpoint.")
 	flag.BoolVar(&runOp.userClusterLogging, "user-cluster-logging", false, "Enable logging in user cluster.")
 	flag.BoolVar(&runOp.userClusterMonitoring, "user-cluster-monitoring", false, "Enable monitoring in user cluster.")
+	flag.BoolVar(&runOp.userClusterTracing, "user-cluster-tracing", false, "Enable tracing in user cluster.")
 	flag.StringVar(&runOp.monitoringAgentScrapeConfigPrefix, "monitoring-agent-scrape-config-prefix", "monitoring-scraping", fmt.Sprintf("The name prefix of ConfigMaps in namespace %s, which will be used to add customized scrape configs for user cluster monitoring Agent.", resources.UserClusterMLANamespace))
 	flag.BoolVar(&runOp.ccmMigration, "ccm-migration", false, "Enable ccm migration in user cluster.")
 	flag.BoolVar(&runOp.ccmMigrationCompleted, "ccm-migration-completed", false, "cluster has been successfully migrated.")
@@ -206,9 +209,9 @@ func main() {
 	if len(runOp.caBundleFile) == 0 {
 		log.Fatal("-ca-bundle must be set")
 	}
-	if runOp.userClusterLogging || runOp.userClusterMonitoring {
+	if runOp.userClusterLogging || runOp.userClusterMonitoring || runOp.userClusterTracing {
 		if runOp.mlaGatewayURL == "" {
-			log.Fatal("-mla-gateway-url must be set when enabling user cluster logging or monitoring")
+			log.Fatal("-mla-gateway-url must be set when enabling user cluster logging, monitoring or tracing")
 		}
 	}
 
@@ -319,6 +322,7 @@ func main() {
 		usercluster.UserClusterMLA{
 			Logging:                           runOp.userClusterLogging,
 			Monitoring:                        runOp.userClusterMonitoring,
+			Tracing:                           runOp.userClusterTracing,
 			MLAGatewayURL:                     runOp.mlaGatewayURL,
 			MonitoringAgentScrapeConfigPrefix: runOp.monitoringAgentScrapeConfigPrefix,
 		},
@@ -393,6 +397,11 @@ func main() {
 	}
 	log.Info("Registered role-cloner controller")
 
+	if err := spotfallbackcontroller.Add(log, mgr, isPausedChecker); err != nil {
+		log.Fatalw("Failed to register spot-fallback controller", zap.Error(err))
+	}
+	log.Info("Registered spot-fallback controller")
+
 	if runOp.ownerEmail != "" {
 		if err := ownerbindingcreator.Add(log, mgr, runOp.ownerEmail, isPausedChecker); err != nil {
 			log.Fatalw("Failed to register owner-binding-creator controller", zap.Error(err))
@@ -132,7 +132,7 @@ func main() {
 	applicationinstallationvalidation.NewAdmissionHandler(log, seedMgr.GetScheme(), seedMgr.GetClient(), options.clusterName).SetupWebhookWithManager(seedMgr)
 
 	// Setup Machine Webhook in user manager.
-	machineValidator, err := machinevalidation.NewValidator(seedMgr.GetClient(), userMgr.GetClient(), log, options.caBundle, options.projectID)
+	machineValidator, err := machinevalidation.NewValidator(seedMgr.GetClient(), userMgr.GetClient(), log, options.caBundle, options.projectID, options.allowSpotInstances)
 	if err != nil {
 		log.Fatalw("Failed to setup Machine validator", zap.Error(err))
 	}
@@ -140,6 +140,12 @@ func main() {
 		log.Fatalw("Failed to setup Machine validation webhook", zap.Error(err))
 	}
 
+	// Setup MachineDeployment Webhook in user manager.
+	machineDeploymentValidator := machinevalidation.NewMachineDeploymentValidator(log, options.allowSpotInstances)
+	if err := builder.WebhookManagedBy(userMgr).For(&clusterv1alpha1.MachineDeployment{}).WithValidator(machineDeploymentValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup MachineDeployment validation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// Start managers
 
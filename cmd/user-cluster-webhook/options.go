@@ -36,6 +36,8 @@ type appOptions struct {
 	caBundle    *certificates.CABundle
 	projectID   string
 	clusterName string
+
+	allowSpotInstances bool
 }
 
 func initApplicationOptions() (appOptions, error) {
@@ -57,6 +59,7 @@ func initApplicationOptions() (appOptions, error) {
 	flag.StringVar(&caBundleFile, "ca-bundle", "", "File containing the PEM-encoded CA bundle for all userclusters")
 	flag.StringVar(&projectID, "project-id", "", "Project ID in which cluster the webhook is running in")
 	flag.StringVar(&clusterName, "cluster-name", "", "Cluster name in which the webhook is running in")
+	flag.BoolVar(&c.allowSpotInstances, "allow-spot-instances", true, "Whether the datacenter of the cluster allows machines to use spot instances")
 	flag.Parse()
 
 	caBundle, err := certificates.NewCABundleFromFile(caBundleFile)
@@ -65,6 +68,7 @@ func initApplicationOptions() (appOptions, error) {
 	}
 	c.caBundle = caBundle
 	c.projectID = projectID
+	c.clusterName = clusterName
 
 	if err := c.userWebhook.Validate(); err != nil {
 		return c, fmt.Errorf("invalid user cluster webhook configuration: %w", err)
@@ -72,7 +72,7 @@ func main() {
 	if err != nil {
 		log.Fatalw("Failed to get users directories", zap.Error(err))
 	}
-	if err := usersshkeys.Add(mgr, log, paths); err != nil {
+	if err := usersshkeys.Add(mgr, log, paths, usersshkeys.DefaultSSHDConfigPath); err != nil {
 		log.Fatalw("Failed registering user ssh key controller", zap.Error(err))
 	}
 
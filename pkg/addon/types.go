@@ -163,6 +163,7 @@ func NewTemplateData(
 			MLA: MLASettings{
 				MonitoringEnabled: cluster.Spec.MLA != nil && cluster.Spec.MLA.MonitoringEnabled,
 				LoggingEnabled:    cluster.Spec.MLA != nil && cluster.Spec.MLA.LoggingEnabled,
+				TracingEnabled:    cluster.Spec.MLA != nil && cluster.Spec.MLA.TracingEnabled,
 			},
 			CSIMigration:                csiMigration,
 			KubeVirtInfraStorageClasses: kubeVirtStorageClasses,
@@ -262,6 +263,8 @@ type MLASettings struct {
 	MonitoringEnabled bool
 	// LoggingEnabled is the flag for enabling logging in user cluster.
 	LoggingEnabled bool
+	// TracingEnabled is the flag for enabling tracing in user cluster.
+	TracingEnabled bool
 }
 
 type CSIOptions struct {
@@ -50,6 +50,11 @@ const (
 
 	// PresetInvalidatedAnnotation is key of the annotation used to indicate why the preset was invalidated.
 	PresetInvalidatedAnnotation = "presetInvalidated"
+
+	// RootCARotationAnnotation is key of the annotation used to request a rotation of the cluster's root CA.
+	// Its value identifies the rotation; setting a new value after a rotation has completed starts another one.
+	// On Nodes that are not managed by a MachineDeployment, it confirms that the Node trusts the new CA.
+	RootCARotationAnnotation = "kubermatic.k8c.io/rotate-root-ca"
 )
 
 const (
@@ -68,6 +73,8 @@ const (
 	MinEtcdClusterSize     = 3
 	MaxEtcdClusterSize     = 9
 
+	DefaultEtcdDefragmentationThreshold = 30
+
 	DefaultKonnectivityKeepaliveTime = "1m"
 )
 
@@ -173,9 +180,18 @@ type ClusterSpec struct {
 	// Optional: Component specific overrides that allow customization of control plane components.
 	ComponentsOverride ComponentSettings `json:"componentsOverride,omitempty"`
 
+	// Optional: ControlPlaneTopology configures how the control plane Pods of this cluster
+	// are spread across the availability zones of the seed cluster.
+	ControlPlaneTopology *ControlPlaneTopologySettings `json:"controlPlaneTopology,omitempty"`
+
 	// Optional: OIDC specifies the OIDC configuration parameters for enabling authentication mechanism for the cluster.
 	OIDC OIDCSettings `json:"oidc,omitempty"`
 
+	// Optional: AuthenticationConfiguration configures structured authentication for the kube-apiserver,
+	// allowing to trust multiple JWT issuers at once. It is rendered into an AuthenticationConfiguration
+	// file and cannot be combined with the `oidc` settings. Requires Kubernetes 1.30 or newer.
+	AuthenticationConfiguration *AuthenticationConfiguration `json:"authenticationConfiguration,omitempty"`
+
 	// A map of optional or early-stage features that can be enabled for the user cluster.
 	// Some feature gates cannot be disabled after being enabled.
 	// The available feature gates vary based on KKP version, Kubernetes version and Seed configuration.
@@ -214,6 +230,12 @@ type ClusterSpec struct {
 	// No SSH keys will be synced after node creation if this is disabled.
 	EnableUserSSHKeyAgent *bool `json:"enableUserSSHKeyAgent,omitempty"`
 
+	// Optional: Configures the UserSSHKeyAgent to trust a per-cluster SSH certificate authority instead of
+	// distributing the static public keys of the assigned UserSSHKeys onto the nodes. The CA is kept on the seed,
+	// which issues short-lived certificates for the assigned keys whose owners have access to the cluster's project.
+	// The certificates are then published in the UserSSHKey status. Requires the UserSSHKeyAgent to be enabled.
+	UserSSHKeyCertificateAuthority *UserSSHKeyCertificateAuthoritySettings `json:"userSSHKeyCertificateAuthority,omitempty"`
+
 	// Deprecated: EnableOperatingSystemManager has been deprecated starting with KKP 2.26 and will be removed in KKP 2.28+. This field is no-op and OSM is always enabled for user clusters.
 	// OSM is responsible for creating and managing worker node configuration.
 	EnableOperatingSystemManager *bool `json:"enableOperatingSystemManager,omitempty"`
@@ -263,6 +285,61 @@ type ClusterSpec struct {
 
 	// Optional: BackupConfig contains the configuration options for managing the Cluster Backup Velero integration feature.
 	BackupConfig *BackupConfig `json:"backupConfig,omitempty"`
+
+	// Optional: ResourceTags are key/value tags that are applied to all cloud resources
+	// managed by KKP for this cluster (networks, security groups, machines, ...). They are
+	// merged with the tags configured on the Project and the Datacenter, with the cluster
+	// tags taking precedence.
+	ResourceTags map[string]string `json:"resourceTags,omitempty"`
+
+	// Optional: PrivateCluster configures the cluster control plane to not be exposed publicly.
+	// Only supported on Azure and GCP with the NodePort expose strategy and Konnectivity enabled.
+	PrivateCluster *PrivateClusterSettings `json:"privateCluster,omitempty"`
+
+	// Optional: DeletionPolicy configures which resources are kept when the cluster is deleted.
+	DeletionPolicy *ClusterDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// PrivateClusterSettings configures a private cluster, whose API server is only reachable
+// from within the cluster's VPC/VNet via a private endpoint. The nodes reach the control plane
+// through this endpoint and the Konnectivity tunnel.
+type PrivateClusterSettings struct {
+	// Enabled makes the API server only reachable via a private endpoint in the cluster network.
+	// This setting cannot be changed after the cluster has been created.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func (c ClusterSpec) IsPrivateCluster() bool {
+	return c.PrivateCluster != nil && c.PrivateCluster.Enabled
+}
+
+// +kubebuilder:validation:Enum=Delete;Retain
+
+// VolumeDeletionPolicy defines what happens to a cluster's PersistentVolumes when the cluster is deleted.
+type VolumeDeletionPolicy string
+
+const (
+	// VolumeDeletionPolicyDelete deletes all PersistentVolumeClaims, which makes the storage
+	// provisioners delete all dynamically provisioned volumes.
+	VolumeDeletionPolicyDelete VolumeDeletionPolicy = "Delete"
+	// VolumeDeletionPolicyRetain switches all bound PersistentVolumes to the Retain reclaim policy
+	// before deleting their claims, so that the underlying volumes are kept.
+	VolumeDeletionPolicyRetain VolumeDeletionPolicy = "Retain"
+)
+
+// ClusterDeletionPolicy configures which resources are kept when the cluster is deleted.
+type ClusterDeletionPolicy struct {
+	// Volumes controls whether the cluster's volumes are deleted (default) or retained. Individual
+	// PersistentVolumeClaims can also be retained by labelling them with "kubermatic.k8c.io/retain-volume=true".
+	// Retained volumes are recorded on the seed and can be imported into a new cluster of the same
+	// project. Note that retained volumes still get removed if the cloud provider cleanup deletes
+	// their container, like a KKP-managed Azure resource group.
+	// +kubebuilder:default=Delete
+	Volumes VolumeDeletionPolicy `json:"volumes,omitempty"`
+}
+
+func (c ClusterSpec) RetainsVolumes() bool {
+	return c.DeletionPolicy != nil && c.DeletionPolicy.Volumes == VolumeDeletionPolicyRetain
 }
 
 // KubernetesDashboard contains settings for the kubernetes-dashboard component as part of the cluster control plane.
@@ -292,6 +369,17 @@ func (c ClusterSpec) IsKubeLBEnabled() bool {
 	return c.KubeLB != nil && c.KubeLB.Enabled
 }
 
+// UserSSHKeyCertificateAuthoritySettings configures the SSH certificate authority of a cluster.
+type UserSSHKeyCertificateAuthoritySettings struct {
+	// Enabled makes the nodes trust SSH certificates issued by the cluster's SSH certificate authority
+	// instead of the static public keys of the assigned UserSSHKeys.
+	Enabled bool `json:"enabled,omitempty"`
+	// CertificateValidity is the lifetime of the issued SSH certificates. Certificates never outlive the
+	// expiry date of their UserSSHKey. Defaults to 8h.
+	// +optional
+	CertificateValidity *metav1.Duration `json:"certificateValidity,omitempty"`
+}
+
 // CNIPluginSettings contains the spec of the CNI plugin used by the Cluster.
 type CNIPluginSettings struct {
 	// Type is the CNI plugin type to be used.
@@ -342,6 +430,13 @@ const (
 	// https://kb.vmware.com/s/article/84446).
 	ClusterFeatureVsphereCSIClusterID = "vsphereCSIClusterID"
 
+	// ClusterFeatureManagedCloudResources enables KKP to create and manage the network, firewall
+	// and placement group of Hetzner clusters and the VPC and firewall of DigitalOcean clusters.
+	// This feature flag is enabled by default for new Hetzner and DigitalOcean clusters. Existing
+	// clusters must opt in manually, as the firewall denies all inbound traffic to the nodes that
+	// is not explicitly allowed, which can cut off traffic to running workloads.
+	ClusterFeatureManagedCloudResources = "managedCloudResources"
+
 	// ClusterFeatureEtcdLauncher enables features related to the experimental etcd-launcher. This includes user-cluster
 	// etcd scaling, automatic volume recovery and new backup/restore controllers.
 	ClusterFeatureEtcdLauncher = "etcdLauncher"
@@ -359,7 +454,7 @@ const (
 	ClusterFeatureEncryptionAtRest = "encryptionAtRest"
 )
 
-// +kubebuilder:validation:Enum="";SeedResourcesUpToDate;ClusterControllerReconciledSuccessfully;AddonControllerReconciledSuccessfully;AddonInstallerControllerReconciledSuccessfully;BackupControllerReconciledSuccessfully;CloudControllerReconciledSuccessfully;UpdateControllerReconciledSuccessfully;MonitoringControllerReconciledSuccessfully;MachineDeploymentReconciledSuccessfully;MLAControllerReconciledSuccessfully;ClusterInitialized;EtcdClusterInitialized;CSIKubeletMigrationCompleted;ClusterUpdateSuccessful;ClusterUpdateInProgress;CSIKubeletMigrationSuccess;CSIKubeletMigrationInProgress;EncryptionControllerReconciledSuccessfully;IPAMControllerReconciledSuccessfully;
+// +kubebuilder:validation:Enum="";SeedResourcesUpToDate;ClusterControllerReconciledSuccessfully;AddonControllerReconciledSuccessfully;AddonInstallerControllerReconciledSuccessfully;BackupControllerReconciledSuccessfully;CloudControllerReconciledSuccessfully;UpdateControllerReconciledSuccessfully;MonitoringControllerReconciledSuccessfully;MachineDeploymentReconciledSuccessfully;MLAControllerReconciledSuccessfully;ClusterInitialized;EtcdClusterInitialized;EtcdMembersHealthy;EtcdNoSpaceRecovered;CSIKubeletMigrationCompleted;ClusterUpdateSuccessful;ClusterUpdateInProgress;CSIKubeletMigrationSuccess;CSIKubeletMigrationInProgress;EncryptionControllerReconciledSuccessfully;IPAMControllerReconciledSuccessfully;CertificateRotationControllerReconciledSuccessfully;RootCARotationControllerReconciledSuccessfully;ControlPlaneZoneSpread;
 
 // ClusterConditionType is used to indicate the type of a cluster condition. For all condition
 // types, the `true` value must indicate success. All condition types must be registered within
@@ -452,10 +547,27 @@ const (
 	ClusterConditionClusterInitialized                                           ClusterConditionType = "ClusterInitialized"
 	ClusterConditionIPAMControllerReconcilingSuccess                             ClusterConditionType = "IPAMControllerReconciledSuccessfully"
 	ClusterConditionClusterBackupControllerReconcilingSuccess                    ClusterConditionType = "ClusterBackupControllerReconciledSuccessfully"
+	ClusterConditionCertificateRotationControllerReconcilingSuccess              ClusterConditionType = "CertificateRotationControllerReconciledSuccessfully"
+	ClusterConditionRootCARotationControllerReconcilingSuccess                   ClusterConditionType = "RootCARotationControllerReconciledSuccessfully"
 
 	ClusterConditionEtcdClusterInitialized ClusterConditionType = "EtcdClusterInitialized"
 	ClusterConditionEncryptionInitialized  ClusterConditionType = "EncryptionInitialized"
 
+	// ClusterConditionEtcdMembersHealthy is true when etcd is healthy and all of its members are
+	// voting members, i.e. no replacement member is still catching up as a learner.
+	ClusterConditionEtcdMembersHealthy ClusterConditionType = "EtcdMembersHealthy"
+
+	// ClusterConditionEtcdNoSpaceRecovered is only set while etcd exceeds its space quota and
+	// has raised a NOSPACE alarm, and for a while after recovering from it. It is true when
+	// etcd-launcher recovered from the alarm by compacting and defragmenting the database, and
+	// false when the recovery failed.
+	ClusterConditionEtcdNoSpaceRecovered ClusterConditionType = "EtcdNoSpaceRecovered"
+
+	// ClusterConditionControlPlaneZoneSpread is only set if a control plane topology is configured.
+	// It is false when all running Pods of any control plane component are scheduled into a single
+	// availability zone.
+	ClusterConditionControlPlaneZoneSpread ClusterConditionType = "ControlPlaneZoneSpread"
+
 	ClusterConditionUpdateProgress ClusterConditionType = "UpdateProgress"
 
 	// ClusterConditionNone is a special value indicating that no cluster condition should be set.
@@ -529,6 +641,9 @@ type ClusterStatus struct {
 	// Extends standard health status for new states.
 	// +optional
 	ExtendedHealth ExtendedClusterHealth `json:"extendedHealth,omitempty"`
+	// Etcd contains the size of the etcd cluster and the progress of resizing it.
+	// +optional
+	Etcd *ClusterEtcdStatus `json:"etcd,omitempty"`
 	// LastProviderReconciliation is the time when the cloud provider resources
 	// were last fully reconciled (during normal cluster reconciliation, KKP does
 	// not re-check things like security groups, networks etc.).
@@ -577,8 +692,79 @@ type ClusterStatus struct {
 	// +optional
 	Encryption *ClusterEncryptionStatus `json:"encryption,omitempty"`
 
+	// RootCARotation describes the progress of the latest rotation of the cluster's root CA.
+	// +optional
+	RootCARotation *ClusterRootCARotationStatus `json:"rootCARotation,omitempty"`
+
+	// ControlPlaneSizing describes the control plane sizing profile that has been selected for
+	// the cluster based on its observed size. Only set if sizing profiles are configured in the
+	// KubermaticConfiguration.
+	// +optional
+	ControlPlaneSizing *ClusterControlPlaneSizingStatus `json:"controlPlaneSizing,omitempty"`
+
+	// Template contains information about the ClusterTemplate this cluster has been created from.
+	// +optional
+	Template *ClusterTemplateStatus `json:"template,omitempty"`
+
 	// ResourceUsage shows the current usage of resources for the cluster.
 	ResourceUsage *ResourceDetails `json:"resourceUsage,omitempty"`
+
+	// CostEstimate is the estimated cost of the cluster's machines, based on the pricing
+	// catalog of the cluster's datacenter. Only available in Enterprise Edition.
+	// +optional
+	CostEstimate *ClusterCostEstimate `json:"costEstimate,omitempty"`
+
+	// ResourceTags are the effective cloud resource tags of this cluster, merged from
+	// the Datacenter, Project and Cluster resource tags.
+	// +optional
+	ResourceTags map[string]string `json:"resourceTags,omitempty"`
+}
+
+// ClusterCostEstimate is the estimated cost of running the machines of a cluster.
+type ClusterCostEstimate struct {
+	// Currency is the ISO 4217 code of the currency the costs are given in.
+	Currency string `json:"currency"`
+	// Hourly is the estimated cost per hour as a decimal string.
+	Hourly string `json:"hourly"`
+	// Monthly is the estimated cost per month (730 hours) as a decimal string.
+	Monthly string `json:"monthly"`
+	// UnpricedMachines is the number of machines whose instance type is not
+	// contained in the pricing catalog and which are therefore not part of the estimate.
+	// +optional
+	UnpricedMachines int `json:"unpricedMachines,omitempty"`
+	// MachineDeployments are the estimated costs of each MachineDeployment at its
+	// configured number of replicas, keyed by name. MachineDeployments whose instance
+	// type is not contained in the pricing catalog are omitted.
+	// +optional
+	MachineDeployments map[string]MachineDeploymentCostEstimate `json:"machineDeployments,omitempty"`
+}
+
+// MachineDeploymentCostEstimate is the estimated cost of a MachineDeployment.
+type MachineDeploymentCostEstimate struct {
+	// InstanceType is the instance type used by the MachineDeployment.
+	InstanceType string `json:"instanceType"`
+	// Replicas is the number of replicas the estimate is based on.
+	Replicas int32 `json:"replicas"`
+	// Hourly is the estimated cost per hour as a decimal string.
+	Hourly string `json:"hourly"`
+	// Monthly is the estimated cost per month (730 hours) as a decimal string.
+	Monthly string `json:"monthly"`
+}
+
+// ClusterTemplateStatus describes the relation of a cluster to the ClusterTemplate it has been created from.
+type ClusterTemplateStatus struct {
+	// Name is the name of the ClusterTemplate.
+	Name string `json:"name"`
+	// Revision is the revision of the template that this cluster has been created from or that has
+	// last been fully applied to it.
+	Revision int64 `json:"revision"`
+	// LatestRevision is the current revision of the template.
+	// +optional
+	LatestRevision int64 `json:"latestRevision,omitempty"`
+	// DriftedFields lists the fields in which the cluster differs from the current revision of
+	// the template.
+	// +optional
+	DriftedFields []string `json:"driftedFields,omitempty"`
 }
 
 // ClusterVersionsStatus contains information regarding the current and desired versions
@@ -652,6 +838,77 @@ const (
 	ClusterEncryptionPhaseEncryptionNeeded ClusterEncryptionPhase = "EncryptionNeeded"
 )
 
+// ClusterRootCARotationStatus holds status information about the rotation of the cluster's root CA.
+type ClusterRootCARotationStatus struct {
+	// ID is the value of the `kubermatic.k8c.io/rotate-root-ca` annotation that requested this rotation.
+	ID string `json:"id"`
+
+	// The current phase of the rotation. Can be one of `AddingNewCA`, `RefreshingNodes`, `ReissuingCertificates`,
+	// `RemovingOldCA` or `Completed`. A phase is only left once all control plane components have been
+	// rolled out with its changes and are healthy again.
+	Phase ClusterRootCARotationPhase `json:"phase"`
+
+	// StartTime is the time at which the rotation was started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// LastTransitionTime is the time at which the rotation entered its current phase.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Message describes what the rotation is currently waiting for.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterControlPlaneSizingStatus describes the size of a user cluster as observed by the seed
+// monitoring and the sizing profile selected for its control plane.
+type ClusterControlPlaneSizingStatus struct {
+	// Profile is the name of the sizing profile whose resources are applied to the control plane.
+	Profile string `json:"profile"`
+
+	// Nodes is the highest number of nodes observed during the last hour.
+	Nodes int64 `json:"nodes"`
+
+	// Objects is the highest number of objects stored in the cluster observed during the last hour.
+	Objects int64 `json:"objects"`
+
+	// RequestsPerSecond is the highest API server request rate observed during the last hour.
+	RequestsPerSecond int64 `json:"requestsPerSecond"`
+
+	// LastObservedTime is the time at which the cluster size was last observed.
+	LastObservedTime metav1.Time `json:"lastObservedTime"`
+
+	// LastTransitionTime is the time at which the selected profile changed last.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// DownscaleObservations is the number of consecutive observations in which the cluster
+	// was below the thresholds of the selected profile.
+	DownscaleObservations int32 `json:"downscaleObservations,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=AddingNewCA;RefreshingNodes;ReissuingCertificates;RemovingOldCA;Completed
+type ClusterRootCARotationPhase string
+
+const (
+	// ClusterRootCARotationPhaseAddingNewCA means that a new CA has been created and is being added to the
+	// CA bundle trusted by the control plane, kubeconfigs and nodes, while the old CA still signs all certificates.
+	ClusterRootCARotationPhaseAddingNewCA ClusterRootCARotationPhase = "AddingNewCA"
+	// ClusterRootCARotationPhaseRefreshingNodes means that all MachineDeployments are being rolled out, so that
+	// the nodes are provisioned with the new CA bundle and receive kubelet certificates signed by the new CA.
+	ClusterRootCARotationPhaseRefreshingNodes ClusterRootCARotationPhase = "RefreshingNodes"
+	// ClusterRootCARotationPhaseReissuingCertificates means that the new CA has become the signing CA and all
+	// certificates and kubeconfigs of the control plane are being re-issued.
+	ClusterRootCARotationPhaseReissuingCertificates ClusterRootCARotationPhase = "ReissuingCertificates"
+	// ClusterRootCARotationPhaseRemovingOldCA means that the old CA is being removed from the CA bundle.
+	ClusterRootCARotationPhaseRemovingOldCA ClusterRootCARotationPhase = "RemovingOldCA"
+	// ClusterRootCARotationPhaseCompleted means that the rotation has finished.
+	ClusterRootCARotationPhaseCompleted ClusterRootCARotationPhase = "Completed"
+)
+
+// InProgress returns true if the rotation has been started but not yet completed.
+func (s *ClusterRootCARotationStatus) InProgress() bool {
+	return s != nil && s.Phase != ClusterRootCARotationPhaseCompleted
+}
+
 // OIDCSettings contains OIDC configuration parameters for enabling authentication mechanism for the cluster.
 type OIDCSettings struct {
 	IssuerURL      string `json:"issuerURL,omitempty"`
@@ -665,6 +922,105 @@ type OIDCSettings struct {
 	GroupsPrefix   string `json:"groupsPrefix,omitempty"`
 }
 
+// AuthenticationConfiguration configures structured authentication for the kube-apiserver.
+type AuthenticationConfiguration struct {
+	// JWT is a list of authenticators for tokens issued by JWT issuers. A token is accepted
+	// if any of the authenticators accepts it.
+	// +kubebuilder:validation:MinItems=1
+	JWT []JWTAuthenticator `json:"jwt"`
+}
+
+// JWTAuthenticator configures an authenticator for tokens issued by a single JWT issuer.
+type JWTAuthenticator struct {
+	// Issuer contains the connection options for the issuer.
+	Issuer JWTIssuer `json:"issuer"`
+	// Optional: ClaimValidationRules are rules that are applied to validate the token claims.
+	ClaimValidationRules []JWTClaimValidationRule `json:"claimValidationRules,omitempty"`
+	// ClaimMappings points claims of a token to be treated as user attributes.
+	ClaimMappings JWTClaimMappings `json:"claimMappings"`
+	// Optional: UserValidationRules are rules that are applied to the final user before completing authentication.
+	UserValidationRules []JWTUserValidationRule `json:"userValidationRules,omitempty"`
+}
+
+// JWTIssuer contains the connection options for a JWT issuer.
+type JWTIssuer struct {
+	// URL is the issuer URL in the format https://url or https://url/path. It must match the
+	// "iss" claim of the tokens and the issuer returned from discovery.
+	URL string `json:"url"`
+	// Optional: DiscoveryURL overrides the URL used to fetch the discovery information.
+	DiscoveryURL string `json:"discoveryURL,omitempty"`
+	// Optional: CertificateAuthority contains the PEM-encoded CA certificates used to validate the
+	// connection when fetching the discovery information. Defaults to the CA bundle of the seed.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// Audiences is the set of acceptable audiences the tokens must be issued to.
+	// +kubebuilder:validation:MinItems=1
+	Audiences []string `json:"audiences"`
+	// Optional: AudienceMatchPolicy defines how the audiences are matched against the "aud" claim.
+	// Must be set to "MatchAny" if multiple audiences are configured.
+	// +kubebuilder:validation:Enum="";MatchAny
+	AudienceMatchPolicy string `json:"audienceMatchPolicy,omitempty"`
+}
+
+// JWTClaimValidationRule validates the claims of a token. Either Claim or Expression must be set.
+type JWTClaimValidationRule struct {
+	// Optional: Claim is the name of a required claim.
+	Claim string `json:"claim,omitempty"`
+	// Optional: RequiredValue is the value of the required claim. If empty, the claim must be present
+	// with an empty value.
+	RequiredValue string `json:"requiredValue,omitempty"`
+	// Optional: Expression is a CEL expression that must evaluate to true for the token to be valid.
+	Expression string `json:"expression,omitempty"`
+	// Optional: Message customizes the error message returned when Expression evaluates to false.
+	Message string `json:"message,omitempty"`
+}
+
+// JWTClaimMappings maps the claims of a token to the attributes of the authenticated user.
+type JWTClaimMappings struct {
+	// Username maps a claim or expression to the username. This mapping is required.
+	Username JWTPrefixedClaimOrExpression `json:"username"`
+	// Optional: Groups maps a claim or expression to the groups of the user.
+	Groups JWTPrefixedClaimOrExpression `json:"groups,omitempty"`
+	// Optional: UID maps a claim or expression to the UID of the user.
+	UID JWTClaimOrExpression `json:"uid,omitempty"`
+	// Optional: Extra maps CEL expressions to extra attributes of the user.
+	Extra []JWTExtraMapping `json:"extra,omitempty"`
+}
+
+// JWTPrefixedClaimOrExpression references either a claim with an optional prefix or a CEL expression.
+type JWTPrefixedClaimOrExpression struct {
+	// Optional: Claim is the name of the claim to use.
+	Claim string `json:"claim,omitempty"`
+	// Optional: Prefix is prepended to the value of the claim. It is required if Claim is set and
+	// can be set to an empty string to disable prefixing.
+	Prefix *string `json:"prefix,omitempty"`
+	// Optional: Expression is a CEL expression that evaluates to the value.
+	Expression string `json:"expression,omitempty"`
+}
+
+// JWTClaimOrExpression references either a claim or a CEL expression.
+type JWTClaimOrExpression struct {
+	// Optional: Claim is the name of the claim to use.
+	Claim string `json:"claim,omitempty"`
+	// Optional: Expression is a CEL expression that evaluates to the value.
+	Expression string `json:"expression,omitempty"`
+}
+
+// JWTExtraMapping maps a CEL expression to an extra attribute of the user.
+type JWTExtraMapping struct {
+	// Key is the domain-prefixed key of the extra attribute, e.g. "example.com/team".
+	Key string `json:"key"`
+	// ValueExpression is a CEL expression that evaluates to a string or list of strings.
+	ValueExpression string `json:"valueExpression"`
+}
+
+// JWTUserValidationRule validates the final user after the claims have been mapped.
+type JWTUserValidationRule struct {
+	// Expression is a CEL expression that must evaluate to true for the user to be valid.
+	Expression string `json:"expression"`
+	// Optional: Message customizes the error message returned when Expression evaluates to false.
+	Message string `json:"message,omitempty"`
+}
+
 // EventRateLimitConfig configures the `EventRateLimit` admission plugin.
 // More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#eventratelimit
 type EventRateLimitConfig struct {
@@ -713,10 +1069,14 @@ type MLASettings struct {
 	MonitoringEnabled bool `json:"monitoringEnabled,omitempty"`
 	// LoggingEnabled is the flag for enabling logging in user cluster.
 	LoggingEnabled bool `json:"loggingEnabled,omitempty"`
+	// TracingEnabled is the flag for enabling tracing in user cluster.
+	TracingEnabled bool `json:"tracingEnabled,omitempty"`
 	// MonitoringResources is the resource requirements for user cluster prometheus.
 	MonitoringResources *corev1.ResourceRequirements `json:"monitoringResources,omitempty"`
 	// LoggingResources is the resource requirements for user cluster promtail.
 	LoggingResources *corev1.ResourceRequirements `json:"loggingResources,omitempty"`
+	// TracingResources is the resource requirements for user cluster OpenTelemetry collector.
+	TracingResources *corev1.ResourceRequirements `json:"tracingResources,omitempty"`
 	// MonitoringReplicas is the number of desired pods of user cluster prometheus deployment.
 	MonitoringReplicas *int32 `json:"monitoringReplicas,omitempty"`
 }
@@ -737,6 +1097,22 @@ const (
 	AntiAffinityTypeRequired  = "required"
 )
 
+// ControlPlaneTopologySettings configures topology-aware scheduling for the control plane
+// (apiserver including the konnectivity server, controller-manager, scheduler and etcd).
+type ControlPlaneTopologySettings struct {
+	// ZoneSpread configures how strictly control plane Pods are spread across the availability
+	// zones of the seed cluster, based on the `topology.kubernetes.io/zone` node label.
+	// Options are "preferred" (default) and "required". Please note that "required" can mean
+	// that Pods stay pending if a zone runs out of capacity.
+	ZoneSpread AntiAffinityType `json:"zoneSpread,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxSkew is the maximum permitted difference of control plane Pods of one kind between
+	// any two zones. Defaults to 1.
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+}
+
 type ComponentSettings struct {
 	// Apiserver configures kube-apiserver settings.
 	Apiserver APIServerSettings `json:"apiserver"`
@@ -819,6 +1195,26 @@ type EtcdStatefulSetSettings struct {
 	ZoneAntiAffinity AntiAffinityType `json:"zoneAntiAffinity,omitempty"`
 	// NodeSelector is a selector which restricts the set of nodes where etcd Pods can run.
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Defragmentation configures when the periodic defragmentation job defragments
+	// etcd members.
+	Defragmentation *EtcdDefragmentationSettings `json:"defragmentation,omitempty"`
+	// QuotaBackendBytes is the maximum size of the etcd database. When the database
+	// exceeds this quota, etcd raises a NOSPACE alarm and only accepts reads and deletes
+	// until the etcd-launcher has compacted and defragmented the members and disarmed
+	// the alarm. Defaults to etcd's built-in default of 2Gi and must not exceed 8Gi,
+	// the maximum recommended by etcd.
+	QuotaBackendBytes *resource.Quantity `json:"quotaBackendBytes,omitempty"`
+}
+
+type EtcdDefragmentationSettings struct {
+	// FragmentationThreshold is the percentage of a member's database size that must be
+	// unused (i.e. dbSize vs. dbSizeInUse) before the member is defragmented. Defaults to 30.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	FragmentationThreshold *int32 `json:"fragmentationThreshold,omitempty"`
+	// MinimumDatabaseSize is the database size below which members are never defragmented,
+	// regardless of their fragmentation. Defaults to 0, i.e. no minimum.
+	MinimumDatabaseSize *resource.Quantity `json:"minimumDatabaseSize,omitempty"`
 }
 
 type LeaderElectionSettings struct {
@@ -1021,6 +1417,16 @@ type DigitaloceanCloudSpec struct {
 
 	// Token is used to authenticate with the DigitalOcean API.
 	Token string `json:"token,omitempty"`
+	// FirewallID is the ID of the firewall that KKP manages for the droplets of this cluster.
+	// It is set by KKP and must not be changed.
+	FirewallID string `json:"firewallID,omitempty"`
+	// VPCID is the ID of the VPC that KKP manages for this cluster.
+	// It is set by KKP and must not be changed.
+	VPCID string `json:"vpcID,omitempty"`
+
+	// Optional: CIDR ranges that will be used to allow access to the node port range in the firewall created by KKP.
+	// If not set, the node port range can be accessed from anywhere.
+	NodePortsAllowedIPRanges *NetworkRanges `json:"nodePortsAllowedIPRanges,omitempty"`
 }
 
 // HetznerCloudSpec specifies access data to hetzner cloud.
@@ -1032,8 +1438,21 @@ type HetznerCloudSpec struct {
 	// Network is the pre-existing Hetzner network in which the machines are running.
 	// While machines can be in multiple networks, a single one must be chosen for the
 	// HCloud CCM to work.
-	// If this is empty, the network configured on the datacenter will be used.
+	// If this is empty, the network configured on the datacenter will be used. If neither
+	// is configured and the managedCloudResources feature is enabled, KKP creates a network
+	// for the cluster.
 	Network string `json:"network,omitempty"`
+	// Optional: Firewall is the name of the firewall attached to the machines. If empty and
+	// the managedCloudResources feature is enabled, KKP creates a firewall for the cluster.
+	Firewall string `json:"firewall,omitempty"`
+	// Optional: PlacementGroupPrefix is the name prefix of the spread placement groups the
+	// machines are assigned to. If empty and the managedCloudResources feature is enabled,
+	// KKP creates a placement group for the cluster.
+	PlacementGroupPrefix string `json:"placementGroupPrefix,omitempty"`
+
+	// Optional: CIDR ranges that will be used to allow access to the node port range in the firewall created by KKP.
+	// If not set, the node port range can be accessed from anywhere.
+	NodePortsAllowedIPRanges *NetworkRanges `json:"nodePortsAllowedIPRanges,omitempty"`
 }
 
 // AzureCloudSpec defines cloud resource references for Microsoft Azure.
@@ -1495,6 +1914,34 @@ const (
 	HealthStatusProvisioning = HealthStatus("HealthStatusProvisioning")
 )
 
+// +kubebuilder:validation:Enum="";ScalingUp;RemovingMember;ScalingDown
+
+// EtcdResizePhase describes the progress of resizing an etcd cluster.
+type EtcdResizePhase string
+
+const (
+	// EtcdResizePhaseScalingUp means a member is being added to the etcd cluster.
+	EtcdResizePhaseScalingUp EtcdResizePhase = "ScalingUp"
+	// EtcdResizePhaseRemovingMember means the last member is being removed from the
+	// etcd cluster, before its Pod is removed.
+	EtcdResizePhaseRemovingMember EtcdResizePhase = "RemovingMember"
+	// EtcdResizePhaseScalingDown means the StatefulSet is shrinking after the last
+	// member has been removed from the etcd cluster.
+	EtcdResizePhaseScalingDown EtcdResizePhase = "ScalingDown"
+)
+
+// ClusterEtcdStatus stores the size of a cluster's etcd ring.
+type ClusterEtcdStatus struct {
+	// Size is the number of members the etcd StatefulSet is scaled to. While the
+	// etcd cluster is resized, this is changed by one member at a time.
+	Size int32 `json:"size"`
+	// TargetSize is the configured etcd cluster size.
+	TargetSize int32 `json:"targetSize"`
+	// ResizePhase is set while the etcd cluster is being resized.
+	// +optional
+	ResizePhase EtcdResizePhase `json:"resizePhase,omitempty"`
+}
+
 // ExtendedClusterHealth stores health information of a cluster.
 type ExtendedClusterHealth struct {
 	Apiserver         HealthStatus `json:"apiserver,omitempty"`
@@ -1512,6 +1959,7 @@ type ExtendedClusterHealth struct {
 	GatekeeperAudit              *HealthStatus `json:"gatekeeperAudit,omitempty"`
 	Monitoring                   *HealthStatus `json:"monitoring,omitempty"`
 	Logging                      *HealthStatus `json:"logging,omitempty"`
+	Tracing                      *HealthStatus `json:"tracing,omitempty"`
 	AlertmanagerConfig           *HealthStatus `json:"alertmanagerConfig,omitempty"`
 	MLAGateway                   *HealthStatus `json:"mlaGateway,omitempty"`
 	OperatingSystemManager       *HealthStatus `json:"operatingSystemManager,omitempty"`
@@ -1650,6 +2098,7 @@ func (cluster *Cluster) GetUserClusterMLAResourceRequirements() map[string]*core
 	return map[string]*corev1.ResourceRequirements{
 		"monitoring": cluster.Spec.MLA.MonitoringResources,
 		"logging":    cluster.Spec.MLA.LoggingResources,
+		"tracing":    cluster.Spec.MLA.TracingResources,
 	}
 }
 
@@ -17,7 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -34,6 +37,11 @@ const (
 	ClusterTemplateUserAnnotationKey         = "user"
 	ClusterTemplateProjectLabelKey           = "project-id"
 	ClusterTemplateHumanReadableNameLabelKey = "name"
+
+	// ClusterTemplateRevisionAnnotationKey is set on the copies of ClusterTemplates that are
+	// synchronized from the master into the seed clusters and contains the revision of the
+	// original template.
+	ClusterTemplateRevisionAnnotationKey = "kubermatic.k8c.io/cluster-template-revision"
 )
 
 const (
@@ -64,10 +72,47 @@ type ClusterTemplate struct {
 	// UserSSHKeys is the list of SSH public keys that should be assigned to all nodes in the cluster.
 	UserSSHKeys []ClusterTemplateSSHKey `json:"userSSHKeys,omitempty"`
 
+	// Addons is the list of addons that should be installed into the clusters created from this template.
+	// They are installed by the template's drift reconciliation, so clusters only receive them if
+	// PropagateUpdates is enabled; otherwise missing or differing addons are only reported.
+	// +optional
+	Addons []ClusterTemplateAddon `json:"addons,omitempty"`
+
+	// PropagateUpdates controls whether changes to this template are applied to the clusters that
+	// have been created from it. Only changes that are safe to apply to an existing cluster are
+	// propagated, namely the Kubernetes version (one minor version at a time), the cluster labels,
+	// the addons and the OPA and MLA settings. The OPA and MLA settings are merged into the cluster's
+	// settings, so that fields not set in the template keep the cluster's values.
+	// Regardless of this setting, any other differences are reported in the cluster status.
+	// +optional
+	PropagateUpdates bool `json:"propagateUpdates,omitempty"`
+
 	// Spec describes the desired state of a user cluster.
 	Spec ClusterSpec `json:"spec,omitempty"`
 }
 
+// ClusterTemplateAddon is an addon that is installed into the clusters created from a template.
+type ClusterTemplateAddon struct {
+	// Name is the name of the addon.
+	Name string `json:"name"`
+	// Variables is free form data to use for parsing the addon's manifest templates.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Variables *runtime.RawExtension `json:"variables,omitempty"`
+}
+
+// Revision returns the revision of the template. Each change to the template
+// results in a new, higher revision.
+func (t *ClusterTemplate) Revision() int64 {
+	if value, ok := t.Annotations[ClusterTemplateRevisionAnnotationKey]; ok {
+		if revision, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return revision
+		}
+	}
+
+	return t.Generation
+}
+
 // +kubebuilder:object:generate=true
 // +kubebuilder:object:root=true
 
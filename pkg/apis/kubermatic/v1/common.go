@@ -16,10 +16,10 @@ limitations under the License.
 
 package v1
 
-// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Tunneling
+// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Tunneling;Gateway
 
 // ExposeStrategy is the strategy used to expose a cluster control plane.
-// Possible values are `NodePort`, `LoadBalancer` or `Tunneling` (requires a feature gate).
+// Possible values are `NodePort`, `LoadBalancer`, `Tunneling` (requires a feature gate) or `Gateway`.
 type ExposeStrategy string
 
 const (
@@ -43,6 +43,17 @@ const (
 	// (e.g. Service of type LoadBalancer) without consuming one or more ports
 	// for each user cluster.
 	ExposeStrategyTunneling ExposeStrategy = "Tunneling"
+	// ExposeStrategyGateway exposes the control plane components through a
+	// Gateway API implementation running in the Seed Cluster instead of the
+	// nodeport-proxy. The API server is routed via a TCPRoute on a dedicated
+	// listener of a shared Gateway, while Konnectivity is routed via a TLSRoute
+	// based on SNI. This strategy requires Konnectivity and a seed with a
+	// configured control plane Gateway.
+	//
+	// As a Gateway supports at most 64 listeners and one of them is shared by
+	// all clusters, a single seed can expose at most 63 clusters using this
+	// strategy. Further clusters are rejected.
+	ExposeStrategyGateway ExposeStrategy = "Gateway"
 )
 
 // Finalizers should be kept to their controllers. Only if a finalizer is
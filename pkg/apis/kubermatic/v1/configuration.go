@@ -42,13 +42,15 @@ const (
 // OperationType is the type defining the operations triggering the compatibility check (CREATE or UPDATE).
 type OperationType string
 
-// +kubebuilder:validation:Enum=Addon;Cluster;ClusterBackup;Project;None
+// +kubebuilder:validation:Enum=Addon;Certificate;Cluster;ClusterBackup;Project;None
 // MetricsCollector is the name of an available metrics collector.
 type MetricsCollector string
 
 const (
 	// AddonCollector is addon metrics collector.
 	AddonCollector MetricsCollector = "Addon"
+	// CertificateCollector is control plane certificate metrics collector.
+	CertificateCollector MetricsCollector = "Certificate"
 	// ClusterBackupCollector is cluster backup metrics collector.
 	ClusterBackupCollector MetricsCollector = "ClusterBackup"
 	// ClusterCollector is cluster metrics collector.
@@ -224,8 +226,14 @@ type KubermaticSeedControllerConfiguration struct {
 	// Replicas sets the number of pod replicas for the seed-controller-manager.
 	Replicas *int32 `json:"replicas,omitempty"`
 	// DisabledCollectors contains a list of metrics collectors that should be disabled.
-	// Acceptable values are "Addon", "Cluster", "ClusterBackup", "Project", and "None".
+	// Acceptable values are "Addon", "Certificate", "Cluster", "ClusterBackup", "Project", and "None".
 	DisabledCollectors []MetricsCollector `json:"disabledCollectors,omitempty"`
+	// CertificateRenewalThreshold is the percentage of their lifetime after which the certificates
+	// of user cluster control planes are renewed. Affected control plane components are restarted
+	// one at a time. Defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	CertificateRenewalThreshold int `json:"certificateRenewalThreshold,omitempty"`
 }
 
 // KubermaticWebhookConfiguration configures the Kubermatic webhook.
@@ -273,6 +281,9 @@ type KubermaticUserClusterConfiguration struct {
 	EtcdVolumeSize string `json:"etcdVolumeSize,omitempty"`
 	// APIServerReplicas configures the replica count for the API-Server deployment inside user clusters.
 	APIServerReplicas *int32 `json:"apiserverReplicas,omitempty"`
+	// ControlPlaneSizing configures profiles that derive the resources of the control plane
+	// components from the size of each user cluster.
+	ControlPlaneSizing *ControlPlaneSizingConfiguration `json:"controlPlaneSizing,omitempty"`
 	// MachineController configures the Machine Controller
 	MachineController MachineControllerConfiguration `json:"machineController,omitempty"`
 	// OperatingSystemManager configures the image repo and the tag version for osm deployment.
@@ -304,6 +315,54 @@ type KubermaticUserClusterMonitoringConfiguration struct {
 	ScrapeAnnotationPrefix string `json:"scrapeAnnotationPrefix,omitempty"`
 }
 
+// ControlPlaneSizingConfiguration configures how the resources of user cluster control planes
+// are derived from the size of the user clusters. The size of a cluster is determined by its
+// number of nodes, the number of objects stored in it and the request rate of its API server,
+// as observed by the seed monitoring Prometheus.
+type ControlPlaneSizingConfiguration struct {
+	// PrometheusURL is the URL of the seed monitoring Prometheus, which federates the metrics of
+	// all user cluster Prometheus instances. Defaults to `http://prometheus.monitoring.svc.cluster.local:9090`.
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+	// Interval is the time between two observations of a cluster. Defaults to 10m.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// DownscaleStabilizationIntervals is the number of consecutive observations in which a
+	// cluster must stay below the thresholds of its current profile before a smaller profile
+	// is selected. Larger profiles are selected immediately. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	DownscaleStabilizationIntervals *int32 `json:"downscaleStabilizationIntervals,omitempty"`
+	// Profiles is the list of sizing profiles, ordered from smallest to largest. A cluster uses
+	// the largest profile for which at least one of the thresholds is reached, or the first
+	// profile if no threshold is reached. Resources configured in a cluster's
+	// `componentsOverride` take precedence over the profile.
+	// +kubebuilder:validation:MinItems=1
+	Profiles []ControlPlaneSizingProfile `json:"profiles"`
+}
+
+// ControlPlaneSizingProfile defines the resources of the control plane components for clusters
+// of a certain size.
+type ControlPlaneSizingProfile struct {
+	// Name is the unique name of the profile, which is shown in the cluster status.
+	Name string `json:"name"`
+	// MinNodes is the number of nodes from which on the profile is used.
+	// +kubebuilder:validation:Minimum=0
+	MinNodes int64 `json:"minNodes,omitempty"`
+	// MinObjects is the number of objects stored in the cluster from which on the profile is used.
+	// +kubebuilder:validation:Minimum=0
+	MinObjects int64 `json:"minObjects,omitempty"`
+	// MinRequestsPerSecond is the API server request rate from which on the profile is used.
+	// +kubebuilder:validation:Minimum=0
+	MinRequestsPerSecond int64 `json:"minRequestsPerSecond,omitempty"`
+
+	// Apiserver configures the resources of the kube-apiserver container.
+	Apiserver *corev1.ResourceRequirements `json:"apiserver,omitempty"`
+	// ControllerManager configures the resources of the kube-controller-manager container.
+	ControllerManager *corev1.ResourceRequirements `json:"controllerManager,omitempty"`
+	// Scheduler configures the resources of the kube-scheduler container.
+	Scheduler *corev1.ResourceRequirements `json:"scheduler,omitempty"`
+	// Etcd configures the resources of the etcd container.
+	Etcd *corev1.ResourceRequirements `json:"etcd,omitempty"`
+}
+
 // MachineControllerConfiguration configures Machine Controller.
 type MachineControllerConfiguration struct {
 	// ImageRepository is used to override the Machine Controller image repository.
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CredentialRotationResourceName represents "Resource" defined in Kubernetes.
+	CredentialRotationResourceName = "credentialrotations"
+
+	// CredentialRotationKindName represents "Kind" defined in Kubernetes.
+	CredentialRotationKindName = "CredentialRotation"
+)
+
+// +kubebuilder:validation:Enum=Pending;InProgress;Completed;Failed
+
+// CredentialRotationPhase represents the lifecycle phase of a CredentialRotation.
+type CredentialRotationPhase string
+
+const (
+	// CredentialRotationPhasePending means the new credentials are being validated.
+	CredentialRotationPhasePending CredentialRotationPhase = "Pending"
+	// CredentialRotationPhaseInProgress means the new credentials have been validated and
+	// are being rolled out to the affected clusters.
+	CredentialRotationPhaseInProgress CredentialRotationPhase = "InProgress"
+	// CredentialRotationPhaseCompleted means all affected clusters are using the new credentials.
+	CredentialRotationPhaseCompleted CredentialRotationPhase = "Completed"
+	// CredentialRotationPhaseFailed means the rotation could not be completed for at least
+	// one cluster. If the validation failed, no cluster has been changed.
+	CredentialRotationPhaseFailed CredentialRotationPhase = "Failed"
+)
+
+// +kubebuilder:validation:Enum=Pending;Restarting;Completed;Failed
+
+// CredentialRotationClusterPhase represents the progress of a CredentialRotation for a single cluster.
+type CredentialRotationClusterPhase string
+
+const (
+	// CredentialRotationClusterPhasePending means the cluster has not yet been updated.
+	CredentialRotationClusterPhasePending CredentialRotationClusterPhase = "Pending"
+	// CredentialRotationClusterPhaseRestarting means the cluster's credentials Secret has been
+	// updated and the control plane components are being restarted.
+	CredentialRotationClusterPhaseRestarting CredentialRotationClusterPhase = "Restarting"
+	// CredentialRotationClusterPhaseCompleted means all control plane components have been
+	// restarted with the new credentials.
+	CredentialRotationClusterPhaseCompleted CredentialRotationClusterPhase = "Completed"
+	// CredentialRotationClusterPhaseFailed means the credentials could not be rotated for the cluster.
+	CredentialRotationClusterPhaseFailed CredentialRotationClusterPhase = "Failed"
+)
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:JSONPath=".spec.preset",name="Preset",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.credentialsSecret",name="Secret",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name="Phase",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// CredentialRotation replaces the cloud provider credentials of all user clusters that
+// have been created from a Preset or that share a credentials Secret, and restarts their
+// control plane components so that the new credentials are picked up.
+type CredentialRotation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CredentialRotationSpec   `json:"spec,omitempty"`
+	Status CredentialRotationStatus `json:"status,omitempty"`
+}
+
+// CredentialRotationSpec specifies which clusters should receive which new credentials.
+// Exactly one of Preset and CredentialsSecret must be set.
+type CredentialRotationSpec struct {
+	// Preset is the name of a Preset. All clusters that have been created using this Preset
+	// will receive the new credentials. The Preset itself is not modified by the rotation
+	// and has to be updated on the master cluster, so that new clusters use the new
+	// credentials as well.
+	// +optional
+	Preset string `json:"preset,omitempty"`
+
+	// CredentialsSecret is the name of a Secret in the KKP namespace. All clusters whose
+	// credentials reference this Secret will receive the new credentials.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// NewCredentials is the name of a Secret in the KKP namespace that contains the new
+	// credentials. It must use the same keys as the clusters' credentials Secrets (for
+	// example "accessKeyId" and "secretAccessKey" for AWS).
+	NewCredentials string `json:"newCredentials"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// CredentialRotationList is a list of credential rotations.
+type CredentialRotationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of the credential rotations.
+	Items []CredentialRotation `json:"items"`
+}
+
+// CredentialRotationStatus contains the progress of a CredentialRotation.
+type CredentialRotationStatus struct {
+	// Phase is the overall phase of the rotation.
+	// +optional
+	Phase CredentialRotationPhase `json:"phase,omitempty"`
+	// Message contains a human readable explanation of the current phase, for example
+	// why the validation of the new credentials failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is the time at which the new credentials have been validated and the
+	// rollout to the clusters began.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time at which the rotation finished for all clusters.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Clusters contains the progress for each affected cluster, keyed by cluster name.
+	// +optional
+	Clusters map[string]CredentialRotationClusterStatus `json:"clusters,omitempty"`
+}
+
+// CredentialRotationClusterStatus contains the progress of a CredentialRotation for a single cluster.
+type CredentialRotationClusterStatus struct {
+	// Phase is the rotation phase of the cluster.
+	Phase CredentialRotationClusterPhase `json:"phase"`
+	// Message contains details, for example why the rotation failed for this cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -60,6 +60,8 @@ const (
 	DefaultSSHPort     = 22
 	DefaultKubeletPort = 10250
 
+	DefaultControlPlaneGatewayTLSPort = 6443
+
 	DefaultKubeconfigFieldPath = "kubeconfig"
 )
 
@@ -291,6 +293,12 @@ type SeedSpec struct {
 	ProxySettings *ProxySettings `json:"proxySettings,omitempty"`
 	// Optional: ExposeStrategy explicitly sets the expose strategy for this seed cluster, if not set, the default provided by the master is used.
 	ExposeStrategy ExposeStrategy `json:"exposeStrategy,omitempty"`
+	// Optional: ControlPlaneGateway configures the Gateway API Gateway that is used to expose
+	// user cluster control planes using the `Gateway` expose strategy. If the seed uses the
+	// `Gateway` expose strategy, the nodeport-proxy is not deployed. Every cluster using the
+	// `Gateway` expose strategy requires its own listener on the Gateway, so at most 63 clusters
+	// on this seed can use it; further clusters are rejected.
+	ControlPlaneGateway *ControlPlaneGatewayConfig `json:"controlPlaneGateway,omitempty"`
 	// Optional: MLA allows configuring seed level MLA (Monitoring, Logging & Alerting) stack settings.
 	MLA *SeedMLASettings `json:"mla,omitempty"`
 	// DefaultComponentSettings are default values to set for newly created clusters.
@@ -313,8 +321,33 @@ type SeedSpec struct {
 	//lint:ignore SA5008 omitcegenyaml is used by the example-yaml-generator
 	KubeLB *KubeLBSettings `json:"kubelb,omitempty,omitcegenyaml"`
 	// DisabledCollectors contains a list of metrics collectors that should be disabled.
-	// Acceptable values are "Addon", "Cluster", "ClusterBackup", "Project", and "None".
+	// Acceptable values are "Addon", "Certificate", "Cluster", "ClusterBackup", "Project", and "None".
 	DisabledCollectors []MetricsCollector `json:"disabledCollectors,omitempty"`
+	// OrphanedCloudResources configures the detection of cloud resources that outlived
+	// the cluster they were created for.
+	OrphanedCloudResources *OrphanedCloudResourcesSettings `json:"orphanedCloudResources,omitempty"`
+}
+
+// OrphanedCloudResourcesSettings configures the periodic scan for KKP-owned cloud
+// resources of clusters that were recorded on this Seed but do not exist anymore.
+// Resources of clusters that never existed on this Seed, e.g. those of other Seeds
+// or tools in the same cloud accounts, are never considered. The scan uses the
+// credentials of the existing clusters and is currently supported for AWS, Azure,
+// GCP and OpenStack.
+type OrphanedCloudResourcesSettings struct {
+	// Enabled enables the periodic scan. Orphaned resources are reported as metrics
+	// and as events on the Seed.
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is the time between two scans. Defaults to 6h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// DeleteOrphanedResources makes KKP delete orphaned resources once they have been
+	// detected for longer than the DeletionGracePeriod. If not set, orphaned resources
+	// are only reported.
+	DeleteOrphanedResources bool `json:"deleteOrphanedResources,omitempty"`
+	// DeletionGracePeriod is the time an orphaned resource must have been detected
+	// before it is deleted. The time of the first detection is persisted, so restarts
+	// do not reset the grace period. Defaults to 24h.
+	DeletionGracePeriod *metav1.Duration `json:"deletionGracePeriod,omitempty"`
 }
 
 // EtcdBackupRestore holds the configuration of the automatic backup and restores.
@@ -359,12 +392,48 @@ type NodeportProxyConfig struct {
 	// Updater configures the component responsible for updating the LoadBalancer
 	// service.
 	Updater NodeportProxyComponent `json:"updater,omitempty"`
+	// Optional: PrivateLoadBalancerService configures an additional LoadBalancer service for
+	// Envoy that exposes the API servers of private clusters, which in turn are not exposed
+	// on the public nodeport-proxy LoadBalancer. The annotations must make the cloud provider
+	// create an internal load balancer, which is then fronted by the Private Link Service
+	// (Azure) or Private Service Connect service attachment (GCP) of the datacenters that
+	// allow private clusters. Private clusters can only be created on seeds that configure
+	// this service.
+	PrivateLoadBalancerService *EnvoyLoadBalancerService `json:"privateLoadBalancerService,omitempty"`
 	// IPFamilyPolicy configures the IP family policy for the LoadBalancer service.
 	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
 	// IPFamilies configures the IP families to use for the LoadBalancer service.
 	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
+// ControlPlaneGatewayConfig configures the Gateway that is shared by all user cluster control planes
+// on a seed using the `Gateway` expose strategy. The Gateway is managed by KKP: it has one TLS
+// passthrough listener for SNI-based routes and one TCP listener per user cluster API server.
+// As a Gateway supports at most 64 listeners, a single seed can expose at most 63 user clusters
+// using this strategy.
+type ControlPlaneGatewayConfig struct {
+	// GatewayClassName is the name of the GatewayClass of the Gateway API implementation that
+	// is running in the seed cluster.
+	GatewayClassName string `json:"gatewayClassName"`
+	// Optional: Annotations are applied to the Gateway, e.g. to further tweak the load balancer
+	// integration of the Gateway API implementation.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Optional: TLSPort is the port of the TLS passthrough listener that is used to route
+	// Konnectivity and MLA traffic based on SNI. Defaults to 6443.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	TLSPort int32 `json:"tlsPort,omitempty"`
+}
+
+// GetTLSPort returns the configured port of the TLS passthrough listener or its default.
+func (c *ControlPlaneGatewayConfig) GetTLSPort() int32 {
+	if c == nil || c.TLSPort == 0 {
+		return DefaultControlPlaneGatewayTLSPort
+	}
+
+	return c.TLSPort
+}
+
 type EnvoyLoadBalancerService struct {
 	// Annotations are used to further tweak the LoadBalancer integration with the
 	// cloud provider.
@@ -494,6 +563,30 @@ type DatacenterSpec struct {
 	// By default, the type of service that will be used is determined by the `ExposeStrategy` used for the cluster.
 	// +optional
 	APIServerServiceType *corev1.ServiceType `json:"apiServerServiceType,omitempty"`
+
+	// Optional: Pricing contains the prices of the instance types available in this datacenter.
+	// If set, the estimated costs of user clusters are calculated.
+	// Only available in Enterprise Edition.
+	Pricing *PricingCatalog `json:"pricing,omitempty"`
+
+	// Optional: ResourceTags are key/value tags that are applied to the cloud resources of all
+	// clusters in this datacenter. Tags configured on a project or cluster take precedence over these.
+	ResourceTags map[string]string `json:"resourceTags,omitempty"`
+}
+
+// PricingCatalog contains the hourly prices of instance types (also called sizes,
+// flavors or machine types, depending on the provider). Prices are given as decimal
+// strings, e.g. "0.0416".
+type PricingCatalog struct {
+	// Currency is the ISO 4217 code of the currency all prices are given in, e.g. "EUR".
+	Currency string `json:"currency"`
+	// InstanceTypes maps instance type names to the price of running one instance for an hour.
+	InstanceTypes map[string]string `json:"instanceTypes,omitempty"`
+	// ConfigMapName is the name of a ConfigMap in the KKP namespace of the Seed holding
+	// additional prices, so that large catalogs can be maintained outside of the Seed.
+	// Its keys have the form "<provider>.<instance type>", e.g. "aws.t3.medium", and its
+	// values are hourly prices. Prices in InstanceTypes take precedence.
+	ConfigMapName string `json:"configMapName,omitempty"`
 }
 
 var (
@@ -660,6 +753,11 @@ type DatacenterSpecAzure struct {
 	// Region to use, for example "westeurope". A list of available regions can be
 	// found at https://azure.microsoft.com/en-us/global-infrastructure/locations/
 	Location string `json:"location"`
+	// Optional: PrivateLinkServiceID is the resource ID of the Private Link Service that
+	// fronts the seed's private nodeport-proxy load balancer (see
+	// `spec.nodeportProxy.privateLoadBalancerService` on the Seed). It is required for private
+	// clusters, for which a private endpoint connected to this service is created in the cluster's VNet.
+	PrivateLinkServiceID string `json:"privateLinkServiceID,omitempty"`
 }
 
 // DatacenterSpecVSphere describes a vSphere datacenter.
@@ -723,6 +821,10 @@ type DatacenterSpecAWS struct {
 	// when machines are created, so under normal circumstances it is not necessary
 	// to define the AMIs statically.
 	Images ImageList `json:"images,omitempty"`
+
+	// Optional: AllowSpotInstances controls whether machines in this datacenter may use spot instances.
+	// Spot instances are allowed if this is not set.
+	AllowSpotInstances *bool `json:"allowSpotInstances,omitempty"`
 }
 
 // DatacenterSpecBaremetal describes a datacenter of baremetal nodes.
@@ -789,6 +891,16 @@ type DatacenterSpecGCP struct {
 	// Refer to the official documentation for more details on this:
 	// https://cloud.google.com/kubernetes-engine/docs/concepts/regional-clusters
 	Regional bool `json:"regional,omitempty"`
+
+	// Optional: PrivateServiceAttachment is the self link of the Private Service Connect service
+	// attachment that fronts the seed's private nodeport-proxy load balancer (see
+	// `spec.nodeportProxy.privateLoadBalancerService` on the Seed). It is required for private
+	// clusters, for which a Private Service Connect endpoint is created in the cluster's network.
+	PrivateServiceAttachment string `json:"privateServiceAttachment,omitempty"`
+
+	// Optional: AllowSpotInstances controls whether machines in this datacenter may use preemptible
+	// or spot VMs. Spot VMs are allowed if this is not set.
+	AllowSpotInstances *bool `json:"allowSpotInstances,omitempty"`
 }
 
 // DatacenterSpecFake describes a fake datacenter.
@@ -990,6 +1102,10 @@ type ContainerdRegistry struct {
 type SeedMLASettings struct {
 	// Optional: UserClusterMLAEnabled controls whether the user cluster MLA (Monitoring, Logging & Alerting) stack is enabled in the seed.
 	UserClusterMLAEnabled bool `json:"userClusterMLAEnabled,omitempty"` //nolint:tagliatelle
+	// Optional: UserClusterTracingEnabled controls whether a Tempo tracing backend is available in the
+	// seed's MLA namespace. The Tempo chart is not part of the MLA stack and has to be installed
+	// separately. User clusters can only enable tracing if this is set.
+	UserClusterTracingEnabled bool `json:"userClusterTracingEnabled,omitempty"`
 }
 
 // MeteringConfiguration contains all the configuration for the metering tool.
@@ -1048,6 +1164,7 @@ type MeteringReportConfiguration struct {
 	// +kubebuilder:default:={"cluster","namespace"}
 
 	// Types of reports to generate. Available report types are cluster and namespace. By default, all types of reports are generated.
+	// Independent of the types, a cost report with the estimated cost of every cluster that has a pricing catalog is generated.
 	Types []string `json:"type,omitempty"`
 
 	// Format is the file format of the generated report, one of "csv" or "json" (defaults to "csv").
@@ -1129,6 +1246,12 @@ func (s *Seed) IsDefaultEtcdAutomaticBackupEnabled() bool {
 	return s.IsEtcdAutomaticBackupEnabled() && s.Spec.EtcdBackupRestore.DefaultDestination != ""
 }
 
+// IsUserClusterTracingEnabled returns true if the user cluster MLA stack of the seed
+// includes a tracing backend.
+func (s *Seed) IsUserClusterTracingEnabled() bool {
+	return s.Spec.MLA != nil && s.Spec.MLA.UserClusterMLAEnabled && s.Spec.MLA.UserClusterTracingEnabled
+}
+
 func (s *Seed) GetEtcdBackupDestination(destinationName string) *BackupDestination {
 	if s.Spec.EtcdBackupRestore == nil {
 		return nil
@@ -85,6 +85,47 @@ type ExternalCluster struct {
 type ExternalClusterStatus struct {
 	// Conditions contains conditions an externalcluster is in, its primary use case is status signaling for controller
 	Condition ExternalClusterCondition `json:"condition,omitempty"`
+
+	// Inventory contains information that is periodically collected from BringYourOwn and KubeOne
+	// clusters through their kubeconfig.
+	// +optional
+	Inventory *ExternalClusterInventory `json:"inventory,omitempty"`
+}
+
+// ExternalClusterInventory describes the state of an external cluster as observed through its kubeconfig.
+type ExternalClusterInventory struct {
+	// LastCollected is the point in time at which the inventory was last collected successfully.
+	LastCollected metav1.Time `json:"lastCollected,omitempty"`
+	// Version is the Kubernetes version reported by the cluster's API server.
+	Version string `json:"version,omitempty"`
+	// Nodes lists the nodes of the cluster.
+	Nodes []ExternalClusterNodeInfo `json:"nodes,omitempty"`
+	// ComponentHealth contains the health of the cluster's API server and of the control plane
+	// components that run as pods in the kube-system namespace.
+	ComponentHealth map[string]HealthStatus `json:"componentHealth,omitempty"`
+	// APIServerCertificateExpiry is the expiry date of the API server's serving certificate.
+	APIServerCertificateExpiry *metav1.Time `json:"apiServerCertificateExpiry,omitempty"`
+	// ClientCertificateExpiry is the expiry date of the client certificate in the kubeconfig,
+	// if it uses one.
+	ClientCertificateExpiry *metav1.Time `json:"clientCertificateExpiry,omitempty"`
+	// Error is the reason why the inventory could not be collected during the last attempt.
+	Error string `json:"error,omitempty"`
+}
+
+// ExternalClusterNodeInfo describes a single node of an external cluster.
+type ExternalClusterNodeInfo struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+	// Roles are the roles of the node, as indicated by its node-role.kubernetes.io labels.
+	Roles []string `json:"roles,omitempty"`
+	// Ready is true if the node's Ready condition is true.
+	Ready bool `json:"ready"`
+	// KubeletVersion is the version of the kubelet running on the node.
+	KubeletVersion string `json:"kubeletVersion,omitempty"`
+	// OSImage is the operating system reported by the node.
+	OSImage string `json:"osImage,omitempty"`
+	// ContainerRuntimeVersion is the container runtime reported by the node.
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
 }
 
 type ExternalClusterCondition struct {
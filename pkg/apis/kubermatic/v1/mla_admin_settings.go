@@ -52,6 +52,8 @@ type MLAAdminSettingSpec struct {
 	MonitoringRateLimits *MonitoringRateLimitSettings `json:"monitoringRateLimits,omitempty"`
 	// LoggingRateLimits contains rate-limiting configuration logging in the user cluster.
 	LoggingRateLimits *LoggingRateLimitSettings `json:"loggingRateLimits,omitempty"`
+	// TracingRateLimits contains rate-limiting configuration for tracing in the user cluster.
+	TracingRateLimits *TracingRateLimitSettings `json:"tracingRateLimits,omitempty"`
 }
 
 // MonitoringRateLimitSettings contains rate-limiting configuration for monitoring in the user cluster.
@@ -88,6 +90,19 @@ type LoggingRateLimitSettings struct {
 	QueryBurstSize int32 `json:"queryBurstSize,omitempty"`
 }
 
+// TracingRateLimitSettings contains rate-limiting configuration for tracing in the user cluster.
+type TracingRateLimitSettings struct {
+	// IngestionRate represents ingestion rate limit in requests per second (nginx `rate` in `r/s`).
+	IngestionRate int32 `json:"ingestionRate,omitempty"`
+	// IngestionBurstSize represents ingestion burst size in number of requests (nginx `burst`).
+	IngestionBurstSize int32 `json:"ingestionBurstSize,omitempty"`
+
+	// QueryRate represents query request rate limit per second (nginx `rate` in `r/s`).
+	QueryRate int32 `json:"queryRate,omitempty"`
+	// QueryBurstSize represents query burst size in number of requests (nginx `burst`).
+	QueryBurstSize int32 `json:"queryBurstSize,omitempty"`
+}
+
 // +kubebuilder:object:generate=true
 // +kubebuilder:object:root=true
 
@@ -71,6 +71,11 @@ type ProjectSpec struct {
 	Name string `json:"name"`
 	// AllowedOperatingSystems defines a map of operating systems that can be used for the machines inside this project.
 	AllowedOperatingSystems allowedOperatingSystems `json:"allowedOperatingSystems,omitempty"`
+	// ResourceTags are key/value tags that are applied to the cloud resources of all clusters
+	// in this project. Tags configured on a cluster take precedence over these. As the clusters
+	// of a project can use any provider, the tags must satisfy the restrictions of all providers,
+	// e.g. the GCP label format.
+	ResourceTags map[string]string `json:"resourceTags,omitempty"`
 }
 
 // ProjectStatus represents the current status of a project.
@@ -107,6 +107,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GroupProjectBindingList{},
 		&ClusterBackupStorageLocation{},
 		&ClusterBackupStorageLocationList{},
+		&CredentialRotation{},
+		&CredentialRotationList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
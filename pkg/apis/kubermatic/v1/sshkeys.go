@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -32,6 +34,7 @@ const (
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:object:generate=true
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=".spec.name",name="HumanReadableName",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.owner",name="Owner",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.project",name="Project",type="string"
@@ -43,14 +46,16 @@ type UserSSHKey struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec SSHKeySpec `json:"spec,omitempty"`
+	Spec   SSHKeySpec   `json:"spec,omitempty"`
+	Status SSHKeyStatus `json:"status,omitempty"`
 }
 
 type SSHKeySpec struct {
 	// Name is the human readable name for this SSH key.
 	Name string `json:"name"`
-	// Owner is the name of the User object that owns this SSH key.
-	// Deprecated: This field is not used anymore.
+	// Owner is the name of the User object that owns this SSH key. Keys only receive SSH
+	// certificates from clusters with the SSH certificate authority mode enabled as long as
+	// their owner has access to the cluster's project through a UserProjectBinding.
 	// +optional
 	Owner string `json:"owner,omitempty"`
 	// Project is the name of the Project object that this SSH key belongs to.
@@ -64,6 +69,34 @@ type SSHKeySpec struct {
 	Fingerprint string `json:"fingerprint"`
 	// PublicKey is the SSH public key.
 	PublicKey string `json:"publicKey"`
+	// ExpiresAt is the optional point in time after which this SSH key is no longer
+	// distributed to any cluster. Once expired, the key is removed from the clusters'
+	// authorized_keys files by the user-ssh-keys-agent.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+type SSHKeyStatus struct {
+	// Certificates are the short-lived SSH certificates issued for this key by the certificate
+	// authorities of the assigned clusters that have the SSH certificate authority mode enabled.
+	// +optional
+	Certificates []SSHKeyCertificate `json:"certificates,omitempty"`
+}
+
+type SSHKeyCertificate struct {
+	// Cluster is the name of the cluster whose certificate authority issued the certificate.
+	Cluster string `json:"cluster"`
+	// Certificate is the SSH certificate in authorized_keys format. It must be presented together
+	// with the private key belonging to this UserSSHKey.
+	Certificate string `json:"certificate"`
+	// ExpiresAt is the point in time after which the certificate is no longer accepted.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// IsExpired returns true if the key has an expiry date set and it lies
+// before or at the given point in time.
+func (sk *UserSSHKey) IsExpired(now time.Time) bool {
+	return sk.Spec.ExpiresAt != nil && !sk.Spec.ExpiresAt.After(now)
 }
 
 func (sk *UserSSHKey) IsUsedByCluster(clustername string) bool {
@@ -819,6 +819,28 @@ func (in *AuditWebhookBackendSettings) DeepCopy() *AuditWebhookBackendSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationConfiguration) DeepCopyInto(out *AuthenticationConfiguration) {
+	*out = *in
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = make([]JWTAuthenticator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationConfiguration.
+func (in *AuthenticationConfiguration) DeepCopy() *AuthenticationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Azure) DeepCopyInto(out *Azure) {
 	*out = *in
@@ -1253,6 +1275,60 @@ func (in *ClusterCondition) DeepCopy() *ClusterCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterControlPlaneSizingStatus) DeepCopyInto(out *ClusterControlPlaneSizingStatus) {
+	*out = *in
+	in.LastObservedTime.DeepCopyInto(&out.LastObservedTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterControlPlaneSizingStatus.
+func (in *ClusterControlPlaneSizingStatus) DeepCopy() *ClusterControlPlaneSizingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterControlPlaneSizingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCostEstimate) DeepCopyInto(out *ClusterCostEstimate) {
+	*out = *in
+	if in.MachineDeployments != nil {
+		in, out := &in.MachineDeployments, &out.MachineDeployments
+		*out = make(map[string]MachineDeploymentCostEstimate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCostEstimate.
+func (in *ClusterCostEstimate) DeepCopy() *ClusterCostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeletionPolicy) DeepCopyInto(out *ClusterDeletionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeletionPolicy.
+func (in *ClusterDeletionPolicy) DeepCopy() *ClusterDeletionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeletionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterEncryptionStatus) DeepCopyInto(out *ClusterEncryptionStatus) {
 	*out = *in
@@ -1273,6 +1349,21 @@ func (in *ClusterEncryptionStatus) DeepCopy() *ClusterEncryptionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEtcdStatus) DeepCopyInto(out *ClusterEtcdStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEtcdStatus.
+func (in *ClusterEtcdStatus) DeepCopy() *ClusterEtcdStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEtcdStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -1352,6 +1443,23 @@ func (in *ClusterNetworkingConfig) DeepCopy() *ClusterNetworkingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRootCARotationStatus) DeepCopyInto(out *ClusterRootCARotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRootCARotationStatus.
+func (in *ClusterRootCARotationStatus) DeepCopy() *ClusterRootCARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRootCARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
@@ -1381,7 +1489,17 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.ComponentsOverride.DeepCopyInto(&out.ComponentsOverride)
+	if in.ControlPlaneTopology != nil {
+		in, out := &in.ControlPlaneTopology, &out.ControlPlaneTopology
+		*out = new(ControlPlaneTopologySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	out.OIDC = in.OIDC
+	if in.AuthenticationConfiguration != nil {
+		in, out := &in.AuthenticationConfiguration, &out.AuthenticationConfiguration
+		*out = new(AuthenticationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Features != nil {
 		in, out := &in.Features, &out.Features
 		*out = make(map[string]bool, len(*in))
@@ -1416,6 +1534,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UserSSHKeyCertificateAuthority != nil {
+		in, out := &in.UserSSHKeyCertificateAuthority, &out.UserSSHKeyCertificateAuthority
+		*out = new(UserSSHKeyCertificateAuthoritySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnableOperatingSystemManager != nil {
 		in, out := &in.EnableOperatingSystemManager, &out.EnableOperatingSystemManager
 		*out = new(bool)
@@ -1466,6 +1589,23 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(BackupConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PrivateCluster != nil {
+		in, out := &in.PrivateCluster, &out.PrivateCluster
+		*out = new(PrivateClusterSettings)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(ClusterDeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -1484,6 +1624,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	out.Address = in.Address
 	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 	in.ExtendedHealth.DeepCopyInto(&out.ExtendedHealth)
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(ClusterEtcdStatus)
+		**out = **in
+	}
 	in.LastProviderReconciliation.DeepCopyInto(&out.LastProviderReconciliation)
 	in.Versions.DeepCopyInto(&out.Versions)
 	if in.ErrorReason != nil {
@@ -1515,11 +1660,38 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(ClusterEncryptionStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RootCARotation != nil {
+		in, out := &in.RootCARotation, &out.RootCARotation
+		*out = new(ClusterRootCARotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneSizing != nil {
+		in, out := &in.ControlPlaneSizing, &out.ControlPlaneSizing
+		*out = new(ClusterControlPlaneSizingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ClusterTemplateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ResourceUsage != nil {
 		in, out := &in.ResourceUsage, &out.ResourceUsage
 		*out = new(ResourceDetails)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CostEstimate != nil {
+		in, out := &in.CostEstimate, &out.CostEstimate
+		*out = new(ClusterCostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1556,6 +1728,13 @@ func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
 		*out = make([]ClusterTemplateSSHKey, len(*in))
 		copy(*out, *in)
 	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]ClusterTemplateAddon, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
@@ -1577,6 +1756,26 @@ func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateAddon) DeepCopyInto(out *ClusterTemplateAddon) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateAddon.
+func (in *ClusterTemplateAddon) DeepCopy() *ClusterTemplateAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTemplateInstance) DeepCopyInto(out *ClusterTemplateInstance) {
 	*out = *in
@@ -1697,6 +1896,26 @@ func (in *ClusterTemplateSSHKey) DeepCopy() *ClusterTemplateSSHKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateStatus) DeepCopyInto(out *ClusterTemplateStatus) {
+	*out = *in
+	if in.DriftedFields != nil {
+		in, out := &in.DriftedFields, &out.DriftedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateStatus.
+func (in *ClusterTemplateStatus) DeepCopy() *ClusterTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVersionsStatus) DeepCopyInto(out *ClusterVersionsStatus) {
 	*out = *in
@@ -2015,6 +2234,115 @@ func (in *ContainerdRegistry) DeepCopy() *ContainerdRegistry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneGatewayConfig) DeepCopyInto(out *ControlPlaneGatewayConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneGatewayConfig.
+func (in *ControlPlaneGatewayConfig) DeepCopy() *ControlPlaneGatewayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneGatewayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneSizingConfiguration) DeepCopyInto(out *ControlPlaneSizingConfiguration) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DownscaleStabilizationIntervals != nil {
+		in, out := &in.DownscaleStabilizationIntervals, &out.DownscaleStabilizationIntervals
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]ControlPlaneSizingProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneSizingConfiguration.
+func (in *ControlPlaneSizingConfiguration) DeepCopy() *ControlPlaneSizingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneSizingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneSizingProfile) DeepCopyInto(out *ControlPlaneSizingProfile) {
+	*out = *in
+	if in.Apiserver != nil {
+		in, out := &in.Apiserver, &out.Apiserver
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneSizingProfile.
+func (in *ControlPlaneSizingProfile) DeepCopy() *ControlPlaneSizingProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneSizingProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneTopologySettings) DeepCopyInto(out *ControlPlaneTopologySettings) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneTopologySettings.
+func (in *ControlPlaneTopologySettings) DeepCopy() *ControlPlaneTopologySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneTopologySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerSettings) DeepCopyInto(out *ControllerSettings) {
 	*out = *in
@@ -2032,6 +2360,125 @@ func (in *ControllerSettings) DeepCopy() *ControllerSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotation) DeepCopyInto(out *CredentialRotation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotation.
+func (in *CredentialRotation) DeepCopy() *CredentialRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CredentialRotation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationClusterStatus) DeepCopyInto(out *CredentialRotationClusterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationClusterStatus.
+func (in *CredentialRotationClusterStatus) DeepCopy() *CredentialRotationClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationList) DeepCopyInto(out *CredentialRotationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CredentialRotation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationList.
+func (in *CredentialRotationList) DeepCopy() *CredentialRotationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CredentialRotationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationSpec) DeepCopyInto(out *CredentialRotationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationSpec.
+func (in *CredentialRotationSpec) DeepCopy() *CredentialRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationStatus) DeepCopyInto(out *CredentialRotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make(map[string]CredentialRotationClusterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationStatus.
+func (in *CredentialRotationStatus) DeepCopy() *CredentialRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomLink) DeepCopyInto(out *CustomLink) {
 	*out = *in
@@ -2228,6 +2675,18 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 		*out = new(corev1.ServiceType)
 		**out = **in
 	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(PricingCatalog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpec.
@@ -2250,6 +2709,11 @@ func (in *DatacenterSpecAWS) DeepCopyInto(out *DatacenterSpecAWS) {
 			(*out)[key] = val
 		}
 	}
+	if in.AllowSpotInstances != nil {
+		in, out := &in.AllowSpotInstances, &out.AllowSpotInstances
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecAWS.
@@ -2395,6 +2859,11 @@ func (in *DatacenterSpecGCP) DeepCopyInto(out *DatacenterSpecGCP) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowSpotInstances != nil {
+		in, out := &in.AllowSpotInstances, &out.AllowSpotInstances
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterSpecGCP.
@@ -2728,6 +3197,11 @@ func (in *DigitaloceanCloudSpec) DeepCopyInto(out *DigitaloceanCloudSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.NodePortsAllowedIPRanges != nil {
+		in, out := &in.NodePortsAllowedIPRanges, &out.NodePortsAllowedIPRanges
+		*out = new(NetworkRanges)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigitaloceanCloudSpec.
@@ -2980,6 +3454,31 @@ func (in *EtcdBackupRestore) DeepCopy() *EtcdBackupRestore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDefragmentationSettings) DeepCopyInto(out *EtcdDefragmentationSettings) {
+	*out = *in
+	if in.FragmentationThreshold != nil {
+		in, out := &in.FragmentationThreshold, &out.FragmentationThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinimumDatabaseSize != nil {
+		in, out := &in.MinimumDatabaseSize, &out.MinimumDatabaseSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDefragmentationSettings.
+func (in *EtcdDefragmentationSettings) DeepCopy() *EtcdDefragmentationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDefragmentationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdRestore) DeepCopyInto(out *EtcdRestore) {
 	*out = *in
@@ -3103,6 +3602,16 @@ func (in *EtcdStatefulSetSettings) DeepCopyInto(out *EtcdStatefulSetSettings) {
 			(*out)[key] = val
 		}
 	}
+	if in.Defragmentation != nil {
+		in, out := &in.Defragmentation, &out.Defragmentation
+		*out = new(EtcdDefragmentationSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuotaBackendBytes != nil {
+		in, out := &in.QuotaBackendBytes, &out.QuotaBackendBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdStatefulSetSettings.
@@ -3209,6 +3718,11 @@ func (in *ExtendedClusterHealth) DeepCopyInto(out *ExtendedClusterHealth) {
 		*out = new(HealthStatus)
 		**out = **in
 	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(HealthStatus)
+		**out = **in
+	}
 	if in.AlertmanagerConfig != nil {
 		in, out := &in.AlertmanagerConfig, &out.AlertmanagerConfig
 		*out = new(HealthStatus)
@@ -3252,7 +3766,7 @@ func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCluster.
@@ -3403,12 +3917,50 @@ func (in *ExternalClusterGKECloudSpec) DeepCopyInto(out *ExternalClusterGKECloud
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterGKECloudSpec.
-func (in *ExternalClusterGKECloudSpec) DeepCopy() *ExternalClusterGKECloudSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterGKECloudSpec.
+func (in *ExternalClusterGKECloudSpec) DeepCopy() *ExternalClusterGKECloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterGKECloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterInventory) DeepCopyInto(out *ExternalClusterInventory) {
+	*out = *in
+	in.LastCollected.DeepCopyInto(&out.LastCollected)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ExternalClusterNodeInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ComponentHealth != nil {
+		in, out := &in.ComponentHealth, &out.ComponentHealth
+		*out = make(map[string]HealthStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.APIServerCertificateExpiry != nil {
+		in, out := &in.APIServerCertificateExpiry, &out.APIServerCertificateExpiry
+		*out = (*in).DeepCopy()
+	}
+	if in.ClientCertificateExpiry != nil {
+		in, out := &in.ClientCertificateExpiry, &out.ClientCertificateExpiry
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterInventory.
+func (in *ExternalClusterInventory) DeepCopy() *ExternalClusterInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalClusterGKECloudSpec)
+	out := new(ExternalClusterInventory)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3512,6 +4064,26 @@ func (in *ExternalClusterNetworkingConfig) DeepCopy() *ExternalClusterNetworking
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterNodeInfo) DeepCopyInto(out *ExternalClusterNodeInfo) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterNodeInfo.
+func (in *ExternalClusterNodeInfo) DeepCopy() *ExternalClusterNodeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterNodeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalClusterProviderVersioningConfiguration) DeepCopyInto(out *ExternalClusterProviderVersioningConfiguration) {
 	*out = *in
@@ -3573,6 +4145,11 @@ func (in *ExternalClusterSpec) DeepCopy() *ExternalClusterSpec {
 func (in *ExternalClusterStatus) DeepCopyInto(out *ExternalClusterStatus) {
 	*out = *in
 	out.Condition = in.Condition
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(ExternalClusterInventory)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterStatus.
@@ -3785,6 +4362,11 @@ func (in *HetznerCloudSpec) DeepCopyInto(out *HetznerCloudSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.NodePortsAllowedIPRanges != nil {
+		in, out := &in.NodePortsAllowedIPRanges, &out.NodePortsAllowedIPRanges
+		*out = new(NetworkRanges)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerCloudSpec.
@@ -4068,6 +4650,156 @@ func (in *Incompatibility) DeepCopy() *Incompatibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuthenticator) DeepCopyInto(out *JWTAuthenticator) {
+	*out = *in
+	in.Issuer.DeepCopyInto(&out.Issuer)
+	if in.ClaimValidationRules != nil {
+		in, out := &in.ClaimValidationRules, &out.ClaimValidationRules
+		*out = make([]JWTClaimValidationRule, len(*in))
+		copy(*out, *in)
+	}
+	in.ClaimMappings.DeepCopyInto(&out.ClaimMappings)
+	if in.UserValidationRules != nil {
+		in, out := &in.UserValidationRules, &out.UserValidationRules
+		*out = make([]JWTUserValidationRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthenticator.
+func (in *JWTAuthenticator) DeepCopy() *JWTAuthenticator {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuthenticator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimMappings) DeepCopyInto(out *JWTClaimMappings) {
+	*out = *in
+	in.Username.DeepCopyInto(&out.Username)
+	in.Groups.DeepCopyInto(&out.Groups)
+	out.UID = in.UID
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make([]JWTExtraMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimMappings.
+func (in *JWTClaimMappings) DeepCopy() *JWTClaimMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimOrExpression) DeepCopyInto(out *JWTClaimOrExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimOrExpression.
+func (in *JWTClaimOrExpression) DeepCopy() *JWTClaimOrExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimOrExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimValidationRule) DeepCopyInto(out *JWTClaimValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimValidationRule.
+func (in *JWTClaimValidationRule) DeepCopy() *JWTClaimValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTExtraMapping) DeepCopyInto(out *JWTExtraMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTExtraMapping.
+func (in *JWTExtraMapping) DeepCopy() *JWTExtraMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTExtraMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTIssuer) DeepCopyInto(out *JWTIssuer) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTIssuer.
+func (in *JWTIssuer) DeepCopy() *JWTIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTPrefixedClaimOrExpression) DeepCopyInto(out *JWTPrefixedClaimOrExpression) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTPrefixedClaimOrExpression.
+func (in *JWTPrefixedClaimOrExpression) DeepCopy() *JWTPrefixedClaimOrExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTPrefixedClaimOrExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTUserValidationRule) DeepCopyInto(out *JWTUserValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTUserValidationRule.
+func (in *JWTUserValidationRule) DeepCopy() *JWTUserValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTUserValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kind) DeepCopyInto(out *Kind) {
 	*out = *in
@@ -4630,6 +5362,11 @@ func (in *KubermaticUserClusterConfiguration) DeepCopyInto(out *KubermaticUserCl
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ControlPlaneSizing != nil {
+		in, out := &in.ControlPlaneSizing, &out.ControlPlaneSizing
+		*out = new(ControlPlaneSizingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	out.MachineController = in.MachineController
 	out.OperatingSystemManager = in.OperatingSystemManager
 }
@@ -4951,6 +5688,11 @@ func (in *MLAAdminSettingSpec) DeepCopyInto(out *MLAAdminSettingSpec) {
 		*out = new(LoggingRateLimitSettings)
 		**out = **in
 	}
+	if in.TracingRateLimits != nil {
+		in, out := &in.TracingRateLimits, &out.TracingRateLimits
+		*out = new(TracingRateLimitSettings)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLAAdminSettingSpec.
@@ -4976,6 +5718,11 @@ func (in *MLASettings) DeepCopyInto(out *MLASettings) {
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TracingResources != nil {
+		in, out := &in.TracingResources, &out.TracingResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MonitoringReplicas != nil {
 		in, out := &in.MonitoringReplicas, &out.MonitoringReplicas
 		*out = new(int32)
@@ -5008,6 +5755,21 @@ func (in *MachineControllerConfiguration) DeepCopy() *MachineControllerConfigura
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentCostEstimate) DeepCopyInto(out *MachineDeploymentCostEstimate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentCostEstimate.
+func (in *MachineDeploymentCostEstimate) DeepCopy() *MachineDeploymentCostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentCostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentOptions) DeepCopyInto(out *MachineDeploymentOptions) {
 	*out = *in
@@ -5281,6 +6043,11 @@ func (in *NodeportProxyConfig) DeepCopyInto(out *NodeportProxyConfig) {
 	in.Envoy.DeepCopyInto(&out.Envoy)
 	in.EnvoyManager.DeepCopyInto(&out.EnvoyManager)
 	in.Updater.DeepCopyInto(&out.Updater)
+	if in.PrivateLoadBalancerService != nil {
+		in, out := &in.PrivateLoadBalancerService, &out.PrivateLoadBalancerService
+		*out = new(EnvoyLoadBalancerService)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IPFamilyPolicy != nil {
 		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
 		*out = new(corev1.IPFamilyPolicy)
@@ -5627,6 +6394,31 @@ func (in OperatingSystemProfileList) DeepCopy() OperatingSystemProfileList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedCloudResourcesSettings) DeepCopyInto(out *OrphanedCloudResourcesSettings) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DeletionGracePeriod != nil {
+		in, out := &in.DeletionGracePeriod, &out.DeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedCloudResourcesSettings.
+func (in *OrphanedCloudResourcesSettings) DeepCopy() *OrphanedCloudResourcesSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedCloudResourcesSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Packet) DeepCopyInto(out *Packet) {
 	*out = *in
@@ -5893,6 +6685,43 @@ func (in *PresetSpec) DeepCopy() *PresetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PricingCatalog) DeepCopyInto(out *PricingCatalog) {
+	*out = *in
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PricingCatalog.
+func (in *PricingCatalog) DeepCopy() *PricingCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(PricingCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateClusterSettings) DeepCopyInto(out *PrivateClusterSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateClusterSettings.
+func (in *PrivateClusterSettings) DeepCopy() *PrivateClusterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateClusterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
@@ -5977,6 +6806,13 @@ func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
@@ -6268,6 +7104,22 @@ func (in *RuleGroupSpec) DeepCopy() *RuleGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKeyCertificate) DeepCopyInto(out *SSHKeyCertificate) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyCertificate.
+func (in *SSHKeyCertificate) DeepCopy() *SSHKeyCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKeyCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHKeySpec) DeepCopyInto(out *SSHKeySpec) {
 	*out = *in
@@ -6276,6 +7128,10 @@ func (in *SSHKeySpec) DeepCopyInto(out *SSHKeySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeySpec.
@@ -6288,6 +7144,28 @@ func (in *SSHKeySpec) DeepCopy() *SSHKeySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKeyStatus) DeepCopyInto(out *SSHKeyStatus) {
+	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]SSHKeyCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyStatus.
+func (in *SSHKeyStatus) DeepCopy() *SSHKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretboxEncryptionConfiguration) DeepCopyInto(out *SecretboxEncryptionConfiguration) {
 	*out = *in
@@ -6438,6 +7316,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = new(ProxySettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ControlPlaneGateway != nil {
+		in, out := &in.ControlPlaneGateway, &out.ControlPlaneGateway
+		*out = new(ControlPlaneGatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MLA != nil {
 		in, out := &in.MLA, &out.MLA
 		*out = new(SeedMLASettings)
@@ -6469,6 +7352,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = make([]MetricsCollector, len(*in))
 		copy(*out, *in)
 	}
+	if in.OrphanedCloudResources != nil {
+		in, out := &in.OrphanedCloudResources, &out.OrphanedCloudResources
+		*out = new(OrphanedCloudResourcesSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedSpec.
@@ -6763,6 +7651,21 @@ func (in *TinkerbellImageSources) DeepCopy() *TinkerbellImageSources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingRateLimitSettings) DeepCopyInto(out *TracingRateLimitSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingRateLimitSettings.
+func (in *TracingRateLimitSettings) DeepCopy() *TracingRateLimitSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingRateLimitSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Update) DeepCopyInto(out *Update) {
 	*out = *in
@@ -6941,6 +7844,7 @@ func (in *UserSSHKey) DeepCopyInto(out *UserSSHKey) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSSHKey.
@@ -6961,6 +7865,26 @@ func (in *UserSSHKey) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserSSHKeyCertificateAuthoritySettings) DeepCopyInto(out *UserSSHKeyCertificateAuthoritySettings) {
+	*out = *in
+	if in.CertificateValidity != nil {
+		in, out := &in.CertificateValidity, &out.CertificateValidity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSSHKeyCertificateAuthoritySettings.
+func (in *UserSSHKeyCertificateAuthoritySettings) DeepCopy() *UserSSHKeyCertificateAuthoritySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UserSSHKeyCertificateAuthoritySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserSSHKeyList) DeepCopyInto(out *UserSSHKeyList) {
 	*out = *in
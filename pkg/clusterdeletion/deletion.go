@@ -34,10 +34,13 @@ const (
 	deletedLBAnnotationName = "kubermatic.k8c.io/cleaned-up-loadbalancers"
 )
 
-func New(seedClient ctrlruntimeclient.Client, recorder record.EventRecorder, userClusterClientGetter func() (ctrlruntimeclient.Client, error)) *Deletion {
+// New returns a new Deletion. The namespace is the KKP namespace on the seed, where
+// retained volumes are recorded.
+func New(seedClient ctrlruntimeclient.Client, recorder record.EventRecorder, namespace string, userClusterClientGetter func() (ctrlruntimeclient.Client, error)) *Deletion {
 	return &Deletion{
 		seedClient:              seedClient,
 		recorder:                recorder,
+		namespace:               namespace,
 		userClusterClientGetter: userClusterClientGetter,
 	}
 }
@@ -45,6 +48,7 @@ func New(seedClient ctrlruntimeclient.Client, recorder record.EventRecorder, use
 type Deletion struct {
 	seedClient              ctrlruntimeclient.Client
 	recorder                record.EventRecorder
+	namespace               string
 	userClusterClientGetter func() (ctrlruntimeclient.Client, error)
 }
 
@@ -52,6 +56,14 @@ type Deletion struct {
 func (d *Deletion) CleanupCluster(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
 	log = log.Named("cleanup")
 
+	// Do not touch anything until the user has confirmed the deletion report.
+	if AwaitingConfirmation(cluster) {
+		confirmed, err := d.checkConfirmation(ctx, log, cluster)
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+
 	// Delete OPA constraints first to make sure some rules dont block deletion
 	if err := d.cleanupConstraints(ctx, log, cluster); err != nil {
 		return err
@@ -0,0 +1,517 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DeletionDryRunAnnotation can be put on a Cluster to request a deletion report. The
+	// report is written into the DeletionReportConfigMapName ConfigMap in the cluster
+	// namespace and the annotation is removed afterwards.
+	DeletionDryRunAnnotation = "kubermatic.k8c.io/deletion-dry-run"
+
+	// DeletionConfirmationRequiredAnnotation makes the cleanup of a deleted Cluster wait
+	// until the DeletionConfirmationAnnotation contains the hash of the current deletion
+	// report. This has to be set before the Cluster is deleted.
+	DeletionConfirmationRequiredAnnotation = "kubermatic.k8c.io/deletion-requires-confirmation"
+
+	// DeletionConfirmationAnnotation contains the hash of the deletion report that the
+	// user has reviewed and agrees with.
+	DeletionConfirmationAnnotation = "kubermatic.k8c.io/deletion-confirmation"
+
+	// DeletionReportConfigMapName is the name of the ConfigMap in the cluster namespace
+	// that contains the latest deletion report.
+	DeletionReportConfigMapName = "cluster-deletion-report"
+
+	// DeletionReportKey is the key in the report ConfigMap holding the JSON encoded report.
+	DeletionReportKey = "report.json"
+	// DeletionReportHashKey is the key in the report ConfigMap holding the report's hash.
+	DeletionReportHashKey = "hash"
+
+	finalizerCleanupPrefix = "kubermatic.k8c.io/cleanup-"
+)
+
+// ReportAction describes what the cluster deletion will do with a resource.
+type ReportAction string
+
+const (
+	// ReportActionDelete means that the resource will be removed.
+	ReportActionDelete ReportAction = "Delete"
+	// ReportActionRetain means that the resource will be left behind.
+	ReportActionRetain ReportAction = "Retain"
+)
+
+// ReportItem is a single resource affected by the cluster deletion.
+type ReportItem struct {
+	Name    string       `json:"name"`
+	Action  ReportAction `json:"action"`
+	Details string       `json:"details,omitempty"`
+}
+
+// Report lists all resources that would be removed or retained if the cluster was
+// deleted right now.
+type Report struct {
+	Cluster                string       `json:"cluster"`
+	LoadBalancers          []ReportItem `json:"loadBalancers,omitempty"`
+	PersistentVolumeClaims []ReportItem `json:"persistentVolumeClaims,omitempty"`
+	PersistentVolumes      []ReportItem `json:"persistentVolumes,omitempty"`
+	MachineDeployments     []ReportItem `json:"machineDeployments,omitempty"`
+	Machines               []ReportItem `json:"machines,omitempty"`
+	CloudResources         []ReportItem `json:"cloudResources,omitempty"`
+	EtcdBackupConfigs      []ReportItem `json:"etcdBackupConfigs,omitempty"`
+	EtcdBackups            []ReportItem `json:"etcdBackups,omitempty"`
+	// UserClusterError is set if the resources inside the user cluster could not be listed,
+	// e.g. because its control plane is broken. The report then only covers the resources
+	// on the seed and at the cloud provider.
+	UserClusterError string `json:"userClusterError,omitempty"`
+}
+
+// Hash returns a stable hash of the report that can be used to confirm the deletion. Only
+// the identifiers of the resources and their actions are hashed, as details like addresses,
+// node names or the current backups can change while the user reviews the report. Machines
+// are covered by their MachineDeployments and backups by their EtcdBackupConfigs.
+func (r *Report) Hash() (string, error) {
+	identifiers := func(items []ReportItem) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, fmt.Sprintf("%s=%s", item.Name, item.Action))
+		}
+		return result
+	}
+
+	encoded, err := json.Marshal(map[string]interface{}{
+		"cluster":                r.Cluster,
+		"loadBalancers":          identifiers(r.LoadBalancers),
+		"persistentVolumeClaims": identifiers(r.PersistentVolumeClaims),
+		"persistentVolumes":      identifiers(r.PersistentVolumes),
+		"machineDeployments":     identifiers(r.MachineDeployments),
+		"cloudResources":         identifiers(r.CloudResources),
+		"etcdBackupConfigs":      identifiers(r.EtcdBackupConfigs),
+		"userClusterUnreachable": r.UserClusterError != "",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AwaitingConfirmation returns true if the cluster requires an explicit confirmation
+// before its resources can be cleaned up.
+func AwaitingConfirmation(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Annotations[DeletionConfirmationRequiredAnnotation] == "true"
+}
+
+// Report predicts what CleanupCluster would do with the cluster's resources, without
+// changing anything. An unreachable user cluster does not fail the report, so that broken
+// clusters can still be deleted; the error is recorded in the report instead.
+func (d *Deletion) Report(ctx context.Context, cluster *kubermaticv1.Cluster) (*Report, error) {
+	report := &Report{
+		Cluster:        cluster.Name,
+		CloudResources: cloudResourcesReport(cluster),
+	}
+
+	// Without a namespace there is neither a user cluster nor any backups.
+	if cluster.Status.NamespaceName == "" {
+		return report, nil
+	}
+
+	if err := d.userClusterReport(ctx, cluster, report); err != nil {
+		report.LoadBalancers = nil
+		report.PersistentVolumeClaims = nil
+		report.PersistentVolumes = nil
+		report.MachineDeployments = nil
+		report.Machines = nil
+		report.UserClusterError = err.Error()
+	}
+
+	var err error
+	if report.EtcdBackupConfigs, report.EtcdBackups, err = d.backupsReport(ctx, cluster); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (d *Deletion) userClusterReport(ctx context.Context, cluster *kubermaticv1.Cluster, report *Report) error {
+	userClusterClient, err := d.userClusterClientGetter()
+	if err != nil {
+		return err
+	}
+
+	if report.LoadBalancers, err = loadBalancersReport(ctx, userClusterClient, cluster); err != nil {
+		return err
+	}
+
+	if report.PersistentVolumeClaims, report.PersistentVolumes, err = volumesReport(ctx, userClusterClient, cluster); err != nil {
+		return err
+	}
+
+	if report.MachineDeployments, report.Machines, err = machinesReport(ctx, userClusterClient, cluster); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PublishReport writes the report and its hash into the report ConfigMap inside
+// the cluster namespace.
+func (d *Deletion) PublishReport(ctx context.Context, cluster *kubermaticv1.Cluster, report *Report) (string, error) {
+	hash, err := report.Hash()
+	if err != nil {
+		return "", fmt.Errorf("failed to hash report: %w", err)
+	}
+
+	if cluster.Status.NamespaceName == "" {
+		return hash, nil
+	}
+
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: DeletionReportConfigMapName}
+	if err := d.seedClient.Get(ctx, key, cm); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return "", fmt.Errorf("failed to get ConfigMap: %w", err)
+	}
+
+	exists := cm.Name != ""
+	cm.Name = key.Name
+	cm.Namespace = key.Namespace
+	cm.Data = map[string]string{
+		DeletionReportKey:     string(encoded),
+		DeletionReportHashKey: hash,
+	}
+
+	if exists {
+		err = d.seedClient.Update(ctx, cm)
+	} else {
+		err = d.seedClient.Create(ctx, cm)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to store report ConfigMap: %w", err)
+	}
+
+	return hash, nil
+}
+
+// checkConfirmation returns true if the deletion report has been confirmed by the user. Once
+// confirmed, the confirmation requirement is removed, as the report naturally changes
+// while the cleanup progresses.
+func (d *Deletion) checkConfirmation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (bool, error) {
+	report, err := d.Report(ctx, cluster)
+	if err != nil {
+		return false, fmt.Errorf("failed to create deletion report: %w", err)
+	}
+
+	hash, err := d.PublishReport(ctx, cluster, report)
+	if err != nil {
+		return false, err
+	}
+
+	if report.UserClusterError != "" {
+		d.recorder.Eventf(cluster, corev1.EventTypeWarning, "ClusterCleanup", "The deletion report does not cover resources inside the user cluster, as it is unreachable: %s", report.UserClusterError)
+	}
+
+	if cluster.Annotations[DeletionConfirmationAnnotation] != hash {
+		d.recorder.Eventf(cluster, corev1.EventTypeNormal, "ClusterCleanup", "Waiting for deletion to be confirmed, review the %s ConfigMap and set the %s annotation to %q.", DeletionReportConfigMapName, DeletionConfirmationAnnotation, hash)
+		return false, nil
+	}
+
+	log.Infow("Deletion has been confirmed", "hash", hash)
+
+	oldCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, DeletionConfirmationRequiredAnnotation)
+	if err := d.seedClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return false, fmt.Errorf("failed to remove %s annotation: %w", DeletionConfirmationRequiredAnnotation, err)
+	}
+
+	return true, nil
+}
+
+func loadBalancersReport(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) ([]ReportItem, error) {
+	serviceList := &corev1.ServiceList{}
+	if err := client.List(ctx, serviceList); err != nil {
+		return nil, fmt.Errorf("failed to list Services: %w", err)
+	}
+
+	action := ReportActionRetain
+	if kuberneteshelper.HasFinalizer(cluster, kubermaticv1.InClusterLBCleanupFinalizer) {
+		action = ReportActionDelete
+	}
+
+	var items []ReportItem
+	for _, service := range serviceList.Items {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		var addresses []string
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			}
+			if ingress.Hostname != "" {
+				addresses = append(addresses, ingress.Hostname)
+			}
+		}
+
+		items = append(items, ReportItem{
+			Name:    objectName(&service),
+			Action:  action,
+			Details: strings.Join(addresses, ","),
+		})
+	}
+
+	return sortItems(items), nil
+}
+
+func volumesReport(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (pvcs []ReportItem, pvs []ReportItem, err error) {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := client.List(ctx, pvcList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list PVCs: %w", err)
+	}
+
+	pvList := &corev1.PersistentVolumeList{}
+	if err := client.List(ctx, pvList); err != nil {
+		return nil, nil, fmt.Errorf("failed to list PVs: %w", err)
+	}
+
+	deleteVolumes := kuberneteshelper.HasFinalizer(cluster, kubermaticv1.InClusterPVCleanupFinalizer)
+
+	action := ReportActionRetain
+	if deleteVolumes {
+		action = ReportActionDelete
+	}
+
+	retainedVolumes := sets.New[string]()
+
+	for _, pvc := range pvcList.Items {
+		if retainsVolume(cluster, &pvc) && pvc.Spec.VolumeName != "" {
+			retainedVolumes.Insert(pvc.Spec.VolumeName)
+		}
+
+		pvcs = append(pvcs, ReportItem{
+			Name:    objectName(&pvc),
+			Action:  action,
+			Details: pvc.Spec.VolumeName,
+		})
+	}
+
+	for _, pv := range pvList.Items {
+		// Only dynamically provisioned volumes with the Delete reclaim policy are removed
+		// by their provisioner once their claim is gone, see cleanupVolumes.
+		action := ReportActionRetain
+		if deleteVolumes && !retainedVolumes.Has(pv.Name) && pv.Annotations[AnnDynamicallyProvisioned] != "" && pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimDelete {
+			action = ReportActionDelete
+		}
+
+		var details string
+		if pv.Spec.CSI != nil {
+			details = pv.Spec.CSI.VolumeHandle
+		}
+
+		pvs = append(pvs, ReportItem{
+			Name:    pv.Name,
+			Action:  action,
+			Details: details,
+		})
+	}
+
+	return sortItems(pvcs), sortItems(pvs), nil
+}
+
+func machinesReport(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (mds []ReportItem, machines []ReportItem, err error) {
+	action := ReportActionRetain
+	if kuberneteshelper.HasFinalizer(cluster, kubermaticv1.NodeDeletionFinalizer) {
+		action = ReportActionDelete
+	}
+
+	listOpts := ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)
+
+	machineDeploymentList := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeploymentList, listOpts); err != nil && !meta.IsNoMatchError(err) {
+		return nil, nil, fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	for _, md := range machineDeploymentList.Items {
+		var replicas int32
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+
+		mds = append(mds, ReportItem{
+			Name:    md.Name,
+			Action:  action,
+			Details: fmt.Sprintf("replicas=%d", replicas),
+		})
+	}
+
+	machineList := &clusterv1alpha1.MachineList{}
+	if err := client.List(ctx, machineList, listOpts); err != nil && !meta.IsNoMatchError(err) {
+		return nil, nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	for _, machine := range machineList.Items {
+		var details string
+		if machine.Status.NodeRef != nil {
+			details = machine.Status.NodeRef.Name
+		}
+
+		machines = append(machines, ReportItem{
+			Name:    machine.Name,
+			Action:  action,
+			Details: details,
+		})
+	}
+
+	return sortItems(mds), sortItems(machines), nil
+}
+
+func (d *Deletion) backupsReport(ctx context.Context, cluster *kubermaticv1.Cluster) (configs []ReportItem, backups []ReportItem, err error) {
+	backupConfigs := &kubermaticv1.EtcdBackupConfigList{}
+	if err := d.seedClient.List(ctx, backupConfigs, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list EtcdBackupConfigs: %w", err)
+	}
+
+	// Deleting an EtcdBackupConfig makes the backup controller delete all of its backups.
+	action := ReportActionRetain
+	if kuberneteshelper.HasFinalizer(cluster, kubermaticv1.EtcdBackupConfigCleanupFinalizer) {
+		action = ReportActionDelete
+	}
+
+	for _, config := range backupConfigs.Items {
+		configs = append(configs, ReportItem{
+			Name:    config.Name,
+			Action:  action,
+			Details: config.Spec.Destination,
+		})
+
+		for _, backup := range config.Status.CurrentBackups {
+			if backup.DeletePhase == kubermaticv1.BackupStatusPhaseCompleted {
+				continue
+			}
+
+			backups = append(backups, ReportItem{
+				Name:    backup.BackupName,
+				Action:  action,
+				Details: config.Spec.Destination,
+			})
+		}
+	}
+
+	return sortItems(configs), sortItems(backups), nil
+}
+
+// cloudResourceIDs maps the cleanup finalizers of the cloud providers to the ID of the
+// resource they protect, as recorded in the cloud spec. Resources whose names are derived
+// from the cluster name are not recorded in the spec and are reported by type only.
+var cloudResourceIDs = map[string]func(cloud kubermaticv1.CloudSpec) string{
+	"aws-security-group":         func(c kubermaticv1.CloudSpec) string { return c.AWS.SecurityGroupID },
+	"aws-instance-profile":       func(c kubermaticv1.CloudSpec) string { return c.AWS.InstanceProfileName },
+	"aws-control-plane-role":     func(c kubermaticv1.CloudSpec) string { return c.AWS.ControlPlaneRoleARN },
+	"azure-security-group":       func(c kubermaticv1.CloudSpec) string { return c.Azure.SecurityGroup },
+	"azure-route-table":          func(c kubermaticv1.CloudSpec) string { return c.Azure.RouteTableName },
+	"azure-subnet":               func(c kubermaticv1.CloudSpec) string { return c.Azure.SubnetName },
+	"azure-vnet":                 func(c kubermaticv1.CloudSpec) string { return c.Azure.VNetName },
+	"azure-resource-group":       func(c kubermaticv1.CloudSpec) string { return c.Azure.ResourceGroup },
+	"azure-availability-set":     func(c kubermaticv1.CloudSpec) string { return c.Azure.AvailabilitySet },
+	"openstack-security-group":   func(c kubermaticv1.CloudSpec) string { return c.Openstack.SecurityGroups },
+	"openstack-network":          func(c kubermaticv1.CloudSpec) string { return c.Openstack.Network },
+	"openstack-network-v2":       func(c kubermaticv1.CloudSpec) string { return c.Openstack.Network },
+	"openstack-subnet-v2":        func(c kubermaticv1.CloudSpec) string { return c.Openstack.SubnetID },
+	"openstack-subnet-ipv6":      func(c kubermaticv1.CloudSpec) string { return c.Openstack.IPv6SubnetID },
+	"openstack-router-v2":        func(c kubermaticv1.CloudSpec) string { return c.Openstack.RouterID },
+	"hetzner-network":            func(c kubermaticv1.CloudSpec) string { return c.Hetzner.Network },
+	"hetzner-firewall":           func(c kubermaticv1.CloudSpec) string { return c.Hetzner.Firewall },
+	"hetzner-placement-group":    func(c kubermaticv1.CloudSpec) string { return c.Hetzner.PlacementGroupPrefix },
+	"digitalocean-firewall":      func(c kubermaticv1.CloudSpec) string { return c.Digitalocean.FirewallID },
+	"digitalocean-vpc":           func(c kubermaticv1.CloudSpec) string { return c.Digitalocean.VPCID },
+	"vsphere-folder":             func(c kubermaticv1.CloudSpec) string { return c.VSphere.Folder },
+	"vmware-cloud-director-vapp": func(c kubermaticv1.CloudSpec) string { return c.VMwareCloudDirector.VApp },
+}
+
+// cloudResourcesReport lists the cloud resources that the cloud provider will remove. Cloud
+// providers track every resource they created (and will delete) with a dedicated finalizer
+// named after the provider, so these finalizers are an accurate list of what is going to
+// be removed. Each resource is reported as "<type>/<ID>" if its ID is known.
+func cloudResourcesReport(cluster *kubermaticv1.Cluster) []ReportItem {
+	providerName, err := kubermaticv1helper.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil || providerName == "" {
+		return nil
+	}
+
+	var items []ReportItem
+	for _, finalizer := range cluster.Finalizers {
+		resource, ok := strings.CutPrefix(finalizer, finalizerCleanupPrefix)
+		if !ok || !strings.HasPrefix(strings.ReplaceAll(resource, "-", ""), providerName) {
+			continue
+		}
+
+		name := resource
+		if getID, ok := cloudResourceIDs[resource]; ok {
+			if id := getID(cluster.Spec.Cloud); id != "" {
+				name = fmt.Sprintf("%s/%s", resource, id)
+			}
+		}
+
+		items = append(items, ReportItem{
+			Name:   name,
+			Action: ReportActionDelete,
+		})
+	}
+
+	return sortItems(items)
+}
+
+func objectName(obj ctrlruntimeclient.Object) string {
+	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+}
+
+func sortItems(items []ReportItem) []ReportItem {
+	slices.SortFunc(items, func(a, b ReportItem) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return items
+}
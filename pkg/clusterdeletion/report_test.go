@@ -0,0 +1,302 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func getReportTestCluster() *kubermaticv1.Cluster {
+	cluster := getClusterWithFinalizer("cluster",
+		kubermaticv1.InClusterLBCleanupFinalizer,
+		kubermaticv1.InClusterPVCleanupFinalizer,
+		kubermaticv1.NodeDeletionFinalizer,
+		kubermaticv1.EtcdBackupConfigCleanupFinalizer,
+		"kubermatic.k8c.io/cleanup-hetzner-firewall",
+		"kubermatic.k8c.io/cleanup-aws-tags",
+	)
+	cluster.Spec.Cloud.Hetzner = &kubermaticv1.HetznerCloudSpec{Firewall: "kubernetes-cluster"}
+	cluster.Status.NamespaceName = testNS
+
+	return cluster
+}
+
+func getReportTestObjects() (seedObjects []ctrlruntimeclient.Object, userClusterObjects []ctrlruntimeclient.Object) {
+	seedObjects = []ctrlruntimeclient.Object{
+		&kubermaticv1.EtcdBackupConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: "daily"},
+			Spec:       kubermaticv1.EtcdBackupConfigSpec{Destination: "s3"},
+			Status: kubermaticv1.EtcdBackupConfigStatus{
+				CurrentBackups: []kubermaticv1.BackupStatus{
+					{BackupName: "daily-2"},
+					{BackupName: "daily-1"},
+					{BackupName: "daily-0", DeletePhase: kubermaticv1.BackupStatusPhaseCompleted},
+				},
+			},
+		},
+	}
+
+	userClusterObjects = []ctrlruntimeclient.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "internal"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-dynamic"},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-dynamic", Annotations: map[string]string{AnnDynamicallyProvisioned: "csi"}},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{VolumeHandle: "vol-1"},
+				},
+			},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-static"},
+			Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain},
+		},
+		&clusterv1alpha1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "workers"},
+			Spec:       clusterv1alpha1.MachineDeploymentSpec{Replicas: ptr.To[int32](3)},
+		},
+	}
+
+	return seedObjects, userClusterObjects
+}
+
+func getReportTestDeletion(cluster *kubermaticv1.Cluster) (*Deletion, ctrlruntimeclient.Client, ctrlruntimeclient.Client) {
+	seedObjects, userClusterObjects := getReportTestObjects()
+
+	seedClient := fake.NewClientBuilder().WithObjects(append(seedObjects, cluster)...).Build()
+	userClusterClient := fake.
+		NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(userClusterObjects...).
+		Build()
+
+	deletion := &Deletion{
+		seedClient: seedClient,
+		recorder:   &record.FakeRecorder{},
+		userClusterClientGetter: func() (ctrlruntimeclient.Client, error) {
+			return userClusterClient, nil
+		},
+	}
+
+	return deletion, seedClient, userClusterClient
+}
+
+func TestReport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cluster  func() *kubermaticv1.Cluster
+		expected *Report
+	}{
+		{
+			name:    "all resources are deleted",
+			cluster: getReportTestCluster,
+			expected: &Report{
+				Cluster:                "cluster",
+				LoadBalancers:          []ReportItem{{Name: "default/ingress", Action: ReportActionDelete, Details: "1.2.3.4"}},
+				PersistentVolumeClaims: []ReportItem{{Name: "default/data", Action: ReportActionDelete, Details: "pv-dynamic"}},
+				PersistentVolumes: []ReportItem{
+					{Name: "pv-dynamic", Action: ReportActionDelete, Details: "vol-1"},
+					{Name: "pv-static", Action: ReportActionRetain},
+				},
+				MachineDeployments: []ReportItem{{Name: "workers", Action: ReportActionDelete, Details: "replicas=3"}},
+				CloudResources:     []ReportItem{{Name: "hetzner-firewall/kubernetes-cluster", Action: ReportActionDelete}},
+				EtcdBackupConfigs:  []ReportItem{{Name: "daily", Action: ReportActionDelete, Details: "s3"}},
+				EtcdBackups: []ReportItem{
+					{Name: "daily-1", Action: ReportActionDelete, Details: "s3"},
+					{Name: "daily-2", Action: ReportActionDelete, Details: "s3"},
+				},
+			},
+		},
+		{
+			name: "in-cluster resources are retained without finalizers",
+			cluster: func() *kubermaticv1.Cluster {
+				cluster := getReportTestCluster()
+				cluster.Finalizers = nil
+				return cluster
+			},
+			expected: &Report{
+				Cluster:                "cluster",
+				LoadBalancers:          []ReportItem{{Name: "default/ingress", Action: ReportActionRetain, Details: "1.2.3.4"}},
+				PersistentVolumeClaims: []ReportItem{{Name: "default/data", Action: ReportActionRetain, Details: "pv-dynamic"}},
+				PersistentVolumes: []ReportItem{
+					{Name: "pv-dynamic", Action: ReportActionRetain, Details: "vol-1"},
+					{Name: "pv-static", Action: ReportActionRetain},
+				},
+				MachineDeployments: []ReportItem{{Name: "workers", Action: ReportActionRetain, Details: "replicas=3"}},
+				EtcdBackupConfigs:  []ReportItem{{Name: "daily", Action: ReportActionRetain, Details: "s3"}},
+				EtcdBackups: []ReportItem{
+					{Name: "daily-1", Action: ReportActionRetain, Details: "s3"},
+					{Name: "daily-2", Action: ReportActionRetain, Details: "s3"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deletion, _, _ := getReportTestDeletion(tc.cluster())
+
+			report, err := deletion.Report(context.Background(), tc.cluster())
+			if err != nil {
+				t.Fatalf("Failed to create report: %v", err)
+			}
+
+			if diff := deep.Equal(tc.expected, report); diff != nil {
+				t.Errorf("Report differs from expectations: %v", diff)
+			}
+		})
+	}
+}
+
+func TestReportUnreachableUserCluster(t *testing.T) {
+	cluster := getReportTestCluster()
+
+	deletion, _, _ := getReportTestDeletion(cluster)
+	deletion.userClusterClientGetter = func() (ctrlruntimeclient.Client, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	report, err := deletion.Report(context.Background(), cluster)
+	if err != nil {
+		t.Fatalf("Expected report for unreachable user cluster, got error: %v", err)
+	}
+
+	expected := &Report{
+		Cluster:           "cluster",
+		CloudResources:    []ReportItem{{Name: "hetzner-firewall/kubernetes-cluster", Action: ReportActionDelete}},
+		EtcdBackupConfigs: []ReportItem{{Name: "daily", Action: ReportActionDelete, Details: "s3"}},
+		EtcdBackups: []ReportItem{
+			{Name: "daily-1", Action: ReportActionDelete, Details: "s3"},
+			{Name: "daily-2", Action: ReportActionDelete, Details: "s3"},
+		},
+		UserClusterError: "connection refused",
+	}
+
+	if diff := deep.Equal(expected, report); diff != nil {
+		t.Errorf("Report differs from expectations: %v", diff)
+	}
+}
+
+func TestReportHashIgnoresVolatileDetails(t *testing.T) {
+	report := &Report{
+		Cluster:            "cluster",
+		LoadBalancers:      []ReportItem{{Name: "default/ingress", Action: ReportActionDelete, Details: "1.2.3.4"}},
+		MachineDeployments: []ReportItem{{Name: "workers", Action: ReportActionDelete, Details: "replicas=3"}},
+		Machines:           []ReportItem{{Name: "workers-abc", Action: ReportActionDelete, Details: "node-1"}},
+		EtcdBackupConfigs:  []ReportItem{{Name: "daily", Action: ReportActionDelete, Details: "s3"}},
+		EtcdBackups:        []ReportItem{{Name: "daily-1", Action: ReportActionDelete, Details: "s3"}},
+	}
+
+	hash, err := report.Hash()
+	if err != nil {
+		t.Fatalf("Failed to hash report: %v", err)
+	}
+
+	report.LoadBalancers[0].Details = "5.6.7.8"
+	report.MachineDeployments[0].Details = "replicas=4"
+	report.Machines = []ReportItem{{Name: "workers-def", Action: ReportActionDelete, Details: "node-2"}}
+	report.EtcdBackups = append(report.EtcdBackups, ReportItem{Name: "daily-2", Action: ReportActionDelete, Details: "s3"})
+
+	if newHash, _ := report.Hash(); newHash != hash {
+		t.Error("Expected hash to ignore addresses, replicas, machines and backups")
+	}
+
+	report.LoadBalancers[0].Action = ReportActionRetain
+	if newHash, _ := report.Hash(); newHash == hash {
+		t.Error("Expected hash to change when an action changes")
+	}
+}
+
+func TestDeletionRequiresConfirmation(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := getReportTestCluster()
+	cluster.Annotations = map[string]string{
+		DeletionConfirmationRequiredAnnotation: "true",
+		DeletionConfirmationAnnotation:         "outdated",
+	}
+
+	deletion, seedClient, userClusterClient := getReportTestDeletion(cluster)
+
+	if err := deletion.CleanupCluster(ctx, kubermaticlog.Logger, cluster); err != nil {
+		t.Fatalf("Deletion failed: %v", err)
+	}
+
+	services := &corev1.ServiceList{}
+	if err := userClusterClient.List(ctx, services); err != nil {
+		t.Fatalf("Failed to list services: %v", err)
+	}
+	if len(services.Items) != 2 {
+		t.Fatalf("Expected services to remain before deletion was confirmed, but %d are left", len(services.Items))
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: testNS, Name: DeletionReportConfigMapName}, cm); err != nil {
+		t.Fatalf("Failed to get report ConfigMap: %v", err)
+	}
+
+	hash := cm.Data[DeletionReportHashKey]
+	if hash == "" {
+		t.Fatal("Report ConfigMap does not contain a hash")
+	}
+
+	cluster.Annotations[DeletionConfirmationAnnotation] = hash
+	if err := deletion.CleanupCluster(ctx, kubermaticlog.Logger, cluster); err != nil {
+		t.Fatalf("Deletion failed: %v", err)
+	}
+
+	if AwaitingConfirmation(cluster) {
+		t.Error("Expected confirmation requirement to be removed")
+	}
+
+	if err := userClusterClient.List(ctx, services); err != nil {
+		t.Fatalf("Failed to list services: %v", err)
+	}
+	if len(services.Items) != 1 {
+		t.Errorf("Expected LoadBalancer service to be deleted after confirmation, but %d services are left", len(services.Items))
+	}
+}
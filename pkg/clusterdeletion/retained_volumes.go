@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RetainVolumeLabel can be put on PersistentVolumeClaims in a user cluster to keep
+	// their volume when the cluster is deleted, regardless of the cluster's deletion policy.
+	RetainVolumeLabel = "kubermatic.k8c.io/retain-volume"
+
+	// RetainedVolumesLabel is put on the ConfigMaps recording retained volumes and
+	// contains the name of the deleted cluster.
+	RetainedVolumesLabel = "kubermatic.k8c.io/retained-volumes"
+
+	// ImportRetainedVolumesAnnotation can be put on a Cluster to import the volumes retained
+	// from a deleted cluster (whose name is the annotation value) of the same project.
+	ImportRetainedVolumesAnnotation = "kubermatic.k8c.io/import-retained-volumes"
+
+	// RetainedVolumesTTL is how long retained volumes can be imported. Afterwards their record
+	// is removed, while the volumes themselves are left at the cloud provider and have to be
+	// cleaned up manually.
+	RetainedVolumesTTL = 30 * 24 * time.Hour
+
+	retainedVolumesKey = "volumes.json"
+
+	// Volumes can only be attached to machines of the same provider and datacenter, so both
+	// are recorded together with the retained volumes.
+	retainedVolumesProviderAnnotation   = "kubermatic.k8c.io/cloud-provider"
+	retainedVolumesDatacenterAnnotation = "kubermatic.k8c.io/datacenter"
+)
+
+// RetainedVolume is a PersistentVolume that was kept when its cluster was deleted.
+type RetainedVolume struct {
+	Name string                      `json:"name"`
+	Spec corev1.PersistentVolumeSpec `json:"spec"`
+}
+
+// RetainedVolumesExpiry returns when the record of retained volumes expires.
+func RetainedVolumesExpiry(cm *corev1.ConfigMap) time.Time {
+	return cm.CreationTimestamp.Add(RetainedVolumesTTL)
+}
+
+// RetainedVolumesConfigMapName returns the name of the ConfigMap in the seed's KKP namespace
+// that records the retained volumes of the given cluster.
+func RetainedVolumesConfigMapName(clusterName string) string {
+	return fmt.Sprintf("retained-volumes-%s", clusterName)
+}
+
+func retainsVolume(cluster *kubermaticv1.Cluster, pvc *corev1.PersistentVolumeClaim) bool {
+	return cluster.Spec.RetainsVolumes() || pvc.Labels[RetainVolumeLabel] == "true"
+}
+
+// retainVolumes switches the volumes bound to retained PVCs to the Retain reclaim policy
+// and records them on the seed. This must happen before the PVCs are deleted.
+func (d *Deletion) retainVolumes(ctx context.Context, log *zap.SugaredLogger, userClusterClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, pvcs []corev1.PersistentVolumeClaim) error {
+	var retained []RetainedVolume
+
+	for _, pvc := range pvcs {
+		if !retainsVolume(cluster, &pvc) || pvc.Spec.VolumeName == "" {
+			continue
+		}
+
+		pv := &corev1.PersistentVolume{}
+		if err := userClusterClient.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+			if ctrlruntimeclient.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return fmt.Errorf("failed to get PV %q: %w", pvc.Spec.VolumeName, err)
+		}
+
+		if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+			log.Infow("Retaining PV...", "pv", pv.Name)
+
+			oldPV := pv.DeepCopy()
+			pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+			if err := userClusterClient.Patch(ctx, pv, ctrlruntimeclient.MergeFrom(oldPV)); err != nil {
+				return fmt.Errorf("failed to set reclaim policy of PV %q: %w", pv.Name, err)
+			}
+		}
+
+		spec := pv.Spec.DeepCopy()
+		// Only keep the claim's name, so that re-creating the claim in a new cluster binds it to the imported volume.
+		if spec.ClaimRef != nil {
+			spec.ClaimRef = &corev1.ObjectReference{
+				Namespace: spec.ClaimRef.Namespace,
+				Name:      spec.ClaimRef.Name,
+			}
+		}
+
+		retained = append(retained, RetainedVolume{Name: pv.Name, Spec: *spec})
+	}
+
+	if len(retained) == 0 {
+		return nil
+	}
+
+	return d.recordRetainedVolumes(ctx, cluster, retained)
+}
+
+func (d *Deletion) recordRetainedVolumes(ctx context.Context, cluster *kubermaticv1.Cluster, volumes []RetainedVolume) error {
+	if d.namespace == "" {
+		return errors.New("no namespace configured to record retained volumes in")
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: d.namespace, Name: RetainedVolumesConfigMapName(cluster.Name)}
+	if err := d.seedClient.Get(ctx, key, cm); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get ConfigMap: %w", err)
+	}
+
+	exists := cm.Name != ""
+
+	existing, err := decodeRetainedVolumes(cm)
+	if err != nil {
+		return err
+	}
+
+	// later passes of the cleanup will see the same volumes again
+	for _, volume := range volumes {
+		idx := slices.IndexFunc(existing, func(v RetainedVolume) bool { return v.Name == volume.Name })
+		if idx >= 0 {
+			existing[idx] = volume
+		} else {
+			existing = append(existing, volume)
+		}
+	}
+
+	slices.SortFunc(existing, func(a, b RetainedVolume) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	encoded, err := json.Marshal(existing)
+	if err != nil {
+		return fmt.Errorf("failed to encode retained volumes: %w", err)
+	}
+
+	cm.Name = key.Name
+	cm.Namespace = key.Namespace
+	cm.Labels = map[string]string{
+		RetainedVolumesLabel:           cluster.Name,
+		kubermaticv1.ProjectIDLabelKey: cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+	}
+	cm.Annotations = map[string]string{
+		retainedVolumesProviderAnnotation:   cloudProviderName(cluster),
+		retainedVolumesDatacenterAnnotation: cluster.Spec.Cloud.DatacenterName,
+	}
+	cm.Data = map[string]string{
+		retainedVolumesKey: string(encoded),
+	}
+
+	if exists {
+		err = d.seedClient.Update(ctx, cm)
+	} else {
+		err = d.seedClient.Create(ctx, cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store retained volumes: %w", err)
+	}
+
+	return nil
+}
+
+func decodeRetainedVolumes(cm *corev1.ConfigMap) ([]RetainedVolume, error) {
+	var volumes []RetainedVolume
+
+	if data := cm.Data[retainedVolumesKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &volumes); err != nil {
+			return nil, fmt.Errorf("failed to decode retained volumes: %w", err)
+		}
+	}
+
+	return volumes, nil
+}
+
+// ImportRetainedVolumes creates PersistentVolumes in the user cluster for all volumes that were
+// retained when the source cluster was deleted. The volumes are pre-bound to their original
+// claims, so re-creating the PersistentVolumeClaims with the same namespace and name in the new
+// cluster binds them. Volumes can only be imported into clusters of the same project, provider
+// and datacenter and only once; the record is removed after a successful import.
+func ImportRetainedVolumes(ctx context.Context, seedClient ctrlruntimeclient.Client, userClusterClient ctrlruntimeclient.Client, namespace string, cluster *kubermaticv1.Cluster, sourceCluster string) (int, error) {
+	cm := &corev1.ConfigMap{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: RetainedVolumesConfigMapName(sourceCluster)}, cm); err != nil {
+		return 0, fmt.Errorf("failed to get retained volumes of cluster %q: %w", sourceCluster, err)
+	}
+
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" || cm.Labels[kubermaticv1.ProjectIDLabelKey] != projectID {
+		return 0, fmt.Errorf("retained volumes of cluster %q do not belong to project %q", sourceCluster, projectID)
+	}
+
+	providerName := cloudProviderName(cluster)
+	datacenter := cluster.Spec.Cloud.DatacenterName
+	if cm.Annotations[retainedVolumesProviderAnnotation] != providerName || cm.Annotations[retainedVolumesDatacenterAnnotation] != datacenter {
+		return 0, fmt.Errorf("retained volumes of cluster %q belong to datacenter %q (%s) and cannot be imported into datacenter %q (%s)",
+			sourceCluster, cm.Annotations[retainedVolumesDatacenterAnnotation], cm.Annotations[retainedVolumesProviderAnnotation], datacenter, providerName)
+	}
+
+	volumes, err := decodeRetainedVolumes(cm)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, volume := range volumes {
+		pv := &corev1.PersistentVolume{}
+		pv.Name = volume.Name
+		pv.Spec = volume.Spec
+
+		if err := userClusterClient.Create(ctx, pv); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+			return 0, fmt.Errorf("failed to create PV %q: %w", volume.Name, err)
+		}
+	}
+
+	if err := seedClient.Delete(ctx, cm); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return 0, fmt.Errorf("failed to delete retained volumes record: %w", err)
+	}
+
+	return len(volumes), nil
+}
+
+func cloudProviderName(cluster *kubermaticv1.Cluster) string {
+	providerName, _ := kubermaticv1helper.ClusterCloudProviderName(cluster.Spec.Cloud)
+	return providerName
+}
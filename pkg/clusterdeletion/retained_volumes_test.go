@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testSeedNS = "kubermatic"
+
+func getRetainTestObjects() []ctrlruntimeclient.Object {
+	return []ctrlruntimeclient.Object{
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-data"},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "labelled", Labels: map[string]string{RetainVolumeLabel: "true"}},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-labelled"},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-data", Annotations: map[string]string{AnnDynamicallyProvisioned: "csi"}},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+				ClaimRef:                      &corev1.ObjectReference{Namespace: "default", Name: "data", UID: "1234"},
+			},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-labelled", Annotations: map[string]string{AnnDynamicallyProvisioned: "csi"}},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+				ClaimRef:                      &corev1.ObjectReference{Namespace: "default", Name: "labelled", UID: "5678"},
+			},
+		},
+	}
+}
+
+func TestCleanupVolumesRetainsVolumes(t *testing.T) {
+	testCases := []struct {
+		name             string
+		policy           *kubermaticv1.ClusterDeletionPolicy
+		expectedRetained []string
+	}{
+		{
+			name:             "only labelled PVCs are retained by default",
+			expectedRetained: []string{"pv-labelled"},
+		},
+		{
+			name:             "all PVCs are retained with the Retain policy",
+			policy:           &kubermaticv1.ClusterDeletionPolicy{Volumes: kubermaticv1.VolumeDeletionPolicyRetain},
+			expectedRetained: []string{"pv-data", "pv-labelled"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cluster := getClusterWithFinalizer("cluster", kubermaticv1.InClusterPVCleanupFinalizer)
+			cluster.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: "project"}
+			cluster.Spec.DeletionPolicy = tc.policy
+
+			userClusterClient := fake.
+				NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(getRetainTestObjects()...).
+				Build()
+			seedClient := fake.NewClientBuilder().WithObjects(cluster).Build()
+
+			d := &Deletion{
+				seedClient: seedClient,
+				recorder:   &record.FakeRecorder{},
+				namespace:  testSeedNS,
+				userClusterClientGetter: func() (ctrlruntimeclient.Client, error) {
+					return userClusterClient, nil
+				},
+			}
+
+			if _, err := d.cleanupVolumes(ctx, zap.NewNop().Sugar(), cluster); err != nil {
+				t.Fatalf("Failed to clean up volumes: %v", err)
+			}
+
+			pvs := &corev1.PersistentVolumeList{}
+			if err := userClusterClient.List(ctx, pvs); err != nil {
+				t.Fatalf("Failed to list PVs: %v", err)
+			}
+
+			var retained []string
+			for _, pv := range pvs.Items {
+				if pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+					retained = append(retained, pv.Name)
+				}
+			}
+
+			if len(retained) != len(tc.expectedRetained) {
+				t.Fatalf("Expected PVs %v to be retained, but got %v", tc.expectedRetained, retained)
+			}
+
+			cm := &corev1.ConfigMap{}
+			if err := seedClient.Get(ctx, types.NamespacedName{Namespace: testSeedNS, Name: RetainedVolumesConfigMapName(cluster.Name)}, cm); err != nil {
+				t.Fatalf("Failed to get retained volumes record: %v", err)
+			}
+
+			recorded, err := decodeRetainedVolumes(cm)
+			if err != nil {
+				t.Fatalf("Failed to decode record: %v", err)
+			}
+
+			if len(recorded) != len(tc.expectedRetained) {
+				t.Fatalf("Expected %d volumes to be recorded, got %d", len(tc.expectedRetained), len(recorded))
+			}
+
+			for i, volume := range recorded {
+				if volume.Name != tc.expectedRetained[i] {
+					t.Errorf("Expected %q to be recorded, got %q", tc.expectedRetained[i], volume.Name)
+				}
+				if volume.Spec.ClaimRef == nil || volume.Spec.ClaimRef.UID != "" {
+					t.Errorf("Expected recorded claimRef of %q to only contain the claim's name, got %v", volume.Name, volume.Spec.ClaimRef)
+				}
+			}
+		})
+	}
+}
+
+func TestImportRetainedVolumes(t *testing.T) {
+	retained := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testSeedNS,
+			Name:      RetainedVolumesConfigMapName("old"),
+			Labels:    map[string]string{kubermaticv1.ProjectIDLabelKey: "project"},
+			Annotations: map[string]string{
+				retainedVolumesProviderAnnotation:   string(kubermaticv1.HetznerCloudProvider),
+				retainedVolumesDatacenterAnnotation: "hetzner-fsn1",
+			},
+		},
+		Data: map[string]string{
+			retainedVolumesKey: `[{"name":"pv-data","spec":{"claimRef":{"namespace":"default","name":"data"},"persistentVolumeReclaimPolicy":"Retain"}}]`,
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		projectID   string
+		datacenter  string
+		cloud       kubermaticv1.CloudSpec
+		errExpected bool
+	}{
+		{
+			name:       "volumes are imported into a cluster of the same project",
+			projectID:  "project",
+			datacenter: "hetzner-fsn1",
+			cloud:      kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+		},
+		{
+			name:        "volumes cannot be imported into a cluster of another project",
+			projectID:   "other",
+			datacenter:  "hetzner-fsn1",
+			cloud:       kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+			errExpected: true,
+		},
+		{
+			name:        "volumes cannot be imported into a cluster in another datacenter",
+			projectID:   "project",
+			datacenter:  "hetzner-nbg1",
+			cloud:       kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+			errExpected: true,
+		},
+		{
+			name:        "volumes cannot be imported into a cluster of another provider",
+			projectID:   "project",
+			datacenter:  "hetzner-fsn1",
+			cloud:       kubermaticv1.CloudSpec{Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{}},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cluster := getClusterWithFinalizer("new")
+			cluster.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: tc.projectID}
+			cluster.Spec.Cloud = tc.cloud
+			cluster.Spec.Cloud.DatacenterName = tc.datacenter
+
+			seedClient := fake.NewClientBuilder().WithObjects(retained.DeepCopy()).Build()
+			userClusterClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+			_, err := ImportRetainedVolumes(ctx, seedClient, userClusterClient, testSeedNS, cluster, "old")
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err=%v, got err=%v", tc.errExpected, err)
+			}
+
+			pv := &corev1.PersistentVolume{}
+			err = userClusterClient.Get(ctx, types.NamespacedName{Name: "pv-data"}, pv)
+			if apierrors.IsNotFound(err) != tc.errExpected {
+				t.Fatalf("Expected PV to be imported=%v, got err=%v", !tc.errExpected, err)
+			}
+
+			err = seedClient.Get(ctx, types.NamespacedName{Namespace: testSeedNS, Name: retained.Name}, &corev1.ConfigMap{})
+			if apierrors.IsNotFound(err) == tc.errExpected {
+				t.Errorf("Expected record to be removed=%v, got err=%v", !tc.errExpected, err)
+			}
+		})
+	}
+}
@@ -54,6 +54,11 @@ func (d *Deletion) cleanupVolumes(ctx context.Context, log *zap.SugaredLogger, c
 		return false, fmt.Errorf("failed to list PVCs from user cluster: %w", err)
 	}
 
+	// Switch volumes that should be kept to the Retain policy before their claims are deleted
+	if err := d.retainVolumes(ctx, log, userClusterClient, cluster, pvcList.Items); err != nil {
+		return false, fmt.Errorf("failed to retain PVs: %w", err)
+	}
+
 	allPVList := &corev1.PersistentVolumeList{}
 	if err := userClusterClient.List(ctx, allPVList); err != nil {
 		return false, fmt.Errorf("failed to list PVs from user cluster: %w", err)
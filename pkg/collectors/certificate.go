@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	certificatePrefix = "kubermatic_cluster_certificate_"
+)
+
+// CertificateCollector exports metrics for the certificates of user cluster control planes.
+type CertificateCollector struct {
+	client ctrlruntimeclient.Reader
+
+	certificateNotBefore       *prometheus.Desc
+	certificateNotAfter        *prometheus.Desc
+	certificateLifetimeElapsed *prometheus.Desc
+}
+
+// MustRegisterCertificateCollector registers the certificate collector at the given prometheus registry.
+func MustRegisterCertificateCollector(registry prometheus.Registerer, client ctrlruntimeclient.Reader) {
+	cc := &CertificateCollector{
+		client: client,
+		certificateNotBefore: prometheus.NewDesc(
+			certificatePrefix+"not_before_timestamp_seconds",
+			"Unix timestamp from which the certificate is valid",
+			[]string{"cluster", "secret", "key"},
+			nil,
+		),
+		certificateNotAfter: prometheus.NewDesc(
+			certificatePrefix+"expiration_timestamp_seconds",
+			"Unix timestamp at which the certificate expires",
+			[]string{"cluster", "secret", "key"},
+			nil,
+		),
+		certificateLifetimeElapsed: prometheus.NewDesc(
+			certificatePrefix+"lifetime_elapsed_percent",
+			"Percentage of the certificate's validity period that has passed",
+			[]string{"cluster", "secret", "key"},
+			nil,
+		),
+	}
+
+	registry.MustRegister(cc)
+}
+
+// Describe returns the metrics descriptors.
+func (cc CertificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cc.certificateNotBefore
+	ch <- cc.certificateNotAfter
+	ch <- cc.certificateLifetimeElapsed
+}
+
+// Collect gets called by prometheus to collect the metrics.
+func (cc CertificateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := cc.client.List(ctx, clusters); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list clusters in CertificateCollector: %w", err))
+		return
+	}
+
+	now := time.Now()
+
+	for _, cluster := range clusters.Items {
+		if cluster.Status.NamespaceName == "" {
+			continue
+		}
+
+		secrets := &corev1.SecretList{}
+		if err := cc.client.List(ctx, secrets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list Secrets of cluster %s in CertificateCollector: %w", cluster.Name, err))
+			continue
+		}
+
+		for _, secret := range secrets.Items {
+			for _, cert := range certificates.SecretLeafCertificates(&secret) {
+				cc.collectCertificate(ch, cluster.Name, cert, now)
+			}
+		}
+	}
+}
+
+func (cc *CertificateCollector) collectCertificate(ch chan<- prometheus.Metric, clusterName string, cert certificates.LeafCertificate, now time.Time) {
+	labels := []string{clusterName, cert.SecretName, cert.Name()}
+
+	ch <- prometheus.MustNewConstMetric(
+		cc.certificateNotBefore,
+		prometheus.GaugeValue,
+		float64(cert.Cert.NotBefore.Unix()),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		cc.certificateNotAfter,
+		prometheus.GaugeValue,
+		float64(cert.Cert.NotAfter.Unix()),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		cc.certificateLifetimeElapsed,
+		prometheus.GaugeValue,
+		cert.LifetimeElapsed(now),
+		labels...,
+	)
+}
@@ -19,6 +19,7 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -42,6 +43,10 @@ type ClusterCollector struct {
 	clusterDeleted *prometheus.Desc
 	clusterInfo    *prometheus.Desc
 	clusterOwner   *prometheus.Desc
+
+	clusterHourlyCost  *prometheus.Desc
+	clusterMonthlyCost *prometheus.Desc
+	projectHourlyCost  *prometheus.Desc
 }
 
 func newClusterCollector(client ctrlruntimeclient.Reader) *ClusterCollector {
@@ -85,6 +90,24 @@ func newClusterCollector(client ctrlruntimeclient.Reader) *ClusterCollector {
 			},
 			nil,
 		),
+		clusterHourlyCost: prometheus.NewDesc(
+			clusterPrefix+"estimated_hourly_cost",
+			"Estimated cost of the cluster's machines per hour",
+			[]string{"cluster", "project", "currency"},
+			nil,
+		),
+		clusterMonthlyCost: prometheus.NewDesc(
+			clusterPrefix+"estimated_monthly_cost",
+			"Estimated cost of the cluster's machines per month",
+			[]string{"cluster", "project", "currency"},
+			nil,
+		),
+		projectHourlyCost: prometheus.NewDesc(
+			projectPrefix+"estimated_hourly_cost",
+			"Estimated cost of the machines of all clusters of the project in this seed per hour",
+			[]string{"project", "currency"},
+			nil,
+		),
 	}
 }
 
@@ -135,6 +158,44 @@ func (cc ClusterCollector) Collect(ch chan<- prometheus.Metric) {
 	for _, cluster := range clusters.Items {
 		cc.collectCluster(ch, &cluster, kubernetesLabels, userMap, labelsGauge)
 	}
+
+	cc.collectProjectCosts(ch, clusters.Items)
+}
+
+type projectCurrency struct {
+	project  string
+	currency string
+}
+
+func (cc *ClusterCollector) collectProjectCosts(ch chan<- prometheus.Metric, clusters []kubermaticv1.Cluster) {
+	costs := map[projectCurrency]float64{}
+	for _, cluster := range clusters {
+		estimate := cluster.Status.CostEstimate
+		if estimate == nil {
+			continue
+		}
+
+		hourly, err := strconv.ParseFloat(estimate.Hourly, 64)
+		if err != nil {
+			continue
+		}
+
+		key := projectCurrency{
+			project:  cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+			currency: estimate.Currency,
+		}
+		costs[key] += hourly
+	}
+
+	for key, hourly := range costs {
+		ch <- prometheus.MustNewConstMetric(
+			cc.projectHourlyCost,
+			prometheus.GaugeValue,
+			hourly,
+			key.project,
+			key.currency,
+		)
+	}
 }
 
 func (cc *ClusterCollector) collectCluster(ch chan<- prometheus.Metric, c *kubermaticv1.Cluster, kubernetesLabels []string, users map[string]string, labelsGaugeVec *prometheus.GaugeVec) {
@@ -174,6 +235,17 @@ func (cc *ClusterCollector) collectCluster(ch chan<- prometheus.Metric, c *kuber
 		clusterOwner,
 	)
 
+	if estimate := c.Status.CostEstimate; estimate != nil {
+		project := c.Labels[kubermaticv1.ProjectIDLabelKey]
+
+		if hourly, err := strconv.ParseFloat(estimate.Hourly, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(cc.clusterHourlyCost, prometheus.GaugeValue, hourly, c.Name, project, estimate.Currency)
+		}
+		if monthly, err := strconv.ParseFloat(estimate.Monthly, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(cc.clusterMonthlyCost, prometheus.GaugeValue, monthly, c.Name, project, estimate.Currency)
+		}
+	}
+
 	infoLabels, err := cc.clusterInfoLabels(c)
 	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("failed to determine labels for cluster %s: %w", c.Name, err))
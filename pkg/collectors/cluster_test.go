@@ -92,3 +92,54 @@ kubermatic_cluster_owner{cluster_name="cluster2",user="username"} 1
 		t.Error(err)
 	}
 }
+
+func TestClusterCostMetrics(t *testing.T) {
+	kubermaticFakeClient := fake.
+		NewClientBuilder().
+		WithObjects(
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "cluster1",
+					Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+				},
+				Status: kubermaticv1.ClusterStatus{
+					CostEstimate: &kubermaticv1.ClusterCostEstimate{Currency: "EUR", Hourly: "0.5", Monthly: "365"},
+				},
+			},
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "cluster2",
+					Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+				},
+				Status: kubermaticv1.ClusterStatus{
+					CostEstimate: &kubermaticv1.ClusterCostEstimate{Currency: "EUR", Hourly: "0.25", Monthly: "182.5"},
+				},
+			},
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "cluster3",
+					Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+				},
+			},
+		).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(newClusterCollector(kubermaticFakeClient)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP kubermatic_cluster_estimated_hourly_cost Estimated cost of the cluster's machines per hour
+# TYPE kubermatic_cluster_estimated_hourly_cost gauge
+kubermatic_cluster_estimated_hourly_cost{cluster="cluster1",currency="EUR",project="my-project"} 0.5
+kubermatic_cluster_estimated_hourly_cost{cluster="cluster2",currency="EUR",project="my-project"} 0.25
+# HELP kubermatic_project_estimated_hourly_cost Estimated cost of the machines of all clusters of the project in this seed per hour
+# TYPE kubermatic_project_estimated_hourly_cost gauge
+kubermatic_project_estimated_hourly_cost{currency="EUR",project="my-project"} 0.75
+`
+
+	if err := testutil.CollectAndCompare(registry, strings.NewReader(expected), "kubermatic_cluster_estimated_hourly_cost", "kubermatic_project_estimated_hourly_cost"); err != nil {
+		t.Error(err)
+	}
+}
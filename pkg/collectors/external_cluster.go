@@ -39,6 +39,11 @@ type ExternalClusterCollector struct {
 	clusterCreated *prometheus.Desc
 	clusterDeleted *prometheus.Desc
 	clusterInfo    *prometheus.Desc
+
+	clusterVersion           *prometheus.Desc
+	clusterNodes             *prometheus.Desc
+	clusterComponentHealthy  *prometheus.Desc
+	clusterCertificateExpiry *prometheus.Desc
 }
 
 // MustRegisterExternalClusterCollector registers the cluster collector at the given prometheus registry.
@@ -68,6 +73,30 @@ func MustRegisterExternalClusterCollector(registry prometheus.Registerer, client
 			},
 			nil,
 		),
+		clusterVersion: prometheus.NewDesc(
+			externalClusterPrefix+"version",
+			"Kubernetes version reported by the external cluster",
+			[]string{"cluster", "version"},
+			nil,
+		),
+		clusterNodes: prometheus.NewDesc(
+			externalClusterPrefix+"nodes",
+			"Number of nodes in the external cluster by readiness",
+			[]string{"cluster", "status"},
+			nil,
+		),
+		clusterComponentHealthy: prometheus.NewDesc(
+			externalClusterPrefix+"component_healthy",
+			"Whether a control plane component of the external cluster is healthy",
+			[]string{"cluster", "component"},
+			nil,
+		),
+		clusterCertificateExpiry: prometheus.NewDesc(
+			externalClusterPrefix+"certificate_expiry_time",
+			"Unix expiry timestamp of the external cluster's certificates",
+			[]string{"cluster", "certificate"},
+			nil,
+		),
 	}
 
 	registry.MustRegister(cc)
@@ -78,6 +107,10 @@ func (cc ExternalClusterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cc.clusterCreated
 	ch <- cc.clusterDeleted
 	ch <- cc.clusterInfo
+	ch <- cc.clusterVersion
+	ch <- cc.clusterNodes
+	ch <- cc.clusterComponentHealthy
+	ch <- cc.clusterCertificateExpiry
 }
 
 // Collect gets called by prometheus to collect the metrics.
@@ -119,4 +152,65 @@ func (cc *ExternalClusterCollector) collectCluster(ch chan<- prometheus.Metric,
 		string(c.Spec.CloudSpec.ProviderName),
 		string(c.Status.Condition.Phase),
 	)
+
+	if c.Status.Inventory != nil {
+		cc.collectInventory(ch, c.Name, c.Status.Inventory)
+	}
+}
+
+func (cc *ExternalClusterCollector) collectInventory(ch chan<- prometheus.Metric, name string, inventory *kubermaticv1.ExternalClusterInventory) {
+	if inventory.Version != "" {
+		ch <- prometheus.MustNewConstMetric(
+			cc.clusterVersion,
+			prometheus.GaugeValue,
+			1,
+			name,
+			inventory.Version,
+		)
+	}
+
+	ready := 0
+	for _, node := range inventory.Nodes {
+		if node.Ready {
+			ready++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(cc.clusterNodes, prometheus.GaugeValue, float64(ready), name, "ready")
+	ch <- prometheus.MustNewConstMetric(cc.clusterNodes, prometheus.GaugeValue, float64(len(inventory.Nodes)-ready), name, "not_ready")
+
+	for component, health := range inventory.ComponentHealth {
+		healthy := 0
+		if health == kubermaticv1.HealthStatusUp {
+			healthy = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			cc.clusterComponentHealthy,
+			prometheus.GaugeValue,
+			float64(healthy),
+			name,
+			component,
+		)
+	}
+
+	if inventory.APIServerCertificateExpiry != nil {
+		ch <- prometheus.MustNewConstMetric(
+			cc.clusterCertificateExpiry,
+			prometheus.GaugeValue,
+			float64(inventory.APIServerCertificateExpiry.Unix()),
+			name,
+			"apiserver",
+		)
+	}
+
+	if inventory.ClientCertificateExpiry != nil {
+		ch <- prometheus.MustNewConstMetric(
+			cc.clusterCertificateExpiry,
+			prometheus.GaugeValue,
+			float64(inventory.ClientCertificateExpiry.Unix()),
+			name,
+			"client",
+		)
+	}
 }
@@ -19,6 +19,7 @@ package clustertemplatesynchronizer
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"go.uber.org/zap"
 
@@ -180,8 +181,17 @@ func clusterTemplateReconcilerFactory(template *kubermaticv1.ClusterTemplate) re
 			c.Name = template.Name
 			c.Spec = template.Spec
 			c.Labels = template.Labels
-			c.Annotations = template.Annotations
+			c.Annotations = map[string]string{}
+			for key, value := range template.Annotations {
+				c.Annotations[key] = value
+			}
+			// the seed copy has its own generation, so the revision of the original is remembered
+			c.Annotations[kubermaticv1.ClusterTemplateRevisionAnnotationKey] = strconv.FormatInt(template.Revision(), 10)
+			c.ClusterLabels = template.ClusterLabels
 			c.InheritedClusterLabels = template.InheritedClusterLabels
+			c.UserSSHKeys = template.UserSSHKeys
+			c.PropagateUpdates = template.PropagateUpdates
+			c.Addons = template.Addons
 			c.Credential = template.Credential
 			return c, nil
 		}
@@ -46,12 +46,20 @@ func TestReconcile(t *testing.T) {
 		seedClient              ctrlruntimeclient.Client
 	}{
 		{
-			name:                    "scenario 1: sync cluster template from master cluster to seed cluster",
-			requestName:             clusterTemplateName,
-			expectedClusterTemplate: generateClusterTemplate(clusterTemplateName, false),
+			name:        "scenario 1: sync cluster template from master cluster to seed cluster",
+			requestName: clusterTemplateName,
+			expectedClusterTemplate: func() *kubermaticv1.ClusterTemplate {
+				ct := generateClusterTemplate(clusterTemplateName, false)
+				ct.Annotations = map[string]string{kubermaticv1.ClusterTemplateRevisionAnnotationKey: "3"}
+				return ct
+			}(),
 			masterClient: fake.
 				NewClientBuilder().
-				WithObjects(generateClusterTemplate(clusterTemplateName, false), generator.GenTestSeed()).
+				WithObjects(func() *kubermaticv1.ClusterTemplate {
+					ct := generateClusterTemplate(clusterTemplateName, false)
+					ct.Generation = 3
+					return ct
+				}(), generator.GenTestSeed()).
 				Build(),
 			seedClient: fake.
 				NewClientBuilder().
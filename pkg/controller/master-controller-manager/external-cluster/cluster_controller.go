@@ -84,8 +84,11 @@ func Add(ctx context.Context, mgr manager.Manager, log *zap.SugaredLogger) error
 			),
 		).
 		Build(reconciler)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return addInventoryController(mgr, log)
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -0,0 +1,373 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	InventoryControllerName = "kkp-external-cluster-inventory-controller"
+
+	// inventoryInterval is the interval in which the inventory of each cluster is collected.
+	inventoryInterval = 5 * time.Minute
+
+	// nodeRoleLabelPrefix is the prefix of the labels denoting the roles of a node.
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+	// apiServerComponent is the name under which the API server's health is reported.
+	apiServerComponent = "kube-apiserver"
+
+	// clientTimeout limits every request to a cluster, so that unreachable clusters
+	// do not block a worker.
+	clientTimeout = 30 * time.Second
+)
+
+// InventoryReconciler periodically collects node inventory, version, component
+// health and certificate expiry of BringYourOwn and KubeOne clusters through
+// their kubeconfig.
+type InventoryReconciler struct {
+	ctrlruntimeclient.Client
+	log      *zap.SugaredLogger
+	recorder record.EventRecorder
+}
+
+func addInventoryController(mgr manager.Manager, log *zap.SugaredLogger) error {
+	reconciler := &InventoryReconciler{
+		log:      log.Named(InventoryControllerName),
+		Client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(InventoryControllerName),
+	}
+
+	// Managed clusters (GKE, EKS, AKS) are inspected through their cloud provider instead.
+	onlyImportedClusters := predicate.NewPredicateFuncs(func(object ctrlruntimeclient.Object) bool {
+		externalCluster, ok := object.(*kubermaticv1.ExternalCluster)
+		return ok && (externalCluster.Spec.CloudSpec.ProviderName == kubermaticv1.ExternalClusterKubeOneProvider || externalCluster.Spec.CloudSpec.ProviderName == kubermaticv1.ExternalClusterBringYourOwnProvider)
+	})
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(InventoryControllerName).
+		For(
+			&kubermaticv1.ExternalCluster{},
+			builder.WithPredicates(
+				onlyImportedClusters,
+				predicate.GenerationChangedPredicate{},
+			),
+		).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *InventoryReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	paused, err := kuberneteshelper.ExternalClusterPausedChecker(ctx, request.Name, r.Client)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check external cluster pause status: %w", err)
+	}
+	if paused {
+		return reconcile.Result{}, nil
+	}
+
+	log := r.log.With("externalcluster", request)
+	log.Debug("Processing...")
+
+	cluster := &kubermaticv1.ExternalCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Debug("Could not find external cluster")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() || cluster.Spec.KubeconfigReference == nil {
+		return reconcile.Result{}, nil
+	}
+
+	// ExternalClusters have no status subresource, so updating the inventory
+	// triggers another reconciliation; avoid collecting more often than needed.
+	if last := cluster.Status.Inventory; last != nil {
+		if age := time.Since(last.LastCollected.Time); age < inventoryInterval {
+			return reconcile.Result{RequeueAfter: inventoryInterval - age}, nil
+		}
+	}
+
+	inventory, collectErr := r.collect(ctx, cluster)
+	if collectErr != nil {
+		log.Debugw("Failed to collect inventory", zap.Error(collectErr))
+
+		// keep the last known inventory, but make it visible that it is outdated
+		inventory = &kubermaticv1.ExternalClusterInventory{}
+		if cluster.Status.Inventory != nil {
+			inventory = cluster.Status.Inventory.DeepCopy()
+		}
+
+		// only report new errors, a cluster can stay unreachable for a long time
+		if inventory.Error != collectErr.Error() {
+			r.recorder.Event(cluster, corev1.EventTypeWarning, "InventoryCollectionFailed", collectErr.Error())
+		}
+		inventory.Error = collectErr.Error()
+	}
+
+	if err := r.updateInventory(ctx, cluster, inventory); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: inventoryInterval}, nil
+}
+
+func (r *InventoryReconciler) collect(ctx context.Context, cluster *kubermaticv1.ExternalCluster) (*kubermaticv1.ExternalClusterInventory, error) {
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)
+	rawKubeconfig, err := secretKeyGetter(cluster.Spec.KubeconfigReference, resources.ExternalClusterKubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(rawKubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	cfg.Timeout = clientTimeout
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	inventory, err := collectInventory(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory.ComponentHealth[apiServerComponent] = kubermaticv1.HealthStatusDown
+	if _, err := client.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx); err == nil {
+		inventory.ComponentHealth[apiServerComponent] = kubermaticv1.HealthStatusUp
+	}
+
+	if expiry, err := apiServerCertificateExpiry(ctx, cfg); err != nil {
+		r.log.Debugw("Failed to determine API server certificate expiry", "externalcluster", cluster.Name, zap.Error(err))
+	} else {
+		inventory.APIServerCertificateExpiry = expiry
+	}
+
+	if expiry, err := clientCertificateExpiry(cfg); err != nil {
+		r.log.Debugw("Failed to determine client certificate expiry", "externalcluster", cluster.Name, zap.Error(err))
+	} else {
+		inventory.ClientCertificateExpiry = expiry
+	}
+
+	return inventory, nil
+}
+
+func (r *InventoryReconciler) updateInventory(ctx context.Context, cluster *kubermaticv1.ExternalCluster, inventory *kubermaticv1.ExternalClusterInventory) error {
+	if reflect.DeepEqual(cluster.Status.Inventory, inventory) {
+		return nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.Inventory = inventory
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to patch cluster inventory: %w", err)
+	}
+
+	return nil
+}
+
+// collectInventory gathers the version, the nodes and the health of the control
+// plane pods of a cluster.
+func collectInventory(ctx context.Context, client kubernetes.Interface) (*kubermaticv1.ExternalClusterInventory, error) {
+	inventory := &kubermaticv1.ExternalClusterInventory{
+		LastCollected:   metav1.Now(),
+		ComponentHealth: map[string]kubermaticv1.HealthStatus{},
+	}
+
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+	inventory.Version = version.GitVersion
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		inventory.Nodes = append(inventory.Nodes, nodeInfo(node))
+	}
+
+	sort.Slice(inventory.Nodes, func(i, j int) bool {
+		return inventory.Nodes[i].Name < inventory.Nodes[j].Name
+	})
+
+	// Control plane components are only visible in clusters where they run as
+	// static pods, like the ones set up by kubeadm/KubeOne.
+	pods, err := client.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+		LabelSelector: "tier=control-plane",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list control plane pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		component := pod.Labels["component"]
+		if component == "" {
+			continue
+		}
+
+		if status, exists := inventory.ComponentHealth[component]; exists && status == kubermaticv1.HealthStatusDown {
+			continue
+		}
+
+		inventory.ComponentHealth[component] = kubermaticv1.HealthStatusDown
+		if podIsReady(pod) {
+			inventory.ComponentHealth[component] = kubermaticv1.HealthStatusUp
+		}
+	}
+
+	return inventory, nil
+}
+
+func nodeInfo(node corev1.Node) kubermaticv1.ExternalClusterNodeInfo {
+	info := kubermaticv1.ExternalClusterNodeInfo{
+		Name:                    node.Name,
+		KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+		OSImage:                 node.Status.NodeInfo.OSImage,
+		ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+	}
+
+	for label := range node.Labels {
+		if role, found := strings.CutPrefix(label, nodeRoleLabelPrefix); found && role != "" {
+			info.Roles = append(info.Roles, role)
+		}
+	}
+	sort.Strings(info.Roles)
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			info.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return info
+}
+
+func podIsReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// apiServerCertificateExpiry connects to the API server and returns the expiry
+// date of its serving certificate.
+func apiServerCertificateExpiry(ctx context.Context, cfg *rest.Config) (*metav1.Time, error) {
+	tlsConfig, err := rest.TLSConfigFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return nil, errors.New("API server does not use TLS")
+	}
+
+	host, err := url.Parse(cfg.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API server URL: %w", err)
+	}
+
+	address := host.Host
+	if host.Port() == "" {
+		address = net.JoinHostPort(host.Hostname(), "443")
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config:    tlsConfig,
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to API server: %w", err)
+	}
+	defer conn.Close()
+
+	certificates := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return nil, errors.New("API server did not present a certificate")
+	}
+
+	expiry := metav1.NewTime(certificates[0].NotAfter)
+
+	return &expiry, nil
+}
+
+// clientCertificateExpiry returns the expiry date of the client certificate
+// used in the kubeconfig, or nil if the kubeconfig uses a different means of
+// authentication.
+func clientCertificateExpiry(cfg *rest.Config) (*metav1.Time, error) {
+	if len(cfg.CertData) == 0 {
+		return nil, nil
+	}
+
+	block, _ := pem.Decode(cfg.CertData)
+	if block == nil {
+		return nil, errors.New("client certificate is not PEM-encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+
+	expiry := metav1.NewTime(cert.NotAfter)
+
+	return &expiry, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCollectInventory(t *testing.T) {
+	objects := []runtime.Object{
+		genNode("worker-1", true, nil),
+		genNode("cp-1", true, []string{"control-plane"}),
+		genNode("worker-2", false, nil),
+		genControlPlanePod("kube-scheduler-cp-1", "kube-scheduler", true),
+		genControlPlanePod("etcd-cp-1", "etcd", true),
+		genControlPlanePod("etcd-cp-2", "etcd", false),
+		genControlPlanePod("etcd-cp-3", "etcd", true),
+	}
+
+	client := fake.NewSimpleClientset(objects...)
+	client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.30.2"}
+
+	inventory, err := collectInventory(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Failed to collect inventory: %v", err)
+	}
+
+	if inventory.LastCollected.IsZero() {
+		t.Error("Expected LastCollected to be set.")
+	}
+
+	if inventory.Version != "v1.30.2" {
+		t.Errorf("Expected version v1.30.2, got %q.", inventory.Version)
+	}
+
+	expectedNodes := []kubermaticv1.ExternalClusterNodeInfo{
+		{Name: "cp-1", Roles: []string{"control-plane"}, Ready: true, KubeletVersion: "v1.30.2"},
+		{Name: "worker-1", Ready: true, KubeletVersion: "v1.30.2"},
+		{Name: "worker-2", Ready: false, KubeletVersion: "v1.30.2"},
+	}
+	if !diff.SemanticallyEqual(expectedNodes, inventory.Nodes) {
+		t.Errorf("Nodes do not match:\n%v", diff.ObjectDiff(expectedNodes, inventory.Nodes))
+	}
+
+	expectedHealth := map[string]kubermaticv1.HealthStatus{
+		"kube-scheduler": kubermaticv1.HealthStatusUp,
+		"etcd":           kubermaticv1.HealthStatusDown,
+	}
+	if !diff.SemanticallyEqual(expectedHealth, inventory.ComponentHealth) {
+		t.Errorf("Component health does not match:\n%v", diff.ObjectDiff(expectedHealth, inventory.ComponentHealth))
+	}
+}
+
+func genNode(name string, ready bool, roles []string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion: "v1.30.2",
+			},
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	if ready {
+		node.Status.Conditions[0].Status = corev1.ConditionTrue
+	}
+
+	for _, role := range roles {
+		node.Labels[nodeRoleLabelPrefix+role] = ""
+	}
+
+	return node
+}
+
+func genControlPlanePod(name string, component string, ready bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+			Labels: map[string]string{
+				"tier":      "control-plane",
+				"component": component,
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	if ready {
+		pod.Status.Conditions[0].Status = corev1.ConditionTrue
+	}
+
+	return pod
+}
+
+func TestReconcileInventoryCollectionFailure(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.ExternalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "imported",
+		},
+		Spec: kubermaticv1.ExternalClusterSpec{
+			CloudSpec: kubermaticv1.ExternalClusterCloudSpec{
+				ProviderName: kubermaticv1.ExternalClusterBringYourOwnProvider,
+			},
+			KubeconfigReference: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Name:      "missing-kubeconfig",
+					Namespace: "kubermatic",
+				},
+			},
+		},
+	}
+
+	client := kubermaticfake.NewClientBuilder().WithObjects(cluster).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	r := &InventoryReconciler{
+		Client:   client,
+		log:      kubermaticlog.Logger,
+		recorder: recorder,
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}}
+
+	reconcileInventory := func() *kubermaticv1.ExternalCluster {
+		t.Helper()
+
+		if _, err := r.Reconcile(ctx, request); err != nil {
+			t.Fatalf("Failed to reconcile: %v", err)
+		}
+
+		updated := &kubermaticv1.ExternalCluster{}
+		if err := client.Get(ctx, request.NamespacedName, updated); err != nil {
+			t.Fatalf("Failed to get cluster: %v", err)
+		}
+
+		if updated.Status.Inventory == nil || updated.Status.Inventory.Error == "" {
+			t.Fatalf("Expected the collection error in the inventory, got %+v.", updated.Status.Inventory)
+		}
+
+		return updated
+	}
+
+	updated := reconcileInventory()
+	if len(recorder.Events) != 1 {
+		t.Fatalf("Expected one event, got %d.", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	// collect again after the interval has passed, failing with the same error
+	oldCluster := updated.DeepCopy()
+	updated.Status.Inventory.LastCollected = metav1.NewTime(time.Now().Add(-2 * inventoryInterval))
+	if err := client.Patch(ctx, updated, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		t.Fatalf("Failed to patch cluster: %v", err)
+	}
+
+	reconcileInventory()
+	if len(recorder.Events) != 0 {
+		t.Fatalf("Expected no event for an unchanged error, got %q.", <-recorder.Events)
+	}
+}
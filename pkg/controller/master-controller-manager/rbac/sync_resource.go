@@ -23,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -568,7 +569,7 @@ func (c *resourcesController) ensureRBACRoleBindingForClusterAddons(ctx context.
 	return nil
 }
 
-func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context, cluster *kubermaticv1.Cluster, resourceName string, groupName string, kindName string) error {
+func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context, cluster *kubermaticv1.Cluster, resourceName string, groupName string, kindName string, additionalRules ...rbacv1.PolicyRule) error {
 	var roleList rbacv1.RoleList
 	opts := &ctrlruntimeclient.ListOptions{Namespace: cluster.Status.NamespaceName}
 	if err := c.client.List(ctx, &roleList, opts); err != nil {
@@ -585,6 +586,7 @@ func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	generatedRole.Rules = append(generatedRole.Rules, additionalRules...)
 
 	var sharedExistingRole rbacv1.Role
 	key := ctrlruntimeclient.ObjectKey{Name: generatedRole.Name, Namespace: cluster.Status.NamespaceName}
@@ -613,6 +615,22 @@ func (c *resourcesController) ensureRBACRoleForEtcdLauncher(ctx context.Context,
 	return nil
 }
 
+// etcdPodPatchRule allows patching the etcd Pods only, which are named after their
+// StatefulSet ordinal.
+func etcdPodPatchRule() rbacv1.PolicyRule {
+	podNames := make([]string, 0, kubermaticv1.MaxEtcdClusterSize)
+	for i := range kubermaticv1.MaxEtcdClusterSize {
+		podNames = append(podNames, fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, i))
+	}
+
+	return rbacv1.PolicyRule{
+		APIGroups:     []string{""},
+		Resources:     []string{"pods"},
+		ResourceNames: podNames,
+		Verbs:         []string{"patch"},
+	}
+}
+
 func (c *resourcesController) ensureRBACRoleBindingForEtcdLauncher(ctx context.Context, cluster *kubermaticv1.Cluster, kindName string) error {
 	generatedRoleBinding := generateRBACRoleBindingForEtcdLauncherServiceAccount(
 		cluster,
@@ -1210,7 +1228,8 @@ func (c *resourcesController) ensureRBACForEtcdLauncher(ctx context.Context, cli
 	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, "Secret"); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC RoleBinding for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
-	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, "pods", "", "Pod"); err != nil {
+	// etcd-launcher reports the state of its member (e.g. learner) on its own Pod
+	if err := c.ensureRBACRoleForEtcdLauncher(ctx, cluster, "pods", "", "Pod", etcdPodPatchRule()); err != nil {
 		return fmt.Errorf("failed to sync etcd restore RBAC Role for %s resource for %s cluster provider: %w", formatMapping(rmapping), c.providerName, err)
 	}
 	if err := c.ensureRBACRoleBindingForEtcdLauncher(ctx, cluster, "Pod"); err != nil {
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usersshkeysynchronizer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/sshca"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func certificateAuthorityEnabled(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.UserSSHKeyCertificateAuthority != nil && cluster.Spec.UserSSHKeyCertificateAuthority.Enabled
+}
+
+// reconcileCertificateAuthority requests certificates from the seed for every
+// key of the cluster's project whose owner still has access to the project and
+// copies the certificates issued by the seed into the keys' status. The CA
+// itself is managed on the seed and its private key never reaches the master.
+// It returns the usersshkeys Secret data, which only consists of the CA entry,
+// and the set of keys that currently hold a certificate for the cluster.
+func (r *Reconciler) reconcileCertificateAuthority(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	seedClient ctrlruntimeclient.Client,
+	cluster *kubermaticv1.Cluster,
+	keys []kubermaticv1.UserSSHKey,
+) (map[string][]byte, map[string]struct{}, error) {
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+
+	members, err := r.projectUsers(ctx, projectID, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requests := map[string]sshca.CertificateRequest{}
+	for _, key := range keys {
+		// only members of the cluster's project can receive certificates for it
+		if key.Spec.Project != projectID {
+			continue
+		}
+
+		if !members.Has(key.Spec.Owner) {
+			log.Debugw("Not requesting a certificate for UserSSHKey as its owner has no access to the project", "key", key.Name, "owner", key.Spec.Owner)
+			continue
+		}
+
+		request := sshca.CertificateRequest{PublicKey: key.Spec.PublicKey}
+		if key.Spec.ExpiresAt != nil {
+			request.ExpiresAt = &key.Spec.ExpiresAt.Time
+		}
+
+		requests[key.Name] = request
+	}
+
+	if err := reconciling.ReconcileSecrets(
+		ctx,
+		[]reconciling.NamedSecretReconcilerFactory{sshca.RequestsSecretReconciler(requests)},
+		cluster.Status.NamespaceName,
+		seedClient,
+	); err != nil {
+		return nil, nil, fmt.Errorf("failed to reconcile SSH certificate requests: %w", err)
+	}
+
+	// the certificates are issued asynchronously by the seed, whose update of
+	// the Secret triggers another reconciliation
+	issued := &corev1.Secret{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.UserSSHKeyCertificatesSecretName}, issued); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string][]byte{}, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("failed to get SSH certificates: %w", err)
+	}
+
+	data := map[string][]byte{}
+	if entry, ok := issued.Data[resources.UserSSHKeyCAEntrySecretKey]; ok {
+		data[resources.UserSSHKeyCAEntrySecretKey] = entry
+	}
+
+	certified := map[string]struct{}{}
+	for i := range keys {
+		key := &keys[i]
+		if _, requested := requests[key.Name]; !requested {
+			continue
+		}
+
+		if err := r.syncCertificate(ctx, key, cluster.Name, string(issued.Data[key.Name])); err != nil {
+			return nil, nil, fmt.Errorf("failed to sync certificate of UserSSHKey %s: %w", key.Name, err)
+		}
+
+		if getCertificate(key, cluster.Name) != nil {
+			certified[key.Name] = struct{}{}
+		}
+	}
+
+	return data, certified, nil
+}
+
+// syncCertificate stores the given certificate in the status of the key, unless
+// it was issued for a different public key, e.g. because the seed did not yet
+// process the latest request.
+func (r *Reconciler) syncCertificate(ctx context.Context, key *kubermaticv1.UserSSHKey, clusterName string, certificate string) error {
+	cert, err := sshca.ParseCertificate(certificate)
+	if err != nil || !sshca.CertifiesKey(cert, key.Spec.PublicKey) {
+		return nil
+	}
+
+	if existing := getCertificate(key, clusterName); existing != nil && existing.Certificate == certificate {
+		return nil
+	}
+
+	oldKey := key.DeepCopy()
+	setCertificate(key, kubermaticv1.SSHKeyCertificate{
+		Cluster:     clusterName,
+		Certificate: certificate,
+		ExpiresAt:   metav1.NewTime(time.Unix(int64(cert.ValidBefore), 0)),
+	})
+
+	if err := r.masterClient.Status().Patch(ctx, key, ctrlruntimeclient.MergeFrom(oldKey)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// removeStaleCertificates removes the certificates issued for the given cluster
+// from all keys that are not in the set of certified keys anymore.
+func (r *Reconciler) removeStaleCertificates(ctx context.Context, keys []kubermaticv1.UserSSHKey, clusterName string, certified map[string]struct{}) error {
+	for _, key := range keys {
+		if _, ok := certified[key.Name]; ok || getCertificate(&key, clusterName) == nil {
+			continue
+		}
+
+		oldKey := key.DeepCopy()
+		removeCertificate(&key, clusterName)
+
+		if err := r.masterClient.Status().Patch(ctx, &key, ctrlruntimeclient.MergeFrom(oldKey)); err != nil {
+			return fmt.Errorf("failed to update status of UserSSHKey %s: %w", key.Name, err)
+		}
+	}
+
+	return nil
+}
+func getCertificate(key *kubermaticv1.UserSSHKey, clusterName string) *kubermaticv1.SSHKeyCertificate {
+	for i, cert := range key.Status.Certificates {
+		if cert.Cluster == clusterName {
+			return &key.Status.Certificates[i]
+		}
+	}
+
+	return nil
+}
+
+func setCertificate(key *kubermaticv1.UserSSHKey, certificate kubermaticv1.SSHKeyCertificate) {
+	if existing := getCertificate(key, certificate.Cluster); existing != nil {
+		*existing = certificate
+		return
+	}
+
+	key.Status.Certificates = append(key.Status.Certificates, certificate)
+}
+
+func removeCertificate(key *kubermaticv1.UserSSHKey, clusterName string) {
+	var certificates []kubermaticv1.SSHKeyCertificate
+	for _, cert := range key.Status.Certificates {
+		if cert.Cluster != clusterName {
+			certificates = append(certificates, cert)
+		}
+	}
+
+	key.Status.Certificates = certificates
+}
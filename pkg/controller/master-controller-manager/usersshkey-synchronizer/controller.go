@@ -19,10 +19,13 @@ package usersshkeysynchronizer
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
@@ -35,6 +38,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -51,6 +56,14 @@ const (
 	// UserSSHKeysClusterIDsCleanupFinalizer is the finalizer that is placed on a Cluster object
 	// to indicate that the assigned SSH keys still need to be cleaned up.
 	UserSSHKeysClusterIDsCleanupFinalizer = "kubermatic.k8c.io/cleanup-usersshkeys-cluster-ids"
+
+	// ExpiryWarningPeriod is the time before a UserSSHKey expires in which
+	// warning events are emitted for it.
+	ExpiryWarningPeriod = 72 * time.Hour
+
+	// ExpiryWarningAnnotation records the expiry date a warning was already
+	// emitted for, so that owners are notified only once per expiry date.
+	ExpiryWarningAnnotation = "kubermatic.k8c.io/ssh-key-expiry-warning"
 )
 
 // Reconciler is a controller which is responsible for synchronizing the
@@ -61,6 +74,7 @@ type Reconciler struct {
 	log          *zap.SugaredLogger
 	workerName   string
 	seedClients  kubernetes.SeedClientMap
+	recorder     record.EventRecorder
 }
 
 func Add(
@@ -80,6 +94,7 @@ func Add(
 		workerName:   workerName,
 		masterClient: mgr.GetClient(),
 		seedClients:  kubernetes.SeedClientMap{},
+		recorder:     mgr.GetEventRecorderFor(ControllerName),
 	}
 
 	bldr := builder.ControllerManagedBy(mgr).
@@ -87,7 +102,9 @@ func Add(
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: numWorkers,
 		}).
-		Watches(&kubermaticv1.UserSSHKey{}, enqueueAllClusters(reconciler.seedClients, workerSelector))
+		Watches(&kubermaticv1.UserSSHKey{}, enqueueAllClusters(reconciler.seedClients, workerSelector)).
+		// losing access to a project revokes the SSH certificates for the project's clusters
+		Watches(&kubermaticv1.UserProjectBinding{}, enqueueAllClusters(reconciler.seedClients, workerSelector))
 
 	for seedName, seedManager := range seedManagers {
 		reconciler.seedClients[seedName] = seedManager.GetClient()
@@ -96,7 +113,7 @@ func Add(
 			seedManager.GetCache(),
 			&corev1.Secret{},
 			controllerutil.TypedEnqueueClusterForNamespacedObjectWithSeedName[*corev1.Secret](seedManager.GetClient(), seedName, workerSelector),
-			predicateutil.TypedByName[*corev1.Secret](resources.UserSSHKeys),
+			predicateutil.TypedByName[*corev1.Secret](resources.UserSSHKeys, resources.UserSSHKeyCertificatesSecretName),
 		))
 
 		bldr.WatchesRawSource(source.Kind(
@@ -116,32 +133,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	log := r.log.With("request", request)
 	log.Debug("Processing")
 
-	err := r.reconcile(ctx, log, request)
+	requeueAfter, err := r.reconcile(ctx, log, request)
 
-	return reconcile.Result{}, err
+	return reconcile.Result{RequeueAfter: requeueAfter}, err
 }
 
-func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, request reconcile.Request) error {
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, request reconcile.Request) (time.Duration, error) {
 	seedClient, ok := r.seedClients[request.Namespace]
 	if !ok {
 		log.Errorw("Got request for seed we don't have a client for", "seed", request.Namespace)
 		// The clients are inserted during controller initialization, so there is no point in retrying
-		return nil
+		return 0, nil
 	}
 
 	cluster := &kubermaticv1.Cluster{}
 	if err := seedClient.Get(ctx, types.NamespacedName{Name: request.Name}, cluster); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Debug("Could not find cluster")
-			return nil
+			return 0, nil
 		}
 
-		return fmt.Errorf("failed to get cluster %s from seed %s: %w", cluster.Name, request.Namespace, err)
+		return 0, fmt.Errorf("failed to get cluster %s from seed %s: %w", cluster.Name, request.Namespace, err)
 	}
 
 	if cluster.Status.NamespaceName == "" {
 		log.Debug("Skipping cluster reconciling because no namespaceName was yet set")
-		return nil
+		return 0, nil
 	}
 
 	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
@@ -149,43 +166,73 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, requ
 			"Skipping because the cluster has a different worker name set",
 			"cluster-worker-name", cluster.Labels[kubermaticv1.WorkerNameLabelKey],
 		)
-		return nil
+		return 0, nil
 	}
 
 	if cluster.Spec.Pause {
 		log.Debug("Skipping cluster reconciling because it was set to paused")
-		return nil
+		return 0, nil
 	}
 
 	userSSHKeys := &kubermaticv1.UserSSHKeyList{}
 	if err := r.masterClient.List(ctx, userSSHKeys); err != nil {
-		return fmt.Errorf("failed to list UserSSHKeys: %w", err)
+		return 0, fmt.Errorf("failed to list UserSSHKeys: %w", err)
 	}
 
 	if cluster.DeletionTimestamp != nil {
 		if err := r.cleanupUserSSHKeys(ctx, userSSHKeys.Items, cluster.Name); err != nil {
-			return fmt.Errorf("failed reconciling keys for a deleted cluster: %w", err)
+			return 0, fmt.Errorf("failed reconciling keys for a deleted cluster: %w", err)
+		}
+
+		if err := r.removeStaleCertificates(ctx, userSSHKeys.Items, cluster.Name, nil); err != nil {
+			return 0, fmt.Errorf("failed to remove certificates for a deleted cluster: %w", err)
+		}
+
+		return 0, kubernetes.TryRemoveFinalizer(ctx, seedClient, cluster, UserSSHKeysClusterIDsCleanupFinalizer)
+	}
+
+	now := time.Now()
+	keys := buildUserSSHKeysForCluster(cluster.Name, userSSHKeys, now)
+	if err := r.warnAboutExpiringKeys(ctx, log, keys, now); err != nil {
+		return 0, fmt.Errorf("failed to warn about expiring keys: %w", err)
+	}
+
+	// make sure to come back once the next key enters its warning period or expires
+	requeueAfter := nextExpiryCheck(keys, now)
+
+	secretData := userSSHKeysSecretData(keys)
+	var certified map[string]struct{}
+
+	// in CA mode, the nodes only trust the cluster's SSH CA and the keys are
+	// certified by the seed instead of being distributed
+	if certificateAuthorityEnabled(cluster) {
+		data, certifiedKeys, err := r.reconcileCertificateAuthority(ctx, log, seedClient, cluster, keys)
+		if err != nil {
+			return 0, err
 		}
 
-		return kubernetes.TryRemoveFinalizer(ctx, seedClient, cluster, UserSSHKeysClusterIDsCleanupFinalizer)
+		secretData = data
+		certified = certifiedKeys
 	}
 
-	keys := buildUserSSHKeysForCluster(cluster.Name, userSSHKeys)
+	if err := r.removeStaleCertificates(ctx, userSSHKeys.Items, cluster.Name, certified); err != nil {
+		return 0, err
+	}
 
 	if err := reconciling.ReconcileSecrets(
 		ctx,
-		[]reconciling.NamedSecretReconcilerFactory{updateUserSSHKeysSecrets(keys)},
+		[]reconciling.NamedSecretReconcilerFactory{updateUserSSHKeysSecrets(secretData)},
 		cluster.Status.NamespaceName,
 		seedClient,
 	); err != nil {
-		return fmt.Errorf("failed to reconcile SSH key secret: %w", err)
+		return 0, fmt.Errorf("failed to reconcile SSH key secret: %w", err)
 	}
 
 	if err := kubernetes.TryAddFinalizer(ctx, seedClient, cluster, UserSSHKeysClusterIDsCleanupFinalizer); err != nil {
-		return fmt.Errorf("failed to add finalizer: %w", err)
+		return 0, fmt.Errorf("failed to add finalizer: %w", err)
 	}
 
-	return nil
+	return requeueAfter, nil
 }
 
 func (r *Reconciler) cleanupUserSSHKeys(ctx context.Context, keys []kubermaticv1.UserSSHKey, clusterName string) error {
@@ -200,10 +247,12 @@ func (r *Reconciler) cleanupUserSSHKeys(ctx context.Context, keys []kubermaticv1
 	return nil
 }
 
-func buildUserSSHKeysForCluster(clusterName string, keys *kubermaticv1.UserSSHKeyList) []kubermaticv1.UserSSHKey {
+// buildUserSSHKeysForCluster returns all keys that are assigned to the given
+// cluster and have not yet expired.
+func buildUserSSHKeysForCluster(clusterName string, keys *kubermaticv1.UserSSHKeyList, now time.Time) []kubermaticv1.UserSSHKey {
 	var clusterKeys []kubermaticv1.UserSSHKey
 	for _, key := range keys.Items {
-		if key.IsUsedByCluster(clusterName) {
+		if key.IsUsedByCluster(clusterName) && !key.IsExpired(now) {
 			clusterKeys = append(clusterKeys, key)
 		}
 	}
@@ -211,6 +260,142 @@ func buildUserSSHKeysForCluster(clusterName string, keys *kubermaticv1.UserSSHKe
 	return clusterKeys
 }
 
+// warnAboutExpiringKeys emits a warning event to the owners of every key that
+// is going to expire within the ExpiryWarningPeriod. Each expiry date is only
+// warned about once, which is tracked using the ExpiryWarningAnnotation.
+func (r *Reconciler) warnAboutExpiringKeys(ctx context.Context, log *zap.SugaredLogger, keys []kubermaticv1.UserSSHKey, now time.Time) error {
+	for i := range keys {
+		key := &keys[i]
+		if key.Spec.ExpiresAt == nil || key.Spec.ExpiresAt.Sub(now) > ExpiryWarningPeriod {
+			continue
+		}
+
+		expiresAt := key.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+		if key.Annotations[ExpiryWarningAnnotation] == expiresAt {
+			continue
+		}
+
+		owners, err := r.keyOwners(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("SSH key %q in project %q expires at %s and will then be removed from all clusters.", key.Spec.Name, key.Spec.Project, expiresAt)
+		for _, owner := range owners {
+			r.recorder.Event(owner, corev1.EventTypeWarning, "SSHKeyExpiring", message)
+		}
+
+		// without any owner, the key itself is the only place left for the warning
+		if len(owners) == 0 {
+			log.Debugw("SSH key has no owner, recording expiry warning on the key", "key", key.Name)
+			r.recorder.Event(key, corev1.EventTypeWarning, "SSHKeyExpiring", message)
+		}
+
+		oldKey := key.DeepCopy()
+		if key.Annotations == nil {
+			key.Annotations = map[string]string{}
+		}
+		key.Annotations[ExpiryWarningAnnotation] = expiresAt
+
+		if err := r.masterClient.Patch(ctx, key, ctrlruntimeclient.MergeFrom(oldKey)); err != nil {
+			return fmt.Errorf("failed to mark UserSSHKey %s as warned: %w", key.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// keyOwners returns the users that should be notified about the given key. These
+// are the key's owner, if it is still recorded, or otherwise the project owners.
+func (r *Reconciler) keyOwners(ctx context.Context, key *kubermaticv1.UserSSHKey) ([]*kubermaticv1.User, error) {
+	if key.Spec.Owner != "" {
+		user := &kubermaticv1.User{}
+		if err := r.masterClient.Get(ctx, types.NamespacedName{Name: key.Spec.Owner}, user); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("failed to get owner of UserSSHKey %s: %w", key.Name, err)
+		}
+
+		return []*kubermaticv1.User{user}, nil
+	}
+
+	owners, err := r.projectUsers(ctx, key.Spec.Project, rbac.GenerateActualGroupNameFor(key.Spec.Project, rbac.OwnerGroupNamePrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*kubermaticv1.User
+	for _, name := range sets.List(owners) {
+		user := &kubermaticv1.User{}
+		if err := r.masterClient.Get(ctx, types.NamespacedName{Name: name}, user); err != nil {
+			return nil, fmt.Errorf("failed to get project owner %s: %w", name, err)
+		}
+
+		result = append(result, user)
+	}
+
+	return result, nil
+}
+
+// projectUsers returns the names of all users bound to the given project via a
+// UserProjectBinding. If group is not empty, only bindings to that group count.
+func (r *Reconciler) projectUsers(ctx context.Context, project string, group string) (sets.Set[string], error) {
+	bindings := &kubermaticv1.UserProjectBindingList{}
+	if err := r.masterClient.List(ctx, bindings); err != nil {
+		return nil, fmt.Errorf("failed to list UserProjectBindings: %w", err)
+	}
+
+	emails := sets.New[string]()
+	for _, binding := range bindings.Items {
+		if binding.Spec.ProjectID == project && (group == "" || binding.Spec.Group == group) {
+			emails.Insert(strings.ToLower(binding.Spec.UserEmail))
+		}
+	}
+
+	names := sets.New[string]()
+	if emails.Len() == 0 {
+		return names, nil
+	}
+
+	users := &kubermaticv1.UserList{}
+	if err := r.masterClient.List(ctx, users); err != nil {
+		return nil, fmt.Errorf("failed to list Users: %w", err)
+	}
+
+	for _, user := range users.Items {
+		if emails.Has(strings.ToLower(user.Spec.Email)) {
+			names.Insert(user.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// nextExpiryCheck returns the duration until the next key either enters its
+// warning period or expires. If no key has an expiry date, 0 is returned.
+func nextExpiryCheck(keys []kubermaticv1.UserSSHKey, now time.Time) time.Duration {
+	var next time.Duration
+
+	for _, key := range keys {
+		if key.Spec.ExpiresAt == nil {
+			continue
+		}
+
+		remaining := key.Spec.ExpiresAt.Sub(now)
+		if remaining > ExpiryWarningPeriod {
+			remaining -= ExpiryWarningPeriod
+		}
+
+		if next == 0 || remaining < next {
+			next = remaining
+		}
+	}
+
+	return next
+}
+
 // enqueueAllClusters enqueues all clusters.
 func enqueueAllClusters(clients kubernetes.SeedClientMap, workerSelector labels.Selector) handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a ctrlruntimeclient.Object) []reconcile.Request {
@@ -238,16 +423,20 @@ func enqueueAllClusters(clients kubernetes.SeedClientMap, workerSelector labels.
 	})
 }
 
+func userSSHKeysSecretData(keys []kubermaticv1.UserSSHKey) map[string][]byte {
+	data := map[string][]byte{}
+	for _, key := range keys {
+		data[key.Name] = []byte(key.Spec.PublicKey)
+	}
+
+	return data
+}
+
 // updateUserSSHKeysSecrets creates a secret in the seed cluster from the user ssh keys.
-func updateUserSSHKeysSecrets(keys []kubermaticv1.UserSSHKey) reconciling.NamedSecretReconcilerFactory {
+func updateUserSSHKeysSecrets(data map[string][]byte) reconciling.NamedSecretReconcilerFactory {
 	return func() (string, reconciling.SecretReconciler) {
 		return resources.UserSSHKeys, func(existing *corev1.Secret) (secret *corev1.Secret, e error) {
-			existing.Data = map[string][]byte{}
-
-			for _, key := range keys {
-				existing.Data[key.Name] = []byte(key.Spec.PublicKey)
-			}
-
+			existing.Data = data
 			existing.Type = corev1.SecretTypeOpaque
 
 			return existing, nil
@@ -18,15 +18,25 @@ package usersshkeysynchronizer
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/sshca"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -42,7 +52,8 @@ func TestUserSSHKeysClusterRemove(t *testing.T) {
 		{
 			name: "Test cleanup cluster ids in UserSSHKey on cluster deletion",
 			reconciler: &Reconciler{
-				log: kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				recorder: record.NewFakeRecorder(10),
 				masterClient: fake.NewClientBuilder().WithObjects(
 					&kubermaticv1.UserSSHKey{
 						ObjectMeta: metav1.ObjectMeta{
@@ -112,3 +123,270 @@ func TestUserSSHKeysClusterRemove(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildUserSSHKeysForClusterSkipsExpiredKeys(t *testing.T) {
+	now := time.Now()
+
+	newKey := func(name string, expiresAt *metav1.Time, clusters ...string) kubermaticv1.UserSSHKey {
+		return kubermaticv1.UserSSHKey{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubermaticv1.SSHKeySpec{
+				Clusters:  clusters,
+				ExpiresAt: expiresAt,
+			},
+		}
+	}
+
+	keys := &kubermaticv1.UserSSHKeyList{
+		Items: []kubermaticv1.UserSSHKey{
+			newKey("permanent", nil, "cluster"),
+			newKey("expired", &metav1.Time{Time: now.Add(-time.Minute)}, "cluster"),
+			newKey("expiring-soon", &metav1.Time{Time: now.Add(time.Hour)}, "cluster"),
+			newKey("expiring-later", &metav1.Time{Time: now.Add(ExpiryWarningPeriod + 2*time.Hour)}, "cluster"),
+			newKey("other-cluster", nil, "other"),
+		},
+	}
+
+	clusterKeys := buildUserSSHKeysForCluster("cluster", keys, now)
+
+	var names []string
+	for _, key := range clusterKeys {
+		names = append(names, key.Name)
+	}
+
+	expected := []string{"permanent", "expiring-soon", "expiring-later"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected keys %v, but got %v", expected, names)
+	}
+
+	if next := nextExpiryCheck(clusterKeys, now); next != time.Hour {
+		t.Fatalf("Expected next expiry check in %v, but got %v", time.Hour, next)
+	}
+
+}
+
+func TestWarnAboutExpiringKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	expiresAt := &metav1.Time{Time: now.Add(time.Hour)}
+
+	key := &kubermaticv1.UserSSHKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "expiring-soon"},
+		Spec: kubermaticv1.SSHKeySpec{
+			Name:      "debugging",
+			Project:   "my-project",
+			Clusters:  []string{"cluster"},
+			ExpiresAt: expiresAt,
+		},
+	}
+
+	masterClient := fake.NewClientBuilder().WithObjects(
+		key,
+		&kubermaticv1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner"},
+			Spec:       kubermaticv1.UserSpec{Email: "owner@example.com"},
+		},
+		&kubermaticv1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+			Spec:       kubermaticv1.UserSpec{Email: "viewer@example.com"},
+		},
+		&kubermaticv1.UserProjectBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner-binding"},
+			Spec: kubermaticv1.UserProjectBindingSpec{
+				UserEmail: "owner@example.com",
+				ProjectID: "my-project",
+				Group:     "owners-my-project",
+			},
+		},
+		&kubermaticv1.UserProjectBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer-binding"},
+			Spec: kubermaticv1.UserProjectBindingSpec{
+				UserEmail: "viewer@example.com",
+				ProjectID: "my-project",
+				Group:     "viewers-my-project",
+			},
+		},
+	).Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{masterClient: masterClient, recorder: recorder}
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	for i := 0; i < 3; i++ {
+		keys := &kubermaticv1.UserSSHKeyList{}
+		if err := masterClient.List(ctx, keys); err != nil {
+			t.Fatalf("Failed to list keys: %v", err)
+		}
+
+		if err := r.warnAboutExpiringKeys(ctx, log, buildUserSSHKeysForCluster("cluster", keys, now), now); err != nil {
+			t.Fatalf("Failed to warn about expiring keys: %v", err)
+		}
+	}
+
+	if len(recorder.Events) != 1 {
+		t.Fatalf("Expected exactly one warning event across all reconciliations, but got %d", len(recorder.Events))
+	}
+
+	// extending the expiry date must result in a new warning once it comes close again
+	updated := &kubermaticv1.UserSSHKey{}
+	if err := masterClient.Get(ctx, types.NamespacedName{Name: key.Name}, updated); err != nil {
+		t.Fatalf("Failed to get key: %v", err)
+	}
+
+	if updated.Annotations[ExpiryWarningAnnotation] == "" {
+		t.Fatal("Expected key to be annotated with the warned expiry date")
+	}
+
+	updated.Spec.ExpiresAt = &metav1.Time{Time: now.Add(2 * time.Hour)}
+	if err := r.warnAboutExpiringKeys(ctx, log, []kubermaticv1.UserSSHKey{*updated}, now); err != nil {
+		t.Fatalf("Failed to warn about expiring keys: %v", err)
+	}
+
+	if len(recorder.Events) != 2 {
+		t.Fatalf("Expected a second warning event after the expiry date changed, but got %d", len(recorder.Events)-1)
+	}
+}
+
+func TestCertificateAuthorityMode(t *testing.T) {
+	ctx := context.Background()
+
+	const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAID/rGpPdKsleLgb0cCshBa/pjo2cZ7sSsepAtuKrxw8k test"
+
+	newKey := func(name, project, owner string) *kubermaticv1.UserSSHKey {
+		return &kubermaticv1.UserSSHKey{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubermaticv1.SSHKeySpec{
+				Owner:     owner,
+				Project:   project,
+				PublicKey: publicKey,
+				Clusters:  []string{"test-cluster"},
+			},
+		}
+	}
+
+	// simulate the certificates issued by the seed
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate CA key: %v", err)
+	}
+
+	ca, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		t.Fatalf("Failed to create CA signer: %v", err)
+	}
+
+	issued, _, err := sshca.IssueCertificate(ca, publicKey, "test", time.Now(), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to issue certificate: %v", err)
+	}
+
+	formerMemberKey := newKey("former-member-key", "my-project", "former-member")
+	formerMemberKey.Status.Certificates = []kubermaticv1.SSHKeyCertificate{{Cluster: "test-cluster", Certificate: issued}}
+
+	masterClient := fake.NewClientBuilder().WithObjects(
+		newKey("project-key", "my-project", "member"),
+		newKey("foreign-key", "other-project", "member"),
+		formerMemberKey,
+		&kubermaticv1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "member"},
+			Spec:       kubermaticv1.UserSpec{Email: "member@example.com"},
+		},
+		&kubermaticv1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "former-member"},
+			Spec:       kubermaticv1.UserSpec{Email: "former@example.com"},
+		},
+		&kubermaticv1.UserProjectBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "member-binding"},
+			Spec: kubermaticv1.UserProjectBindingSpec{
+				UserEmail: "member@example.com",
+				ProjectID: "my-project",
+				Group:     "editors-my-project",
+			},
+		},
+	).WithStatusSubresource(&kubermaticv1.UserSSHKey{}).Build()
+
+	seedClient := fake.NewClientBuilder().WithObjects(
+		&kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cluster",
+				Labels: map[string]string{
+					kubermaticv1.ProjectIDLabelKey: "my-project",
+				},
+			},
+			Spec: kubermaticv1.ClusterSpec{
+				UserSSHKeyCertificateAuthority: &kubermaticv1.UserSSHKeyCertificateAuthoritySettings{
+					Enabled: true,
+				},
+			},
+			Status: kubermaticv1.ClusterStatus{
+				NamespaceName: "cluster-test-cluster",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "cluster-test-cluster",
+				Name:      resources.UserSSHKeyCertificatesSecretName,
+			},
+			Data: map[string][]byte{
+				resources.UserSSHKeyCAEntrySecretKey: sshca.PublicKey(ca.PublicKey()),
+				"project-key":                        []byte(issued),
+				"former-member-key":                  []byte(issued),
+			},
+		},
+	).Build()
+
+	reconciler := &Reconciler{
+		log:          kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		recorder:     record.NewFakeRecorder(10),
+		masterClient: masterClient,
+		seedClients: map[string]ctrlruntimeclient.Client{
+			"seed": seedClient,
+		},
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "seed", Name: "test-cluster"}}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	requests := &corev1.Secret{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: "cluster-test-cluster", Name: resources.UserSSHKeyCertificateRequestsSecretName}, requests); err != nil {
+		t.Fatalf("Failed to get certificate requests Secret: %v", err)
+	}
+
+	if _, ok := requests.Data["project-key"]; len(requests.Data) != 1 || !ok {
+		t.Fatalf("Expected only the key of a project member to be requested, but got %v.", requests.Data)
+	}
+
+	secret := &corev1.Secret{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Namespace: "cluster-test-cluster", Name: resources.UserSSHKeys}, secret); err != nil {
+		t.Fatalf("Failed to get usersshkeys Secret: %v", err)
+	}
+
+	if len(secret.Data) != 1 || !strings.HasPrefix(string(secret.Data[resources.UserSSHKeyCAEntrySecretKey]), "ssh-ed25519 ") {
+		t.Fatalf("Expected Secret to only contain the CA, but got %v.", secret.Data)
+	}
+
+	expectCertificate := func(name string, expected bool) {
+		t.Helper()
+
+		key := &kubermaticv1.UserSSHKey{}
+		if err := masterClient.Get(ctx, types.NamespacedName{Name: name}, key); err != nil {
+			t.Fatalf("Failed to get UserSSHKey: %v", err)
+		}
+
+		cert := getCertificate(key, "test-cluster")
+		if expected && (cert == nil || cert.Certificate != issued) {
+			t.Fatalf("Expected UserSSHKey %s to have the issued certificate.", name)
+		}
+
+		if !expected && cert != nil {
+			t.Fatalf("Expected UserSSHKey %s to not have a certificate.", name)
+		}
+	}
+
+	expectCertificate("project-key", true)
+	expectCertificate("foreign-key", false)
+	expectCertificate("former-member-key", false)
+}
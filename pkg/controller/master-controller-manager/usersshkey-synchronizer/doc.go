@@ -19,5 +19,16 @@ The usersshkeysynchronizer controller is responsible for synchronizing usersshke
 a secret in the cluster namespace. From there, the usercluster controller synchronizes them
 into the usercluster and then a DaemonSet that runs on all nodes synchronizes them onto the
 .ssh/authorized_keys file.
+
+Keys with an expiry date are left out of the secret once they expired, which in turn makes
+the agent remove them from the nodes. Shortly before a key expires, a single warning event is
+emitted to the key's owners (or the project owners, if the key has no owner recorded).
+
+Clusters with the SSH certificate authority mode enabled get a per-cluster SSH CA, which is managed
+by the seed and never leaves it. Instead of the public keys, only the CA is distributed to the nodes.
+The keys belonging to the cluster's project whose owners still have access to the project through a
+UserProjectBinding are requested to be certified by the seed, and the resulting short-lived certificates
+are copied into their status. Once an owner loses access, the key is not requested anymore, so its
+certificate is neither renewed nor published anymore.
 */
 package usersshkeysynchronizer
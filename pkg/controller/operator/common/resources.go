@@ -65,6 +65,9 @@ const (
 	// UserAdmissionWebhookName is the name of the validating webhook for Users.
 	UserAdmissionWebhookName = "kubermatic-users"
 
+	// ProjectAdmissionWebhookName is the name of the validating webhook for Projects.
+	ProjectAdmissionWebhookName = "kubermatic-projects"
+
 	// ResourceQuotaAdmissionWebhookName is the name of the validating and mutating webhook for ResourceQuotas.
 	ResourceQuotaAdmissionWebhookName = "kubermatic-resourcequotas"
 
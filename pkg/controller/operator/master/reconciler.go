@@ -186,6 +186,7 @@ func (r *Reconciler) cleanupDeletedConfiguration(ctx context.Context, config *ku
 
 	validating := []string{
 		common.UserAdmissionWebhookName,
+		common.ProjectAdmissionWebhookName,
 		common.UserSSHKeyAdmissionWebhookName,
 		common.SeedAdmissionWebhookName(config),
 		common.KubermaticConfigurationAdmissionWebhookName(config),
@@ -431,6 +432,7 @@ func (r *Reconciler) reconcileValidatingWebhooks(ctx context.Context, config *ku
 		common.SeedAdmissionWebhookReconciler(ctx, config, r.Client),
 		common.KubermaticConfigurationAdmissionWebhookReconciler(ctx, config, r.Client),
 		kubermatic.UserValidatingWebhookConfigurationReconciler(ctx, config, r.Client),
+		kubermatic.ProjectValidatingWebhookConfigurationReconciler(ctx, config, r.Client),
 		kubermatic.UserSSHKeyValidatingWebhookConfigurationReconciler(ctx, config, r.Client),
 		common.ApplicationDefinitionValidatingWebhookConfigurationReconciler(ctx, config, r.Client),
 		kubermatic.ResourceQuotaValidatingWebhookConfigurationReconciler(ctx, config, r.Client),
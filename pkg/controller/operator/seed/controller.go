@@ -43,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -226,6 +227,16 @@ func createSeedWatches(bldr *builder.Builder, seedName string, seedManager manag
 	// they are marked for deletion inside seed clusters
 	watch(&kubermaticv1.Seed{}, predicateutil.ByNamespace(namespace), workerlabel.Predicate(workerName))
 
+	// the nodeport-proxy is only deployed while clusters use an expose strategy that needs it
+	watch(&kubermaticv1.Cluster{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, okOld := e.ObjectOld.(*kubermaticv1.Cluster)
+			newCluster, okNew := e.ObjectNew.(*kubermaticv1.Cluster)
+
+			return okOld && okNew && oldCluster.Spec.ExposeStrategy != newCluster.Spec.ExposeStrategy
+		},
+	})
+
 	// namespaces are not managed by the operator and so can use neither namespacePredicate
 	// nor ManagedByPredicate, but still need to get their labels reconciled
 	watch(&corev1.Namespace{}, predicateutil.ByName(namespace))
@@ -45,7 +45,9 @@ import (
 	"k8c.io/reconciler/pkg/reconciling"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -285,23 +287,28 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *kubermaticv1.K
 		return err
 	}
 
-	if err := r.reconcileServiceAccounts(ctx, cfg, seed, client, log); err != nil {
+	nodeportProxy, err := nodeportProxyEnabled(ctx, cfg, seed, client)
+	if err != nil {
 		return err
 	}
 
-	if err := r.reconcileRoles(ctx, cfg, seed, client, log); err != nil {
+	if err := r.reconcileServiceAccounts(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
 		return err
 	}
 
-	if err := r.reconcileRoleBindings(ctx, cfg, seed, client, log); err != nil {
+	if err := r.reconcileRoles(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
 		return err
 	}
 
-	if err := r.reconcileClusterRoles(ctx, cfg, seed, client, log); err != nil {
+	if err := r.reconcileRoleBindings(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
 		return err
 	}
 
-	if err := r.reconcileClusterRoleBindings(ctx, cfg, seed, client, log); err != nil {
+	if err := r.reconcileClusterRoles(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
+		return err
+	}
+
+	if err := r.reconcileClusterRoleBindings(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
 		return err
 	}
 
@@ -317,18 +324,26 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *kubermaticv1.K
 		return err
 	}
 
-	if err := r.reconcileDeployments(ctx, cfg, seed, client, log, caBundle); err != nil {
+	if err := r.reconcileDeployments(ctx, cfg, seed, nodeportProxy, client, log, caBundle); err != nil {
 		return err
 	}
 
-	if err := r.reconcilePodDisruptionBudgets(ctx, cfg, seed, client, log); err != nil {
+	if err := r.reconcilePodDisruptionBudgets(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
 		return err
 	}
 
-	if err := r.reconcileServices(ctx, cfg, seed, client, log); err != nil {
+	if err := r.reconcileServices(ctx, cfg, seed, nodeportProxy, client, log); err != nil {
 		return err
 	}
 
+	// An explicitly disabled nodeport-proxy may have been replaced by another
+	// installation using the same names, so it is never removed.
+	if !nodeportProxy && !seed.Spec.NodeportProxy.Disable {
+		if err := r.cleanupNodeportProxy(ctx, cfg, client, log); err != nil {
+			return err
+		}
+	}
+
 	isCiliumDeployed, err := networkpolicy.CiliumCRDExists(ctx, client)
 	if err != nil {
 		return err
@@ -344,7 +359,7 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *kubermaticv1.K
 	// Once the webhooks are reconciled above, we can now clean up unneeded services.
 	common.CleanupWebhookServices(ctx, client, log, cfg.Namespace)
 
-	if err := metering.ReconcileMeteringResources(ctx, client, r.scheme, cfg, seed); err != nil {
+	if err := metering.ReconcileMeteringResources(ctx, client, r.scheme, cfg, seed, r.versions); err != nil {
 		return err
 	}
 
@@ -383,7 +398,39 @@ func (r *Reconciler) reconcileCRDs(ctx context.Context, cfg *kubermaticv1.Kuberm
 	return nil
 }
 
-func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+// nodeportProxyEnabled returns true if the nodeport-proxy should be deployed into the seed.
+// Clusters using the Gateway expose strategy are reached through the control plane
+// Gateway instead, so the nodeport-proxy is not needed if the seed defaults to it,
+// unless clusters explicitly use another expose strategy.
+func nodeportProxyEnabled(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client) (bool, error) {
+	if seed.Spec.NodeportProxy.Disable {
+		return false, nil
+	}
+
+	exposeStrategy := seed.Spec.ExposeStrategy
+	if exposeStrategy == "" {
+		exposeStrategy = cfg.Spec.ExposeStrategy
+	}
+
+	if exposeStrategy != kubermaticv1.ExposeStrategyGateway {
+		return true, nil
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := client.List(ctx, clusters); err != nil {
+		return false, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	for _, cluster := range clusters.Items {
+		if cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling Kubermatic ServiceAccounts")
 
 	creators := []reconciling.NamedServiceAccountReconcilerFactory{
@@ -391,7 +438,7 @@ func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, cfg *kubermat
 		common.WebhookServiceAccountReconciler(cfg),
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(creators, nodeportproxy.ServiceAccountReconciler(cfg))
 	}
 
@@ -415,14 +462,14 @@ func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, cfg *kubermat
 	return nil
 }
 
-func (r *Reconciler) reconcileRoles(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+func (r *Reconciler) reconcileRoles(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling Roles")
 
 	creators := []reconciling.NamedRoleReconcilerFactory{
 		common.WebhookRoleReconciler(cfg),
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(creators, nodeportproxy.RoleReconciler())
 	}
 
@@ -433,14 +480,14 @@ func (r *Reconciler) reconcileRoles(ctx context.Context, cfg *kubermaticv1.Kuber
 	return nil
 }
 
-func (r *Reconciler) reconcileRoleBindings(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+func (r *Reconciler) reconcileRoleBindings(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling RoleBindings")
 
 	creators := []reconciling.NamedRoleBindingReconcilerFactory{
 		common.WebhookRoleBindingReconciler(cfg),
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(creators, nodeportproxy.RoleBindingReconciler(cfg))
 	}
 
@@ -451,14 +498,14 @@ func (r *Reconciler) reconcileRoleBindings(ctx context.Context, cfg *kubermaticv
 	return nil
 }
 
-func (r *Reconciler) reconcileClusterRoles(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+func (r *Reconciler) reconcileClusterRoles(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling ClusterRoles")
 
 	creators := []reconciling.NamedClusterRoleReconcilerFactory{
 		common.WebhookClusterRoleReconciler(cfg),
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(creators, nodeportproxy.ClusterRoleReconciler(cfg))
 	}
 
@@ -473,7 +520,7 @@ func (r *Reconciler) reconcileClusterRoles(ctx context.Context, cfg *kubermaticv
 	return nil
 }
 
-func (r *Reconciler) reconcileClusterRoleBindings(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+func (r *Reconciler) reconcileClusterRoleBindings(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling ClusterRoleBindings")
 
 	creators := []reconciling.NamedClusterRoleBindingReconcilerFactory{
@@ -481,7 +528,7 @@ func (r *Reconciler) reconcileClusterRoleBindings(ctx context.Context, cfg *kube
 		common.WebhookClusterRoleBindingReconciler(cfg),
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(creators, nodeportproxy.ClusterRoleBindingReconciler(cfg))
 	}
 
@@ -540,7 +587,7 @@ func (r *Reconciler) reconcileSecrets(ctx context.Context, cfg *kubermaticv1.Kub
 	return nil
 }
 
-func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *corev1.ConfigMap) error {
+func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *corev1.ConfigMap) error {
 	log.Debug("reconciling Deployments")
 
 	creators := []reconciling.NamedDeploymentReconcilerFactory{
@@ -553,7 +600,7 @@ func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *kubermaticv1
 		return err
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(
 			creators,
 			nodeportproxy.EnvoyDeploymentReconciler(cfg, seed, supportsFailureDomainZoneAntiAffinity, r.versions),
@@ -607,14 +654,14 @@ func (r *Reconciler) reconcileCiliumNetworkPolicies(ctx context.Context, cfg *ku
 	return nil
 }
 
-func (r *Reconciler) reconcilePodDisruptionBudgets(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+func (r *Reconciler) reconcilePodDisruptionBudgets(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling PodDisruptionBudgets")
 
 	creators := []reconciling.NamedPodDisruptionBudgetReconcilerFactory{
 		kubermaticseed.SeedControllerManagerPDBReconciler(cfg),
 	}
 
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = append(creators, nodeportproxy.EnvoyPDBReconciler())
 	}
 
@@ -638,7 +685,7 @@ func (r *Reconciler) reconcilePodDisruptionBudgets(ctx context.Context, cfg *kub
 	return nil
 }
 
-func (r *Reconciler) reconcileServices(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+func (r *Reconciler) reconcileServices(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, nodeportProxy bool, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling Services")
 
 	creators := []reconciling.NamedServiceReconcilerFactory{
@@ -652,16 +699,26 @@ func (r *Reconciler) reconcileServices(ctx context.Context, cfg *kubermaticv1.Ku
 	// The nodeport-proxy LoadBalancer is not given an owner reference, so in case someone accidentally deletes
 	// the Seed resource, the current LoadBalancer IP is not lost. To be truly destructive, users would need to
 	// remove the entire Kubermatic namespace.
-	if !seed.Spec.NodeportProxy.Disable {
+	if nodeportProxy {
 		creators = []reconciling.NamedServiceReconcilerFactory{
 			nodeportproxy.ServiceReconciler(seed),
 		}
 
+		if seed.Spec.NodeportProxy.PrivateLoadBalancerService != nil {
+			creators = append(creators, nodeportproxy.PrivateServiceReconciler(seed))
+		}
+
 		if err := reconciling.ReconcileServices(ctx, creators, cfg.Namespace, client); err != nil {
 			return fmt.Errorf("failed to reconcile nodeport-proxy Services: %w", err)
 		}
 	}
 
+	if !nodeportProxy || seed.Spec.NodeportProxy.PrivateLoadBalancerService == nil {
+		if err := common.DeleteService(ctx, client, nodeportproxy.PrivateServiceName, cfg.Namespace); err != nil {
+			return fmt.Errorf("failed to clean up private nodeport-proxy Service: %w", err)
+		}
+	}
+
 	if cfg.Spec.FeatureGates[features.VerticalPodAutoscaler] {
 		creators := []reconciling.NamedServiceReconcilerFactory{
 			vpa.AdmissionControllerServiceReconciler(),
@@ -676,6 +733,41 @@ func (r *Reconciler) reconcileServices(ctx context.Context, cfg *kubermaticv1.Ku
 	return nil
 }
 
+// cleanupNodeportProxy removes the nodeport-proxy once all clusters on the seed are
+// exposed through the control plane Gateway.
+func (r *Reconciler) cleanupNodeportProxy(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	log.Debug("cleaning up nodeport-proxy")
+
+	namespaced := []struct {
+		name string
+		obj  ctrlruntimeclient.Object
+	}{
+		{name: nodeportproxy.EnvoyDeploymentName, obj: &appsv1.Deployment{}},
+		{name: nodeportproxy.UpdaterDeploymentName, obj: &appsv1.Deployment{}},
+		{name: nodeportproxy.EnvoyDeploymentName, obj: &policyv1.PodDisruptionBudget{}},
+		{name: nodeportproxy.ServiceName, obj: &corev1.Service{}},
+		{name: nodeportproxy.RoleBindingName, obj: &rbacv1.RoleBinding{}},
+		{name: nodeportproxy.RoleName, obj: &rbacv1.Role{}},
+		{name: nodeportproxy.ServiceAccountName, obj: &corev1.ServiceAccount{}},
+	}
+
+	for _, o := range namespaced {
+		if err := common.DeleteObject(ctx, client, o.name, cfg.Namespace, o.obj); err != nil {
+			return fmt.Errorf("failed to clean up nodeport-proxy %T %s: %w", o.obj, o.name, err)
+		}
+	}
+
+	if err := common.CleanupClusterResource(ctx, client, &rbacv1.ClusterRoleBinding{}, nodeportproxy.ClusterRoleBindingName(cfg)); err != nil {
+		return fmt.Errorf("failed to clean up nodeport-proxy ClusterRoleBinding: %w", err)
+	}
+
+	if err := common.CleanupClusterResource(ctx, client, &rbacv1.ClusterRole{}, nodeportproxy.ClusterRoleName(cfg)); err != nil {
+		return fmt.Errorf("failed to clean up nodeport-proxy ClusterRole: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Reconciler) reconcileAdmissionWebhooks(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling Admission Webhooks")
 
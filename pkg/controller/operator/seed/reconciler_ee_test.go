@@ -21,6 +21,7 @@ package seed
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -77,6 +78,17 @@ func TestMeteringReconciling(t *testing.T) {
 				if err != nil {
 					return fmt.Errorf("failed to find reporting cronjob: %w", err)
 				}
+
+				// the estimated costs are reported next to the metering tool's reports
+				containers := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers
+				if len(containers) != 2 || containers[1].Command[0] != "metering-cost-report" {
+					return fmt.Errorf("expected reporting cronjob to run the cost report, but got containers %v", containers)
+				}
+
+				if args := containers[1].Args; slices.Contains(args, "cluster") {
+					return fmt.Errorf("expected cost report to not receive report types, but got args %v", args)
+				}
+
 				return nil
 			},
 		},
@@ -27,6 +27,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/controller/operator/seed/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/defaulting"
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
@@ -41,6 +42,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -129,6 +131,7 @@ func getSeeds(now metav1.Time) map[string]*kubermaticv1.Seed {
 						"weekly-test": {
 							Schedule: "0 1 * * 6",
 							Interval: 7,
+							Types:    []string{"cluster"},
 						},
 					},
 				},
@@ -215,6 +218,51 @@ func TestBasicReconciling(t *testing.T) {
 			},
 		},
 
+		{
+			name:            "nodeport-proxy is removed once all clusters use the Gateway expose strategy",
+			seedToReconcile: "europe",
+			configuration:   &k8cConfig,
+			seedsOnMaster:   []string{"europe"},
+			syncedSeeds:     sets.New("europe"),
+			assertion: func(test *testcase, reconciler *Reconciler) error {
+				ctx := context.Background()
+
+				if err := reconciler.reconcile(ctx, reconciler.log, test.seedToReconcile); err != nil {
+					return fmt.Errorf("reconciliation failed: %w", err)
+				}
+
+				seedClient := reconciler.seedClients["europe"]
+				serviceName := types.NamespacedName{Namespace: "kubermatic", Name: nodeportproxy.ServiceName}
+				deploymentName := types.NamespacedName{Namespace: "kubermatic", Name: nodeportproxy.EnvoyDeploymentName}
+
+				must(t, seedClient.Get(ctx, serviceName, &corev1.Service{}))
+				must(t, seedClient.Get(ctx, deploymentName, &appsv1.Deployment{}))
+
+				seed := &kubermaticv1.Seed{}
+				must(t, seedClient.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: "europe"}, seed))
+				seed.Spec.ExposeStrategy = kubermaticv1.ExposeStrategyGateway
+				must(t, seedClient.Update(ctx, seed))
+
+				if err := reconciler.reconcile(ctx, reconciler.log, test.seedToReconcile); err != nil {
+					return fmt.Errorf("reconciliation failed: %w", err)
+				}
+
+				if err := seedClient.Get(ctx, serviceName, &corev1.Service{}); !apierrors.IsNotFound(err) {
+					return fmt.Errorf("nodeport-proxy Service should have been deleted, but getting it returned %w", err)
+				}
+
+				if err := seedClient.Get(ctx, deploymentName, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+					return fmt.Errorf("nodeport-proxy Deployment should have been deleted, but getting it returned %w", err)
+				}
+
+				if err := seedClient.Get(ctx, types.NamespacedName{Name: nodeportproxy.ClusterRoleName(test.configuration)}, &rbacv1.ClusterRole{}); !apierrors.IsNotFound(err) {
+					return fmt.Errorf("nodeport-proxy ClusterRole should have been deleted, but getting it returned %w", err)
+				}
+
+				return nil
+			},
+		},
+
 		{
 			name:            "all cluster-wide resources are cleaned up when deleting a seed",
 			seedToReconcile: "europe",
@@ -483,3 +531,66 @@ func createTestReconciler(allSeeds map[string]*kubermaticv1.Seed, cfg *kubermati
 		versions:               versions,
 	}
 }
+
+func TestNodeportProxyEnabled(t *testing.T) {
+	cluster := func(name string, exposeStrategy kubermaticv1.ExposeStrategy) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubermaticv1.ClusterSpec{ExposeStrategy: exposeStrategy},
+		}
+	}
+
+	testcases := []struct {
+		name           string
+		exposeStrategy kubermaticv1.ExposeStrategy
+		disabled       bool
+		clusters       []ctrlruntimeclient.Object
+		expected       bool
+	}{
+		{
+			name:           "NodePort seed",
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			expected:       true,
+		},
+		{
+			name:           "disabled nodeport-proxy",
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			disabled:       true,
+			expected:       false,
+		},
+		{
+			name:           "Gateway seed with Gateway clusters only",
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			clusters:       []ctrlruntimeclient.Object{cluster("a", kubermaticv1.ExposeStrategyGateway)},
+			expected:       false,
+		},
+		{
+			name:           "Gateway seed with a Tunneling cluster",
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			clusters: []ctrlruntimeclient.Object{
+				cluster("a", kubermaticv1.ExposeStrategyGateway),
+				cluster("b", kubermaticv1.ExposeStrategyTunneling),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			seed := &kubermaticv1.Seed{}
+			seed.Spec.ExposeStrategy = tc.exposeStrategy
+			seed.Spec.NodeportProxy.Disable = tc.disabled
+
+			client := fake.NewClientBuilder().WithObjects(tc.clusters...).Build()
+
+			enabled, err := nodeportProxyEnabled(context.Background(), &kubermaticv1.KubermaticConfiguration{}, seed, client)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if enabled != tc.expected {
+				t.Fatalf("Expected %v, got %v", tc.expected, enabled)
+			}
+		})
+	}
+}
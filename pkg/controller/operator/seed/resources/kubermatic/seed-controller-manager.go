@@ -87,6 +87,7 @@ func SeedControllerManagerDeploymentReconciler(workerName string, versions kuber
 				fmt.Sprintf("-max-parallel-reconcile=%d", cfg.Spec.SeedController.MaximumParallelReconciles),
 				fmt.Sprintf("-pprof-listen-address=%s", *cfg.Spec.SeedController.PProfEndpoint),
 				fmt.Sprintf("-disabled-collectors=%s", disabledCollectors),
+				fmt.Sprintf("-certificate-renewal-threshold=%d", cfg.Spec.SeedController.CertificateRenewalThreshold),
 			}
 
 			if cfg.Spec.ImagePullSecret != "" {
@@ -25,6 +25,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/ee/metering"
 	meteringprometheus "k8c.io/kubermatic/v2/pkg/ee/metering/prometheus"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,13 +33,13 @@ import (
 )
 
 // ReconcileMeteringResources reconciles the metering related resources.
-func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Client, scheme *runtime.Scheme, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed) error {
-	return metering.ReconcileMeteringResources(ctx, client, scheme, cfg, seed)
+func ReconcileMeteringResources(ctx context.Context, client ctrlruntimeclient.Client, scheme *runtime.Scheme, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions kubermatic.Versions) error {
+	return metering.ReconcileMeteringResources(ctx, client, scheme, cfg, seed, versions)
 }
 
 // CronJobReconciler returns the func to create/update the metering report cronjob. Available only for ee.
-func CronJobReconciler(rn string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, r registry.ImageRewriter, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
-	return metering.CronJobReconciler(rn, mrc, caBundleName, r, seed)
+func CronJobReconciler(rn string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, r registry.ImageRewriter, seed *kubermaticv1.Seed, cfg *kubermaticv1.KubermaticConfiguration, versions kubermatic.Versions) reconciling.NamedCronJobReconcilerFactory {
+	return metering.CronJobReconciler(rn, mrc, caBundleName, r, seed, cfg, versions)
 }
 
 // MeteringPrometheusReconciler returns the func to create/update the metering prometheus statefulset. Available only for ee.
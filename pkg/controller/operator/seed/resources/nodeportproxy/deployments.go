@@ -98,6 +98,10 @@ func EnvoyDeploymentReconciler(cfg *kubermaticv1.KubermaticConfiguration, seed *
 				fmt.Sprintf("-envoy-sni-port=%d", EnvoySNIPort),
 				fmt.Sprintf("-envoy-tunneling-port=%d", EnvoyTunnelingPort),
 			}
+			if seed.Spec.NodeportProxy.PrivateLoadBalancerService != nil {
+				args = append(args, fmt.Sprintf("-private-lb-name=%s", PrivateServiceName))
+			}
+
 			d.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    "envoy-manager",
@@ -231,6 +235,10 @@ func UpdaterDeploymentReconciler(cfg *kubermaticv1.KubermaticConfiguration, seed
 				fmt.Sprintf("-envoy-sni-port=%d", EnvoySNIPort),
 				fmt.Sprintf("-envoy-tunneling-port=%d", EnvoyTunnelingPort),
 			}
+			if seed.Spec.NodeportProxy.PrivateLoadBalancerService != nil {
+				args = append(args, fmt.Sprintf("-private-lb-name=%s", PrivateServiceName))
+			}
+
 			d.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    "lb-updater",
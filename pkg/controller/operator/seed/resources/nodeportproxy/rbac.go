@@ -39,7 +39,7 @@ func RoleReconciler() reconciling.NamedRoleReconcilerFactory {
 					APIGroups:     []string{""},
 					Resources:     []string{"services"},
 					Verbs:         []string{"update"},
-					ResourceNames: []string{ServiceName},
+					ResourceNames: []string{ServiceName, PrivateServiceName},
 				},
 			}
 
@@ -32,6 +32,9 @@ import (
 const (
 	// ServiceName is the name for the created service object.
 	ServiceName = "nodeport-proxy"
+	// PrivateServiceName is the name of the service object that exposes the
+	// API servers of private clusters.
+	PrivateServiceName = "nodeport-proxy-private"
 )
 
 // ServiceReconciler bootstraps the nodeport-proxy service object for a seed cluster resource.
@@ -94,3 +97,55 @@ func ServiceReconciler(seed *kubermaticv1.Seed) reconciling.NamedServiceReconcil
 		}
 	}
 }
+
+// PrivateServiceReconciler bootstraps the internal LoadBalancer service for private clusters. Like
+// the public service, its ports are managed by the lb-updater.
+func PrivateServiceReconciler(seed *kubermaticv1.Seed) reconciling.NamedServiceReconcilerFactory {
+	return func() (string, reconciling.ServiceReconciler) {
+		return PrivateServiceName, func(s *corev1.Service) (*corev1.Service, error) {
+			settings := seed.Spec.NodeportProxy.PrivateLoadBalancerService
+
+			s.Spec.Type = corev1.ServiceTypeLoadBalancer
+			s.Spec.Selector = map[string]string{
+				common.NameLabel: EnvoyDeploymentName,
+			}
+
+			if s.Annotations == nil {
+				s.Annotations = make(map[string]string)
+			}
+
+			s.Spec.LoadBalancerSourceRanges = nil
+			if settings != nil {
+				for k, v := range settings.Annotations {
+					s.Annotations[k] = v
+				}
+
+				for _, cidr := range settings.SourceRanges {
+					s.Spec.LoadBalancerSourceRanges = append(s.Spec.LoadBalancerSourceRanges, string(cidr))
+				}
+			}
+
+			if seed.Spec.NodeportProxy.IPFamilies != nil {
+				s.Spec.IPFamilies = seed.Spec.NodeportProxy.IPFamilies
+			}
+
+			if seed.Spec.NodeportProxy.IPFamilyPolicy != nil {
+				s.Spec.IPFamilyPolicy = seed.Spec.NodeportProxy.IPFamilyPolicy
+			}
+
+			// Services need at least one port to be valid, so create it initially.
+			if len(s.Spec.Ports) == 0 {
+				s.Spec.Ports = []corev1.ServicePort{
+					{
+						Name:       "healthz",
+						Port:       EnvoyPort,
+						TargetPort: intstr.FromInt(EnvoyPort),
+						Protocol:   corev1.ProtocolTCP,
+					},
+				}
+			}
+
+			return s, nil
+		}
+	}
+}
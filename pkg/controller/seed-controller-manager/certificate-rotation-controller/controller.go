@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterotationcontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-certificate-rotation-controller"
+
+	// RenewedAtAnnotation is set on Secrets whose certificates have been renewed by this controller.
+	RenewedAtAnnotation = "kubermatic.k8c.io/certificates-renewed-at"
+
+	// renewalCooldown is the minimum time between two renewals in the same cluster; it gives the
+	// cluster controller time to start rolling out the components that use the renewed certificates.
+	renewalCooldown = 5 * time.Minute
+
+	// maxRequeueInterval limits how long the controller waits until it checks a cluster again.
+	maxRequeueInterval = 12 * time.Hour
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log        *zap.SugaredLogger
+	workerName string
+	recorder   record.EventRecorder
+	versions   kubermatic.Versions
+
+	// renewalThreshold is the percentage of their lifetime after which certificates are renewed.
+	renewalThreshold int
+}
+
+// Add creates a new certificate rotation controller.
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int, workerName string, versions kubermatic.Versions, renewalThreshold int) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		log:        log.Named(ControllerName),
+		workerName: workerName,
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		versions:   versions,
+
+		renewalThreshold: renewalThreshold,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.Cluster{}).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		return reconcile.Result{}, nil
+	}
+
+	// Add a wrapping here so we can emit an event on error
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionCertificateRotationControllerReconcilingSuccess,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, log, cluster)
+		},
+	)
+
+	if result == nil || err != nil {
+		result = &reconcile.Result{}
+	}
+
+	if err != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return *result, err
+}
+
+// dueSecret is a Secret with at least one certificate that is due for renewal.
+type dueSecret struct {
+	secret *corev1.Secret
+	certs  []certificates.LeafCertificate
+	// expiry is the earliest expiry of the due certificates.
+	expiry time.Time
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list Secrets: %w", err)
+	}
+
+	signers := []*certificates.SignerKeyPair{}
+	for _, secret := range secrets.Items {
+		signer, err := certificates.SecretSigner(&secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA from Secret %s: %w", secret.Name, err)
+		}
+
+		if signer != nil {
+			signers = append(signers, signer)
+		}
+	}
+
+	now := time.Now()
+	nextCheck := now.Add(maxRequeueInterval)
+
+	var (
+		due         []dueSecret
+		lastRenewal time.Time
+	)
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+
+		if renewedAt, err := time.Parse(time.RFC3339, secret.Annotations[RenewedAtAnnotation]); err == nil && renewedAt.After(lastRenewal) {
+			lastRenewal = renewedAt
+		}
+
+		current := dueSecret{secret: secret}
+
+		for _, cert := range certificates.SecretLeafCertificates(secret) {
+			// certificates issued by a CA outside of the cluster namespace cannot be renewed
+			if findSigner(signers, cert) == nil {
+				continue
+			}
+
+			renewAt := cert.RenewalTime(r.renewalThreshold)
+			if renewAt.After(now) {
+				if renewAt.Before(nextCheck) {
+					nextCheck = renewAt
+				}
+				continue
+			}
+
+			if current.expiry.IsZero() || cert.Cert.NotAfter.Before(current.expiry) {
+				current.expiry = cert.Cert.NotAfter
+			}
+			current.certs = append(current.certs, cert)
+		}
+
+		if len(current.certs) > 0 {
+			due = append(due, current)
+		}
+	}
+
+	if len(due) == 0 {
+		return &reconcile.Result{RequeueAfter: nextCheck.Sub(now)}, nil
+	}
+
+	// renew the certificates closest to their expiry first
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].expiry.Before(due[j].expiry)
+	})
+
+	names := []string{}
+	for _, d := range due {
+		names = append(names, d.secret.Name)
+	}
+	log = log.With("secret", due[0].secret.Name)
+	log.Debugw("Certificates are due for renewal", "secrets", names)
+
+	if since := now.Sub(lastRenewal); since < renewalCooldown {
+		return &reconcile.Result{RequeueAfter: renewalCooldown - since}, nil
+	}
+
+	settled, err := r.controlPlaneSettled(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if !settled {
+		log.Debug("Control plane is not settled, waiting before renewing certificates")
+		return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if err := r.renewSecret(ctx, due[0], signers, now); err != nil {
+		return nil, fmt.Errorf("failed to renew certificates in Secret %s: %w", due[0].secret.Name, err)
+	}
+
+	keys := []string{}
+	for _, cert := range due[0].certs {
+		keys = append(keys, cert.Name())
+	}
+
+	log.Infow("Renewed certificates", "certificates", keys)
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "CertificatesRenewed", "Renewed certificates %s in Secret %s after %d%% of their lifetime", strings.Join(keys, ", "), due[0].secret.Name, r.renewalThreshold)
+
+	return &reconcile.Result{RequeueAfter: renewalCooldown}, nil
+}
+
+func (r *Reconciler) renewSecret(ctx context.Context, due dueSecret, signers []*certificates.SignerKeyPair, now time.Time) error {
+	oldSecret := due.secret.DeepCopy()
+
+	for _, cert := range due.certs {
+		certPEM, keyPEM, err := certificates.RenewCertificate(cert.Cert, findSigner(signers, cert), certificates.Duration365d)
+		if err != nil {
+			return fmt.Errorf("failed to renew certificate %s: %w", cert.Name(), err)
+		}
+
+		if err := certificates.SetSecretCertificate(due.secret, cert, certPEM, keyPEM); err != nil {
+			return fmt.Errorf("failed to store certificate %s: %w", cert.Name(), err)
+		}
+	}
+
+	if due.secret.Annotations == nil {
+		due.secret.Annotations = map[string]string{}
+	}
+	due.secret.Annotations[RenewedAtAnnotation] = now.UTC().Format(time.RFC3339)
+
+	return r.Patch(ctx, due.secret, ctrlruntimeclient.MergeFromWithOptions(oldSecret, ctrlruntimeclient.MergeFromWithOptimisticLock{}))
+}
+
+// controlPlaneSettled returns true if the control plane is healthy and no Deployment or
+// StatefulSet in the cluster namespace is still rolling out.
+func (r *Reconciler) controlPlaneSettled(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if !cluster.Status.ExtendedHealth.ControlPlaneHealthy() {
+		return false, nil
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, fmt.Errorf("failed to list Deployments: %w", err)
+	}
+
+	for _, d := range deployments.Items {
+		replicas := ptr.Deref(d.Spec.Replicas, 1)
+		if d.Status.ObservedGeneration < d.Generation || d.Status.UpdatedReplicas != replicas || d.Status.ReadyReplicas != replicas {
+			return false, nil
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, fmt.Errorf("failed to list StatefulSets: %w", err)
+	}
+
+	for _, s := range statefulSets.Items {
+		replicas := ptr.Deref(s.Spec.Replicas, 1)
+		if s.Status.ObservedGeneration < s.Generation || s.Status.UpdatedReplicas != replicas || s.Status.ReadyReplicas != replicas {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func findSigner(signers []*certificates.SignerKeyPair, cert certificates.LeafCertificate) *certificates.SignerKeyPair {
+	for _, signer := range signers {
+		if cert.Cert.CheckSignatureFrom(signer.Cert) == nil {
+			return signer
+		}
+	}
+
+	return nil
+}
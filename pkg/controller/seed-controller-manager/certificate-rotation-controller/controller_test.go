@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterotationcontroller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testNamespace = "cluster-test"
+
+func TestReconcile(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	now := time.Now()
+	healthy := kubermaticv1.ExtendedClusterHealth{
+		Etcd:       kubermaticv1.HealthStatusUp,
+		Controller: kubermaticv1.HealthStatusUp,
+		Apiserver:  kubermaticv1.HealthStatusUp,
+		Scheduler:  kubermaticv1.HealthStatusUp,
+	}
+
+	testCases := []struct {
+		name          string
+		health        kubermaticv1.ExtendedClusterHealth
+		objects       []ctrlruntimeclient.Object
+		expectRenewal bool
+	}{
+		{
+			name:   "certificate past the threshold is renewed",
+			health: healthy,
+			objects: []ctrlruntimeclient.Object{
+				certSecret(t, ca, "apiserver-tls", "apiserver-tls", now.Add(-300*24*time.Hour), nil),
+				certSecret(t, ca, resources.KubeletClientCertificatesSecretName, "kubelet-client", now, nil),
+			},
+			expectRenewal: true,
+		},
+		{
+			name:   "certificate within the threshold is not renewed",
+			health: healthy,
+			objects: []ctrlruntimeclient.Object{
+				certSecret(t, ca, "apiserver-tls", "apiserver-tls", now.Add(-200*24*time.Hour), nil),
+			},
+		},
+		{
+			name:   "no renewal shortly after another renewal",
+			health: healthy,
+			objects: []ctrlruntimeclient.Object{
+				certSecret(t, ca, "apiserver-tls", "apiserver-tls", now.Add(-300*24*time.Hour), nil),
+				certSecret(t, ca, resources.KubeletClientCertificatesSecretName, "kubelet-client", now, map[string]string{
+					RenewedAtAnnotation: now.Add(-time.Minute).UTC().Format(time.RFC3339),
+				}),
+			},
+		},
+		{
+			name: "no renewal while the control plane is unhealthy",
+			objects: []ctrlruntimeclient.Object{
+				certSecret(t, ca, "apiserver-tls", "apiserver-tls", now.Add(-300*24*time.Hour), nil),
+			},
+		},
+		{
+			name:   "no renewal while a component is rolling out",
+			health: healthy,
+			objects: []ctrlruntimeclient.Object{
+				certSecret(t, ca, "apiserver-tls", "apiserver-tls", now.Add(-300*24*time.Hour), nil),
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "apiserver", Namespace: testNamespace},
+					Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+					Status:     appsv1.DeploymentStatus{UpdatedReplicas: 1, ReadyReplicas: 2},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName:  testNamespace,
+					ExtendedHealth: tc.health,
+				},
+			}
+
+			objects := append([]ctrlruntimeclient.Object{cluster, caSecret(ca)}, tc.objects...)
+			client := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+			r := &Reconciler{
+				Client:           client,
+				log:              kubermaticlog.Logger,
+				recorder:         record.NewFakeRecorder(10),
+				renewalThreshold: 80,
+			}
+
+			result, err := r.reconcile(ctx, r.log, cluster)
+			if err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			if result == nil || result.RequeueAfter <= 0 {
+				t.Fatalf("Expected the cluster to be requeued, got %v", result)
+			}
+
+			secret := &corev1.Secret{}
+			if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: testNamespace, Name: "apiserver-tls"}, secret); err != nil {
+				t.Fatalf("Failed to get Secret: %v", err)
+			}
+
+			certs, err := triple.ParseCertsPEM(secret.Data["apiserver-tls.crt"])
+			if err != nil {
+				t.Fatalf("Failed to parse certificate: %v", err)
+			}
+
+			renewed := now.Sub(certs[0].NotBefore) < time.Hour
+			if renewed != tc.expectRenewal {
+				t.Fatalf("Expected renewal to be %v, but certificate is valid from %v", tc.expectRenewal, certs[0].NotBefore)
+			}
+
+			if !tc.expectRenewal {
+				return
+			}
+
+			if certs[0].Subject.CommonName != "apiserver-tls" {
+				t.Errorf("Expected common name to be kept, got %q", certs[0].Subject.CommonName)
+			}
+
+			if err := certs[0].CheckSignatureFrom(ca.Cert); err != nil {
+				t.Errorf("Expected renewed certificate to be signed by the CA: %v", err)
+			}
+
+			if _, err := triple.ParseRSAKeyPair(secret.Data["apiserver-tls.crt"], secret.Data["apiserver-tls.key"]); err != nil {
+				t.Errorf("Expected a valid key pair: %v", err)
+			}
+
+			if secret.Annotations[RenewedAtAnnotation] == "" {
+				t.Errorf("Expected Secret to have the %s annotation", RenewedAtAnnotation)
+			}
+		})
+	}
+}
+
+func caSecret(ca *triple.KeyPair) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName, Namespace: testNamespace},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+		},
+	}
+}
+
+func TestReconcileKubeconfig(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	const (
+		server      = "https://apiserver-external.cluster-test.svc.cluster.local"
+		commonName  = "system:kube-scheduler"
+		clusterName = "test"
+	)
+
+	now := time.Now()
+	cert, privateKey := clientCertificate(t, ca, commonName, now.Add(-300*24*time.Hour))
+
+	config := resources.GetBaseKubeconfig(ca.Cert, server, clusterName)
+	config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
+		"default": {
+			ClientCertificateData: triple.EncodeCertPEM(cert),
+			ClientKeyData:         triple.EncodePrivateKeyPEM(privateKey),
+		},
+	}
+
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatalf("Failed to encode kubeconfig: %v", err)
+	}
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: testNamespace,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Etcd:       kubermaticv1.HealthStatusUp,
+				Controller: kubermaticv1.HealthStatusUp,
+				Apiserver:  kubermaticv1.HealthStatusUp,
+				Scheduler:  kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.SchedulerKubeconfigSecretName, Namespace: testNamespace},
+		Data: map[string][]byte{
+			resources.KubeconfigSecretKey: kubeconfig,
+		},
+	}
+
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithObjects(cluster, caSecret(ca), secret).Build()
+
+	r := &Reconciler{
+		Client:           client,
+		log:              kubermaticlog.Logger,
+		recorder:         record.NewFakeRecorder(10),
+		renewalThreshold: 80,
+	}
+
+	if _, err := r.reconcile(ctx, r.log, cluster); err != nil {
+		t.Fatalf("Reconciling failed: %v", err)
+	}
+
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+
+	renewed, err := clientcmd.Load(secret.Data[resources.KubeconfigSecretKey])
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+
+	certs, err := triple.ParseCertsPEM(renewed.AuthInfos["default"].ClientCertificateData)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	if now.Sub(certs[0].NotBefore) > time.Hour {
+		t.Fatalf("Expected the certificate to be renewed, but it is valid from %v", certs[0].NotBefore)
+	}
+
+	// the kubeconfig reconciler must accept the renewed kubeconfig, otherwise it would replace it
+	valid, err := resources.IsValidKubeconfig(secret.Data[resources.KubeconfigSecretKey], ca.Cert, server, commonName, nil, clusterName)
+	if err != nil || !valid {
+		t.Fatalf("Expected the renewed kubeconfig to be valid, got %v (%v)", valid, err)
+	}
+}
+
+// clientCertificate creates a one year client certificate issued at the given time.
+func clientCertificate(t *testing.T, ca *triple.KeyPair, commonName string, notBefore time.Time) (*x509.Certificate, *rsa.PrivateKey) {
+	privateKey, err := triple.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to create private key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, privateKey.Public(), ca.Key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	return cert, privateKey
+}
+
+// certSecret creates a Secret with a one year client certificate issued at the given time.
+func certSecret(t *testing.T, ca *triple.KeyPair, name, key string, notBefore time.Time, annotations map[string]string) *corev1.Secret {
+	cert, privateKey := clientCertificate(t, ca, key, notBefore)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Annotations: annotations},
+		Data: map[string][]byte{
+			key + ".crt":              triple.EncodeCertPEM(cert),
+			key + ".key":              triple.EncodePrivateKeyPEM(privateKey),
+			resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+		},
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package certificaterotationcontroller contains a controller that renews the
+certificates of user cluster control planes once a configurable percentage of
+their lifetime has passed, long before the certificate reconcilers would
+regenerate them shortly before they expire. This includes the client
+certificates embedded in the kubeconfigs of control plane components.
+
+Certificates are renewed one Secret at a time: the next Secret is only renewed
+once the control plane components that were restarted because of the previous
+renewal have finished rolling out.
+*/
+package certificaterotationcontroller
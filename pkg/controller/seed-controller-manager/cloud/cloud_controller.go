@@ -21,6 +21,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/clusterdeletion"
 	"k8c.io/kubermatic/v2/pkg/defaulting"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -91,6 +93,8 @@ func Add(
 			MaxConcurrentReconciles: numWorkers,
 		}).
 		For(&kubermaticv1.Cluster{}).
+		Watches(&kubermaticv1.Project{}, enqueueClustersForProject(reconciler, reconciler.log), builder.WithPredicates(projectResourceTagsChanged())).
+		Watches(&kubermaticv1.Seed{}, enqueueClustersForSeed(reconciler, reconciler.log), builder.WithPredicates(seedResourceTagsChanged())).
 		Build(reconciler)
 
 	return err
@@ -155,6 +159,12 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 			return &reconcile.Result{RequeueAfter: 5 * time.Second}, nil
 		}
 
+		// the deletion has not yet been confirmed by the user
+		if clusterdeletion.AwaitingConfirmation(cluster) {
+			log.Debug("Cluster deletion has not yet been confirmed, retrying later")
+			return &reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
 		if _, err := prov.CleanUpCloudProvider(ctx, cluster, r.clusterUpdater); err != nil {
 			return nil, fmt.Errorf("failed cloud provider cleanup: %w", err)
 		}
@@ -173,6 +183,26 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return nil, fmt.Errorf("failed cloud provider init: %w", err)
 	}
 
+	// Keep track of the effective resource tags; whenever they change, the cloud provider
+	// reconciliation is forced by resetting the last reconciliation timestamp, so that the
+	// new tags are applied to existing resources right away.
+	tags, err := r.resourceTags(ctx, &datacenter, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if !maps.Equal(tags, cluster.Status.ResourceTags) {
+		log.Infow("Resource tags have changed", "tags", tags)
+
+		err = kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.ResourceTags = tags
+			c.Status.LastProviderReconciliation = metav1.Time{}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update resource tags: %w", err)
+		}
+	}
+
 	// initialize the cloud provider resources that need to exist for the cluster to function;
 	// this will only create the resources once, and becomes a NOP after the first call.
 	//
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// resourceTags returns the effective resource tags for the given cluster, merged from
+// its datacenter, its project and the cluster itself.
+func (r *Reconciler) resourceTags(ctx context.Context, datacenter *kubermaticv1.Datacenter, cluster *kubermaticv1.Cluster) (map[string]string, error) {
+	var project *kubermaticv1.Project
+
+	if projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]; projectID != "" {
+		project = &kubermaticv1.Project{}
+		if err := r.Get(ctx, types.NamespacedName{Name: projectID}, project); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get project %q: %w", projectID, err)
+			}
+			project = nil
+		}
+	}
+
+	return provider.EffectiveResourceTags(datacenter, project, cluster), nil
+}
+
+// projectResourceTagsChanged only lets update events pass where the resource tags
+// of a project have changed.
+func projectResourceTagsChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldProject, okOld := e.ObjectOld.(*kubermaticv1.Project)
+			newProject, okNew := e.ObjectNew.(*kubermaticv1.Project)
+
+			return okOld && okNew && !maps.Equal(oldProject.Spec.ResourceTags, newProject.Spec.ResourceTags)
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// enqueueClustersForProject enqueues all clusters belonging to a project.
+func enqueueClustersForProject(client ctrlruntimeclient.Client, log *zap.SugaredLogger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a ctrlruntimeclient.Object) []reconcile.Request {
+		clusters := &kubermaticv1.ClusterList{}
+		if err := client.List(ctx, clusters, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: a.GetName()}); err != nil {
+			log.Errorw("Failed to list clusters for project", "project", a.GetName(), zap.Error(err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cluster := range clusters.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}})
+		}
+
+		return requests
+	})
+}
+
+// seedResourceTagsChanged only lets update events pass where the resource tags of
+// at least one datacenter have changed.
+func seedResourceTagsChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSeed, okOld := e.ObjectOld.(*kubermaticv1.Seed)
+			newSeed, okNew := e.ObjectNew.(*kubermaticv1.Seed)
+
+			return okOld && okNew && len(changedDatacenters(oldSeed, newSeed)) > 0
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// changedDatacenters returns the names of all datacenters in newSeed whose resource
+// tags differ from oldSeed.
+func changedDatacenters(oldSeed, newSeed *kubermaticv1.Seed) []string {
+	var changed []string
+
+	for name, dc := range newSeed.Spec.Datacenters {
+		if !maps.Equal(oldSeed.Spec.Datacenters[name].Spec.ResourceTags, dc.Spec.ResourceTags) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+// enqueueClustersForSeed enqueues all clusters. Seed updates are rare and the cloud
+// controller only talks to the cloud provider when the effective tags of a cluster
+// have actually changed, so no further filtering by datacenter is done.
+func enqueueClustersForSeed(client ctrlruntimeclient.Client, log *zap.SugaredLogger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ ctrlruntimeclient.Object) []reconcile.Request {
+		clusters := &kubermaticv1.ClusterList{}
+		if err := client.List(ctx, clusters); err != nil {
+			log.Errorw("Failed to list clusters", zap.Error(err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cluster := range clusters.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}})
+		}
+
+		return requests
+	})
+}
@@ -18,6 +18,7 @@ package clustercredentialscontroller
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -26,6 +27,8 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -55,13 +58,16 @@ type Reconciler struct {
 	versions   kubermatic.Versions
 }
 
-// Add creates a new cluster-credentials controller.
+// Add creates a new cluster-credentials controller, together with the controller
+// that handles CredentialRotations.
 func Add(
 	mgr manager.Manager,
 	numWorkers int,
 	workerName string,
 	log *zap.SugaredLogger,
 	versions kubermatic.Versions,
+	seedGetter provider.SeedGetter,
+	caBundle *x509.CertPool,
 ) error {
 	reconciler := &Reconciler{
 		Client: mgr.GetClient(),
@@ -79,6 +85,28 @@ func Add(
 		}).
 		For(&kubermaticv1.Cluster{}).
 		Build(reconciler)
+	if err != nil {
+		return err
+	}
+
+	rotationReconciler := &RotationReconciler{
+		Client: mgr.GetClient(),
+
+		recorder:   mgr.GetEventRecorderFor(RotationControllerName),
+		log:        log.Named(RotationControllerName),
+		seedGetter: seedGetter,
+		getProvider: func(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+			return cloud.Provider(datacenter, secretKeyGetter, caBundle)
+		},
+	}
+
+	_, err = builder.ControllerManagedBy(mgr).
+		Named(RotationControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+		}).
+		For(&kubermaticv1.CredentialRotation{}).
+		Build(rotationReconciler)
 
 	return err
 }
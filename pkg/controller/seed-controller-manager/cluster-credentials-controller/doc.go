@@ -23,5 +23,11 @@ In a perfect future, we would not even ever create a Cluster with inline credent
 but for historical reasons it's the safest method to handle credentials for now.
 It is also super convenient that users do not have to manually create a Secret
 somewhere themselves.
+
+The package also contains the credential rotation controller, which handles
+CredentialRotation objects: it validates new credentials against every cluster
+created from a given Preset (or sharing a given credentials Secret), writes them
+into the clusters' Secrets and restarts the control plane components so that
+machine-controller, the CCM and others pick up the new credentials.
 */
 package clustercredentialscontroller
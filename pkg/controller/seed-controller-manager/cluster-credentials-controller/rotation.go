@@ -0,0 +1,462 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercredentialscontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	RotationControllerName = "kkp-credential-rotation-controller"
+
+	// rotationProgressInterval is how often the rollout of a rotation is checked.
+	rotationProgressInterval = 10 * time.Second
+)
+
+// providerGetter returns the cloud provider for a datacenter.
+type providerGetter func(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error)
+
+// RotationReconciler rotates the credentials of all clusters affected by a CredentialRotation.
+type RotationReconciler struct {
+	ctrlruntimeclient.Client
+
+	recorder    record.EventRecorder
+	log         *zap.SugaredLogger
+	seedGetter  provider.SeedGetter
+	getProvider providerGetter
+}
+
+func (r *RotationReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("rotation", request.Name)
+	log.Debug("Reconciling")
+
+	rotation := &kubermaticv1.CredentialRotation{}
+	if err := r.Get(ctx, request.NamespacedName, rotation); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if rotation.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// finished rotations are never started again
+	if phase := rotation.Status.Phase; phase == kubermaticv1.CredentialRotationPhaseCompleted || phase == kubermaticv1.CredentialRotationPhaseFailed {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, rotation)
+	if result == nil || err != nil {
+		result = &reconcile.Result{}
+	}
+
+	if err != nil {
+		r.recorder.Event(rotation, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return *result, err
+}
+
+func (r *RotationReconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rotation *kubermaticv1.CredentialRotation) (*reconcile.Result, error) {
+	newCredentials := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: rotation.Spec.NewCredentials, Namespace: resources.KubermaticNamespace}, newCredentials); err != nil {
+		return nil, fmt.Errorf("failed to get new credentials Secret: %w", err)
+	}
+
+	if rotation.Status.Phase == kubermaticv1.CredentialRotationPhaseInProgress {
+		return r.rollout(ctx, log, rotation, newCredentials)
+	}
+
+	return r.validate(ctx, log, rotation, newCredentials)
+}
+
+// validate determines the affected clusters and checks that the new credentials work for
+// every one of them. Only if all clusters pass, the rotation moves on to the rollout, so
+// that a bad Secret never leaves clusters half-rotated.
+func (r *RotationReconciler) validate(ctx context.Context, log *zap.SugaredLogger, rotation *kubermaticv1.CredentialRotation, newCredentials *corev1.Secret) (*reconcile.Result, error) {
+	if err := validateRotationSpec(rotation.Spec); err != nil {
+		return nil, r.finish(ctx, rotation, kubermaticv1.CredentialRotationPhaseFailed, err.Error())
+	}
+
+	clusters, err := r.getAffectedClusters(ctx, rotation.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine affected clusters: %w", err)
+	}
+
+	if len(clusters) == 0 {
+		return nil, r.finish(ctx, rotation, kubermaticv1.CredentialRotationPhaseCompleted, "No clusters are using the given Preset or Secret.")
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current Seed: %w", err)
+	}
+
+	newRef := &providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{
+			Name:      newCredentials.Name,
+			Namespace: newCredentials.Namespace,
+		},
+	}
+
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, r)
+
+	var failures []string
+	for _, cluster := range clusters {
+		if err := r.validateCredentials(ctx, seed, cluster, newRef, secretKeyGetter); err != nil {
+			log.Infow("New credentials are invalid for cluster", "cluster", cluster.Name, zap.Error(err))
+			failures = append(failures, fmt.Sprintf("%s: %v", cluster.Name, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return nil, r.finish(ctx, rotation, kubermaticv1.CredentialRotationPhaseFailed, fmt.Sprintf("New credentials are invalid, no cluster has been changed: %s", strings.Join(failures, "; ")))
+	}
+
+	err = r.updateStatus(ctx, rotation, func(rot *kubermaticv1.CredentialRotation) {
+		rot.Status.Phase = kubermaticv1.CredentialRotationPhaseInProgress
+		rot.Status.Message = ""
+		rot.Status.StartTime = ptr.To(metav1.Now())
+		rot.Status.Clusters = map[string]kubermaticv1.CredentialRotationClusterStatus{}
+
+		for _, cluster := range clusters {
+			rot.Status.Clusters[cluster.Name] = kubermaticv1.CredentialRotationClusterStatus{
+				Phase: kubermaticv1.CredentialRotationClusterPhasePending,
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	log.Infow("New credentials validated, starting rollout", "clusters", len(clusters))
+	r.recorder.Eventf(rotation, corev1.EventTypeNormal, "CredentialsValidated", "New credentials are valid for %d cluster(s)", len(clusters))
+
+	return &reconcile.Result{Requeue: true}, nil
+}
+
+func validateRotationSpec(spec kubermaticv1.CredentialRotationSpec) error {
+	if spec.NewCredentials == "" {
+		return errors.New("no new credentials Secret specified")
+	}
+
+	if (spec.Preset == "") == (spec.CredentialsSecret == "") {
+		return errors.New("exactly one of preset and credentialsSecret must be specified")
+	}
+
+	return nil
+}
+
+func (r *RotationReconciler) getAffectedClusters(ctx context.Context, spec kubermaticv1.CredentialRotationSpec) ([]*kubermaticv1.Cluster, error) {
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return nil, err
+	}
+
+	var affected []*kubermaticv1.Cluster
+	for i, cluster := range clusters.Items {
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+
+		if spec.Preset != "" {
+			if cluster.Annotations[kubermaticv1.PresetNameAnnotation] == spec.Preset {
+				affected = append(affected, &clusters.Items[i])
+			}
+
+			continue
+		}
+
+		ref, err := resources.GetCredentialsReference(&cluster)
+		if err != nil || ref == nil {
+			continue
+		}
+
+		if ref.Name == spec.CredentialsSecret && ref.Namespace == resources.KubermaticNamespace {
+			affected = append(affected, &clusters.Items[i])
+		}
+	}
+
+	return affected, nil
+}
+
+// validateCredentials validates the cluster's cloud spec as if it was already using the new credentials.
+func (r *RotationReconciler) validateCredentials(ctx context.Context, seed *kubermaticv1.Seed, cluster *kubermaticv1.Cluster, newRef *providerconfig.GlobalSecretKeySelector, secretKeyGetter provider.SecretKeySelectorValueFunc) error {
+	datacenter, ok := seed.Spec.Datacenters[cluster.Spec.Cloud.DatacenterName]
+	if !ok {
+		return fmt.Errorf("datacenter %q not found", cluster.Spec.Cloud.DatacenterName)
+	}
+
+	cloudProvider, err := r.getProvider(&datacenter, secretKeyGetter)
+	if err != nil {
+		return fmt.Errorf("failed to create cloud provider: %w", err)
+	}
+
+	candidate := cluster.DeepCopy()
+	if err := resources.SetCredentialsReference(candidate, newRef); err != nil {
+		return err
+	}
+
+	return cloudProvider.ValidateCloudSpec(ctx, candidate.Spec.Cloud)
+}
+
+// rollout moves every affected cluster through the Pending -> Restarting -> Completed phases.
+func (r *RotationReconciler) rollout(ctx context.Context, log *zap.SugaredLogger, rotation *kubermaticv1.CredentialRotation, newCredentials *corev1.Secret) (*reconcile.Result, error) {
+	clusterNames := make([]string, 0, len(rotation.Status.Clusters))
+	for name := range rotation.Status.Clusters {
+		clusterNames = append(clusterNames, name)
+	}
+	sort.Strings(clusterNames)
+
+	startTime := time.Now()
+	if rotation.Status.StartTime != nil {
+		startTime = rotation.Status.StartTime.Time
+	}
+
+	restartValue := startTime.UTC().Format(time.RFC3339)
+	clusterStatuses := map[string]kubermaticv1.CredentialRotationClusterStatus{}
+
+	for _, name := range clusterNames {
+		status := rotation.Status.Clusters[name]
+		clusterLog := log.With("cluster", name)
+
+		switch status.Phase {
+		case kubermaticv1.CredentialRotationClusterPhasePending:
+			failure, err := r.rotateCluster(ctx, name, newCredentials, restartValue)
+			if err != nil {
+				return nil, fmt.Errorf("failed to rotate credentials of cluster %s: %w", name, err)
+			}
+
+			if failure != "" {
+				clusterLog.Infow("Failed to rotate credentials", "reason", failure)
+				status = kubermaticv1.CredentialRotationClusterStatus{Phase: kubermaticv1.CredentialRotationClusterPhaseFailed, Message: failure}
+			} else {
+				clusterLog.Info("Credentials updated, restarting control plane")
+				status = kubermaticv1.CredentialRotationClusterStatus{Phase: kubermaticv1.CredentialRotationClusterPhaseRestarting}
+			}
+
+		case kubermaticv1.CredentialRotationClusterPhaseRestarting:
+			restarted, err := r.isControlPlaneRestarted(ctx, name)
+			switch {
+			case err != nil:
+				clusterLog.Infow("Control plane failed to restart", zap.Error(err))
+				status = kubermaticv1.CredentialRotationClusterStatus{Phase: kubermaticv1.CredentialRotationClusterPhaseFailed, Message: err.Error()}
+			case restarted:
+				clusterLog.Info("Control plane restarted with new credentials")
+				status = kubermaticv1.CredentialRotationClusterStatus{Phase: kubermaticv1.CredentialRotationClusterPhaseCompleted}
+			}
+		}
+
+		clusterStatuses[name] = status
+	}
+
+	var pending, failed int
+	for _, status := range clusterStatuses {
+		switch status.Phase {
+		case kubermaticv1.CredentialRotationClusterPhaseFailed:
+			failed++
+		case kubermaticv1.CredentialRotationClusterPhasePending, kubermaticv1.CredentialRotationClusterPhaseRestarting:
+			pending++
+		}
+	}
+
+	if err := r.updateStatus(ctx, rotation, func(rot *kubermaticv1.CredentialRotation) {
+		rot.Status.Clusters = clusterStatuses
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	if pending > 0 {
+		return &reconcile.Result{RequeueAfter: rotationProgressInterval}, nil
+	}
+
+	if failed > 0 {
+		return nil, r.finish(ctx, rotation, kubermaticv1.CredentialRotationPhaseFailed, fmt.Sprintf("Credentials could not be rotated for %d of %d cluster(s).", failed, len(clusterStatuses)))
+	}
+
+	return nil, r.finish(ctx, rotation, kubermaticv1.CredentialRotationPhaseCompleted, fmt.Sprintf("Credentials have been rotated for %d cluster(s).", len(clusterStatuses)))
+}
+
+// rotateCluster writes the new credentials into the cluster's credentials Secret and its copy
+// in the cluster namespace, then triggers a restart of all control plane components, as many
+// of them (machine-controller, CCM, CSI drivers, ...) read the credentials only on startup.
+// Problems that cannot be resolved by retrying are returned as a failure message.
+func (r *RotationReconciler) rotateCluster(ctx context.Context, name string, newCredentials *corev1.Secret, restartValue string) (string, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "Cluster does not exist anymore.", nil
+		}
+
+		return "", err
+	}
+
+	if cluster.DeletionTimestamp != nil {
+		return "Cluster is being deleted.", nil
+	}
+
+	ref, err := resources.GetCredentialsReference(cluster)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	if ref == nil {
+		return "Cluster does not use a credentials Secret.", nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		return "", fmt.Errorf("failed to get credentials Secret: %w", err)
+	}
+
+	oldSecret := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	for key, value := range newCredentials.Data {
+		secret.Data[key] = value
+	}
+
+	if err := r.Patch(ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret)); err != nil {
+		return "", fmt.Errorf("failed to update credentials Secret: %w", err)
+	}
+
+	// Do not wait for the cluster reconciler to mirror the Secret, as the restarted components
+	// would otherwise still start with the old credentials.
+	if cluster.Status.NamespaceName != "" {
+		creators := []reconciling.NamedSecretReconcilerFactory{
+			secretReconciler(secret),
+		}
+
+		if err := reconciling.ReconcileSecrets(ctx, creators, cluster.Status.NamespaceName, r); err != nil {
+			return "", fmt.Errorf("failed to ensure credentials secret: %w", err)
+		}
+	}
+
+	oldCluster := cluster.DeepCopy()
+	kuberneteshelper.EnsureAnnotations(cluster, map[string]string{
+		resources.ClusterLastRestartAnnotation: restartValue,
+	})
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return "", fmt.Errorf("failed to trigger control plane restart: %w", err)
+	}
+
+	return "", nil
+}
+
+// isControlPlaneRestarted checks whether all control plane Deployments have been rolled
+// out with the cluster's current last-restart annotation.
+func (r *RotationReconciler) isControlPlaneRestarted(ctx context.Context, name string) (bool, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, errors.New("cluster does not exist anymore")
+		}
+
+		return false, err
+	}
+
+	if cluster.Status.NamespaceName == "" {
+		return true, nil
+	}
+
+	// Compare against the cluster's annotation instead of the rotation's value, so that
+	// the rotation does not get stuck if another restart was triggered in the meantime.
+	restartValue := cluster.Annotations[resources.ClusterLastRestartAnnotation]
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, fmt.Errorf("failed to list Deployments: %w", err)
+	}
+
+	for i, deployment := range deployments.Items {
+		value, ok := deployment.Spec.Template.Annotations[resources.ClusterLastRestartAnnotation]
+		if !ok {
+			continue
+		}
+
+		if value != restartValue {
+			return false, nil
+		}
+
+		complete, err := kuberneteshelper.IsDeploymentRolloutComplete(&deployments.Items[i], 0)
+		if err != nil {
+			return false, err
+		}
+
+		if !complete {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *RotationReconciler) finish(ctx context.Context, rotation *kubermaticv1.CredentialRotation, phase kubermaticv1.CredentialRotationPhase, message string) error {
+	if err := r.updateStatus(ctx, rotation, func(rot *kubermaticv1.CredentialRotation) {
+		rot.Status.Phase = phase
+		rot.Status.Message = message
+		rot.Status.CompletionTime = ptr.To(metav1.Now())
+	}); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	eventType := corev1.EventTypeNormal
+	if phase == kubermaticv1.CredentialRotationPhaseFailed {
+		eventType = corev1.EventTypeWarning
+	}
+
+	r.recorder.Event(rotation, eventType, "CredentialRotation"+string(phase), message)
+
+	return nil
+}
+
+func (r *RotationReconciler) updateStatus(ctx context.Context, rotation *kubermaticv1.CredentialRotation, modify func(*kubermaticv1.CredentialRotation)) error {
+	oldRotation := rotation.DeepCopy()
+	modify(rotation)
+	if reflect.DeepEqual(oldRotation.Status, rotation.Status) {
+		return nil
+	}
+
+	return r.Status().Patch(ctx, rotation, ctrlruntimeclient.MergeFrom(oldRotation))
+}
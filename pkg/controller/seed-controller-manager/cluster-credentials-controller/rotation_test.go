@@ -0,0 +1,321 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercredentialscontroller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testPreset   = "my-preset"
+	oldToken     = "old-token"
+	newToken     = "new-token"
+	rotationName = "rotate-my-preset"
+)
+
+// tokenProvider accepts only the new token, like a real provider would reject revoked credentials.
+type tokenProvider struct {
+	provider.CloudProvider
+
+	secretKeyGetter provider.SecretKeySelectorValueFunc
+}
+
+func (p *tokenProvider) ValidateCloudSpec(_ context.Context, spec kubermaticv1.CloudSpec) error {
+	token, err := p.secretKeyGetter(spec.Hetzner.CredentialsReference, resources.HetznerToken)
+	if err != nil {
+		return err
+	}
+
+	if token != newToken {
+		return errors.New("invalid token")
+	}
+
+	return nil
+}
+
+func genRotationCluster(name, preset, namespace string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				kubermaticv1.PresetNameAnnotation: preset,
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				ProviderName:   string(kubermaticv1.HetznerCloudProvider),
+				DatacenterName: "hetzner-hel1",
+				Hetzner: &kubermaticv1.HetznerCloudSpec{
+					CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+						ObjectReference: corev1.ObjectReference{
+							Name:      "credential-hetzner-" + name,
+							Namespace: resources.KubermaticNamespace,
+						},
+					},
+				},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: namespace,
+		},
+	}
+}
+
+func genCredentialsSecret(name, token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: resources.KubermaticNamespace,
+		},
+		Data: map[string][]byte{
+			resources.HetznerToken: []byte(token),
+		},
+	}
+}
+
+func newRotationReconciler(client ctrlruntimeclient.Client) *RotationReconciler {
+	seed := &kubermaticv1.Seed{
+		Spec: kubermaticv1.SeedSpec{
+			Datacenters: map[string]kubermaticv1.Datacenter{
+				"hetzner-hel1": {
+					Spec: kubermaticv1.DatacenterSpec{
+						Hetzner: &kubermaticv1.DatacenterSpecHetzner{},
+					},
+				},
+			},
+		},
+	}
+
+	return &RotationReconciler{
+		Client:     client,
+		recorder:   record.NewFakeRecorder(10),
+		log:        kubermaticlog.Logger,
+		seedGetter: func() (*kubermaticv1.Seed, error) { return seed, nil },
+		getProvider: func(_ *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+			return &tokenProvider{secretKeyGetter: secretKeyGetter}, nil
+		},
+	}
+}
+
+func reconcileRotation(t *testing.T, r *RotationReconciler) *kubermaticv1.CredentialRotation {
+	t.Helper()
+
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: rotationName}}
+
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	rotation := &kubermaticv1.CredentialRotation{}
+	if err := r.Get(ctx, request.NamespacedName, rotation); err != nil {
+		t.Fatalf("Failed to get rotation: %v", err)
+	}
+
+	return rotation
+}
+
+func getToken(t *testing.T, client ctrlruntimeclient.Client, namespace, name string) string {
+	t.Helper()
+
+	secret := &corev1.Secret{}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		t.Fatalf("Failed to get Secret %s/%s: %v", namespace, name, err)
+	}
+
+	return string(secret.Data[resources.HetznerToken])
+}
+
+func TestRotationWithInvalidCredentials(t *testing.T) {
+	client := fake.NewClientBuilder().
+		WithObjects(
+			&kubermaticv1.CredentialRotation{
+				ObjectMeta: metav1.ObjectMeta{Name: rotationName},
+				Spec: kubermaticv1.CredentialRotationSpec{
+					Preset:         testPreset,
+					NewCredentials: "new-credentials",
+				},
+			},
+			genCredentialsSecret("new-credentials", "revoked-token"),
+			genCredentialsSecret("credential-hetzner-a", oldToken),
+			genRotationCluster("a", testPreset, "cluster-a"),
+		).
+		Build()
+
+	r := newRotationReconciler(client)
+
+	rotation := reconcileRotation(t, r)
+	if rotation.Status.Phase != kubermaticv1.CredentialRotationPhaseFailed {
+		t.Fatalf("Expected rotation to fail, but phase is %q", rotation.Status.Phase)
+	}
+
+	if token := getToken(t, client, resources.KubermaticNamespace, "credential-hetzner-a"); token != oldToken {
+		t.Errorf("Expected cluster credentials to remain unchanged, but token is %q", token)
+	}
+}
+
+func TestRotationWithInvalidSpec(t *testing.T) {
+	client := fake.NewClientBuilder().
+		WithObjects(
+			&kubermaticv1.CredentialRotation{
+				ObjectMeta: metav1.ObjectMeta{Name: rotationName},
+				Spec: kubermaticv1.CredentialRotationSpec{
+					Preset:            testPreset,
+					CredentialsSecret: "credential-hetzner-a",
+					NewCredentials:    "new-credentials",
+				},
+			},
+			genCredentialsSecret("new-credentials", newToken),
+		).
+		Build()
+
+	rotation := reconcileRotation(t, newRotationReconciler(client))
+	if rotation.Status.Phase != kubermaticv1.CredentialRotationPhaseFailed {
+		t.Fatalf("Expected rotation to fail, but phase is %q", rotation.Status.Phase)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	ctx := context.Background()
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.MachineControllerDeploymentName,
+			Namespace: "cluster-a",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						resources.ClusterLastRestartAnnotation: "",
+					},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			AvailableReplicas: 1,
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithObjects(
+			&kubermaticv1.CredentialRotation{
+				ObjectMeta: metav1.ObjectMeta{Name: rotationName},
+				Spec: kubermaticv1.CredentialRotationSpec{
+					Preset:         testPreset,
+					NewCredentials: "new-credentials",
+				},
+			},
+			genCredentialsSecret("new-credentials", newToken),
+			genCredentialsSecret("credential-hetzner-a", oldToken),
+			genCredentialsSecret("credential-hetzner-b", oldToken),
+			genCredentialsSecret("credential-hetzner-c", oldToken),
+			genRotationCluster("a", testPreset, "cluster-a"),
+			genRotationCluster("b", testPreset, ""),
+			genRotationCluster("c", "other-preset", "cluster-c"),
+			deployment,
+		).
+		Build()
+
+	r := newRotationReconciler(client)
+
+	// first the credentials are validated
+	rotation := reconcileRotation(t, r)
+	if rotation.Status.Phase != kubermaticv1.CredentialRotationPhaseInProgress {
+		t.Fatalf("Expected rotation to be in progress, but phase is %q (%s)", rotation.Status.Phase, rotation.Status.Message)
+	}
+
+	if len(rotation.Status.Clusters) != 2 {
+		t.Fatalf("Expected 2 affected clusters, but got %v", rotation.Status.Clusters)
+	}
+
+	// then the credentials are written and the control planes restarted
+	rotation = reconcileRotation(t, r)
+	for _, name := range []string{"a", "b"} {
+		if phase := rotation.Status.Clusters[name].Phase; phase != kubermaticv1.CredentialRotationClusterPhaseRestarting {
+			t.Errorf("Expected cluster %s to be restarting, but phase is %q", name, phase)
+		}
+
+		if token := getToken(t, client, resources.KubermaticNamespace, "credential-hetzner-"+name); token != newToken {
+			t.Errorf("Expected credentials of cluster %s to be rotated, but token is %q", name, token)
+		}
+	}
+
+	if token := getToken(t, client, "cluster-a", resources.ClusterCloudCredentialsSecretName); token != newToken {
+		t.Errorf("Expected credentials in cluster namespace to be rotated, but token is %q", token)
+	}
+
+	if token := getToken(t, client, resources.KubermaticNamespace, "credential-hetzner-c"); token != oldToken {
+		t.Errorf("Expected credentials of unaffected cluster to remain unchanged, but token is %q", token)
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "a"}, cluster); err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	restartValue := cluster.Annotations[resources.ClusterLastRestartAnnotation]
+	if restartValue == "" {
+		t.Fatal("Expected control plane restart to be triggered, but cluster has no restart annotation")
+	}
+
+	// cluster b has no control plane yet, cluster a still waits for the machine-controller
+	rotation = reconcileRotation(t, r)
+	if phase := rotation.Status.Clusters["a"].Phase; phase != kubermaticv1.CredentialRotationClusterPhaseRestarting {
+		t.Errorf("Expected cluster a to be restarting, but phase is %q", phase)
+	}
+
+	if phase := rotation.Status.Clusters["b"].Phase; phase != kubermaticv1.CredentialRotationClusterPhaseCompleted {
+		t.Errorf("Expected cluster b to be completed, but phase is %q", phase)
+	}
+
+	// simulate the control plane rollout
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(deployment), deployment); err != nil {
+		t.Fatalf("Failed to get Deployment: %v", err)
+	}
+
+	deployment.Spec.Template.Annotations[resources.ClusterLastRestartAnnotation] = restartValue
+	if err := client.Update(ctx, deployment); err != nil {
+		t.Fatalf("Failed to update Deployment: %v", err)
+	}
+
+	rotation = reconcileRotation(t, r)
+	if rotation.Status.Phase != kubermaticv1.CredentialRotationPhaseCompleted {
+		t.Fatalf("Expected rotation to be completed, but phase is %q (%s)", rotation.Status.Phase, rotation.Status.Message)
+	}
+}
@@ -84,8 +84,11 @@ func Add(
 		}).
 		For(&kubermaticv1.ClusterTemplateInstance{}).
 		Build(reconciler)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return addDriftController(mgr, log, workerName, workerSelector, numWorkers)
 }
 
 // Reconcile reconciles the kubermatic cluster template instance in the seed cluster.
@@ -259,10 +262,16 @@ func genNewCluster(template *kubermaticv1.ClusterTemplate, instance *kubermaticv
 	}
 	newCluster.Labels[kubermaticv1.ProjectIDLabelKey] = instance.Spec.ProjectID
 	newCluster.Labels[kubermaticv1.ClusterTemplateInstanceLabelKey] = instance.Name
+	newCluster.Labels[kubermaticv1.ClusterTemplateLabelKey] = template.Name
 	newCluster.Spec = template.Spec
 
 	newCluster.Spec.HumanReadableName = fmt.Sprintf("%s-%s", newCluster.Spec.HumanReadableName, name)
 	newCluster.Status.UserEmail = template.Annotations[kubermaticv1.ClusterTemplateUserAnnotationKey]
+	newCluster.Status.Template = &kubermaticv1.ClusterTemplateStatus{
+		Name:           template.Name,
+		Revision:       template.Revision(),
+		LatestRevision: template.Revision(),
+	}
 
 	return newCluster
 }
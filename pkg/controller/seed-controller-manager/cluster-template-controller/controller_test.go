@@ -155,7 +155,7 @@ func genCluster(name, userEmail string, instance kubermaticv1.ClusterTemplateIns
 	return &kubermaticv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            name,
-			Labels:          map[string]string{kubermaticv1.ProjectIDLabelKey: instance.Spec.ProjectID, kubermaticv1.ClusterTemplateInstanceLabelKey: instance.Name},
+			Labels:          map[string]string{kubermaticv1.ProjectIDLabelKey: instance.Spec.ProjectID, kubermaticv1.ClusterTemplateInstanceLabelKey: instance.Name, kubermaticv1.ClusterTemplateLabelKey: instance.Spec.ClusterTemplateID},
 			ResourceVersion: "1",
 			Annotations:     map[string]string{kubermaticv1.ClusterTemplateUserAnnotationKey: userEmail},
 		},
@@ -168,6 +168,9 @@ func genCluster(name, userEmail string, instance kubermaticv1.ClusterTemplateIns
 		},
 		Status: kubermaticv1.ClusterStatus{
 			UserEmail: userEmail,
+			Template: &kubermaticv1.ClusterTemplateStatus{
+				Name: instance.Spec.ClusterTemplateID,
+			},
 		},
 	}
 }
@@ -0,0 +1,474 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplatecontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	DriftControllerName = "kkp-cluster-template-drift-controller"
+)
+
+// ignoredSpecFields are not compared between template and cluster, because
+// they are always modified when a cluster is created from a template (the
+// name gets a suffix and the cloud spec receives the cluster's credentials).
+var ignoredSpecFields = []string{"humanReadableName", "cloud"}
+
+// driftReconciler compares the clusters created from a ClusterTemplate with
+// the current revision of that template and optionally applies safe updates.
+type driftReconciler struct {
+	log                     *zap.SugaredLogger
+	workerNameLabelSelector labels.Selector
+	recorder                record.EventRecorder
+	seedClient              ctrlruntimeclient.Client
+}
+
+func addDriftController(mgr manager.Manager, log *zap.SugaredLogger, workerName string, workerSelector labels.Selector, numWorkers int) error {
+	reconciler := &driftReconciler{
+		log:                     log.Named(DriftControllerName),
+		workerNameLabelSelector: workerSelector,
+		recorder:                mgr.GetEventRecorderFor(DriftControllerName),
+		seedClient:              mgr.GetClient(),
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(DriftControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.ClusterTemplate{}).
+		Watches(&kubermaticv1.Cluster{}, enqueueClusterTemplate(), builder.WithPredicates(workerlabel.Predicate(workerName))).
+		Watches(&kubermaticv1.Addon{}, enqueueClusterTemplateForAddon(mgr.GetClient())).
+		Build(reconciler)
+
+	return err
+}
+
+func enqueueClusterTemplate() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj ctrlruntimeclient.Object) []reconcile.Request {
+		template := obj.GetLabels()[kubermaticv1.ClusterTemplateLabelKey]
+		if template == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: template}}}
+	})
+}
+
+func enqueueClusterTemplateForAddon(client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj ctrlruntimeclient.Object) []reconcile.Request {
+		addon, ok := obj.(*kubermaticv1.Addon)
+		if !ok {
+			return nil
+		}
+
+		cluster := &kubermaticv1.Cluster{}
+		if err := client.Get(ctx, types.NamespacedName{Name: addon.Spec.Cluster.Name}, cluster); err != nil {
+			return nil
+		}
+
+		template := cluster.Labels[kubermaticv1.ClusterTemplateLabelKey]
+		if template == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: template}}}
+	})
+}
+
+// Reconcile compares all clusters created from the template with its current revision.
+func (r *driftReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("template", request.Name)
+	log.Debug("Reconciling")
+
+	template := &kubermaticv1.ClusterTemplate{}
+	if err := r.seedClient.Get(ctx, request.NamespacedName, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get cluster template %s: %w", request.Name, err)
+	}
+
+	if !template.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.seedClient.List(ctx, clusters, ctrlruntimeclient.MatchingLabels{kubermaticv1.ClusterTemplateLabelKey: template.Name}); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var errs []error
+	for _, cluster := range clusters.Items {
+		if !r.workerNameLabelSelector.Matches(labels.Set(cluster.Labels)) || !cluster.DeletionTimestamp.IsZero() || cluster.Spec.Pause {
+			continue
+		}
+
+		if err := r.reconcileCluster(ctx, log.With("cluster", cluster.Name), template, &cluster); err != nil {
+			r.recorder.Event(&cluster, corev1.EventTypeWarning, "ClusterTemplateDriftFailed", err.Error())
+			errs = append(errs, fmt.Errorf("cluster %s: %w", cluster.Name, err))
+		}
+	}
+
+	return reconcile.Result{}, kerrors.NewAggregate(errs)
+}
+
+func (r *driftReconciler) reconcileCluster(ctx context.Context, log *zap.SugaredLogger, template *kubermaticv1.ClusterTemplate, cluster *kubermaticv1.Cluster) error {
+	if template.PropagateUpdates {
+		oldCluster := cluster.DeepCopy()
+		if err := applySafeUpdates(template, cluster); err != nil {
+			return fmt.Errorf("failed to apply template changes: %w", err)
+		}
+
+		if !reflect.DeepEqual(oldCluster, cluster) {
+			log.Infow("Applying cluster template changes", "revision", template.Revision())
+
+			if err := r.seedClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+				return fmt.Errorf("failed to apply template changes: %w", err)
+			}
+		}
+
+		// the version difference remains reported as drift
+		if template.Spec.Version.GreaterThan(&cluster.Spec.Version) {
+			log.Infow("Not updating to the template's Kubernetes version, as clusters can only be updated by one minor version at a time", "version", template.Spec.Version.String())
+		}
+
+		if err := r.reconcileAddons(ctx, template, cluster); err != nil {
+			return fmt.Errorf("failed to install template addons: %w", err)
+		}
+	}
+
+	drift, err := driftedFields(template, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to compare cluster with template: %w", err)
+	}
+
+	addonsDrifted, err := r.addonsDrifted(ctx, template, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to compare cluster addons with template: %w", err)
+	}
+
+	if addonsDrifted {
+		drift = append(drift, "addons")
+		sort.Strings(drift)
+	}
+
+	status := &kubermaticv1.ClusterTemplateStatus{
+		Name:           template.Name,
+		LatestRevision: template.Revision(),
+		DriftedFields:  drift,
+	}
+
+	if cluster.Status.Template != nil {
+		status.Revision = cluster.Status.Template.Revision
+	}
+
+	// a cluster without any differences is up-to-date with the latest revision
+	if len(drift) == 0 {
+		status.Revision = template.Revision()
+	}
+
+	if reflect.DeepEqual(cluster.Status.Template, status) {
+		return nil
+	}
+
+	return helper.UpdateClusterStatus(ctx, r.seedClient, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Template = status
+	})
+}
+
+// applySafeUpdates applies those settings of the template to the cluster that
+// can be changed on existing clusters without disruption. Kubernetes versions
+// are only ever upgraded, and only by a single minor version at a time. The
+// OPA and MLA settings of the template are merged into the cluster's settings.
+func applySafeUpdates(template *kubermaticv1.ClusterTemplate, cluster *kubermaticv1.Cluster) error {
+	if template.Spec.Version.Semver() != nil && template.Spec.Version.GreaterThan(&cluster.Spec.Version) && isSupportedUpgrade(&cluster.Spec.Version, &template.Spec.Version) {
+		cluster.Spec.Version = template.Spec.Version.DeepCopy()
+	}
+
+	if len(template.ClusterLabels) > 0 && cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+
+	for key, value := range template.ClusterLabels {
+		cluster.Labels[key] = value
+	}
+
+	if template.Spec.OPAIntegration != nil {
+		if cluster.Spec.OPAIntegration == nil {
+			cluster.Spec.OPAIntegration = &kubermaticv1.OPAIntegrationSettings{}
+		}
+
+		if err := mergeSettings(template.Spec.OPAIntegration, cluster.Spec.OPAIntegration); err != nil {
+			return fmt.Errorf("failed to merge OPA integration settings: %w", err)
+		}
+	}
+
+	if template.Spec.MLA != nil {
+		if cluster.Spec.MLA == nil {
+			cluster.Spec.MLA = &kubermaticv1.MLASettings{}
+		}
+
+		if err := mergeSettings(template.Spec.MLA, cluster.Spec.MLA); err != nil {
+			return fmt.Errorf("failed to merge MLA settings: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// isSupportedUpgrade returns true if a cluster can be updated from one version
+// to the other, which Kubernetes only supports for a single minor version at a time.
+func isSupportedUpgrade(from, to *semver.Semver) bool {
+	fromVersion, toVersion := from.Semver(), to.Semver()
+	if fromVersion == nil || toVersion == nil {
+		return false
+	}
+
+	return fromVersion.Major() == toVersion.Major() && toVersion.Minor() <= fromVersion.Minor()+1
+}
+
+// mergeSettings overwrites all fields in target with the non-empty fields of
+// source, leaving all other fields of target untouched.
+func mergeSettings(source, target interface{}) error {
+	encodedSource, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+
+	sourceFields := map[string]interface{}{}
+	if err := json.Unmarshal(encodedSource, &sourceFields); err != nil {
+		return err
+	}
+
+	encodedTarget, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	targetFields := map[string]interface{}{}
+	if err := json.Unmarshal(encodedTarget, &targetFields); err != nil {
+		return err
+	}
+
+	mergeFields(sourceFields, targetFields)
+
+	merged, err := json.Marshal(targetFields)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(merged, target)
+}
+
+func mergeFields(source, target map[string]interface{}) {
+	for key, value := range source {
+		sourceMap, isMap := value.(map[string]interface{})
+		targetMap, targetIsMap := target[key].(map[string]interface{})
+
+		if isMap && targetIsMap {
+			mergeFields(sourceMap, targetMap)
+			continue
+		}
+
+		target[key] = value
+	}
+}
+
+// reconcileAddons installs the template's addons into the cluster and keeps
+// their variables in sync with the template. Addons that are not part of the
+// template are left alone.
+func (r *driftReconciler) reconcileAddons(ctx context.Context, template *kubermaticv1.ClusterTemplate, cluster *kubermaticv1.Cluster) error {
+	if len(template.Addons) == 0 || cluster.Status.NamespaceName == "" {
+		return nil
+	}
+
+	var factories []reconciling.NamedAddonReconcilerFactory
+	for _, addon := range template.Addons {
+		factories = append(factories, templateAddonReconciler(cluster, addon))
+	}
+
+	return reconciling.ReconcileAddons(ctx, factories, cluster.Status.NamespaceName, r.seedClient)
+}
+
+func templateAddonReconciler(cluster *kubermaticv1.Cluster, addon kubermaticv1.ClusterTemplateAddon) reconciling.NamedAddonReconcilerFactory {
+	return func() (string, reconciling.AddonReconciler) {
+		return addon.Name, func(existing *kubermaticv1.Addon) (*kubermaticv1.Addon, error) {
+			existing.Spec.Name = addon.Name
+			existing.Spec.Variables = addon.Variables.DeepCopy()
+			existing.Spec.Cluster = corev1.ObjectReference{
+				APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+				Kind:       kubermaticv1.ClusterKindName,
+				Name:       cluster.Name,
+			}
+
+			return existing, nil
+		}
+	}
+}
+
+// addonsDrifted returns true if any of the template's addons is missing in the
+// cluster or uses different variables.
+func (r *driftReconciler) addonsDrifted(ctx context.Context, template *kubermaticv1.ClusterTemplate, cluster *kubermaticv1.Cluster) (bool, error) {
+	if len(template.Addons) == 0 {
+		return false, nil
+	}
+
+	// without a namespace, the cluster cannot have any addons yet
+	if cluster.Status.NamespaceName == "" {
+		return true, nil
+	}
+
+	for _, addon := range template.Addons {
+		existing := &kubermaticv1.Addon{}
+		if err := r.seedClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: addon.Name}, existing); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+
+			return false, err
+		}
+
+		if !variablesEqual(addon.Variables, existing.Spec.Variables) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func variablesEqual(a, b *runtime.RawExtension) bool {
+	decode := func(ext *runtime.RawExtension) interface{} {
+		if ext == nil || len(ext.Raw) == 0 {
+			return nil
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(ext.Raw, &value); err != nil {
+			return string(ext.Raw)
+		}
+
+		return value
+	}
+
+	return reflect.DeepEqual(decode(a), decode(b))
+}
+
+// driftedFields returns the fields in which the cluster differs from the
+// template. Fields that the template leaves empty are not compared, as they
+// are usually filled in by defaulting.
+func driftedFields(template *kubermaticv1.ClusterTemplate, cluster *kubermaticv1.Cluster) ([]string, error) {
+	templateSpec, err := toUnstructured(template.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterSpec, err := toUnstructured(cluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range ignoredSpecFields {
+		delete(templateSpec, field)
+	}
+
+	var drift []string
+	for field, value := range templateSpec {
+		if !isSubset(value, clusterSpec[field]) {
+			drift = append(drift, "spec."+field)
+		}
+	}
+
+	for key, value := range template.ClusterLabels {
+		if cluster.Labels[key] != value {
+			drift = append(drift, "metadata.labels")
+			break
+		}
+	}
+
+	sort.Strings(drift)
+
+	return drift, nil
+}
+
+func toUnstructured(spec kubermaticv1.ClusterSpec) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// isSubset returns true if all non-empty values in expected are also present
+// in actual.
+func isSubset(expected, actual interface{}) bool {
+	switch value := expected.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return len(value) == 0
+		}
+
+		for key, v := range value {
+			if !isSubset(v, actualMap[key]) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		return len(value) == 0 || reflect.DeepEqual(value, actual)
+	default:
+		return reflect.ValueOf(expected).IsZero() || reflect.DeepEqual(expected, actual)
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplatecontroller
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func genDriftTemplate(propagate bool) *kubermaticv1.ClusterTemplate {
+	return &kubermaticv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "template",
+			Generation:  2,
+			Annotations: map[string]string{kubermaticv1.ClusterTemplateRevisionAnnotationKey: "5"},
+		},
+		ClusterLabels:    map[string]string{"team": "platform"},
+		PropagateUpdates: propagate,
+		Spec: kubermaticv1.ClusterSpec{
+			HumanReadableName: "template",
+			Version:           *semver.NewSemverOrDie("1.31.1"),
+			ExposeStrategy:    kubermaticv1.ExposeStrategyTunneling,
+			OPAIntegration: &kubermaticv1.OPAIntegrationSettings{
+				Enabled: true,
+			},
+		},
+	}
+}
+
+func genDriftCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			Labels: map[string]string{
+				kubermaticv1.ClusterTemplateLabelKey: "template",
+			},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			HumanReadableName: "template-cluster",
+			Version:           *semver.NewSemverOrDie("1.30.5"),
+			ExposeStrategy:    kubermaticv1.ExposeStrategyNodePort,
+			// defaulted fields must not be reported as drift
+			ContainerRuntime: "containerd",
+		},
+		Status: kubermaticv1.ClusterStatus{
+			Template: &kubermaticv1.ClusterTemplateStatus{
+				Name:     "template",
+				Revision: 4,
+			},
+		},
+	}
+}
+
+func TestDriftReconciliation(t *testing.T) {
+	workerSelector, err := workerlabel.LabelSelector("")
+	if err != nil {
+		t.Fatalf("failed to build worker-name selector: %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		template        *kubermaticv1.ClusterTemplate
+		expectedVersion string
+		expectedStatus  *kubermaticv1.ClusterTemplateStatus
+	}{
+		{
+			name:            "drift is only reported",
+			template:        genDriftTemplate(false),
+			expectedVersion: "1.30.5",
+			expectedStatus: &kubermaticv1.ClusterTemplateStatus{
+				Name:           "template",
+				Revision:       4,
+				LatestRevision: 5,
+				DriftedFields:  []string{"metadata.labels", "spec.exposeStrategy", "spec.opaIntegration", "spec.version"},
+			},
+		},
+		{
+			name:            "safe updates are propagated",
+			template:        genDriftTemplate(true),
+			expectedVersion: "1.31.1",
+			expectedStatus: &kubermaticv1.ClusterTemplateStatus{
+				Name:           "template",
+				Revision:       4,
+				LatestRevision: 5,
+				DriftedFields:  []string{"spec.exposeStrategy"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			seedClient := fake.NewClientBuilder().WithObjects(tc.template, genDriftCluster()).Build()
+
+			r := &driftReconciler{
+				log:                     kubermaticlog.Logger,
+				workerNameLabelSelector: workerSelector,
+				recorder:                record.NewFakeRecorder(10),
+				seedClient:              seedClient,
+			}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "template"}}); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "cluster"}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			if v := cluster.Spec.Version.String(); v != tc.expectedVersion {
+				t.Errorf("Expected cluster version %s, but got %s.", tc.expectedVersion, v)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedStatus, cluster.Status.Template) {
+				t.Fatalf("Status differs:\n%v", diff.ObjectDiff(tc.expectedStatus, cluster.Status.Template))
+			}
+		})
+	}
+}
+
+func TestApplySafeUpdatesResolvesDrift(t *testing.T) {
+	template := genDriftTemplate(true)
+	template.Spec.ExposeStrategy = kubermaticv1.ExposeStrategyNodePort
+
+	cluster := genDriftCluster()
+	if err := applySafeUpdates(template, cluster); err != nil {
+		t.Fatalf("failed to apply safe updates: %v", err)
+	}
+
+	drift, err := driftedFields(template, cluster)
+	if err != nil {
+		t.Fatalf("failed to compute drift: %v", err)
+	}
+
+	if len(drift) > 0 {
+		t.Fatalf("Expected no drift after applying safe updates, but got %v.", drift)
+	}
+}
+
+func TestApplySafeUpdatesStepsMinorVersions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		clusterVersion  string
+		templateVersion string
+		expectedVersion string
+	}{
+		{
+			name:            "patch release",
+			clusterVersion:  "1.31.0",
+			templateVersion: "1.31.1",
+			expectedVersion: "1.31.1",
+		},
+		{
+			name:            "next minor release",
+			clusterVersion:  "1.30.5",
+			templateVersion: "1.31.1",
+			expectedVersion: "1.31.1",
+		},
+		{
+			name:            "skipping a minor release",
+			clusterVersion:  "1.29.9",
+			templateVersion: "1.31.1",
+			expectedVersion: "1.29.9",
+		},
+		{
+			name:            "downgrade",
+			clusterVersion:  "1.31.2",
+			templateVersion: "1.31.1",
+			expectedVersion: "1.31.2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := genDriftTemplate(true)
+			template.Spec.Version = *semver.NewSemverOrDie(tc.templateVersion)
+
+			cluster := genDriftCluster()
+			cluster.Spec.Version = *semver.NewSemverOrDie(tc.clusterVersion)
+
+			if err := applySafeUpdates(template, cluster); err != nil {
+				t.Fatalf("failed to apply safe updates: %v", err)
+			}
+
+			if v := cluster.Spec.Version.String(); v != tc.expectedVersion {
+				t.Errorf("Expected cluster version %s, but got %s.", tc.expectedVersion, v)
+			}
+		})
+	}
+}
+
+func TestApplySafeUpdatesMergesMLASettings(t *testing.T) {
+	replicas := int32(3)
+
+	template := genDriftTemplate(true)
+	template.Spec.MLA = &kubermaticv1.MLASettings{
+		LoggingEnabled: true,
+	}
+
+	cluster := genDriftCluster()
+	cluster.Spec.MLA = &kubermaticv1.MLASettings{
+		MonitoringEnabled:  true,
+		MonitoringReplicas: &replicas,
+	}
+
+	if err := applySafeUpdates(template, cluster); err != nil {
+		t.Fatalf("failed to apply safe updates: %v", err)
+	}
+
+	expected := &kubermaticv1.MLASettings{
+		MonitoringEnabled:  true,
+		LoggingEnabled:     true,
+		MonitoringReplicas: &replicas,
+	}
+
+	if !diff.SemanticallyEqual(expected, cluster.Spec.MLA) {
+		t.Fatalf("MLA settings differ:\n%v", diff.ObjectDiff(expected, cluster.Spec.MLA))
+	}
+}
+
+func TestTemplateAddons(t *testing.T) {
+	workerSelector, err := workerlabel.LabelSelector("")
+	if err != nil {
+		t.Fatalf("failed to build worker-name selector: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		propagate     bool
+		expectedDrift []string
+	}{
+		{
+			name:          "missing addons are reported",
+			propagate:     false,
+			expectedDrift: []string{"addons", "metadata.labels", "spec.opaIntegration", "spec.version"},
+		},
+		{
+			name:          "missing addons are installed",
+			propagate:     true,
+			expectedDrift: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			template := genDriftTemplate(tc.propagate)
+			template.Spec.ExposeStrategy = kubermaticv1.ExposeStrategyNodePort
+			template.Addons = []kubermaticv1.ClusterTemplateAddon{{
+				Name:      "my-addon",
+				Variables: &runtime.RawExtension{Raw: []byte(`{"replicas":2}`)},
+			}}
+
+			cluster := genDriftCluster()
+			cluster.Status.NamespaceName = "cluster-cluster"
+
+			seedClient := fake.NewClientBuilder().WithObjects(template, cluster).Build()
+
+			r := &driftReconciler{
+				log:                     kubermaticlog.Logger,
+				workerNameLabelSelector: workerSelector,
+				recorder:                record.NewFakeRecorder(10),
+				seedClient:              seedClient,
+			}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "template"}}); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "cluster"}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedDrift, cluster.Status.Template.DriftedFields) {
+				t.Fatalf("Drifted fields differ:\n%v", diff.ObjectDiff(tc.expectedDrift, cluster.Status.Template.DriftedFields))
+			}
+
+			addon := &kubermaticv1.Addon{}
+			err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: "cluster-cluster", Name: "my-addon"}, addon)
+			if tc.propagate && err != nil {
+				t.Fatalf("Expected addon to be installed: %v", err)
+			}
+
+			if !tc.propagate && err == nil {
+				t.Fatal("Expected addon to not be installed.")
+			}
+		})
+	}
+}
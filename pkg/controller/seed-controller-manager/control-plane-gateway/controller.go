@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanegateway
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources/controlplanegateway"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-control-plane-gateway-controller"
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log        *zap.SugaredLogger
+	recorder   record.EventRecorder
+	seedGetter provider.SeedGetter
+	workerName string
+}
+
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	namespace string,
+	seedName string,
+	workerName string,
+	seedGetter provider.SeedGetter,
+) error {
+	reconciler := &Reconciler{
+		Client:     mgr.GetClient(),
+		log:        log.Named(ControllerName),
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		seedGetter: seedGetter,
+		workerName: workerName,
+	}
+
+	// All clusters share a single Gateway, so every change is reconciled at once.
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&kubermaticv1.Seed{}, builder.WithPredicates(
+			predicateutil.ByNamespace(namespace),
+			predicateutil.ByName(seedName),
+		)).
+		Watches(&kubermaticv1.Cluster{}, controllerutil.EnqueueConst(seedName)).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// The Gateway is shared by all clusters of the seed and must not be
+	// modified by a seed-ctrl-mgr that only handles a subset of them.
+	if r.workerName != "" {
+		return reconcile.Result{}, nil
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	if seed.Spec.ControlPlaneGateway == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.reconcile(ctx, seed); err != nil {
+		r.recorder.Event(seed, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, seed *kubermaticv1.Seed) error {
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	exposed, skipped := controlplanegateway.ExposedClusters(clusters.Items)
+	for i := range skipped {
+		r.recorder.Eventf(&skipped[i], corev1.EventTypeWarning, "GatewayListenerLimitReached",
+			"The control plane Gateway already exposes %d clusters, the API server of this cluster cannot be exposed.", controlplanegateway.MaxClusters)
+	}
+
+	reconcilers := []reconciling.NamedGatewayReconcilerFactory{
+		controlplanegateway.GatewayReconciler(seed, exposed),
+	}
+
+	if err := reconciling.ReconcileGateways(ctx, reconcilers, seed.Namespace, r); err != nil {
+		return fmt.Errorf("failed to reconcile Gateway: %w", err)
+	}
+
+	return nil
+}
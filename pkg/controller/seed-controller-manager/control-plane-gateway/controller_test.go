@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanegateway
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func genCluster(name string, exposeStrategy kubermaticv1.ExposeStrategy, port int32) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ExposeStrategy: exposeStrategy,
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + name,
+			Address: kubermaticv1.ClusterAddress{
+				Port: port,
+			},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name              string
+		gatewayConfig     *kubermaticv1.ControlPlaneGatewayConfig
+		workerName        string
+		clusters          []ctrlruntimeclient.Object
+		expectedListeners []gatewayapiv1.SectionName
+	}{
+		{
+			name:          "listeners for all clusters using the Gateway expose strategy",
+			gatewayConfig: &kubermaticv1.ControlPlaneGatewayConfig{GatewayClassName: "envoy"},
+			clusters: []ctrlruntimeclient.Object{
+				genCluster("b", kubermaticv1.ExposeStrategyGateway, 30001),
+				genCluster("a", kubermaticv1.ExposeStrategyGateway, 30002),
+				genCluster("c", kubermaticv1.ExposeStrategyNodePort, 30003),
+				// no port allocated yet
+				genCluster("d", kubermaticv1.ExposeStrategyGateway, 0),
+			},
+			expectedListeners: []gatewayapiv1.SectionName{"tls", "apiserver-a", "apiserver-b"},
+		},
+		{
+			name: "no Gateway without configuration",
+			clusters: []ctrlruntimeclient.Object{
+				genCluster("a", kubermaticv1.ExposeStrategyGateway, 30001),
+			},
+		},
+		{
+			name:          "no Gateway when running with a worker name",
+			gatewayConfig: &kubermaticv1.ControlPlaneGatewayConfig{GatewayClassName: "envoy"},
+			workerName:    "worker",
+			clusters: []ctrlruntimeclient.Object{
+				genCluster("a", kubermaticv1.ExposeStrategyGateway, 30001),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			seed := &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "seed",
+					Namespace: "kubermatic",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ControlPlaneGateway: tc.gatewayConfig,
+				},
+			}
+
+			scheme := fake.NewScheme()
+			utilruntime.Must(gatewayapiv1.AddToScheme(scheme))
+
+			client := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tc.clusters, seed)...).
+				Build()
+
+			r := &Reconciler{
+				Client:     client,
+				log:        kubermaticlog.Logger,
+				recorder:   record.NewFakeRecorder(10),
+				seedGetter: test.NewSeedGetter(seed),
+				workerName: tc.workerName,
+			}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: seed.Name}}); err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			gw := &gatewayapiv1.Gateway{}
+			err := client.Get(ctx, types.NamespacedName{Namespace: seed.Namespace, Name: resources.ControlPlaneGatewayName}, gw)
+
+			if tc.expectedListeners == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("Expected no Gateway, but got: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to get Gateway: %v", err)
+			}
+
+			if gw.Spec.GatewayClassName != gatewayapiv1.ObjectName(tc.gatewayConfig.GatewayClassName) {
+				t.Errorf("Expected GatewayClass %q, got %q", tc.gatewayConfig.GatewayClassName, gw.Spec.GatewayClassName)
+			}
+
+			var listeners []gatewayapiv1.SectionName
+			for _, l := range gw.Spec.Listeners {
+				listeners = append(listeners, l.Name)
+			}
+
+			if len(listeners) != len(tc.expectedListeners) {
+				t.Fatalf("Expected listeners %v, got %v", tc.expectedListeners, listeners)
+			}
+			for i := range listeners {
+				if listeners[i] != tc.expectedListeners[i] {
+					t.Fatalf("Expected listeners %v, got %v", tc.expectedListeners, listeners)
+				}
+			}
+		})
+	}
+}
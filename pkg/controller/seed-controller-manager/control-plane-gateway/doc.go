@@ -0,0 +1,24 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package controlplanegateway contains a controller that reconciles the Gateway used by the
+Gateway expose strategy. The Gateway is shared by all control planes of the seed and
+has one TLS passthrough listener for SNI-based routing and one TCP listener per user
+cluster API server. The routes attaching the control plane components to the listeners
+are reconciled by the cluster controllers in the cluster namespaces.
+*/
+package controlplanegateway
@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanesizingcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-control-plane-sizing-controller"
+
+	// DefaultPrometheusURL is the address of the Prometheus installed by the seed monitoring stack.
+	DefaultPrometheusURL = "http://prometheus.monitoring.svc.cluster.local:9090"
+
+	// DefaultInterval is used if the KubermaticConfiguration does not configure an interval.
+	DefaultInterval = 10 * time.Minute
+
+	// DefaultDownscaleStabilizationIntervals is used if the KubermaticConfiguration does not
+	// configure the number of intervals before a cluster is moved to a smaller profile.
+	DefaultDownscaleStabilizationIntervals = 3
+)
+
+// querier is the part of the Prometheus API that is required to observe the size of clusters.
+type querier interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...promv1.Option) (model.Value, promv1.Warnings, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log          *zap.SugaredLogger
+	recorder     record.EventRecorder
+	workerName   string
+	versions     kubermatic.Versions
+	configGetter provider.KubermaticConfigurationGetter
+	newQuerier   func(address string) (querier, error)
+	now          func() time.Time
+}
+
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	configGetter provider.KubermaticConfigurationGetter,
+	versions kubermatic.Versions,
+) error {
+	reconciler := &Reconciler{
+		Client:       mgr.GetClient(),
+		log:          log.Named(ControllerName),
+		recorder:     mgr.GetEventRecorderFor(ControllerName),
+		workerName:   workerName,
+		versions:     versions,
+		configGetter: configGetter,
+		newQuerier:   newPrometheusQuerier,
+		now:          time.Now,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.Cluster{}).
+		Build(reconciler)
+
+	return err
+}
+
+func newPrometheusQuerier(address string) (querier, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: address})
+	if err != nil {
+		return nil, err
+	}
+
+	return promv1.NewAPI(client), nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	// The controller requeues every cluster periodically, so it does not maintain a
+	// ReconciledSuccessfully condition, which would never become true.
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionNone,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, log, cluster)
+		},
+	)
+
+	if result == nil || err != nil {
+		result = &reconcile.Result{}
+	}
+
+	if err != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		return nil, nil
+	}
+
+	config, err := r.configGetter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KubermaticConfiguration: %w", err)
+	}
+
+	sizing := config.Spec.UserCluster.ControlPlaneSizing
+	if sizing == nil || len(sizing.Profiles) == 0 {
+		if cluster.Status.ControlPlaneSizing == nil {
+			return nil, nil
+		}
+
+		return nil, kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.ControlPlaneSizing = nil
+		})
+	}
+
+	interval := DefaultInterval
+	if sizing.Interval != nil && sizing.Interval.Duration > 0 {
+		interval = sizing.Interval.Duration
+	}
+
+	// Every change to the cluster triggers a reconciliation, but the cluster
+	// is only observed once per interval.
+	now := r.now()
+	if status := cluster.Status.ControlPlaneSizing; status != nil && hasProfile(sizing.Profiles, status.Profile) {
+		if next := status.LastObservedTime.Add(interval); now.Before(next) {
+			return &reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	address := sizing.PrometheusURL
+	if address == "" {
+		address = DefaultPrometheusURL
+	}
+
+	q, err := r.newQuerier(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Prometheus client: %w", err)
+	}
+
+	size, err := observeClusterSize(ctx, q, cluster.Status.NamespaceName, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to observe cluster size: %w", err)
+	}
+
+	stabilizationIntervals := int32(DefaultDownscaleStabilizationIntervals)
+	if sizing.DownscaleStabilizationIntervals != nil && *sizing.DownscaleStabilizationIntervals > 0 {
+		stabilizationIntervals = *sizing.DownscaleStabilizationIntervals
+	}
+
+	selected := selectProfile(sizing.Profiles, size)
+
+	var (
+		oldProfile string
+		profile    *kubermaticv1.ControlPlaneSizingProfile
+	)
+	err = kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		var observations int32
+
+		profile = selected
+
+		transitionTime := metav1.NewTime(now)
+		if status := c.Status.ControlPlaneSizing; status != nil {
+			oldProfile = status.Profile
+			profile, observations = stabilizeProfile(sizing.Profiles, oldProfile, status.DownscaleObservations, selected, stabilizationIntervals)
+			if oldProfile == profile.Name {
+				transitionTime = status.LastTransitionTime
+			}
+		}
+
+		c.Status.ControlPlaneSizing = &kubermaticv1.ClusterControlPlaneSizingStatus{
+			Profile:               profile.Name,
+			Nodes:                 size.nodes,
+			Objects:               size.objects,
+			RequestsPerSecond:     size.requestsPerSecond,
+			LastObservedTime:      metav1.NewTime(now),
+			LastTransitionTime:    transitionTime,
+			DownscaleObservations: observations,
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cluster status: %w", err)
+	}
+
+	if oldProfile != profile.Name {
+		log.Infow("Selected control plane sizing profile", "profile", profile.Name, "previous", oldProfile)
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "ControlPlaneSizingProfileChanged",
+			"Control plane sizing profile %q selected for %d nodes, %d objects and %d requests per second.", profile.Name, size.nodes, size.objects, size.requestsPerSecond)
+	}
+
+	return &reconcile.Result{RequeueAfter: interval}, nil
+}
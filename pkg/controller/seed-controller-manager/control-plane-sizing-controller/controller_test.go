@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanesizingcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeQuerier returns the value of the first metric name contained in the query.
+type fakeQuerier struct {
+	values  map[string]float64
+	queries int
+}
+
+func (q *fakeQuerier) Query(_ context.Context, query string, _ time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	q.queries++
+
+	for metric, value := range q.values {
+		if strings.Contains(query, metric) {
+			return model.Vector{{Value: model.SampleValue(value)}}, nil, nil
+		}
+	}
+
+	return model.Vector{}, nil, nil
+}
+
+var testProfiles = []kubermaticv1.ControlPlaneSizingProfile{
+	{Name: "small"},
+	{Name: "medium", MinNodes: 10, MinObjects: 10000},
+	{Name: "large", MinNodes: 50, MinRequestsPerSecond: 500},
+}
+
+func TestSelectProfile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		size     clusterSize
+		expected string
+	}{
+		{
+			name:     "empty cluster",
+			expected: "small",
+		},
+		{
+			name:     "node threshold reached",
+			size:     clusterSize{nodes: 10},
+			expected: "medium",
+		},
+		{
+			name:     "object threshold reached",
+			size:     clusterSize{nodes: 2, objects: 20000},
+			expected: "medium",
+		},
+		{
+			name:     "largest profile wins",
+			size:     clusterSize{nodes: 12, requestsPerSecond: 800},
+			expected: "large",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if profile := selectProfile(testProfiles, tc.size); profile.Name != tc.expected {
+				t.Fatalf("Expected profile %q, got %q", tc.expected, profile.Name)
+			}
+		})
+	}
+}
+
+func TestStabilizeProfile(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		current              string
+		observations         int32
+		selected             string
+		expected             string
+		expectedObservations int32
+	}{
+		{
+			name:     "no profile selected before",
+			selected: "small",
+			expected: "small",
+		},
+		{
+			name:     "larger profile is selected immediately",
+			current:  "small",
+			selected: "large",
+			expected: "large",
+		},
+		{
+			name:         "same profile resets the observations",
+			current:      "medium",
+			observations: 2,
+			selected:     "medium",
+			expected:     "medium",
+		},
+		{
+			name:                 "smaller profile is delayed",
+			current:              "large",
+			selected:             "small",
+			expected:             "large",
+			expectedObservations: 1,
+		},
+		{
+			name:                 "smaller profile is still delayed",
+			current:              "large",
+			observations:         1,
+			selected:             "medium",
+			expected:             "large",
+			expectedObservations: 2,
+		},
+		{
+			name:         "smaller profile is selected after the stabilization window",
+			current:      "large",
+			observations: 2,
+			selected:     "medium",
+			expected:     "medium",
+		},
+		{
+			name:         "upscale during the stabilization window resets the observations",
+			current:      "medium",
+			observations: 2,
+			selected:     "large",
+			expected:     "large",
+		},
+		{
+			name:         "removed profile is replaced immediately",
+			current:      "huge",
+			observations: 1,
+			selected:     "small",
+			expected:     "small",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected := &testProfiles[profileIndex(testProfiles, tc.selected)]
+
+			profile, observations := stabilizeProfile(testProfiles, tc.current, tc.observations, selected, 3)
+			if profile.Name != tc.expected {
+				t.Errorf("Expected profile %q, got %q", tc.expected, profile.Name)
+			}
+
+			if observations != tc.expectedObservations {
+				t.Errorf("Expected %d observations, got %d", tc.expectedObservations, observations)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		status          *kubermaticv1.ClusterControlPlaneSizingStatus
+		sizing          *kubermaticv1.ControlPlaneSizingConfiguration
+		values          map[string]float64
+		expectedProfile string
+		expectedQueries int
+	}{
+		{
+			name:            "profile is selected from observed size",
+			sizing:          &kubermaticv1.ControlPlaneSizingConfiguration{Profiles: testProfiles},
+			values:          map[string]float64{"kube_node_info": 14, "apiserver_storage_objects": 3000},
+			expectedProfile: "medium",
+			expectedQueries: 3,
+		},
+		{
+			name:   "cluster is not observed again within the interval",
+			sizing: &kubermaticv1.ControlPlaneSizingConfiguration{Profiles: testProfiles},
+			status: &kubermaticv1.ClusterControlPlaneSizingStatus{
+				Profile:          "small",
+				LastObservedTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			values:          map[string]float64{"kube_node_info": 60},
+			expectedProfile: "small",
+		},
+		{
+			name:   "smaller profile is not selected within the stabilization window",
+			sizing: &kubermaticv1.ControlPlaneSizingConfiguration{Profiles: testProfiles},
+			status: &kubermaticv1.ClusterControlPlaneSizingStatus{
+				Profile:          "medium",
+				LastObservedTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			values:          map[string]float64{"kube_node_info": 2},
+			expectedProfile: "medium",
+			expectedQueries: 3,
+		},
+		{
+			name: "smaller profile is selected after the stabilization window",
+			sizing: &kubermaticv1.ControlPlaneSizingConfiguration{
+				Profiles:                        testProfiles,
+				DownscaleStabilizationIntervals: ptr.To[int32](2),
+			},
+			status: &kubermaticv1.ClusterControlPlaneSizingStatus{
+				Profile:               "medium",
+				LastObservedTime:      metav1.NewTime(now.Add(-time.Hour)),
+				DownscaleObservations: 1,
+			},
+			values:          map[string]float64{"kube_node_info": 2},
+			expectedProfile: "small",
+			expectedQueries: 3,
+		},
+		{
+			name:   "status is removed without profiles",
+			status: &kubermaticv1.ClusterControlPlaneSizingStatus{Profile: "small"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "abcd",
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName:      "cluster-abcd",
+					ControlPlaneSizing: tc.status,
+				},
+			}
+
+			config := &kubermaticv1.KubermaticConfiguration{}
+			config.Spec.UserCluster.ControlPlaneSizing = tc.sizing
+
+			q := &fakeQuerier{values: tc.values}
+			client := fake.NewClientBuilder().WithObjects(cluster).Build()
+
+			r := &Reconciler{
+				Client:       client,
+				log:          kubermaticlog.Logger,
+				recorder:     record.NewFakeRecorder(10),
+				configGetter: test.NewConfigGetter(config),
+				newQuerier: func(string) (querier, error) {
+					return q, nil
+				},
+				now: func() time.Time {
+					return now
+				},
+			}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}}); err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			updated := &kubermaticv1.Cluster{}
+			if err := client.Get(ctx, types.NamespacedName{Name: cluster.Name}, updated); err != nil {
+				t.Fatalf("Failed to get cluster: %v", err)
+			}
+
+			if q.queries != tc.expectedQueries {
+				t.Errorf("Expected %d queries, got %d", tc.expectedQueries, q.queries)
+			}
+
+			status := updated.Status.ControlPlaneSizing
+			if tc.expectedProfile == "" {
+				if status != nil {
+					t.Fatalf("Expected no sizing status, got %+v", status)
+				}
+				return
+			}
+
+			if status == nil || status.Profile != tc.expectedProfile {
+				t.Fatalf("Expected profile %q, got %+v", tc.expectedProfile, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package controlplanesizingcontroller contains a controller that selects a sizing profile for the
+control plane of each user cluster. The size of a cluster is observed through the metrics that the
+seed monitoring Prometheus federates from the user cluster Prometheus instances: the number of
+nodes, the number of objects stored in the cluster and the request rate of its API server. The
+highest value of the last hour is used for each metric, so that short spikes do not cause the
+control plane to be scaled down and up again. Larger profiles are selected immediately, while a
+smaller profile is only selected after the cluster has stayed below the thresholds of its current
+profile for a number of consecutive observations.
+
+The selected profile is stored in the cluster status, from where the resource reconcilers of the
+control plane components pick it up.
+*/
+package controlplanesizingcontroller
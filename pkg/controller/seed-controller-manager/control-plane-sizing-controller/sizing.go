@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanesizingcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+// clusterSize is the size of a user cluster as observed by the seed monitoring.
+type clusterSize struct {
+	nodes             int64
+	objects           int64
+	requestsPerSecond int64
+}
+
+const (
+	// The metrics are recorded by the user cluster Prometheus and federated
+	// into the seed Prometheus, which labels them with the cluster namespace.
+	nodesQuery             = `max(max_over_time(job:kube_node_info:count{namespace=%q}[1h]))`
+	objectsQuery           = `max(max_over_time(job:apiserver_storage_objects:sum{namespace=%q}[1h]))`
+	requestsPerSecondQuery = `max(max_over_time(job:apiserver_request_total:rate5msum{namespace=%q}[1h]))`
+)
+
+// observeClusterSize queries the highest values of the last hour. Metrics that are not
+// available (yet) count as zero.
+func observeClusterSize(ctx context.Context, q querier, namespace string, now time.Time) (clusterSize, error) {
+	size := clusterSize{}
+
+	for query, target := range map[string]*int64{
+		nodesQuery:             &size.nodes,
+		objectsQuery:           &size.objects,
+		requestsPerSecondQuery: &size.requestsPerSecond,
+	} {
+		value, err := queryScalar(ctx, q, fmt.Sprintf(query, namespace), now)
+		if err != nil {
+			return size, err
+		}
+
+		*target = value
+	}
+
+	return size, nil
+}
+
+func queryScalar(ctx context.Context, q querier, query string, now time.Time) (int64, error) {
+	result, _, err := q.Query(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %q: %w", query, err)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result type %q for query %q", result.Type(), query)
+	}
+
+	if len(vector) == 0 {
+		return 0, nil
+	}
+
+	return int64(vector[0].Value), nil
+}
+
+// selectProfile returns the largest profile for which at least one threshold is reached,
+// or the first profile if no threshold is reached.
+func selectProfile(profiles []kubermaticv1.ControlPlaneSizingProfile, size clusterSize) *kubermaticv1.ControlPlaneSizingProfile {
+	selected := &profiles[0]
+
+	for i := range profiles {
+		p := &profiles[i]
+
+		if (p.MinNodes > 0 && size.nodes >= p.MinNodes) ||
+			(p.MinObjects > 0 && size.objects >= p.MinObjects) ||
+			(p.MinRequestsPerSecond > 0 && size.requestsPerSecond >= p.MinRequestsPerSecond) {
+			selected = p
+		}
+	}
+
+	return selected
+}
+
+// stabilizeProfile delays switching to a smaller profile until the cluster has been
+// observed below the thresholds of its current profile for the given number of
+// consecutive intervals. It returns the profile to use and the updated number of
+// observations below the current profile.
+func stabilizeProfile(profiles []kubermaticv1.ControlPlaneSizingProfile, current string, observations int32, selected *kubermaticv1.ControlPlaneSizingProfile, stabilizationIntervals int32) (*kubermaticv1.ControlPlaneSizingProfile, int32) {
+	currentIndex := profileIndex(profiles, current)
+	if currentIndex < 0 || profileIndex(profiles, selected.Name) >= currentIndex {
+		return selected, 0
+	}
+
+	observations++
+	if observations >= stabilizationIntervals {
+		return selected, 0
+	}
+
+	return &profiles[currentIndex], observations
+}
+
+func profileIndex(profiles []kubermaticv1.ControlPlaneSizingProfile, name string) int {
+	for i, p := range profiles {
+		if p.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func hasProfile(profiles []kubermaticv1.ControlPlaneSizingProfile, name string) bool {
+	for _, p := range profiles {
+		if p.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -18,6 +18,7 @@ package initialmachinedeployment
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 
@@ -28,6 +29,7 @@ import (
 	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
@@ -53,6 +55,8 @@ type UserClusterClientProvider interface {
 	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
 }
 
+type providerGetter func(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error)
+
 type Reconciler struct {
 	ctrlruntimeclient.Client
 
@@ -60,12 +64,13 @@ type Reconciler struct {
 	recorder                      record.EventRecorder
 	seedGetter                    provider.SeedGetter
 	userClusterConnectionProvider UserClusterClientProvider
+	getProvider                   providerGetter
 	log                           *zap.SugaredLogger
 	versions                      kubermatic.Versions
 }
 
 // Add creates a new initialmachinedeployment controller.
-func Add(ctx context.Context, mgr manager.Manager, numWorkers int, workerName string, seedGetter provider.SeedGetter, userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger, versions kubermatic.Versions) error {
+func Add(ctx context.Context, mgr manager.Manager, numWorkers int, workerName string, seedGetter provider.SeedGetter, userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger, versions kubermatic.Versions, caBundle *x509.CertPool) error {
 	reconciler := &Reconciler{
 		Client: mgr.GetClient(),
 
@@ -73,8 +78,11 @@ func Add(ctx context.Context, mgr manager.Manager, numWorkers int, workerName st
 		recorder:                      mgr.GetEventRecorderFor(ControllerName),
 		seedGetter:                    seedGetter,
 		userClusterConnectionProvider: userClusterConnectionProvider,
-		log:                           log,
-		versions:                      versions,
+		getProvider: func(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+			return cloud.Provider(datacenter, secretKeyGetter, caBundle)
+		},
+		log:      log,
+		versions: versions,
 	}
 
 	_, err := builder.ControllerManagedBy(mgr).
@@ -180,6 +188,10 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return nil, fmt.Errorf("failed to get user cluster client: %w", err)
 	}
 
+	if err := r.checkCapacity(ctx, log, cluster, datacenter, machineDeployment, userClusterClient); err != nil {
+		return nil, err
+	}
+
 	if err := r.createInitialMachineDeployment(ctx, log, machineDeployment, cluster, datacenter, userClusterClient); err != nil {
 		return nil, fmt.Errorf("failed to create initial MachineDeployment: %w", err)
 	}
@@ -218,6 +230,36 @@ func (r *Reconciler) createInitialMachineDeployment(ctx context.Context, log *za
 	return nil
 }
 
+// checkCapacity ensures that the cloud account has enough quota left for the initial
+// MachineDeployment, so that users are told early instead of machines failing to be
+// created later on. Errors while determining the quotas are only logged.
+func (r *Reconciler) checkCapacity(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, datacenter *kubermaticv1.Datacenter, machineDeployment *clusterv1alpha1.MachineDeployment, userClusterClient ctrlruntimeclient.Client) error {
+	cloudProvider, err := r.getProvider(datacenter, provider.SecretKeySelectorValueFuncFactory(ctx, r))
+	if err != nil {
+		return fmt.Errorf("failed to create cloud provider: %w", err)
+	}
+
+	checker, ok := cloudProvider.(provider.CapacityChecker)
+	if !ok {
+		return nil
+	}
+
+	request, err := provider.NewCapacityRequest(ctx, machineDeployment, userClusterClient)
+	if err != nil {
+		return fmt.Errorf("initial MachineDeployment is invalid: %w", err)
+	}
+
+	if err := checker.CheckCapacity(ctx, cluster.Spec.Cloud, request); err != nil {
+		if provider.IsInsufficientQuotaError(err) {
+			return fmt.Errorf("cloud account does not have enough capacity left for the initial MachineDeployment: %w", err)
+		}
+
+		log.Warnw("Failed to check cloud capacity", zap.Error(err))
+	}
+
+	return nil
+}
+
 func (r *Reconciler) getTargetDatacenter(cluster *kubermaticv1.Cluster) (*kubermaticv1.Datacenter, error) {
 	seed, err := r.seedGetter()
 	if err != nil {
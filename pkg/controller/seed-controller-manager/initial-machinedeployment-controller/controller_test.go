@@ -31,6 +31,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/machine"
 	"k8c.io/kubermatic/v2/pkg/machine/operatingsystem"
 	"k8c.io/kubermatic/v2/pkg/machine/provider"
+	kubermaticprovider "k8c.io/kubermatic/v2/pkg/provider"
+	fakecloud "k8c.io/kubermatic/v2/pkg/provider/cloud/fake"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -135,10 +137,11 @@ func TestReconcile(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name      string
-		mcHealthy bool
-		cluster   *kubermaticv1.Cluster
-		validate  func(cluster *kubermaticv1.Cluster, userClusterClient ctrlruntimeclient.Client, reconcileErr error) error
+		name          string
+		mcHealthy     bool
+		quotaExceeded bool
+		cluster       *kubermaticv1.Cluster
+		validate      func(cluster *kubermaticv1.Cluster, userClusterClient ctrlruntimeclient.Client, reconcileErr error) error
 	}{
 		{
 			name:      "no annotation exists, nothing should happen",
@@ -206,6 +209,33 @@ func TestReconcile(t *testing.T) {
 			},
 		},
 
+		{
+			name:          "insufficient cloud quota should cause errors and keep the annotation",
+			mcHealthy:     true,
+			quotaExceeded: true,
+			cluster:       genCluster(string(mdAnnotation)),
+			validate: func(cluster *kubermaticv1.Cluster, userClusterClient ctrlruntimeclient.Client, reconcileErr error) error {
+				if reconcileErr == nil {
+					return errors.New("reconciling with insufficient quota should have produced an error, but got nil")
+				}
+
+				if _, ok := cluster.Annotations[kubermaticv1.InitialMachineDeploymentRequestAnnotation]; !ok {
+					return errors.New("annotation should have been kept to retry later, but it was removed")
+				}
+
+				machineDeployments := clusterv1alpha1.MachineDeploymentList{}
+				if err := userClusterClient.List(context.Background(), &machineDeployments); err != nil {
+					return fmt.Errorf("failed to list MachineDeployments in user cluster: %w", err)
+				}
+
+				if len(machineDeployments.Items) > 0 {
+					return errors.New("should not have created a MachineDeployment in the user cluster")
+				}
+
+				return nil
+			},
+		},
+
 		{
 			name:      "invalid annotations should cause errors and then be removed",
 			mcHealthy: true,
@@ -266,6 +296,14 @@ func TestReconcile(t *testing.T) {
 
 				userClusterConnectionProvider: newFakeClientProvider(userClusterClient),
 
+				getProvider: func(_ *kubermaticv1.Datacenter, _ kubermaticprovider.SecretKeySelectorValueFunc) (kubermaticprovider.CloudProvider, error) {
+					if test.quotaExceeded {
+						return fakecloud.NewCloudProviderWithQuota(0), nil
+					}
+
+					return fakecloud.NewCloudProvider(), nil
+				},
+
 				// this dummy seedGetter returns the same dummy hetzner DC for all tests
 				seedGetter: func() (*kubermaticv1.Seed, error) {
 					return &kubermaticv1.Seed{
@@ -24,6 +24,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/machine"
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
 	"k8c.io/kubermatic/v2/pkg/validation/nodeupdate"
 	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
 	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
@@ -97,6 +98,21 @@ func CompleteMachineDeployment(md *clusterv1alpha1.MachineDeployment, cluster *k
 	}
 	md.Spec.Template.Spec.ProviderSpec = *providerSpec
 
+	// spot machines might never be provisioned when there is no spare capacity, so fall back
+	// to on-demand machines unless the user configured the fallback themselves
+	isSpot, err := spot.IsSpotMachineDeployment(md)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := md.Annotations[spot.FallbackTimeoutAnnotation]; isSpot && !ok {
+		if md.Annotations == nil {
+			md.Annotations = make(map[string]string)
+		}
+
+		md.Annotations[spot.FallbackTimeoutAnnotation] = spot.DefaultFallbackTimeout.String()
+	}
+
 	return md, nil
 }
 
@@ -121,6 +137,10 @@ func completeCloudProviderSpec(config *providerconfig.Config, cluster *kubermati
 		return err
 	}
 
+	if err := spot.ValidateAllowed(cloudProviderSpec, datacenter); err != nil {
+		return err
+	}
+
 	// re-encode the spec back into the config
 	config.CloudProviderSpec, err = machine.EncodeAsRawExtension(cloudProviderSpec)
 	if err != nil {
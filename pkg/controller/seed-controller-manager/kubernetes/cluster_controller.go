@@ -291,16 +291,12 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	if cluster.DeletionTimestamp != nil {
 		log.Debug("Cleaning up cluster")
 
-		// Defer getting the client to make sure we only request it if we actually need it
-		userClusterClientGetter := func() (ctrlruntimeclient.Client, error) {
-			client, err := r.userClusterConnProvider.GetClient(ctx, cluster)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get user cluster client: %w", err)
-			}
-			return client, nil
+		seed, err := r.seedGetter()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seed: %w", err)
 		}
 
-		if err := clusterdeletion.New(r.Client, r.recorder, userClusterClientGetter).CleanupCluster(ctx, log, cluster); err != nil {
+		if err := clusterdeletion.New(r.Client, r.recorder, seed.Namespace, r.userClusterClientGetter(ctx, cluster)).CleanupCluster(ctx, log, cluster); err != nil {
 			return nil, err
 		}
 
@@ -313,11 +309,26 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return nil, fmt.Errorf("failed to ensure cluster namespace: %w", err)
 	}
 
+	if err := r.reconcileDeletionReport(ctx, log, cluster); err != nil {
+		// this is purely informational and must not block the cluster reconciliation
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "DeletionReportFailed", err.Error())
+	}
+
 	// synchronize cluster.status.health for Kubernetes clusters
 	if err := r.syncHealth(ctx, cluster); err != nil {
 		return nil, fmt.Errorf("failed to sync health: %w", err)
 	}
 
+	if err := r.reconcileRetainedVolumesImport(ctx, log, cluster); err != nil {
+		// a broken import request must not block the cluster reconciliation
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "RetainedVolumesImportFailed", "Failed to import retained volumes: %v", err)
+	}
+
+	etcdResizing, err := r.reconcileEtcdResize(ctx, log, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile etcd cluster size: %w", err)
+	}
+
 	res, err := r.reconcileCluster(ctx, cluster, namespace)
 	if err != nil {
 		updateErr := r.updateClusterError(ctx, cluster, kubermaticv1.ReconcileClusterError, err.Error())
@@ -331,9 +342,25 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return nil, fmt.Errorf("failed to clear error on cluster: %w", err)
 	}
 
+	// etcd Pods are not watched, so regularly check on the progress of resizing etcd
+	if etcdResizing && (res == nil || res.IsZero()) {
+		res = &reconcile.Result{RequeueAfter: 10 * time.Second}
+	}
+
 	return res, nil
 }
 
+// userClusterClientGetter defers getting the client to make sure we only request it if we actually need it.
+func (r *Reconciler) userClusterClientGetter(ctx context.Context, cluster *kubermaticv1.Cluster) func() (ctrlruntimeclient.Client, error) {
+	return func() (ctrlruntimeclient.Client, error) {
+		client, err := r.userClusterConnProvider.GetClient(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user cluster client: %w", err)
+		}
+		return client, nil
+	}
+}
+
 func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster), opts ...ctrlruntimeclient.MergeFromOption) error {
 	oldCluster := cluster.DeepCopy()
 	modify(cluster)
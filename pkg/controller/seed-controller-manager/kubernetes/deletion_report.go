@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/clusterdeletion"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcileDeletionReport creates a deletion report (a dry-run of the cluster deletion)
+// when it was requested via annotation. The annotation is removed once the report has
+// been published, so the (potentially expensive) report is only created once per request.
+func (r *Reconciler) reconcileDeletionReport(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	if _, ok := cluster.Annotations[clusterdeletion.DeletionDryRunAnnotation]; !ok {
+		return nil
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	deletion := clusterdeletion.New(r.Client, r.recorder, seed.Namespace, r.userClusterClientGetter(ctx, cluster))
+
+	report, err := deletion.Report(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to create deletion report: %w", err)
+	}
+
+	hash, err := deletion.PublishReport(ctx, cluster, report)
+	if err != nil {
+		return fmt.Errorf("failed to publish deletion report: %w", err)
+	}
+
+	log.Infow("Published deletion report", "hash", hash)
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "DeletionReport", "Deletion report with hash %q has been written to the %s ConfigMap.", hash, clusterdeletion.DeletionReportConfigMapName)
+
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		delete(c.Annotations, clusterdeletion.DeletionDryRunAnnotation)
+	})
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// reconcileEtcdResize determines the next size of the etcd StatefulSet and records it in the
+// cluster status, from where it is picked up by the StatefulSet reconciler. The etcd cluster is
+// resized one member at a time and only while all members are healthy voting members. When
+// scaling down, the StatefulSet only shrinks after etcd-launcher has removed the last member
+// from the etcd cluster. The returned bool is true while a resize is in progress.
+func (r *Reconciler) reconcileEtcdResize(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (bool, error) {
+	if !cluster.Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] || cluster.Status.NamespaceName == "" {
+		return false, nil
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, sts); err != nil {
+		// the initial size is set when the StatefulSet is created
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get etcd StatefulSet: %w", err)
+	}
+
+	replicas := ptr.Deref(sts.Spec.Replicas, kubermaticv1.DefaultEtcdClusterSize)
+	previous := cluster.Status.Etcd
+
+	// wait for the StatefulSet to be updated to the previously determined size
+	if previous != nil && previous.Size != replicas {
+		return true, nil
+	}
+
+	healthy := etcdHealthyForResize(cluster, sts)
+
+	status := &kubermaticv1.ClusterEtcdStatus{
+		Size:       replicas,
+		TargetSize: etcd.GetClusterSize(cluster.Spec.ComponentsOverride.Etcd),
+	}
+
+	var previousPhase kubermaticv1.EtcdResizePhase
+	if previous != nil {
+		previousPhase = previous.ResizePhase
+	}
+
+	switch {
+	case status.TargetSize > replicas:
+		status.ResizePhase = kubermaticv1.EtcdResizePhaseScalingUp
+		if healthy {
+			status.Size = replicas + 1
+		}
+
+	case status.TargetSize < replicas:
+		removed, err := r.etcdMemberRemoved(ctx, cluster, replicas-1)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case removed:
+			status.ResizePhase = kubermaticv1.EtcdResizePhaseScalingDown
+			status.Size = replicas - 1
+		case healthy || previousPhase == kubermaticv1.EtcdResizePhaseRemovingMember:
+			status.ResizePhase = kubermaticv1.EtcdResizePhaseRemovingMember
+		default:
+			status.ResizePhase = previousPhase
+		}
+
+	default:
+		// the last step is only complete once the StatefulSet has rolled out and, when
+		// scaling up, the new member has been promoted
+		if !healthy {
+			status.ResizePhase = previousPhase
+			break
+		}
+
+		if err := r.cleanupEtcdVolumes(ctx, log, cluster, replicas); err != nil {
+			return false, err
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(previous, status) {
+		log.Infow("Updating etcd cluster size", "size", status.Size, "target", status.TargetSize, "phase", status.ResizePhase)
+
+		if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.Etcd = status
+		}); err != nil {
+			return false, fmt.Errorf("failed to update etcd status: %w", err)
+		}
+	}
+
+	return status.ResizePhase != "", nil
+}
+
+// etcdHealthyForResize returns true if all etcd Pods are ready and all members are voting members.
+func etcdHealthyForResize(cluster *kubermaticv1.Cluster, sts *appsv1.StatefulSet) bool {
+	return cluster.Status.ExtendedHealth.Etcd == kubermaticv1.HealthStatusUp &&
+		cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdMembersHealthy, corev1.ConditionTrue) &&
+		sts.Status.ReadyReplicas == ptr.Deref(sts.Spec.Replicas, kubermaticv1.DefaultEtcdClusterSize)
+}
+
+// etcdMemberRemoved returns true if the member with the given ordinal has removed itself
+// from the etcd cluster.
+func (r *Reconciler) etcdMemberRemoved(ctx context.Context, cluster *kubermaticv1.Cluster, ordinal int32) (bool, error) {
+	pod := &corev1.Pod{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, ordinal)}
+
+	if err := r.Get(ctx, key, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get etcd Pod: %w", err)
+	}
+
+	return pod.Annotations[resources.EtcdMemberStateAnnotation] == resources.EtcdMemberStateRemoved, nil
+}
+
+// cleanupEtcdVolumes deletes the PVCs of members that have been removed by scaling down,
+// as a StatefulSet does not clean them up itself.
+func (r *Reconciler) cleanupEtcdVolumes(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, replicas int32) error {
+	for ordinal := replicas; ordinal < kubermaticv1.MaxEtcdClusterSize; ordinal++ {
+		podName := fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, ordinal)
+
+		pod := &corev1.Pod{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: podName}, pod)
+		if err == nil {
+			continue // the Pod is still terminating
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get etcd Pod: %w", err)
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		err = r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: fmt.Sprintf("data-%s", podName)}, pvc)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get etcd PVC: %w", err)
+		}
+
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+
+		log.Infow("Deleting volume of removed etcd member", "pvc", pvc.Name)
+
+		if err := r.Delete(ctx, pvc); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete etcd PVC %s: %w", pvc.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileEtcdResize(t *testing.T) {
+	const namespace = "cluster-test"
+
+	etcdCluster := func(targetSize int32, healthy bool, status *kubermaticv1.ClusterEtcdStatus) *kubermaticv1.Cluster {
+		cluster := &kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: kubermaticv1.ClusterSpec{
+				Features: map[string]bool{kubermaticv1.ClusterFeatureEtcdLauncher: true},
+				ComponentsOverride: kubermaticv1.ComponentSettings{
+					Etcd: kubermaticv1.EtcdStatefulSetSettings{ClusterSize: ptr.To(targetSize)},
+				},
+			},
+			Status: kubermaticv1.ClusterStatus{
+				NamespaceName: namespace,
+				Etcd:          status,
+			},
+		}
+
+		if healthy {
+			cluster.Status.ExtendedHealth.Etcd = kubermaticv1.HealthStatusUp
+			cluster.Status.Conditions = map[kubermaticv1.ClusterConditionType]kubermaticv1.ClusterCondition{
+				kubermaticv1.ClusterConditionEtcdMembersHealthy: {Status: corev1.ConditionTrue},
+			}
+		}
+
+		return cluster
+	}
+
+	statefulSet := func(replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.EtcdStatefulSetName, Namespace: namespace},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(replicas)},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: replicas},
+		}
+	}
+
+	pod := func(ordinal int, state string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("etcd-%d", ordinal), Namespace: namespace},
+		}
+
+		if state != "" {
+			pod.Annotations = map[string]string{resources.EtcdMemberStateAnnotation: state}
+		}
+
+		return pod
+	}
+
+	pvc := func(ordinal int) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("data-etcd-%d", ordinal), Namespace: namespace},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		cluster          *kubermaticv1.Cluster
+		objects          []ctrlruntimeclient.Object
+		expectedStatus   kubermaticv1.ClusterEtcdStatus
+		expectedResizing bool
+		expectedPVCs     []string
+	}{
+		{
+			name:           "cluster at target size",
+			cluster:        etcdCluster(3, true, nil),
+			objects:        []ctrlruntimeclient.Object{statefulSet(3)},
+			expectedStatus: kubermaticv1.ClusterEtcdStatus{Size: 3, TargetSize: 3},
+		},
+		{
+			name:             "scaling up adds one member",
+			cluster:          etcdCluster(5, true, nil),
+			objects:          []ctrlruntimeclient.Object{statefulSet(3)},
+			expectedStatus:   kubermaticv1.ClusterEtcdStatus{Size: 4, TargetSize: 5, ResizePhase: kubermaticv1.EtcdResizePhaseScalingUp},
+			expectedResizing: true,
+		},
+		{
+			name:             "scaling up waits for etcd to be healthy",
+			cluster:          etcdCluster(5, false, &kubermaticv1.ClusterEtcdStatus{Size: 4, TargetSize: 5, ResizePhase: kubermaticv1.EtcdResizePhaseScalingUp}),
+			objects:          []ctrlruntimeclient.Object{statefulSet(4)},
+			expectedStatus:   kubermaticv1.ClusterEtcdStatus{Size: 4, TargetSize: 5, ResizePhase: kubermaticv1.EtcdResizePhaseScalingUp},
+			expectedResizing: true,
+		},
+		{
+			name:             "scaling up is not finished before the last member is healthy",
+			cluster:          etcdCluster(5, false, &kubermaticv1.ClusterEtcdStatus{Size: 5, TargetSize: 5, ResizePhase: kubermaticv1.EtcdResizePhaseScalingUp}),
+			objects:          []ctrlruntimeclient.Object{statefulSet(5)},
+			expectedStatus:   kubermaticv1.ClusterEtcdStatus{Size: 5, TargetSize: 5, ResizePhase: kubermaticv1.EtcdResizePhaseScalingUp},
+			expectedResizing: true,
+		},
+		{
+			name:             "scaling down removes the last member first",
+			cluster:          etcdCluster(3, true, &kubermaticv1.ClusterEtcdStatus{Size: 5, TargetSize: 5}),
+			objects:          []ctrlruntimeclient.Object{statefulSet(5), pod(4, resources.EtcdMemberStateVoter)},
+			expectedStatus:   kubermaticv1.ClusterEtcdStatus{Size: 5, TargetSize: 3, ResizePhase: kubermaticv1.EtcdResizePhaseRemovingMember},
+			expectedResizing: true,
+		},
+		{
+			name:             "scaling down shrinks the StatefulSet once the member is removed",
+			cluster:          etcdCluster(3, false, &kubermaticv1.ClusterEtcdStatus{Size: 5, TargetSize: 3, ResizePhase: kubermaticv1.EtcdResizePhaseRemovingMember}),
+			objects:          []ctrlruntimeclient.Object{statefulSet(5), pod(4, resources.EtcdMemberStateRemoved)},
+			expectedStatus:   kubermaticv1.ClusterEtcdStatus{Size: 4, TargetSize: 3, ResizePhase: kubermaticv1.EtcdResizePhaseScalingDown},
+			expectedResizing: true,
+		},
+		{
+			name:           "volumes of removed members are cleaned up",
+			cluster:        etcdCluster(3, true, &kubermaticv1.ClusterEtcdStatus{Size: 3, TargetSize: 3, ResizePhase: kubermaticv1.EtcdResizePhaseScalingDown}),
+			objects:        []ctrlruntimeclient.Object{statefulSet(3), pvc(0), pvc(2), pvc(3), pvc(4), pod(4, resources.EtcdMemberStateRemoved)},
+			expectedStatus: kubermaticv1.ClusterEtcdStatus{Size: 3, TargetSize: 3},
+			expectedPVCs:   []string{"data-etcd-0", "data-etcd-2", "data-etcd-4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := fake.NewClientBuilder().
+				WithObjects(append(test.objects, test.cluster)...).
+				WithStatusSubresource(test.cluster).
+				Build()
+
+			r := &Reconciler{Client: client}
+
+			resizing, err := r.reconcileEtcdResize(ctx, zap.NewNop().Sugar(), test.cluster)
+			if err != nil {
+				t.Fatalf("Failed to reconcile: %v", err)
+			}
+
+			if resizing != test.expectedResizing {
+				t.Errorf("Expected resizing to be %v, got %v", test.expectedResizing, resizing)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := client.Get(ctx, types.NamespacedName{Name: test.cluster.Name}, cluster); err != nil {
+				t.Fatalf("Failed to get cluster: %v", err)
+			}
+
+			if cluster.Status.Etcd == nil || *cluster.Status.Etcd != test.expectedStatus {
+				t.Errorf("Expected etcd status %+v, got %+v", test.expectedStatus, cluster.Status.Etcd)
+			}
+
+			for _, name := range test.expectedPVCs {
+				if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &corev1.PersistentVolumeClaim{}); err != nil {
+					t.Errorf("Expected PVC %s to exist, got %v", name, err)
+				}
+			}
+
+			if test.expectedPVCs != nil {
+				err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "data-etcd-3"}, &corev1.PersistentVolumeClaim{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Expected PVC data-etcd-3 to be deleted, got %v", err)
+				}
+			}
+		})
+	}
+}
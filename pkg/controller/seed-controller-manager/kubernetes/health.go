@@ -19,19 +19,34 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/applications"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// controlPlaneApps are the app labels of all control plane Pods that are spread across zones
+// if a control plane topology is configured. The konnectivity server is part of the apiserver Pods.
+var controlPlaneApps = []string{
+	resources.ApiserverDeploymentName,
+	resources.ControllerManagerDeploymentName,
+	resources.SchedulerDeploymentName,
+	resources.EtcdStatefulSetName,
+}
+
 func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cluster) (*kubermaticv1.ExtendedClusterHealth, error) {
 	ns := cluster.Status.NamespaceName
 	extendedHealth := cluster.Status.ExtendedHealth.DeepCopy()
@@ -125,12 +140,183 @@ func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cl
 	return extendedHealth, nil
 }
 
+// etcdMembersCondition determines the EtcdMembersHealthy condition, based on the etcd health
+// and the member states that etcd-launcher reports on the etcd Pods.
+func (r *Reconciler) etcdMembersCondition(ctx context.Context, cluster *kubermaticv1.Cluster, etcdHealth kubermaticv1.HealthStatus) (corev1.ConditionStatus, string, string, error) {
+	if etcdHealth != kubermaticv1.HealthStatusUp {
+		return corev1.ConditionFalse, "EtcdUnavailable", "Etcd is not healthy", nil
+	}
+
+	pods, err := r.etcdPods(ctx, cluster)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var learners, failed []string
+	for _, pod := range pods.Items {
+		switch pod.Annotations[resources.EtcdMemberStateAnnotation] {
+		case resources.EtcdMemberStateLearner:
+			learners = append(learners, pod.Name)
+		case resources.EtcdMemberStatePromotionFailed:
+			failed = append(failed, pod.Name)
+		}
+	}
+
+	sort.Strings(learners)
+	sort.Strings(failed)
+
+	switch {
+	case len(failed) > 0:
+		return corev1.ConditionFalse, "LearnerPromotionFailed", fmt.Sprintf("Learner members did not catch up with the leader in time: %s", strings.Join(failed, ", ")), nil
+	case len(learners) > 0:
+		return corev1.ConditionFalse, "LearnerCatchingUp", fmt.Sprintf("Learner members are catching up with the leader: %s", strings.Join(learners, ", ")), nil
+	default:
+		return corev1.ConditionTrue, "", "All etcd members are healthy voting members", nil
+	}
+}
+
+// etcdNoSpaceCondition determines the EtcdNoSpaceRecovered condition, based on the NOSPACE recovery
+// outcomes that etcd-launcher reports on the etcd Pods. An empty status means that no recovery is
+// reported (anymore) and the condition should be removed.
+func (r *Reconciler) etcdNoSpaceCondition(ctx context.Context, cluster *kubermaticv1.Cluster) (corev1.ConditionStatus, string, string, error) {
+	pods, err := r.etcdPods(ctx, cluster)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var recovered, failed []string
+	for _, pod := range pods.Items {
+		switch pod.Annotations[resources.EtcdNoSpaceRecoveryAnnotation] {
+		case resources.EtcdNoSpaceRecoverySucceeded:
+			recovered = append(recovered, pod.Name)
+		case resources.EtcdNoSpaceRecoveryFailed:
+			failed = append(failed, pod.Name)
+		}
+	}
+
+	sort.Strings(recovered)
+	sort.Strings(failed)
+
+	switch {
+	case len(failed) > 0:
+		return corev1.ConditionFalse, "NoSpaceRecoveryFailed", fmt.Sprintf("Etcd exceeded its space quota and could not be recovered by %s, consider increasing the quota", strings.Join(failed, ", ")), nil
+	case len(recovered) > 0:
+		return corev1.ConditionTrue, "NoSpaceRecovered", fmt.Sprintf("Etcd exceeded its space quota and was recovered by %s", strings.Join(recovered, ", ")), nil
+	default:
+		return "", "", "", nil
+	}
+}
+
+// controlPlaneZoneSpreadCondition determines the ControlPlaneZoneSpread condition, based on the
+// availability zones of the seed nodes that the running control plane Pods are scheduled to.
+// Every component must run in at least two zones, as losing the single zone of one component
+// makes the whole control plane unavailable. An empty status means that the condition should
+// be left alone.
+func (r *Reconciler) controlPlaneZoneSpreadCondition(ctx context.Context, cluster *kubermaticv1.Cluster) (corev1.ConditionStatus, string, string, error) {
+	selector, err := labels.Parse(fmt.Sprintf("%s in (%s)", resources.AppLabelKey, strings.Join(controlPlaneApps, ",")))
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to create control plane Pod selector: %w", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName), ctrlruntimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", "", "", fmt.Errorf("failed to list control plane Pods: %w", err)
+	}
+
+	nodeZones := map[string]string{}
+	appZones := map[string]sets.Set[string]{}
+	zones := sets.New[string]()
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+
+		zone, ok := nodeZones[pod.Spec.NodeName]
+		if !ok {
+			node := &corev1.Node{}
+			if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return "", "", "", fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+			}
+
+			zone = node.Labels[resources.TopologyKeyZone]
+			nodeZones[pod.Spec.NodeName] = zone
+		}
+
+		app := pod.Labels[resources.AppLabelKey]
+		if _, ok := appZones[app]; !ok {
+			appZones[app] = sets.New[string]()
+		}
+
+		if zone != "" {
+			appZones[app].Insert(zone)
+			zones.Insert(zone)
+		}
+	}
+
+	// nothing is running yet, so there is nothing to judge
+	if len(appZones) == 0 {
+		return "", "", "", nil
+	}
+
+	if zones.Len() == 0 {
+		return corev1.ConditionFalse, "NoZoneInformation", fmt.Sprintf("None of the seed nodes running the control plane have a %s label", resources.TopologyKeyZone), nil
+	}
+
+	var confined []string
+	for app, appZone := range appZones {
+		switch appZone.Len() {
+		case 0:
+			confined = append(confined, fmt.Sprintf("%s (unknown zone)", app))
+		case 1:
+			confined = append(confined, fmt.Sprintf("%s (%s)", app, sets.List(appZone)[0]))
+		}
+	}
+
+	if len(confined) > 0 {
+		sort.Strings(confined)
+		return corev1.ConditionFalse, "SingleZone", fmt.Sprintf("Control plane components are running in a single zone: %s", strings.Join(confined, ", ")), nil
+	}
+
+	return corev1.ConditionTrue, "", fmt.Sprintf("Every control plane component is spread across zones %s", strings.Join(sets.List(zones), ", ")), nil
+}
+
+func (r *Reconciler) etcdPods(ctx context.Context, cluster *kubermaticv1.Cluster) (*corev1.PodList, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName), ctrlruntimeclient.MatchingLabels(etcd.GetBasePodLabels(cluster))); err != nil {
+		return nil, fmt.Errorf("failed to list etcd Pods: %w", err)
+	}
+
+	return pods, nil
+}
+
 func (r *Reconciler) syncHealth(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	extendedHealth, err := r.clusterHealth(ctx, cluster)
 	if err != nil {
 		return err
 	}
 
+	etcdMembersStatus, etcdMembersReason, etcdMembersMessage, err := r.etcdMembersCondition(ctx, cluster, extendedHealth.Etcd)
+	if err != nil {
+		return err
+	}
+
+	etcdNoSpaceStatus, etcdNoSpaceReason, etcdNoSpaceMessage, err := r.etcdNoSpaceCondition(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	var zoneSpreadStatus corev1.ConditionStatus
+	var zoneSpreadReason, zoneSpreadMessage string
+	if cluster.Spec.ControlPlaneTopology != nil {
+		zoneSpreadStatus, zoneSpreadReason, zoneSpreadMessage, err = r.controlPlaneZoneSpreadCondition(ctx, cluster)
+		if err != nil {
+			return err
+		}
+	}
+
 	return kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
 		c.Status.ExtendedHealth = *extendedHealth
 
@@ -147,6 +333,41 @@ func (r *Reconciler) syncHealth(ctx context.Context, cluster *kubermaticv1.Clust
 			)
 		}
 
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionEtcdMembersHealthy,
+			etcdMembersStatus,
+			etcdMembersReason,
+			etcdMembersMessage,
+		)
+
+		if etcdNoSpaceStatus == "" {
+			delete(c.Status.Conditions, kubermaticv1.ClusterConditionEtcdNoSpaceRecovered)
+		} else {
+			kubermaticv1helper.SetClusterCondition(
+				c,
+				r.versions,
+				kubermaticv1.ClusterConditionEtcdNoSpaceRecovered,
+				etcdNoSpaceStatus,
+				etcdNoSpaceReason,
+				etcdNoSpaceMessage,
+			)
+		}
+
+		if c.Spec.ControlPlaneTopology == nil {
+			delete(c.Status.Conditions, kubermaticv1.ClusterConditionControlPlaneZoneSpread)
+		} else if zoneSpreadStatus != "" {
+			kubermaticv1helper.SetClusterCondition(
+				c,
+				r.versions,
+				kubermaticv1.ClusterConditionControlPlaneZoneSpread,
+				zoneSpreadStatus,
+				zoneSpreadReason,
+				zoneSpreadMessage,
+			)
+		}
+
 		if kubermaticv1helper.IsClusterInitialized(cluster, r.versions) {
 			kubermaticv1helper.SetClusterCondition(
 				c,
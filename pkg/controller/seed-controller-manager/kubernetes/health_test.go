@@ -0,0 +1,318 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/etcd"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEtcdMembersCondition(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test-cluster"},
+	}
+
+	etcdPod := func(name, state string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cluster.Status.NamespaceName,
+				Labels:    etcd.GetBasePodLabels(cluster),
+			},
+		}
+
+		if state != "" {
+			pod.Annotations = map[string]string{resources.EtcdMemberStateAnnotation: state}
+		}
+
+		return pod
+	}
+
+	tests := []struct {
+		name           string
+		etcdHealth     kubermaticv1.HealthStatus
+		pods           []ctrlruntimeclient.Object
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "etcd is down",
+			etcdHealth:     kubermaticv1.HealthStatusDown,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "EtcdUnavailable",
+		},
+		{
+			name:       "all members are voters",
+			etcdHealth: kubermaticv1.HealthStatusUp,
+			pods: []ctrlruntimeclient.Object{
+				etcdPod("etcd-0", resources.EtcdMemberStateVoter),
+				etcdPod("etcd-1", ""),
+			},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:       "a learner is catching up",
+			etcdHealth: kubermaticv1.HealthStatusUp,
+			pods: []ctrlruntimeclient.Object{
+				etcdPod("etcd-0", resources.EtcdMemberStateVoter),
+				etcdPod("etcd-1", resources.EtcdMemberStateLearner),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "LearnerCatchingUp",
+		},
+		{
+			name:       "a learner failed to be promoted",
+			etcdHealth: kubermaticv1.HealthStatusUp,
+			pods: []ctrlruntimeclient.Object{
+				etcdPod("etcd-0", resources.EtcdMemberStateLearner),
+				etcdPod("etcd-1", resources.EtcdMemberStatePromotionFailed),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "LearnerPromotionFailed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(test.pods...).Build(),
+			}
+
+			status, reason, _, err := r.etcdMembersCondition(context.Background(), cluster, test.etcdHealth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status != test.expectedStatus {
+				t.Errorf("Expected status %q, got %q", test.expectedStatus, status)
+			}
+
+			if reason != test.expectedReason {
+				t.Errorf("Expected reason %q, got %q", test.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func TestEtcdNoSpaceCondition(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test-cluster"},
+	}
+
+	etcdPod := func(name, outcome string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cluster.Status.NamespaceName,
+				Labels:    etcd.GetBasePodLabels(cluster),
+			},
+		}
+
+		if outcome != "" {
+			pod.Annotations = map[string]string{resources.EtcdNoSpaceRecoveryAnnotation: outcome}
+		}
+
+		return pod
+	}
+
+	tests := []struct {
+		name           string
+		pods           []ctrlruntimeclient.Object
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name: "no recovery was needed",
+			pods: []ctrlruntimeclient.Object{
+				etcdPod("etcd-0", ""),
+				etcdPod("etcd-1", ""),
+			},
+		},
+		{
+			name: "recovery succeeded",
+			pods: []ctrlruntimeclient.Object{
+				etcdPod("etcd-0", resources.EtcdNoSpaceRecoverySucceeded),
+				etcdPod("etcd-1", ""),
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "NoSpaceRecovered",
+		},
+		{
+			name: "recovery failed",
+			pods: []ctrlruntimeclient.Object{
+				etcdPod("etcd-0", resources.EtcdNoSpaceRecoverySucceeded),
+				etcdPod("etcd-1", resources.EtcdNoSpaceRecoveryFailed),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "NoSpaceRecoveryFailed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(test.pods...).Build(),
+			}
+
+			status, reason, _, err := r.etcdNoSpaceCondition(context.Background(), cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status != test.expectedStatus {
+				t.Errorf("Expected status %q, got %q", test.expectedStatus, status)
+			}
+
+			if reason != test.expectedReason {
+				t.Errorf("Expected reason %q, got %q", test.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func TestControlPlaneZoneSpreadCondition(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test-cluster"},
+	}
+
+	node := func(name, zone string) *corev1.Node {
+		n := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		}
+
+		if zone != "" {
+			n.Labels = map[string]string{resources.TopologyKeyZone: zone}
+		}
+
+		return n
+	}
+
+	pod := func(name, app, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cluster.Status.NamespaceName,
+				Labels:    resources.BaseAppLabels(app, nil),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		}
+	}
+
+	nodes := []ctrlruntimeclient.Object{
+		node("node-a", "zone-a"),
+		node("node-b", "zone-b"),
+		node("node-c", ""),
+	}
+
+	tests := []struct {
+		name           string
+		pods           []ctrlruntimeclient.Object
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name: "no control plane Pods are running",
+		},
+		{
+			name: "control plane is spread across zones",
+			pods: []ctrlruntimeclient.Object{
+				pod("apiserver-1", resources.ApiserverDeploymentName, "node-a"),
+				pod("apiserver-2", resources.ApiserverDeploymentName, "node-b"),
+				pod("etcd-0", resources.EtcdStatefulSetName, "node-a"),
+				pod("etcd-1", resources.EtcdStatefulSetName, "node-b"),
+			},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "one component lives in a single zone",
+			pods: []ctrlruntimeclient.Object{
+				pod("apiserver-1", resources.ApiserverDeploymentName, "node-a"),
+				pod("apiserver-2", resources.ApiserverDeploymentName, "node-b"),
+				pod("controller-manager-1", resources.ControllerManagerDeploymentName, "node-a"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "SingleZone",
+		},
+		{
+			name: "one component runs on nodes without zones",
+			pods: []ctrlruntimeclient.Object{
+				pod("apiserver-1", resources.ApiserverDeploymentName, "node-a"),
+				pod("apiserver-2", resources.ApiserverDeploymentName, "node-b"),
+				pod("scheduler-1", resources.SchedulerDeploymentName, "node-c"),
+				pod("scheduler-2", resources.SchedulerDeploymentName, "node-c"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "SingleZone",
+		},
+		{
+			name: "control plane lives in a single zone",
+			pods: []ctrlruntimeclient.Object{
+				pod("apiserver-1", resources.ApiserverDeploymentName, "node-a"),
+				pod("scheduler-1", resources.SchedulerDeploymentName, "node-a"),
+				pod("dns-resolver-1", resources.DNSResolverDeploymentName, "node-b"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "SingleZone",
+		},
+		{
+			name: "seed nodes have no zones",
+			pods: []ctrlruntimeclient.Object{
+				pod("apiserver-1", resources.ApiserverDeploymentName, "node-c"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "NoZoneInformation",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(append(test.pods, nodes...)...).Build(),
+			}
+
+			status, reason, _, err := r.controlPlaneZoneSpreadCondition(context.Background(), cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status != test.expectedStatus {
+				t.Errorf("Expected status %q, got %q", test.expectedStatus, status)
+			}
+
+			if reason != test.expectedReason {
+				t.Errorf("Expected reason %q, got %q", test.expectedReason, reason)
+			}
+		})
+	}
+}
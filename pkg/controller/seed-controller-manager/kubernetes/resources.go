@@ -37,6 +37,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/cloudconfig"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudcontroller"
 	"k8c.io/kubermatic/v2/pkg/resources/controllermanager"
+	"k8c.io/kubermatic/v2/pkg/resources/controlplanegateway"
 	"k8c.io/kubermatic/v2/pkg/resources/csi"
 	"k8c.io/kubermatic/v2/pkg/resources/dns"
 	"k8c.io/kubermatic/v2/pkg/resources/etcd"
@@ -48,6 +49,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 	"k8c.io/kubermatic/v2/pkg/resources/openvpn"
 	"k8c.io/kubermatic/v2/pkg/resources/operatingsystemmanager"
+	"k8c.io/kubermatic/v2/pkg/resources/pricing"
 	kkpreconciling "k8c.io/kubermatic/v2/pkg/resources/reconciling"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling/modifier"
 	"k8c.io/kubermatic/v2/pkg/resources/scheduler"
@@ -60,6 +62,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -95,6 +98,12 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return nil, fmt.Errorf("failed to sync address: %w", err)
 	}
 
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		if err := r.ensureGatewayRoutes(ctx, cluster, data); err != nil {
+			return nil, err
+		}
+	}
+
 	// We should not proceed without having an IP address unless tunneling
 	// strategy is used. Its required for all Kubeconfigs & triggers errors
 	// otherwise.
@@ -344,9 +353,10 @@ func (r *Reconciler) ensureNamespaceExists(ctx context.Context, log *zap.Sugared
 func GetServiceReconcilers(data *resources.TemplateData) []reconciling.NamedServiceReconcilerFactory {
 	extName := data.Cluster().Status.Address.ExternalName
 	apiServerServiceType := data.DC().Spec.APIServerServiceType
+	privateCluster := data.Cluster().Spec.IsPrivateCluster()
 
 	creators := []reconciling.NamedServiceReconcilerFactory{
-		apiserver.ServiceReconciler(data.Cluster().Spec.ExposeStrategy, extName, apiServerServiceType),
+		apiserver.ServiceReconciler(data.Cluster().Spec.ExposeStrategy, extName, apiServerServiceType, privateCluster),
 		etcd.ServiceReconciler(data),
 		userclusterwebhook.ServiceReconciler(),
 		operatingsystemmanager.ServiceReconciler(),
@@ -357,7 +367,7 @@ func GetServiceReconcilers(data *resources.TemplateData) []reconciling.NamedServ
 	}
 
 	if data.IsKonnectivityEnabled() {
-		creators = append(creators, konnectivity.ServiceReconciler(data.Cluster().Spec.ExposeStrategy, extName))
+		creators = append(creators, konnectivity.ServiceReconciler(data.Cluster().Spec.ExposeStrategy, extName, privateCluster))
 	} else {
 		creators = append(creators,
 			openvpn.ServiceReconciler(data.Cluster().Spec.ExposeStrategy),
@@ -378,6 +388,33 @@ func (r *Reconciler) ensureServices(ctx context.Context, c *kubermaticv1.Cluster
 	return reconciling.ReconcileServices(ctx, creators, c.Status.NamespaceName, r)
 }
 
+// ensureGatewayRoutes attaches the control plane to the Gateway of the seed. The Gateway
+// itself and its per-cluster listeners are managed by the control-plane-gateway controller.
+func (r *Reconciler) ensureGatewayRoutes(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
+	seed := data.Seed()
+	if seed.Spec.ControlPlaneGateway == nil {
+		return fmt.Errorf("expose strategy %q requires the seed to configure a control plane Gateway", kubermaticv1.ExposeStrategyGateway)
+	}
+
+	tcpRouteReconcilers := []kkpreconciling.NamedTCPRouteReconcilerFactory{
+		controlplanegateway.APIServerTCPRouteReconciler(c, seed),
+	}
+	if err := kkpreconciling.ReconcileTCPRoutes(ctx, tcpRouteReconcilers, c.Status.NamespaceName, r); err != nil {
+		return fmt.Errorf("failed to ensure TCPRoutes: %w", err)
+	}
+
+	if data.IsKonnectivityEnabled() {
+		tlsRouteReconcilers := []kkpreconciling.NamedTLSRouteReconcilerFactory{
+			controlplanegateway.KonnectivityTLSRouteReconciler(c, seed),
+		}
+		if err := kkpreconciling.ReconcileTLSRoutes(ctx, tlsRouteReconcilers, c.Status.NamespaceName, r); err != nil {
+			return fmt.Errorf("failed to ensure TLSRoutes: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetDeploymentReconcilers returns all DeploymentReconcilers that are currently in use.
 func GetDeploymentReconcilers(data *resources.TemplateData, enableAPIserverOIDCAuthentication bool, versions kubermatic.Versions) []reconciling.NamedDeploymentReconcilerFactory {
 	deployments := []reconciling.NamedDeploymentReconcilerFactory{
@@ -702,12 +739,24 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 			)
 		}
 
-		issuerURL := c.Spec.OIDC.IssuerURL
-		if issuerURL == "" && r.features.KubernetesOIDCAuthentication {
-			issuerURL = data.OIDCIssuerURL()
+		var issuerURLs []string
+		if apiserver.IsAuthenticationConfigurationEnabled(c) {
+			issuerURLs = apiserver.AuthenticationIssuerURLs(c.Spec.AuthenticationConfiguration)
+			if r.features.KubernetesOIDCAuthentication {
+				issuerURLs = append(issuerURLs, data.OIDCIssuerURL())
+			}
+		} else if c.Spec.OIDC.IssuerURL != "" {
+			issuerURLs = []string{c.Spec.OIDC.IssuerURL}
+		} else if r.features.KubernetesOIDCAuthentication {
+			issuerURLs = []string{data.OIDCIssuerURL()}
 		}
 
-		if issuerURL != "" {
+		var issuerIPs []net.IP
+		for _, issuerURL := range issuerURLs {
+			if issuerURL == "" {
+				continue
+			}
+
 			u, err := url.Parse(issuerURL)
 			if err != nil {
 				return fmt.Errorf("failed to parse OIDC issuer URL %q: %w", issuerURL, err)
@@ -718,7 +767,11 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 			if err != nil {
 				return fmt.Errorf("failed to resolve OIDC issuer URL %q: %w", issuerURL, err)
 			}
-			namedNetworkPolicyReconcilerFactories = append(namedNetworkPolicyReconcilerFactories, apiserver.OIDCIssuerAllowReconciler(ipList, cfg.Spec.Ingress.NamespaceOverride))
+			issuerIPs = append(issuerIPs, ipList...)
+		}
+
+		if len(issuerIPs) > 0 {
+			namedNetworkPolicyReconcilerFactories = append(namedNetworkPolicyReconcilerFactories, apiserver.OIDCIssuerAllowReconciler(issuerIPs, cfg.Spec.Ingress.NamespaceOverride))
 		}
 
 		apiIPs, err := r.fetchKubernetesServiceIPList(ctx, resolverCtx)
@@ -737,12 +790,15 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 }
 
 // GetConfigMapReconcilers returns all ConfigMapReconcilers that are currently in use.
-func GetConfigMapReconcilers(data *resources.TemplateData) []reconciling.NamedConfigMapReconcilerFactory {
+func GetConfigMapReconcilers(data *resources.TemplateData, enableAPIserverOIDCAuthentication bool) []reconciling.NamedConfigMapReconcilerFactory {
 	creators := []reconciling.NamedConfigMapReconcilerFactory{
 		apiserver.AuditConfigMapReconciler(data),
 		apiserver.AdmissionControlReconciler(data),
 		apiserver.CABundleReconciler(data),
 	}
+	if apiserver.IsAuthenticationConfigurationEnabled(data.Cluster()) {
+		creators = append(creators, apiserver.AuthenticationConfigurationReconciler(data, enableAPIserverOIDCAuthentication))
+	}
 	if !data.Cluster().Spec.DisableCSIDriver {
 		creators = append(creators, csi.ConfigMapsReconcilers(data)...)
 	}
@@ -760,12 +816,59 @@ func GetConfigMapReconcilers(data *resources.TemplateData) []reconciling.NamedCo
 }
 
 func (r *Reconciler) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
-	creators := GetConfigMapReconcilers(data)
+	creators := GetConfigMapReconcilers(data, r.features.KubernetesOIDCAuthentication)
 
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure that the ConfigMap exists: %w", err)
 	}
 
+	return r.ensurePricingCatalog(ctx, c, data)
+}
+
+// ensurePricingCatalog writes the effective pricing catalog of the cluster's datacenter
+// into the cluster namespace, where it is picked up to estimate the cluster's costs.
+func (r *Reconciler) ensurePricingCatalog(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
+	dc := data.DC()
+
+	if dc.Spec.Pricing == nil {
+		cm := &corev1.ConfigMap{}
+		key := types.NamespacedName{Namespace: c.Status.NamespaceName, Name: resources.PricingCatalogConfigMapName}
+
+		if err := r.Get(ctx, key, cm); err != nil {
+			return ctrlruntimeclient.IgnoreNotFound(err)
+		}
+
+		if err := r.Delete(ctx, cm); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete pricing catalog: %w", err)
+		}
+
+		return nil
+	}
+
+	providerName, err := kubermaticv1helper.ClusterCloudProviderName(c.Spec.Cloud)
+	if err != nil {
+		return fmt.Errorf("failed to determine cloud provider: %w", err)
+	}
+
+	var seedPrices *corev1.ConfigMap
+	if name := dc.Spec.Pricing.ConfigMapName; name != "" {
+		seedPrices = &corev1.ConfigMap{}
+		key := types.NamespacedName{Namespace: data.Seed().Namespace, Name: name}
+
+		if err := r.Get(ctx, key, seedPrices); err != nil {
+			return fmt.Errorf("failed to get pricing ConfigMap %q: %w", name, err)
+		}
+	}
+
+	catalog := pricing.EffectiveCatalog(dc, providerName, seedPrices)
+	creators := []reconciling.NamedConfigMapReconcilerFactory{
+		pricing.ConfigMapReconciler(catalog),
+	}
+
+	if err := reconciling.ReconcileConfigMaps(ctx, creators, c.Status.NamespaceName, r.Client); err != nil {
+		return fmt.Errorf("failed to ensure pricing catalog: %w", err)
+	}
+
 	return nil
 }
 
@@ -801,6 +904,13 @@ func GetPodDisruptionBudgetReconcilers(data *resources.TemplateData) []reconcili
 		creators = append(creators, nodeportproxy.PodDisruptionBudgetReconciler())
 	}
 
+	if data.Cluster().Spec.ControlPlaneTopology != nil {
+		creators = append(creators,
+			controllermanager.PodDisruptionBudgetReconciler(),
+			scheduler.PodDisruptionBudgetReconciler(),
+		)
+	}
+
 	return creators
 }
 
@@ -811,6 +921,24 @@ func (r *Reconciler) ensurePodDisruptionBudgets(ctx context.Context, c *kubermat
 		return fmt.Errorf("failed to ensure that the PodDisruptionBudget exists: %w", err)
 	}
 
+	// the controller-manager and scheduler are only protected by PDBs if the
+	// control plane is spread across zones
+	if c.Spec.ControlPlaneTopology == nil {
+		for _, name := range []string{resources.ControllerManagerPodDisruptionBudgetName, resources.SchedulerPodDisruptionBudgetName} {
+			pdb := &policyv1.PodDisruptionBudget{}
+			if err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: c.Status.NamespaceName}, pdb); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("failed to get PodDisruptionBudget %s: %w", name, err)
+			}
+
+			if err := r.Client.Delete(ctx, pdb); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete PodDisruptionBudget %s: %w", name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/clusterdeletion"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcileRetainedVolumesImport creates the PersistentVolumes retained from a deleted cluster
+// in this cluster, when requested via annotation. This requires a working API server, so the
+// import is delayed until the cluster is up.
+func (r *Reconciler) reconcileRetainedVolumesImport(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	sourceCluster, ok := cluster.Annotations[clusterdeletion.ImportRetainedVolumesAnnotation]
+	if !ok || cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return nil
+	}
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	userClusterClient, err := r.userClusterClientGetter(ctx, cluster)()
+	if err != nil {
+		return err
+	}
+
+	imported, err := clusterdeletion.ImportRetainedVolumes(ctx, r.Client, userClusterClient, seed.Namespace, cluster, sourceCluster)
+	if err != nil {
+		return err
+	}
+
+	log.Infow("Imported retained volumes", "source", sourceCluster, "volumes", imported)
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "RetainedVolumesImported", "Imported %d PersistentVolume(s) retained from cluster %s.", imported, sourceCluster)
+
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		delete(c.Annotations, clusterdeletion.ImportRetainedVolumesAnnotation)
+	})
+}
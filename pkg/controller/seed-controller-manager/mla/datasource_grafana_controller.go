@@ -32,7 +32,10 @@ import (
 	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/controlplanegateway"
+	kkpreconciling "k8c.io/kubermatic/v2/pkg/resources/reconciling"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
@@ -51,6 +54,7 @@ import (
 const (
 	PrometheusType   = "prometheus"
 	lokiType         = "loki"
+	tempoType        = "tempo"
 	alertmanagerType = "alertmanager"
 )
 
@@ -144,6 +148,7 @@ func (r *datasourceGrafanaReconciler) Reconcile(ctx context.Context, request rec
 type datasourceGrafanaController struct {
 	ctrlruntimeclient.Client
 	clientProvider grafanaClientProvider
+	seedGetter     provider.SeedGetter
 	mlaNamespace   string
 
 	log               *zap.SugaredLogger
@@ -153,6 +158,7 @@ type datasourceGrafanaController struct {
 func newDatasourceGrafanaController(
 	client ctrlruntimeclient.Client,
 	clientProvider grafanaClientProvider,
+	seedGetter provider.SeedGetter,
 	mlaNamespace string,
 
 	log *zap.SugaredLogger,
@@ -162,6 +168,7 @@ func newDatasourceGrafanaController(
 		Client:         client,
 		mlaNamespace:   mlaNamespace,
 		clientProvider: clientProvider,
+		seedGetter:     seedGetter,
 
 		log:               log,
 		overwriteRegistry: overwriteRegistry,
@@ -211,7 +218,7 @@ func (r *datasourceGrafanaController) reconcile(ctx context.Context, cluster *ku
 	// set header from the very beginning so all other calls will be within this organization
 	grafanaClient.SetOrgIDHeader(org.ID)
 
-	mlaDisabled := !cluster.Spec.MLA.LoggingEnabled && !cluster.Spec.MLA.MonitoringEnabled
+	mlaDisabled := !cluster.Spec.MLA.LoggingEnabled && !cluster.Spec.MLA.MonitoringEnabled && !cluster.Spec.MLA.TracingEnabled
 	if !cluster.DeletionTimestamp.IsZero() || mlaDisabled {
 		if err := r.handleDeletion(ctx, cluster, grafanaClient); err != nil {
 			return nil, fmt.Errorf("handling deletion: %w", err)
@@ -223,10 +230,16 @@ func (r *datasourceGrafanaController) reconcile(ctx context.Context, cluster *ku
 		return nil, fmt.Errorf("failed to add finalizer: %w", err)
 	}
 
+	seed, err := r.seedGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seed: %w", err)
+	}
+
 	data := resources.NewTemplateDataBuilder().
 		WithContext(ctx).
 		WithClient(r.Client).
 		WithCluster(cluster).
+		WithSeed(seed).
 		WithOverwriteRegistry(r.overwriteRegistry).
 		Build()
 
@@ -235,7 +248,10 @@ func (r *datasourceGrafanaController) reconcile(ctx context.Context, cluster *ku
 		return nil, fmt.Errorf("failed to get MLAAdminSetting: %w", err)
 	}
 
-	if err := r.ensureConfigMaps(ctx, cluster, settings); err != nil {
+	// traces can only be stored if the seed provides a tracing backend
+	tracingEnabled := cluster.Spec.MLA.TracingEnabled && seed.IsUserClusterTracingEnabled()
+
+	if err := r.ensureConfigMaps(ctx, cluster, settings, tracingEnabled); err != nil {
 		return nil, fmt.Errorf("failed to reconcile ConfigMaps in namespace %s: %w", cluster.Status.NamespaceName, err)
 	}
 	if err := r.ensureSecrets(ctx, cluster, data); err != nil {
@@ -248,7 +264,7 @@ func (r *datasourceGrafanaController) reconcile(ctx context.Context, cluster *ku
 	if err != nil {
 		return nil, err
 	}
-	if err := r.ensureServices(ctx, cluster); err != nil {
+	if err := r.ensureServices(ctx, cluster, data); err != nil {
 		return nil, fmt.Errorf("failed to reconcile Services in namespace %s: %w", "mla", err)
 	}
 
@@ -299,6 +315,26 @@ func (r *datasourceGrafanaController) reconcile(ctx context.Context, cluster *ku
 		return nil, fmt.Errorf("failed to ensure Grafana Prometheus Datasources: %w", err)
 	}
 
+	tempoDS := grafanasdk.Datasource{
+		OrgID:  org.ID,
+		UID:    getDatasourceUIDForCluster(tempoType, cluster),
+		Name:   getTempoDatasourceNameForCluster(cluster),
+		Type:   tempoType,
+		Access: "proxy",
+		URL:    fmt.Sprintf("http://mla-gateway.%s.svc.cluster.local/tempo", cluster.Status.NamespaceName),
+		JSONData: map[string]interface{}{
+			"tracesToLogsV2": map[string]interface{}{
+				"datasourceUid": getDatasourceUIDForCluster(lokiType, cluster),
+			},
+			"serviceMap": map[string]interface{}{
+				"datasourceUid": getDatasourceUIDForCluster(PrometheusType, cluster),
+			},
+		},
+	}
+	if err := r.reconcileDatasource(ctx, tracingEnabled, tempoDS, grafanaClient); err != nil {
+		return nil, fmt.Errorf("failed to ensure Grafana Tempo Datasources: %w", err)
+	}
+
 	return nil, nil
 }
 
@@ -367,9 +403,9 @@ func (r *datasourceGrafanaController) ensureDeployments(ctx context.Context, c *
 	return nil
 }
 
-func (r *datasourceGrafanaController) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, settings *kubermaticv1.MLAAdminSetting) error {
+func (r *datasourceGrafanaController) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, settings *kubermaticv1.MLAAdminSetting, tracingEnabled bool) error {
 	creators := []reconciling.NamedConfigMapReconcilerFactory{
-		GatewayConfigMapReconciler(c, r.mlaNamespace, settings),
+		GatewayConfigMapReconciler(c, r.mlaNamespace, settings, tracingEnabled),
 	}
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure that the ConfigMap exists: %w", err)
@@ -388,12 +424,25 @@ func (r *datasourceGrafanaController) ensureSecrets(ctx context.Context, c *kube
 	return nil
 }
 
-func (r *datasourceGrafanaController) ensureServices(ctx context.Context, c *kubermaticv1.Cluster) error {
+func (r *datasourceGrafanaController) ensureServices(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := []reconciling.NamedServiceReconcilerFactory{
 		GatewayInternalServiceReconciler(),
 		GatewayExternalServiceReconciler(c),
 	}
-	return reconciling.ReconcileServices(ctx, creators, c.Status.NamespaceName, r.Client)
+	if err := reconciling.ReconcileServices(ctx, creators, c.Status.NamespaceName, r.Client); err != nil {
+		return err
+	}
+
+	if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		routeReconcilers := []kkpreconciling.NamedTLSRouteReconcilerFactory{
+			controlplanegateway.TLSRouteReconciler(resources.MLAGatewayTLSRouteName, data.Seed(), resources.MLAGatewaySNIPrefix+c.Status.Address.ExternalName, gatewayExternalName, 80),
+		}
+		if err := kkpreconciling.ReconcileTLSRoutes(ctx, routeReconcilers, c.Status.NamespaceName, r.Client); err != nil {
+			return fmt.Errorf("failed to ensure TLSRoutes: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func (r *datasourceGrafanaController) CleanUp(ctx context.Context) error {
@@ -435,6 +484,10 @@ func (r *datasourceGrafanaController) handleDeletion(ctx context.Context, cluste
 			return fmt.Errorf("unable to delete datasource: %w (status: %s, message: %s)",
 				err, ptr.Deref(status.Status, "no status"), ptr.Deref(status.Message, "no message"))
 		}
+		if status, err := grafanaClient.DeleteDatasourceByUID(ctx, getDatasourceUIDForCluster(tempoType, cluster)); err != nil {
+			return fmt.Errorf("unable to delete datasource: %w (status: %s, message: %s)",
+				err, ptr.Deref(status.Status, "no status"), ptr.Deref(status.Message, "no message"))
+		}
 	}
 	if cluster.DeletionTimestamp.IsZero() && cluster.Status.NamespaceName != "" {
 		for _, resource := range ResourcesOnDeletion(cluster.Status.NamespaceName) {
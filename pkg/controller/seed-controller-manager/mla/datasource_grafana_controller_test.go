@@ -35,7 +35,9 @@ import (
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
+	"k8c.io/kubermatic/v2/pkg/test"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/test/generator"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -47,7 +49,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func newTestDatasourceGrafanaReconciler(t *testing.T, objects []ctrlruntimeclient.Object, handler http.Handler) (*datasourceGrafanaReconciler, *httptest.Server) {
+func newTestDatasourceGrafanaReconciler(t *testing.T, objects []ctrlruntimeclient.Object, handler http.Handler, tracingBackend bool) (*datasourceGrafanaReconciler, *httptest.Server) {
 	dynamicClient := fake.
 		NewClientBuilder().
 		WithObjects(objects...).
@@ -59,9 +61,15 @@ func newTestDatasourceGrafanaReconciler(t *testing.T, objects []ctrlruntimeclien
 		t.Fatalf("unable to initialize grafana client: %v", err)
 	}
 
+	seed := generator.GenTestSeed()
+	seed.Spec.MLA = &kubermaticv1.SeedMLASettings{
+		UserClusterMLAEnabled:     true,
+		UserClusterTracingEnabled: tracingBackend,
+	}
+
 	datasourceGrafanaController := newDatasourceGrafanaController(dynamicClient, func(ctx context.Context) (*grafanasdk.Client, error) {
 		return grafanaClient, nil
-	}, "mla", kubermaticlog.Logger, "")
+	}, test.NewSeedGetter(seed), "mla", kubermaticlog.Logger, "")
 	reconciler := datasourceGrafanaReconciler{
 		Client:                      dynamicClient,
 		log:                         kubermaticlog.Logger,
@@ -81,6 +89,7 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 		err                     bool
 		hasFinalizer            bool
 		hasResources            bool
+		tracingBackend          bool
 		expectExposeAnnotations map[string]string
 	}{
 		{
@@ -182,6 +191,15 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 					},
 					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource created", "id": 3}`)), StatusCode: http.StatusOK},
 				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
 			},
 		},
 		{
@@ -252,6 +270,15 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 					},
 					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
 				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
 			},
 		},
 		{
@@ -353,6 +380,15 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 					},
 					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource updated", "id": 3}`)), StatusCode: http.StatusOK},
 				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
 			},
 		},
 		{
@@ -443,6 +479,15 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 					},
 					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
 				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
 			},
 		},
 		{
@@ -538,6 +583,15 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 					},
 					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource created", "id": 2}`)), StatusCode: http.StatusOK},
 				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
 			},
 		},
 		{
@@ -632,6 +686,190 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 					},
 					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
 				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+			},
+		},
+		{
+			name:           "MLA Tracing enabled for cluster",
+			requestName:    "clusterUID",
+			hasFinalizer:   true,
+			hasResources:   true,
+			tracingBackend: true,
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "projectUID",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				&kubermaticv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "projectUID"},
+						Name:   "clusterUID",
+					},
+					Spec: kubermaticv1.ClusterSpec{
+						HumanReadableName: "Super Cluster",
+						MLA: &kubermaticv1.MLASettings{
+							TracingEnabled: true,
+						},
+						ExposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+					},
+					Status: kubermaticv1.ClusterStatus{
+						NamespaceName: "cluster-clusterUID",
+						Address: kubermaticv1.ClusterAddress{
+							ExternalName: "abcd.test.kubermatic.io",
+						},
+					},
+				},
+			},
+			expectExposeAnnotations: map[string]string{
+				nodeportproxy.DefaultExposeAnnotationKey: nodeportproxy.NodePortType.String(),
+			},
+			requests: []request{
+				{
+					name:     "get org by id",
+					request:  httptest.NewRequest(http.MethodGet, "/api/orgs/1", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":1,"name":"projectName-projectUID","address":{"address1":"","address2":"","city":"","zipCode":"","state":"","country":""}}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete alertmanager datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/alertmanager-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete loki datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/loki-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete prometheus datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/prometheus-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "get datasource by uid",
+					request: &http.Request{
+						Method: http.MethodGet,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{StatusCode: http.StatusNotFound},
+				},
+				{
+					name: "create tempo datasource",
+					request: &http.Request{
+						Method: http.MethodPost,
+						URL:    &url.URL{Path: "/api/datasources"},
+						Body:   io.NopCloser(strings.NewReader(`{"name":"Tempo Super Cluster", "orgId":1,  "type":"tempo", "uid":"tempo-clusterUID", "url":"http://mla-gateway.cluster-clusterUID.svc.cluster.local/tempo", "access":"proxy", "id":0, "isDefault":false, "jsonData":null, "secureJsonData":null}`)),
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource created", "id": 4}`)), StatusCode: http.StatusOK},
+				},
+			},
+		},
+		{
+			name:         "MLA Tracing enabled for cluster without tracing backend in the seed",
+			requestName:  "clusterUID",
+			hasFinalizer: true,
+			hasResources: true,
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.Project{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "projectUID",
+						Annotations: map[string]string{GrafanaOrgAnnotationKey: "1"},
+					},
+					Spec: kubermaticv1.ProjectSpec{
+						Name: "projectName",
+					},
+				},
+				&kubermaticv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "projectUID"},
+						Name:   "clusterUID",
+					},
+					Spec: kubermaticv1.ClusterSpec{
+						HumanReadableName: "Super Cluster",
+						MLA: &kubermaticv1.MLASettings{
+							TracingEnabled: true,
+						},
+						ExposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+					},
+					Status: kubermaticv1.ClusterStatus{
+						NamespaceName: "cluster-clusterUID",
+						Address: kubermaticv1.ClusterAddress{
+							ExternalName: "abcd.test.kubermatic.io",
+						},
+					},
+				},
+			},
+			expectExposeAnnotations: map[string]string{
+				nodeportproxy.DefaultExposeAnnotationKey: nodeportproxy.NodePortType.String(),
+			},
+			requests: []request{
+				{
+					name:     "get org by id",
+					request:  httptest.NewRequest(http.MethodGet, "/api/orgs/1", nil),
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":1,"name":"projectName-projectUID","address":{"address1":"","address2":"","city":"","zipCode":"","state":"","country":""}}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete alertmanager datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/alertmanager-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete loki datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/loki-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete prometheus datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/prometheus-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
+				{
+					name: "delete tempo datasource",
+					request: &http.Request{
+						Method: http.MethodDelete,
+						URL:    &url.URL{Path: "/api/datasources/uid/tempo-clusterUID"},
+						Header: map[string][]string{"X-Grafana-Org-Id": {"1"}},
+					},
+					response: &http.Response{Body: io.NopCloser(strings.NewReader(`{"message": "datasource deleted"}`)), StatusCode: http.StatusOK},
+				},
 			},
 		},
 	}
@@ -641,7 +879,7 @@ func TestDatasourceGrafanaReconcile(t *testing.T) {
 			t.Parallel()
 			ctx := context.Background()
 			r, assertExpectation := buildTestServer(t, tc.requests...)
-			controller, server := newTestDatasourceGrafanaReconciler(t, tc.objects, r)
+			controller, server := newTestDatasourceGrafanaReconciler(t, tc.objects, r, tc.tracingBackend)
 			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.requestName}}
 			_, err := controller.Reconcile(ctx, request)
 			if err != nil && !tc.err {
@@ -28,6 +28,7 @@ import (
 	grafanasdk "github.com/kubermatic/grafanasdk"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
@@ -111,6 +112,7 @@ func Add(
 	numWorkers int,
 	workerName string,
 	versions kubermatic.Versions,
+	seedGetter provider.SeedGetter,
 	mlaNamespace string,
 	grafanaURL string,
 	grafanaHeader string,
@@ -131,7 +133,7 @@ func Add(
 
 	orgGrafanaController := newOrgGrafanaController(mgr.GetClient(), log, mlaNamespace, clientProvider)
 	alertmanagerController := newAlertmanagerController(mgr.GetClient(), log, httpClient, cortexAlertmanagerURL)
-	datasourceGrafanaController := newDatasourceGrafanaController(mgr.GetClient(), clientProvider, mlaNamespace, log, overwriteRegistry)
+	datasourceGrafanaController := newDatasourceGrafanaController(mgr.GetClient(), clientProvider, seedGetter, mlaNamespace, log, overwriteRegistry)
 	userGrafanaController := newUserGrafanaController(mgr.GetClient(), log, clientProvider, httpClient, grafanaURL, grafanaHeader)
 	ruleGroupController := newRuleGroupController(mgr.GetClient(), log, httpClient, cortexRulerURL, lokiRulerURL, mlaNamespace)
 	dashboardGrafanaController := newDashboardGrafanaController(mgr.GetClient(), log, mlaNamespace, clientProvider)
@@ -198,3 +200,7 @@ func getLokiDatasourceNameForCluster(cluster *kubermaticv1.Cluster) string {
 func getPrometheusDatasourceNameForCluster(cluster *kubermaticv1.Cluster) string {
 	return fmt.Sprintf("Prometheus %s", cluster.Spec.HumanReadableName)
 }
+
+func getTempoDatasourceNameForCluster(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("Tempo %s", cluster.Spec.HumanReadableName)
+}
@@ -75,6 +75,12 @@ http {
 {{ if .CortexReadLimit }}
   limit_req_zone $binary_remote_addr zone=cortex_read_limit:1m rate={{ .CortexReadLimit }}r/s;
 {{ end }}
+{{ if and .TracingEnabled .TempoWriteLimit }}
+  limit_req_zone $binary_remote_addr zone=tempo_write_limit:1m rate={{ .TempoWriteLimit }}r/s;
+{{ end }}
+{{ if and .TracingEnabled .TempoReadLimit }}
+  limit_req_zone $binary_remote_addr zone=tempo_read_limit:1m rate={{ .TempoReadLimit }}r/s;
+{{ end }}
 
   # write path - exposed to user clusters
   server {
@@ -99,6 +105,15 @@ http {
 	location = /api/v1/push {
 	  proxy_pass      http://cortex-distributor.{{ .Namespace }}.svc.cluster.local:8080$request_uri;
 	}
+{{ if .TracingEnabled }}
+	# Tempo (OTLP/HTTP)
+	location = /v1/traces {
+{{ if .TempoWriteLimit }}
+      limit_req zone=tempo_write_limit{{ if .TempoWriteLimitBurst }} burst={{ .TempoWriteLimitBurst }} nodelay{{ end }};
+{{ end }}
+	  proxy_pass       http://tempo-distributor.{{ .Namespace }}.svc.cluster.local:4318$request_uri;
+	}
+{{ end }}
   }
 
   # read path - cluster-local access only
@@ -147,6 +162,15 @@ http {
 	location = /api/prom/api/v1/rules {
 	  proxy_pass       http://cortex-ruler.{{ .Namespace }}.svc.cluster.local:8080/prometheus/api/v1/rules;
 	}
+{{ if .TracingEnabled }}
+	# Tempo
+	location /tempo/ {
+{{ if .TempoReadLimit }}
+      limit_req zone=tempo_read_limit{{ if .TempoReadLimitBurst }} burst={{ .TempoReadLimitBurst }} nodelay{{ end }};
+{{ end }}
+	  proxy_pass       http://tempo-query-frontend.{{ .Namespace }}.svc.cluster.local:3100/;
+	}
+{{ end }}
   }
 }
 `
@@ -179,6 +203,11 @@ type configTemplateData struct {
 	LokiWriteLimitBurst  int32
 	LokiReadLimit        int32
 	LokiReadLimitBurst   int32
+	TracingEnabled       bool
+	TempoWriteLimit      int32
+	TempoWriteLimitBurst int32
+	TempoReadLimit       int32
+	TempoReadLimitBurst  int32
 }
 
 func renderTemplate(tpl string, data interface{}) (string, error) {
@@ -195,18 +224,21 @@ func renderTemplate(tpl string, data interface{}) (string, error) {
 	return strings.TrimSpace(output.String()), nil
 }
 
-func GatewayConfigMapReconciler(c *kubermaticv1.Cluster, mlaNamespace string, s *kubermaticv1.MLAAdminSetting) reconciling.NamedConfigMapReconcilerFactory {
+// GatewayConfigMapReconciler returns the nginx configuration of the MLA gateway. The Tempo locations
+// are only configured if tracingEnabled is set, as the seed might not have a tracing backend.
+func GatewayConfigMapReconciler(c *kubermaticv1.Cluster, mlaNamespace string, s *kubermaticv1.MLAAdminSetting, tracingEnabled bool) reconciling.NamedConfigMapReconcilerFactory {
 	return func() (string, reconciling.ConfigMapReconciler) {
 		return gatewayName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Data == nil {
 				cm.Data = map[string]string{}
 			}
 			configData := configTemplateData{
-				Namespace:     mlaNamespace,
-				TenantID:      c.Name,
-				SSLCertFile:   fmt.Sprintf("%s/%s", certificatesVolumePath, resources.MLAGatewayCertSecretKey),
-				SSLKeyFile:    fmt.Sprintf("%s/%s", certificatesVolumePath, resources.MLAGatewayKeySecretKey),
-				SSLCACertFile: fmt.Sprintf("%s/%s", caCertificatesVolumePath, resources.MLAGatewayCACertKey),
+				Namespace:      mlaNamespace,
+				TenantID:       c.Name,
+				SSLCertFile:    fmt.Sprintf("%s/%s", certificatesVolumePath, resources.MLAGatewayCertSecretKey),
+				SSLKeyFile:     fmt.Sprintf("%s/%s", certificatesVolumePath, resources.MLAGatewayKeySecretKey),
+				SSLCACertFile:  fmt.Sprintf("%s/%s", caCertificatesVolumePath, resources.MLAGatewayCACertKey),
+				TracingEnabled: tracingEnabled,
 			}
 			if s != nil && s.Spec.MonitoringRateLimits != nil {
 				// NOTE: Cortex write path rate-limiting is implemented directly by Cortex configuration
@@ -219,6 +251,12 @@ func GatewayConfigMapReconciler(c *kubermaticv1.Cluster, mlaNamespace string, s
 				configData.LokiReadLimit = s.Spec.LoggingRateLimits.QueryRate
 				configData.LokiReadLimitBurst = s.Spec.LoggingRateLimits.QueryBurstSize
 			}
+			if s != nil && s.Spec.TracingRateLimits != nil {
+				configData.TempoWriteLimit = s.Spec.TracingRateLimits.IngestionRate
+				configData.TempoWriteLimitBurst = s.Spec.TracingRateLimits.IngestionBurstSize
+				configData.TempoReadLimit = s.Spec.TracingRateLimits.QueryRate
+				configData.TempoReadLimitBurst = s.Spec.TracingRateLimits.QueryBurstSize
+			}
 			config, err := renderTemplate(nginxConfig, configData)
 			if err != nil {
 				return nil, fmt.Errorf("failed to render Prometheus config: %w", err)
@@ -278,6 +316,12 @@ func GatewayExternalServiceReconciler(c *kubermaticv1.Cluster) reconciling.Named
 				s.Annotations[nodeportproxy.PortHostMappingAnnotationKey] =
 					fmt.Sprintf(`{%q: %q}`, extPortName, resources.MLAGatewaySNIPrefix+c.Status.Address.ExternalName)
 				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				// Exposes MLA GW via SNI by a TLSRoute on the control plane Gateway.
+				s.Spec.Type = corev1.ServiceTypeClusterIP
+				delete(s.Annotations, nodeportproxy.DefaultExposeAnnotationKey)
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				delete(s.Annotations, nodeportproxy.PortHostMappingAnnotationKey)
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", c.Spec.ExposeStrategy)
 			}
@@ -291,7 +335,7 @@ func GatewayExternalServiceReconciler(c *kubermaticv1.Cluster) reconciling.Named
 			s.Spec.Ports[0].Port = 80
 			s.Spec.Ports[0].TargetPort = intstr.FromString(extPortName)
 
-			if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+			if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 				s.Spec.Ports[0].NodePort = 0 // allows switching from other expose strategies
 			}
 
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mla
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGatewayConfigMapReconcilerTracing(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+	}
+	settings := &kubermaticv1.MLAAdminSetting{
+		Spec: kubermaticv1.MLAAdminSettingSpec{
+			TracingRateLimits: &kubermaticv1.TracingRateLimitSettings{
+				IngestionRate: 10,
+				QueryRate:     5,
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		tracingEnabled bool
+	}{
+		{
+			name:           "seed provides a tracing backend",
+			tracingEnabled: true,
+		},
+		{
+			name:           "seed provides no tracing backend",
+			tracingEnabled: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, reconciler := GatewayConfigMapReconciler(cluster, "mla", settings, test.tracingEnabled)()
+
+			cm, err := reconciler(&corev1.ConfigMap{})
+			if err != nil {
+				t.Fatalf("Failed to reconcile ConfigMap: %v", err)
+			}
+
+			config := cm.Data["nginx.conf"]
+			for _, expected := range []string{"tempo-distributor.mla", "tempo-query-frontend.mla", "zone=tempo_write_limit", "zone=tempo_read_limit"} {
+				if contains := strings.Contains(config, expected); contains != test.tracingEnabled {
+					t.Errorf("Expected config to contain %q: %t, but got %t", expected, test.tracingEnabled, contains)
+				}
+			}
+
+			if !strings.Contains(config, "loki-distributed-distributor.mla") {
+				t.Error("Expected config to always contain the Loki locations")
+			}
+		})
+	}
+}
@@ -0,0 +1,661 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedcloudresources
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-orphaned-cloud-resources-controller"
+
+	// DefaultScanInterval is used if the Seed does not configure an interval.
+	DefaultScanInterval = 6 * time.Hour
+
+	// DefaultDeletionGracePeriod is used if the Seed does not configure a grace period.
+	DefaultDeletionGracePeriod = 24 * time.Hour
+
+	// StateConfigMapName is the name of the ConfigMap in the seed namespace that
+	// persists the controller's state across restarts.
+	StateConfigMapName = "orphaned-cloud-resources"
+
+	stateConfigMapKey = "state.json"
+
+	// credentialsSecretPrefix is the name prefix of the Secrets in the seed
+	// namespace that hold copies of the credentials of remembered cloud accounts.
+	credentialsSecretPrefix = "orphaned-cloud-resources-"
+)
+
+// providerGetter returns the cloud provider for a datacenter.
+type providerGetter func(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log         *zap.SugaredLogger
+	recorder    record.EventRecorder
+	namespace   string
+	seedGetter  provider.SeedGetter
+	getProvider providerGetter
+	now         func() time.Time
+}
+
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	namespace string,
+	seedName string,
+	seedGetter provider.SeedGetter,
+	caBundle *x509.CertPool,
+) error {
+	reconciler := &Reconciler{
+		Client:     mgr.GetClient(),
+		log:        log.Named(ControllerName),
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		namespace:  namespace,
+		seedGetter: seedGetter,
+		getProvider: func(datacenter *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+			return cloud.Provider(datacenter, secretKeyGetter, caBundle)
+		},
+		now: time.Now,
+	}
+
+	// The scan is expensive, so it runs in a single worker and is triggered by
+	// the Seed and then requeued periodically. Clusters are only watched to
+	// record them as belonging to this seed, which does not trigger a scan.
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&kubermaticv1.Seed{}, builder.WithPredicates(
+			predicateutil.ByNamespace(namespace),
+			predicateutil.ByName(seedName),
+		)).
+		Watches(&kubermaticv1.Cluster{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc:  func(event.CreateEvent) bool { return true },
+			UpdateFunc:  func(event.UpdateEvent) bool { return false },
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		})).
+		Build(reconciler)
+
+	return err
+}
+
+// cloudAccount is a set of credentials for a datacenter, represented by the
+// CloudSpec of one of the clusters using it.
+type cloudAccount struct {
+	datacenter *kubermaticv1.Datacenter
+	provider   string
+	dcName     string
+	cloud      kubermaticv1.CloudSpec
+	// credentialsSecret is the name of the copy of the account's credentials in
+	// the seed namespace; it is empty for clusters with inline credentials.
+	credentialsSecret string
+}
+
+// orphan is a resource found in a cloud account that belongs to a cluster
+// which does not exist anymore.
+type orphan struct {
+	account  *cloudAccount
+	scanner  provider.OrphanScanner
+	resource provider.ClusterResource
+}
+
+func (o orphan) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", o.account.provider, o.account.dcName, o.resource.Type, o.resource.ID)
+}
+
+// scanState is persisted in a ConfigMap, so that it survives controller restarts.
+type scanState struct {
+	// Clusters are all clusters that have been recorded on this seed, keyed by
+	// name. Only resources of these clusters are ever considered orphaned, so
+	// that resources of other seeds or tools in the same cloud accounts, which
+	// happen to use similar tags, are never touched.
+	Clusters map[string]clusterRecord `json:"clusters,omitempty"`
+	// Orphans are the orphaned resources found so far, keyed by orphan.key().
+	Orphans map[string]orphanRecord `json:"orphans,omitempty"`
+	// Accounts are the cloud accounts used by the recorded clusters, keyed by
+	// provider, datacenter and credentials checksum. They are remembered, so
+	// that the resources of deleted clusters can still be found and deleted
+	// once the last cluster using an account is gone.
+	Accounts map[string]accountRecord `json:"accounts,omitempty"`
+}
+
+type clusterRecord struct {
+	Provider   string `json:"provider"`
+	Datacenter string `json:"datacenter"`
+	// Account is the key of the cluster's cloud account, if it is remembered.
+	Account string `json:"account,omitempty"`
+}
+
+type accountRecord struct {
+	Provider   string `json:"provider"`
+	Datacenter string `json:"datacenter"`
+	// Cloud is the CloudSpec of one of the account's clusters.
+	Cloud kubermaticv1.CloudSpec `json:"cloud"`
+	// CredentialsSecret is the name of the copy of the account's credentials,
+	// which outlives the credentials Secrets of the clusters.
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+type orphanRecord struct {
+	// FirstSeen is when the resource was detected for the first time.
+	FirstSeen time.Time `json:"firstSeen"`
+	// DeletionFailed is set once the deletion failed and was reported, so that
+	// the failure is not reported again on every scan.
+	DeletionFailed bool `json:"deletionFailed,omitempty"`
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	seed, err := r.seedGetter()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	settings := seed.Spec.OrphanedCloudResources
+	if settings == nil || !settings.Enabled {
+		orphanedResources.Reset()
+		return reconcile.Result{}, nil
+	}
+
+	// cluster-scoped requests are new clusters that need to be recorded
+	if request.Namespace == "" {
+		return reconcile.Result{}, r.recordCluster(ctx, request.Name)
+	}
+
+	interval := DefaultScanInterval
+	if settings.Interval != nil && settings.Interval.Duration > 0 {
+		interval = settings.Interval.Duration
+	}
+
+	var gracePeriod *time.Duration
+	if settings.DeleteOrphanedResources {
+		gracePeriod = ptr.To(DefaultDeletionGracePeriod)
+		if settings.DeletionGracePeriod != nil {
+			gracePeriod = &settings.DeletionGracePeriod.Duration
+		}
+	}
+
+	if err := r.reconcile(ctx, seed, gracePeriod); err != nil {
+		r.recorder.Event(seed, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// recordCluster adds the given cluster to the set of clusters of this seed.
+func (r *Reconciler) recordCluster(ctx context.Context, name string) error {
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	state, err := r.loadState(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, recorded := state.Clusters[cluster.Name]; recorded {
+		return nil
+	}
+
+	state.Clusters[cluster.Name] = clusterRecord{
+		Provider:   cluster.Spec.Cloud.ProviderName,
+		Datacenter: cluster.Spec.Cloud.DatacenterName,
+	}
+
+	return r.saveState(ctx, state)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, seed *kubermaticv1.Seed, gracePeriod *time.Duration) error {
+	state, err := r.loadState(ctx)
+	if err != nil {
+		return err
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	// Clusters that are being deleted still exist and are cleaned up by the
+	// cloud controller.
+	existing := sets.New[string]()
+	for _, cluster := range clusters.Items {
+		existing.Insert(cluster.Name)
+
+		record := state.Clusters[cluster.Name]
+		record.Provider = cluster.Spec.Cloud.ProviderName
+		record.Datacenter = cluster.Spec.Cloud.DatacenterName
+		state.Clusters[cluster.Name] = record
+	}
+
+	accounts, err := r.cloudAccounts(ctx, seed, clusters.Items, state)
+	if err != nil {
+		return err
+	}
+
+	orphans, scanned := r.findOrphans(ctx, accounts, existing, state)
+
+	// update metrics and remember new orphans
+	now := r.now()
+	seen := sets.New[string]()
+	orphanedClusters := sets.New[string]()
+
+	orphanedResources.Reset()
+	for _, o := range orphans {
+		orphanedResources.WithLabelValues(o.account.provider, o.account.dcName, o.resource.Type).Inc()
+
+		key := o.key()
+		seen.Insert(key)
+		orphanedClusters.Insert(o.resource.Cluster)
+
+		if _, known := state.Orphans[key]; !known {
+			state.Orphans[key] = orphanRecord{FirstSeen: now}
+			r.recorder.Eventf(seed, corev1.EventTypeWarning, "OrphanedCloudResource",
+				"%s %s in datacenter %s belongs to cluster %s, which does not exist anymore", o.resource.Type, o.resource.ID, o.account.dcName, o.resource.Cluster)
+		}
+	}
+
+	// forget resources that disappeared in the meantime
+	for key := range state.Orphans {
+		if !seen.Has(key) {
+			delete(state.Orphans, key)
+		}
+	}
+
+	// forget deleted clusters without any resources left in the accounts that
+	// could be scanned for them
+	for name, record := range state.Clusters {
+		if !existing.Has(name) && !orphanedClusters.Has(name) && scanned.Has(record.Provider+"/"+record.Datacenter) {
+			delete(state.Clusters, name)
+		}
+	}
+
+	if gracePeriod != nil {
+		r.deleteOrphans(ctx, seed, state, orphans, now, *gracePeriod)
+	}
+
+	if err := r.forgetUnusedAccounts(ctx, state); err != nil {
+		return err
+	}
+
+	return r.saveState(ctx, state)
+}
+
+// forgetUnusedAccounts removes the accounts that are not used by any recorded
+// cluster anymore, together with the copies of their credentials.
+func (r *Reconciler) forgetUnusedAccounts(ctx context.Context, state *scanState) error {
+	used := sets.New[string]()
+	for _, record := range state.Clusters {
+		used.Insert(record.Account)
+	}
+
+	unused := sets.New[string]()
+	for key, account := range state.Accounts {
+		if !used.Has(key) {
+			unused.Insert(account.CredentialsSecret)
+			delete(state.Accounts, key)
+		}
+	}
+
+	// the same credentials can be used in multiple datacenters
+	for _, account := range state.Accounts {
+		unused.Delete(account.CredentialsSecret)
+	}
+
+	for _, name := range sets.List(unused) {
+		secret := &corev1.Secret{}
+		secret.Name = name
+		secret.Namespace = r.namespace
+
+		if err := r.Delete(ctx, secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete credentials copy %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteOrphans deletes all orphans whose grace period has passed. Orphans are
+// ordered per account as returned by the provider, which is the order in which
+// they can be deleted.
+func (r *Reconciler) deleteOrphans(ctx context.Context, seed *kubermaticv1.Seed, state *scanState, orphans []orphan, now time.Time, gracePeriod time.Duration) {
+	for _, o := range orphans {
+		key := o.key()
+		record := state.Orphans[key]
+		if now.Sub(record.FirstSeen) < gracePeriod {
+			continue
+		}
+
+		log := r.log.With("provider", o.account.provider, "datacenter", o.account.dcName, "type", o.resource.Type, "id", o.resource.ID, "cluster", o.resource.Cluster)
+		log.Info("Deleting orphaned cloud resource")
+
+		if err := o.scanner.DeleteClusterResource(ctx, o.account.cloud, o.resource); err != nil {
+			log.Errorw("Failed to delete orphaned cloud resource", zap.Error(err))
+
+			if !record.DeletionFailed {
+				record.DeletionFailed = true
+				state.Orphans[key] = record
+
+				r.recorder.Eventf(seed, corev1.EventTypeWarning, "OrphanedCloudResourceDeletionFailed",
+					"Failed to delete %s %s in datacenter %s: %v", o.resource.Type, o.resource.ID, o.account.dcName, err)
+			}
+
+			continue
+		}
+
+		deletedResources.WithLabelValues(o.account.provider, o.account.dcName, o.resource.Type).Inc()
+		delete(state.Orphans, key)
+
+		r.recorder.Eventf(seed, corev1.EventTypeNormal, "OrphanedCloudResourceDeleted",
+			"Deleted %s %s in datacenter %s, which belonged to cluster %s", o.resource.Type, o.resource.ID, o.account.dcName, o.resource.Cluster)
+	}
+}
+
+func (r *Reconciler) loadState(ctx context.Context) (*scanState, error) {
+	state := &scanState{}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: StateConfigMapName}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get state: %w", err)
+		}
+	} else if data := cm.Data[stateConfigMapKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), state); err != nil {
+			return nil, fmt.Errorf("failed to parse state: %w", err)
+		}
+	}
+
+	if state.Clusters == nil {
+		state.Clusters = map[string]clusterRecord{}
+	}
+
+	if state.Orphans == nil {
+		state.Orphans = map[string]orphanRecord{}
+	}
+
+	if state.Accounts == nil {
+		state.Accounts = map[string]accountRecord{}
+	}
+
+	return state, nil
+}
+
+func (r *Reconciler) saveState(ctx context.Context, state *scanState) error {
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	factories := []reconciling.NamedConfigMapReconcilerFactory{
+		func() (string, reconciling.ConfigMapReconciler) {
+			return StateConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+				cm.Data = map[string]string{stateConfigMapKey: string(encoded)}
+				return cm, nil
+			}
+		},
+	}
+
+	if err := reconciling.ReconcileConfigMaps(ctx, factories, r.namespace, r); err != nil {
+		return fmt.Errorf("failed to persist state: %w", err)
+	}
+
+	return nil
+}
+
+// cloudAccounts returns one account per distinct set of credentials and
+// datacenter used by the given clusters, plus the remembered accounts of
+// recorded clusters that do not exist anymore. Accounts with referenced
+// credentials are remembered in the state.
+func (r *Reconciler) cloudAccounts(ctx context.Context, seed *kubermaticv1.Seed, clusters []kubermaticv1.Cluster, state *scanState) ([]*cloudAccount, error) {
+	accounts := map[string]*cloudAccount{}
+
+	for _, cluster := range clusters {
+		datacenter, found := seed.Spec.Datacenters[cluster.Spec.Cloud.DatacenterName]
+		if !found {
+			continue
+		}
+
+		secret, credentials, err := r.credentials(ctx, &cluster)
+		if err != nil {
+			r.log.Debugw("Skipping cluster with unreadable credentials", "cluster", cluster.Name, zap.Error(err))
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", cluster.Spec.Cloud.ProviderName, cluster.Spec.Cloud.DatacenterName, credentials)
+		if _, exists := accounts[key]; !exists {
+			account := &cloudAccount{
+				datacenter: datacenter.DeepCopy(),
+				provider:   cluster.Spec.Cloud.ProviderName,
+				dcName:     cluster.Spec.Cloud.DatacenterName,
+				cloud:      *cluster.Spec.Cloud.DeepCopy(),
+			}
+
+			// inline credentials are never copied into the state
+			if secret != nil {
+				account.credentialsSecret = credentialsSecretPrefix + credentials[:16]
+				if err := r.copyCredentials(ctx, secret, account.credentialsSecret); err != nil {
+					return nil, err
+				}
+
+				state.Accounts[key] = accountRecord{
+					Provider:          account.provider,
+					Datacenter:        account.dcName,
+					Cloud:             account.cloud,
+					CredentialsSecret: account.credentialsSecret,
+				}
+			}
+
+			accounts[key] = account
+		}
+
+		if _, remembered := state.Accounts[key]; remembered {
+			record := state.Clusters[cluster.Name]
+			record.Account = key
+			state.Clusters[cluster.Name] = record
+		}
+	}
+
+	// keep scanning the accounts of deleted clusters until all of their
+	// resources are gone
+	for _, record := range state.Clusters {
+		account, remembered := state.Accounts[record.Account]
+		if _, exists := accounts[record.Account]; exists || !remembered {
+			continue
+		}
+
+		datacenter, found := seed.Spec.Datacenters[account.Datacenter]
+		if !found {
+			continue
+		}
+
+		accounts[record.Account] = &cloudAccount{
+			datacenter:        datacenter.DeepCopy(),
+			provider:          account.Provider,
+			dcName:            account.Datacenter,
+			cloud:             *account.Cloud.DeepCopy(),
+			credentialsSecret: account.CredentialsSecret,
+		}
+	}
+
+	keys := sets.List(sets.KeySet(accounts))
+	result := make([]*cloudAccount, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, accounts[key])
+	}
+
+	return result, nil
+}
+
+// credentials returns the Secret holding the credentials of a cluster and a
+// checksum identifying them, so that clusters sharing the same cloud account
+// are only scanned once. The Secret is nil for clusters with inline credentials.
+func (r *Reconciler) credentials(ctx context.Context, cluster *kubermaticv1.Cluster) (*corev1.Secret, string, error) {
+	ref, err := resources.GetCredentialsReference(cluster)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if ref == nil {
+		return nil, cluster.Name, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, "", err
+	}
+
+	hash := sha256.New()
+	for _, key := range sets.List(sets.KeySet(secret.Data)) {
+		hash.Write([]byte(key))
+		hash.Write(secret.Data[key])
+	}
+
+	return secret, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// copyCredentials copies the credentials of a cluster into the seed namespace,
+// where they remain after the cluster and its credentials have been deleted.
+func (r *Reconciler) copyCredentials(ctx context.Context, secret *corev1.Secret, name string) error {
+	factories := []reconciling.NamedSecretReconcilerFactory{
+		func() (string, reconciling.SecretReconciler) {
+			return name, func(s *corev1.Secret) (*corev1.Secret, error) {
+				s.Data = secret.Data
+				return s, nil
+			}
+		},
+	}
+
+	if err := reconciling.ReconcileSecrets(ctx, factories, r.namespace, r); err != nil {
+		return fmt.Errorf("failed to copy credentials: %w", err)
+	}
+
+	return nil
+}
+
+// secretKeyGetter returns the function the cloud provider of an account uses
+// to read its credentials. Remembered accounts read them from their copy, as
+// the referenced Secret might not exist anymore.
+func (r *Reconciler) secretKeyGetter(ctx context.Context, account *cloudAccount) provider.SecretKeySelectorValueFunc {
+	getter := provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)
+	if account.credentialsSecret == "" {
+		return getter
+	}
+
+	return func(_ *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
+		return getter(&providerconfig.GlobalSecretKeySelector{
+			ObjectReference: corev1.ObjectReference{
+				Namespace: r.namespace,
+				Name:      account.credentialsSecret,
+			},
+		}, key)
+	}
+}
+
+// findOrphans returns the resources of clusters that were recorded on this seed
+// but do not exist anymore. It also returns the provider/datacenter pairs for
+// which all accounts could be scanned successfully.
+func (r *Reconciler) findOrphans(ctx context.Context, accounts []*cloudAccount, existing sets.Set[string], state *scanState) ([]orphan, sets.Set[string]) {
+	var orphans []orphan
+	found := sets.New[string]()
+	scanned := sets.New[string]()
+	failed := sets.New[string]()
+
+	for _, account := range accounts {
+		log := r.log.With("provider", account.provider, "datacenter", account.dcName)
+		location := account.provider + "/" + account.dcName
+
+		prov, err := r.getProvider(account.datacenter, r.secretKeyGetter(ctx, account))
+		if err != nil {
+			log.Errorw("Failed to create cloud provider", zap.Error(err))
+			failed.Insert(location)
+			continue
+		}
+
+		scanner, ok := prov.(provider.OrphanScanner)
+		if !ok {
+			log.Debug("Provider does not support scanning for orphaned resources")
+			continue
+		}
+
+		clusterResources, err := scanner.ListClusterResources(ctx, account.cloud)
+		if err != nil {
+			log.Errorw("Failed to list cloud resources", zap.Error(err))
+			scanErrors.WithLabelValues(account.provider, account.dcName).Inc()
+			failed.Insert(location)
+			continue
+		}
+
+		scanned.Insert(location)
+
+		for _, resource := range clusterResources {
+			if existing.Has(resource.Cluster) {
+				continue
+			}
+
+			// resources of clusters that never existed on this seed belong to
+			// someone else
+			if _, recorded := state.Clusters[resource.Cluster]; !recorded {
+				continue
+			}
+
+			// the same resource can be visible through multiple accounts
+			o := orphan{
+				account:  account,
+				scanner:  scanner,
+				resource: resource,
+			}
+			if !found.Has(o.key()) {
+				found.Insert(o.key())
+				orphans = append(orphans, o)
+			}
+		}
+	}
+
+	return orphans, scanned.Difference(failed)
+}
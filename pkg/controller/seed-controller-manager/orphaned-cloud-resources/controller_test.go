@@ -0,0 +1,349 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedcloudresources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeScanner struct {
+	provider.CloudProvider
+
+	resources []provider.ClusterResource
+	scans     int
+	deleted   []string
+}
+
+func (s *fakeScanner) ListClusterResources(_ context.Context, _ kubermaticv1.CloudSpec) ([]provider.ClusterResource, error) {
+	s.scans++
+	return s.resources, nil
+}
+
+func (s *fakeScanner) DeleteClusterResource(_ context.Context, _ kubermaticv1.CloudSpec, resource provider.ClusterResource) error {
+	s.deleted = append(s.deleted, resource.ID)
+	return nil
+}
+
+func genCluster(name string) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				ProviderName:   string(kubermaticv1.AWSCloudProvider),
+				DatacenterName: "aws-eu-central-1",
+				AWS: &kubermaticv1.AWSCloudSpec{
+					CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+						ObjectReference: corev1.ObjectReference{
+							Name:      "credential-aws",
+							Namespace: "kubermatic",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func genSeed(deleteOrphans bool) *kubermaticv1.Seed {
+	return &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "europe",
+			Namespace: "kubermatic",
+		},
+		Spec: kubermaticv1.SeedSpec{
+			Datacenters: map[string]kubermaticv1.Datacenter{
+				"aws-eu-central-1": {
+					Spec: kubermaticv1.DatacenterSpec{
+						AWS: &kubermaticv1.DatacenterSpecAWS{},
+					},
+				},
+			},
+			OrphanedCloudResources: &kubermaticv1.OrphanedCloudResourcesSettings{
+				Enabled:                 true,
+				DeleteOrphanedResources: deleteOrphans,
+				DeletionGracePeriod:     &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+}
+
+func genState(t *testing.T, state scanState) *corev1.ConfigMap {
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to encode state: %v", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      StateConfigMapName,
+			Namespace: "kubermatic",
+		},
+		Data: map[string]string{stateConfigMapKey: string(encoded)},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name            string
+		deleteOrphans   bool
+		expectedDeleted []string
+	}{
+		{
+			name:          "orphans are only reported by default",
+			deleteOrphans: false,
+		},
+		{
+			name:            "orphans are deleted after the grace period if enabled",
+			deleteOrphans:   true,
+			expectedDeleted: []string{"sg-2", "vol-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			seed := genSeed(tc.deleteOrphans)
+
+			credentials := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "credential-aws",
+					Namespace: "kubermatic",
+				},
+				Data: map[string][]byte{
+					"accessKeyId": []byte("key"),
+				},
+			}
+
+			// only "gone" was recorded on this seed; "foreign" belongs to someone else
+			state := genState(t, scanState{
+				Clusters: map[string]clusterRecord{
+					"gone": {Provider: string(kubermaticv1.AWSCloudProvider), Datacenter: "aws-eu-central-1"},
+				},
+			})
+
+			client := fake.NewClientBuilder().
+				WithObjects(seed, credentials, state, genCluster("alive"), genCluster("also-alive")).
+				Build()
+
+			scanner := &fakeScanner{
+				resources: []provider.ClusterResource{
+					{Cluster: "alive", Type: "security-group", ID: "sg-1", Owned: true},
+					{Cluster: "gone", Type: "security-group", ID: "sg-2", Owned: true},
+					{Cluster: "gone", Type: "volume", ID: "vol-1", Owned: true},
+					{Cluster: "foreign", Type: "security-group", ID: "sg-3", Owned: true},
+				},
+			}
+
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			recorder := record.NewFakeRecorder(10)
+
+			newReconciler := func() *Reconciler {
+				return &Reconciler{
+					Client:     client,
+					log:        kubermaticlog.Logger,
+					recorder:   recorder,
+					namespace:  "kubermatic",
+					seedGetter: func() (*kubermaticv1.Seed, error) { return seed, nil },
+					getProvider: func(_ *kubermaticv1.Datacenter, _ provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+						return scanner, nil
+					},
+					now: func() time.Time { return now },
+				}
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: seed.Namespace, Name: seed.Name}}
+
+			result, err := newReconciler().Reconcile(context.Background(), request)
+			if err != nil {
+				t.Fatalf("Failed to reconcile: %v", err)
+			}
+
+			if result.RequeueAfter != DefaultScanInterval {
+				t.Errorf("Expected requeue after %v, got %v.", DefaultScanInterval, result.RequeueAfter)
+			}
+
+			// both clusters share the same credentials
+			if scanner.scans != 1 {
+				t.Errorf("Expected the cloud account to be scanned once, but it was scanned %d times.", scanner.scans)
+			}
+
+			if len(scanner.deleted) > 0 {
+				t.Errorf("Expected no resources to be deleted during the grace period, but got %v.", scanner.deleted)
+			}
+
+			if len(recorder.Events) != 2 {
+				t.Errorf("Expected 2 events for the orphaned resources, got %d.", len(recorder.Events))
+			}
+
+			// the first detection is persisted, so a restarted controller
+			// continues the grace period
+			now = now.Add(2 * time.Hour)
+
+			if _, err := newReconciler().Reconcile(context.Background(), request); err != nil {
+				t.Fatalf("Failed to reconcile: %v", err)
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedDeleted, scanner.deleted) {
+				t.Errorf("Deleted resources do not match:\n%v", diff.ObjectDiff(tc.expectedDeleted, scanner.deleted))
+			}
+
+			persisted, err := newReconciler().loadState(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to load state: %v", err)
+			}
+
+			if tc.deleteOrphans && len(persisted.Orphans) != 0 {
+				t.Errorf("Expected deleted resources to be forgotten, but got %v.", persisted.Orphans)
+			}
+
+			if !tc.deleteOrphans && len(persisted.Orphans) != 2 {
+				t.Errorf("Expected 2 orphans to be remembered, but got %v.", persisted.Orphans)
+			}
+
+			for _, name := range []string{"alive", "also-alive"} {
+				if _, ok := persisted.Clusters[name]; !ok {
+					t.Errorf("Expected cluster %q to be recorded, but it was not.", name)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileRemembersCloudAccounts(t *testing.T) {
+	seed := genSeed(true)
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credential-aws",
+			Namespace: "kubermatic",
+		},
+		Data: map[string][]byte{
+			"accessKeyId": []byte("key"),
+		},
+	}
+
+	cluster := genCluster("last")
+
+	client := fake.NewClientBuilder().
+		WithObjects(seed, credentials, cluster).
+		Build()
+
+	scanner := &fakeScanner{}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	reconciler := &Reconciler{
+		Client:     client,
+		log:        kubermaticlog.Logger,
+		recorder:   record.NewFakeRecorder(10),
+		namespace:  "kubermatic",
+		seedGetter: func() (*kubermaticv1.Seed, error) { return seed, nil },
+		getProvider: func(_ *kubermaticv1.Datacenter, _ provider.SecretKeySelectorValueFunc) (provider.CloudProvider, error) {
+			return scanner, nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: seed.Namespace, Name: seed.Name}}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	state, err := reconciler.loadState(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+
+	account, ok := state.Accounts[state.Clusters["last"].Account]
+	if !ok {
+		t.Fatalf("Expected the cloud account of the cluster to be remembered, but got %v.", state.Accounts)
+	}
+
+	// the last cluster of the account and its credentials are deleted
+	if err := client.Delete(ctx, cluster); err != nil {
+		t.Fatalf("Failed to delete cluster: %v", err)
+	}
+
+	if err := client.Delete(ctx, credentials); err != nil {
+		t.Fatalf("Failed to delete credentials: %v", err)
+	}
+
+	scanner.resources = []provider.ClusterResource{
+		{Cluster: "last", Type: "volume", ID: "vol-1", Owned: true},
+	}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if scanner.scans != 2 {
+		t.Fatalf("Expected the remembered cloud account to be scanned, but it was scanned %d times in total.", scanner.scans)
+	}
+
+	// the account is scanned with its copied credentials until the orphan
+	// has been deleted after the grace period
+	now = now.Add(2 * time.Hour)
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if !diff.SemanticallyEqual([]string{"vol-1"}, scanner.deleted) {
+		t.Errorf("Deleted resources do not match:\n%v", diff.ObjectDiff([]string{"vol-1"}, scanner.deleted))
+	}
+
+	// once nothing is left, the cluster and its account are forgotten
+	scanner.resources = nil
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	state, err = reconciler.loadState(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+
+	if len(state.Clusters) != 0 || len(state.Accounts) != 0 {
+		t.Errorf("Expected the cluster and its account to be forgotten, but got %v and %v.", state.Clusters, state.Accounts)
+	}
+
+	copied := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: account.CredentialsSecret}, copied); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the copied credentials to be deleted, but getting them returned %v.", err)
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package orphanedcloudresources contains a controller that periodically scans the cloud
+accounts used by the clusters of a seed for KKP-owned resources of clusters which do not
+exist anymore. Such resources are left behind when the cloud provider cleanup was skipped,
+for example because its finalizer was removed manually.
+
+Only clusters that were recorded on this seed are considered, so resources of other seeds
+or tools sharing the same cloud accounts are never touched. The recorded clusters and the
+time each orphaned resource was first detected are persisted in a ConfigMap in the seed
+namespace, so the deletion grace period survives controller restarts. The cloud accounts of
+the recorded clusters are remembered as well, together with a copy of their credentials in
+the seed namespace, so that an account is still scanned after its last cluster was deleted,
+until none of the deleted clusters' resources are left.
+
+Orphaned resources are reported as metrics and events on the Seed and are only deleted
+after the grace period if deletion is explicitly enabled.
+*/
+package orphanedcloudresources
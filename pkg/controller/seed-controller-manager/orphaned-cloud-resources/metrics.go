@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedcloudresources
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	orphanedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "orphaned_cloud_resources",
+		Name:      "resources",
+		Help:      "The number of cloud resources that belong to a cluster which does not exist anymore",
+	}, []string{"provider", "datacenter", "type"})
+
+	deletedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "orphaned_cloud_resources",
+		Name:      "deleted_total",
+		Help:      "The total number of orphaned cloud resources that have been deleted",
+	}, []string{"provider", "datacenter", "type"})
+
+	scanErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubermatic",
+		Subsystem: "orphaned_cloud_resources",
+		Name:      "scan_errors_total",
+		Help:      "The total number of failed scans of a cloud account",
+	}, []string{"provider", "datacenter"})
+)
+
+func MustRegisterMetrics(c prometheus.Registerer) {
+	c.MustRegister(orphanedResources)
+	c.MustRegister(deletedResources)
+	c.MustRegister(scanErrors)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retainedvolumescontroller
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/clusterdeletion"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-retained-volumes-controller"
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log *zap.SugaredLogger
+	now func() time.Time
+}
+
+// Add creates a new retained volumes controller.
+func Add(mgr manager.Manager, log *zap.SugaredLogger, namespace string) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		log: log.Named(ControllerName),
+		now: time.Now,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicateutil.ByNamespace(namespace),
+			predicateutil.ByLabelExists(clusterdeletion.RetainedVolumesLabel),
+		)).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("configmap", request.NamespacedName)
+	log.Debug("Reconciling")
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, request.NamespacedName, cm); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cm.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if remaining := clusterdeletion.RetainedVolumesExpiry(cm).Sub(r.now()); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	log.Infow("Removing expired record of retained volumes, the volumes have to be cleaned up manually", "cluster", cm.Labels[clusterdeletion.RetainedVolumesLabel])
+
+	return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(r.Delete(ctx, cm))
+}
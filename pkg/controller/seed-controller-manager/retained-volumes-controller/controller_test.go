@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retainedvolumescontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8c.io/kubermatic/v2/pkg/clusterdeletion"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcile(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		now             time.Time
+		expectedDeleted bool
+		expectedRequeue time.Duration
+	}{
+		{
+			name:            "record is kept until it expires",
+			now:             created.Add(24 * time.Hour),
+			expectedRequeue: clusterdeletion.RetainedVolumesTTL - 24*time.Hour,
+		},
+		{
+			name:            "expired record is removed",
+			now:             created.Add(clusterdeletion.RetainedVolumesTTL),
+			expectedDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "kubermatic",
+					Name:              clusterdeletion.RetainedVolumesConfigMapName("old"),
+					Labels:            map[string]string{clusterdeletion.RetainedVolumesLabel: "old"},
+					CreationTimestamp: metav1.NewTime(created),
+				},
+			}
+
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(cm).Build(),
+				log:    kubermaticlog.Logger,
+				now:    func() time.Time { return tc.now },
+			}
+
+			key := types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			if result.RequeueAfter != tc.expectedRequeue {
+				t.Errorf("Expected requeue after %v, got %v", tc.expectedRequeue, result.RequeueAfter)
+			}
+
+			err = r.Get(ctx, key, &corev1.ConfigMap{})
+			if apierrors.IsNotFound(err) != tc.expectedDeleted {
+				t.Errorf("Expected record to be deleted=%v, got err=%v", tc.expectedDeleted, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package retainedvolumescontroller contains a controller that removes the records
+of volumes retained from deleted clusters once they have not been imported into
+another cluster within clusterdeletion.RetainedVolumesTTL. The volumes themselves
+are left untouched at the cloud provider.
+*/
+package retainedvolumescontroller
@@ -0,0 +1,672 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootcarotationcontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "kkp-root-ca-rotation-controller"
+
+	// checkInterval is how often a cluster is checked while its root CA is being rotated.
+	checkInterval = 30 * time.Second
+
+	// secretRevisionLabelSuffix is the suffix of the pod template labels that contain the revisions
+	// of the Secrets mounted into the pods.
+	secretRevisionLabelSuffix = "-secret-revision"
+)
+
+// userClusterConnectionProvider offers functions to retrieve clients for the given user clusters.
+type userClusterConnectionProvider interface {
+	GetClient(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log                     *zap.SugaredLogger
+	userClusterConnProvider userClusterConnectionProvider
+	workerName              string
+	recorder                record.EventRecorder
+	versions                kubermatic.Versions
+}
+
+// Add creates a new root CA rotation controller.
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int, workerName string, userClusterConnProvider userClusterConnectionProvider, versions kubermatic.Versions) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		log:                     log.Named(ControllerName),
+		userClusterConnProvider: userClusterConnProvider,
+		workerName:              workerName,
+		recorder:                mgr.GetEventRecorderFor(ControllerName),
+		versions:                versions,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.Cluster{}, builder.WithPredicates(predicateutil.Factory(func(o ctrlruntimeclient.Object) bool {
+			return rotationRelevant(o.(*kubermaticv1.Cluster))
+		}))).
+		Build(reconciler)
+
+	return err
+}
+
+// rotationRelevant returns true if a rotation has been requested or is still in progress.
+func rotationRelevant(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Annotations[kubermaticv1.RootCARotationAnnotation] != "" || cluster.Status.RootCARotation.InProgress()
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" || !rotationRelevant(cluster) {
+		return reconcile.Result{}, nil
+	}
+
+	// Add a wrapping here so we can emit an event on error
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionRootCARotationControllerReconcilingSuccess,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, log, cluster)
+		},
+	)
+
+	if result == nil || err != nil {
+		result = &reconcile.Result{}
+	}
+
+	if err != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	rotation := cluster.Status.RootCARotation
+	requestedID := cluster.Annotations[kubermaticv1.RootCARotationAnnotation]
+
+	if !rotation.InProgress() {
+		if requestedID == "" || (rotation != nil && rotation.ID == requestedID) {
+			return nil, nil
+		}
+
+		// a rotation is only started on a healthy control plane
+		settled, message, err := r.controlPlaneSettled(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if !settled {
+			log.Debugw("Waiting before starting root CA rotation", "reason", message)
+			return &reconcile.Result{RequeueAfter: checkInterval}, nil
+		}
+
+		log.Infow("Starting root CA rotation", "id", requestedID)
+
+		return r.transition(ctx, cluster, &kubermaticv1.ClusterRootCARotationStatus{
+			ID:        requestedID,
+			StartTime: metav1.Now(),
+		}, kubermaticv1.ClusterRootCARotationPhaseAddingNewCA)
+	}
+
+	if requestedID != rotation.ID {
+		log.Debugw("Ignoring root CA rotation request while another rotation is in progress", "id", requestedID)
+	}
+
+	log = log.With("id", rotation.ID, "phase", rotation.Phase)
+
+	var (
+		nextPhase kubermaticv1.ClusterRootCARotationPhase
+		done      bool
+		message   string
+		err       error
+	)
+
+	switch rotation.Phase {
+	case kubermaticv1.ClusterRootCARotationPhaseAddingNewCA:
+		nextPhase = kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes
+		if err := r.addNewCA(ctx, cluster); err != nil {
+			return nil, fmt.Errorf("failed to add the new CA: %w", err)
+		}
+		done, message, err = r.controlPlaneSettled(ctx, cluster)
+
+	case kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes:
+		nextPhase = kubermaticv1.ClusterRootCARotationPhaseReissuingCertificates
+		if err := r.addNewCA(ctx, cluster); err != nil {
+			return nil, fmt.Errorf("failed to add the new CA: %w", err)
+		}
+
+		// nodes are only replaced once the kube-controller-manager signs their certificates with the new CA
+		done, message, err = r.controlPlaneSettled(ctx, cluster)
+		if done && err == nil {
+			done, message, err = r.refreshNodes(ctx, log, cluster)
+		}
+
+	case kubermaticv1.ClusterRootCARotationPhaseReissuingCertificates:
+		nextPhase = kubermaticv1.ClusterRootCARotationPhaseRemovingOldCA
+		if err := r.swapCA(ctx, cluster); err != nil {
+			return nil, fmt.Errorf("failed to make the new CA the signing CA: %w", err)
+		}
+
+		done, message, err = r.certificatesReissued(ctx, cluster)
+		if done && err == nil {
+			done, message, err = r.controlPlaneSettled(ctx, cluster)
+		}
+
+	case kubermaticv1.ClusterRootCARotationPhaseRemovingOldCA:
+		nextPhase = kubermaticv1.ClusterRootCARotationPhaseCompleted
+		if err := r.removeOldCA(ctx, cluster); err != nil {
+			return nil, fmt.Errorf("failed to remove the old CA: %w", err)
+		}
+		done, message, err = r.controlPlaneSettled(ctx, cluster)
+
+	default:
+		return nil, fmt.Errorf("unknown root CA rotation phase %q", rotation.Phase)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !done {
+		log.Debugw("Waiting before advancing root CA rotation", "reason", message)
+		return r.wait(ctx, cluster, message)
+	}
+
+	return r.transition(ctx, cluster, rotation.DeepCopy(), nextPhase)
+}
+
+// transition moves the rotation into the given phase.
+func (r *Reconciler) transition(ctx context.Context, cluster *kubermaticv1.Cluster, rotation *kubermaticv1.ClusterRootCARotationStatus, phase kubermaticv1.ClusterRootCARotationPhase) (*reconcile.Result, error) {
+	rotation.Phase = phase
+	rotation.LastTransitionTime = metav1.Now()
+	rotation.Message = ""
+
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.RootCARotation = rotation
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update root CA rotation status: %w", err)
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "RootCARotation", "Root CA rotation %s entered phase %s", rotation.ID, phase)
+
+	if phase == kubermaticv1.ClusterRootCARotationPhaseCompleted {
+		return nil, nil
+	}
+
+	return &reconcile.Result{RequeueAfter: checkInterval}, nil
+}
+
+// wait records what the rotation is waiting for.
+func (r *Reconciler) wait(ctx context.Context, cluster *kubermaticv1.Cluster, message string) (*reconcile.Result, error) {
+	if cluster.Status.RootCARotation.Message != message {
+		if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.RootCARotation.Message = message
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update root CA rotation status: %w", err)
+		}
+	}
+
+	return &reconcile.Result{RequeueAfter: checkInterval}, nil
+}
+
+// addNewCA creates the new CA, if it does not exist yet, and adds it to the trust bundle of the root CA.
+func (r *Reconciler) addNewCA(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	caSecret, err := r.getSecret(ctx, cluster, resources.CASecretName)
+	if err != nil {
+		return err
+	}
+
+	rotationSecret, err := r.getSecret(ctx, cluster, resources.RootCARotationSecretName)
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	if rotationSecret == nil {
+		newCA, err := triple.NewCA(fmt.Sprintf("root-ca-%d.%s", time.Now().Unix(), cluster.Status.Address.ExternalName))
+		if err != nil {
+			return fmt.Errorf("failed to create new CA: %w", err)
+		}
+
+		rotationSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            resources.RootCARotationSecretName,
+				Namespace:       cluster.Status.NamespaceName,
+				OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(cluster)},
+			},
+			Data: map[string][]byte{
+				resources.CACertSecretKey: triple.EncodeCertPEM(newCA.Cert),
+				resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(newCA.Key),
+			},
+		}
+
+		if err := r.Create(ctx, rotationSecret); err != nil {
+			return fmt.Errorf("failed to create Secret %s: %w", resources.RootCARotationSecretName, err)
+		}
+	}
+
+	bundle := slices.Concat(caSecret.Data[resources.CACertSecretKey], rotationSecret.Data[resources.CACertSecretKey])
+
+	return r.updateCASecret(ctx, caSecret, caSecret.Data[resources.CACertSecretKey], caSecret.Data[resources.CAKeySecretKey], bundle)
+}
+
+// swapCA makes the new CA the signing CA, while the old CA remains trusted.
+func (r *Reconciler) swapCA(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	caSecret, err := r.getSecret(ctx, cluster, resources.CASecretName)
+	if err != nil {
+		return err
+	}
+
+	rotationSecret, err := r.getSecret(ctx, cluster, resources.RootCARotationSecretName)
+	if err != nil {
+		return err
+	}
+
+	newCACert := rotationSecret.Data[resources.CACertSecretKey]
+	oldCACert := caSecret.Data[resources.CACertSecretKey]
+
+	// the CAs have already been swapped
+	if bytes.Equal(newCACert, oldCACert) {
+		return nil
+	}
+
+	return r.updateCASecret(ctx, caSecret, newCACert, rotationSecret.Data[resources.CAKeySecretKey], slices.Concat(newCACert, oldCACert))
+}
+
+// removeOldCA removes all but the signing CA from the trust bundle.
+func (r *Reconciler) removeOldCA(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	caSecret, err := r.getSecret(ctx, cluster, resources.CASecretName)
+	if err != nil {
+		return err
+	}
+
+	caCert := caSecret.Data[resources.CACertSecretKey]
+	if err := r.updateCASecret(ctx, caSecret, caCert, caSecret.Data[resources.CAKeySecretKey], caCert); err != nil {
+		return err
+	}
+
+	rotationSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.RootCARotationSecretName,
+			Namespace: cluster.Status.NamespaceName,
+		},
+	}
+
+	if err := r.Delete(ctx, rotationSecret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Secret %s: %w", resources.RootCARotationSecretName, err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateCASecret(ctx context.Context, caSecret *corev1.Secret, caCert, caKey, bundle []byte) error {
+	if bytes.Equal(caSecret.Data[resources.CACertSecretKey], caCert) &&
+		bytes.Equal(caSecret.Data[resources.CAKeySecretKey], caKey) &&
+		bytes.Equal(caSecret.Data[resources.CATrustBundleSecretKey], bundle) {
+		return nil
+	}
+
+	oldSecret := caSecret.DeepCopy()
+	caSecret.Data[resources.CACertSecretKey] = caCert
+	caSecret.Data[resources.CAKeySecretKey] = caKey
+	caSecret.Data[resources.CATrustBundleSecretKey] = bundle
+
+	if err := r.Patch(ctx, caSecret, ctrlruntimeclient.MergeFromWithOptions(oldSecret, ctrlruntimeclient.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to update Secret %s: %w", caSecret.Name, err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) getSecret(ctx context.Context, cluster *kubermaticv1.Cluster, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get Secret %s: %w", name, err)
+	}
+
+	return secret, nil
+}
+
+// refreshNodes rolls out all MachineDeployments, so that the nodes trust the new CA and receive kubelet
+// certificates signed by it. It returns true once all MachineDeployments have been rolled out and every
+// Node has been refreshed. Nodes that are not managed by a MachineDeployment (e.g. edge nodes) cannot be
+// refreshed by KKP; they have to be updated by the cluster admin, who confirms this by annotating them
+// with the rotation ID.
+func (r *Reconciler) refreshNodes(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (bool, string, error) {
+	rotationSecret, err := r.getSecret(ctx, cluster, resources.RootCARotationSecretName)
+	if err != nil {
+		return false, "", err
+	}
+
+	userClusterClient, err := r.userClusterConnProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	// new nodes bootstrap with the CA bundle from the cluster-info ConfigMap, so they
+	// must only be created once it contains the new CA
+	clusterInfo := &corev1.ConfigMap{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespacePublic, Name: resources.ClusterInfoConfigMapName}, clusterInfo); err != nil {
+		return false, "", fmt.Errorf("failed to get ConfigMap %s: %w", resources.ClusterInfoConfigMapName, err)
+	}
+
+	trusted, err := clusterInfoTrusts(clusterInfo, rotationSecret.Data[resources.CACertSecretKey])
+	if err != nil {
+		return false, "", err
+	}
+
+	if !trusted {
+		return false, fmt.Sprintf("Waiting for ConfigMap %s/%s to contain the new CA", metav1.NamespacePublic, resources.ClusterInfoConfigMapName), nil
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return false, "", fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	rotationID := cluster.Status.RootCARotation.ID
+
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.Spec.Template.Annotations[kubermaticv1.RootCARotationAnnotation] == rotationID {
+			continue
+		}
+
+		oldMD := md.DeepCopy()
+		if md.Spec.Template.Annotations == nil {
+			md.Spec.Template.Annotations = map[string]string{}
+		}
+		md.Spec.Template.Annotations[kubermaticv1.RootCARotationAnnotation] = rotationID
+
+		if err := userClusterClient.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+			return false, "", fmt.Errorf("failed to roll out MachineDeployment %s: %w", md.Name, err)
+		}
+
+		log.Infow("Rolling out MachineDeployment to refresh nodes", "machinedeployment", md.Name)
+	}
+
+	for _, md := range machineDeployments.Items {
+		if !machineDeploymentRolledOut(&md) {
+			return false, fmt.Sprintf("Waiting for MachineDeployment %s to be rolled out", md.Name), nil
+		}
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := userClusterClient.List(ctx, machines, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return false, "", fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	// Machines created by the rollout inherit the annotation from the MachineDeployment's template
+	refreshedNodes := sets.New[string]()
+	for _, machine := range machines.Items {
+		if machine.Annotations[kubermaticv1.RootCARotationAnnotation] == rotationID && machine.Status.NodeRef != nil {
+			refreshedNodes.Insert(machine.Status.NodeRef.Name)
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := userClusterClient.List(ctx, nodes); err != nil {
+		return false, "", fmt.Errorf("failed to list Nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		if !refreshedNodes.Has(node.Name) && node.Annotations[kubermaticv1.RootCARotationAnnotation] != rotationID {
+			return false, fmt.Sprintf("Waiting for Node %s to be refreshed; Nodes not managed by a MachineDeployment must be updated to trust the new CA and annotated with %s=%s", node.Name, kubermaticv1.RootCARotationAnnotation, rotationID), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+func clusterInfoTrusts(clusterInfo *corev1.ConfigMap, caCert []byte) (bool, error) {
+	kubeconfig, err := clientcmd.Load([]byte(clusterInfo.Data[resources.KubeconfigSecretKey]))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse kubeconfig in ConfigMap %s: %w", clusterInfo.Name, err)
+	}
+
+	for _, c := range kubeconfig.Clusters {
+		if bytes.Contains(c.CertificateAuthorityData, caCert) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func machineDeploymentRolledOut(md *clusterv1alpha1.MachineDeployment) bool {
+	replicas := ptr.Deref(md.Spec.Replicas, 1)
+
+	return md.Status.ObservedGeneration >= md.Generation &&
+		md.Status.Replicas == replicas &&
+		md.Status.UpdatedReplicas == replicas &&
+		md.Status.AvailableReplicas == replicas
+}
+
+// certificatesReissued returns true once no certificate in the cluster namespace is signed by a CA
+// that is only trusted because it is part of the trust bundle, i.e. by the old CA.
+func (r *Reconciler) certificatesReissued(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, string, error) {
+	caSecret, err := r.getSecret(ctx, cluster, resources.CASecretName)
+	if err != nil {
+		return false, "", err
+	}
+
+	bundle, err := certutil.ParseCertsPEM(caSecret.Data[resources.CATrustBundleSecretKey])
+	if err != nil {
+		return false, "", fmt.Errorf("failed to parse CA trust bundle: %w", err)
+	}
+
+	caCerts, err := certutil.ParseCertsPEM(caSecret.Data[resources.CACertSecretKey])
+	if err != nil {
+		return false, "", fmt.Errorf("failed to parse CA: %w", err)
+	}
+
+	oldCAs := slices.DeleteFunc(bundle, caCerts[0].Equal)
+
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, "", fmt.Errorf("failed to list Secrets: %w", err)
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+
+		for _, cert := range certificates.SecretLeafCertificates(secret) {
+			if signedByAny(cert.Cert, oldCAs) {
+				return false, fmt.Sprintf("Waiting for certificate %s in Secret %s to be re-issued", cert.CertKey, secret.Name), nil
+			}
+		}
+
+		clientCerts, err := kubeconfigClientCertificates(secret)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to parse kubeconfig in Secret %s: %w", secret.Name, err)
+		}
+
+		for _, cert := range clientCerts {
+			if signedByAny(cert, oldCAs) {
+				return false, fmt.Sprintf("Waiting for kubeconfig in Secret %s to be re-issued", secret.Name), nil
+			}
+		}
+	}
+
+	return true, "", nil
+}
+
+// kubeconfigClientCertificates returns the client certificates of the kubeconfig in the given Secret.
+func kubeconfigClientCertificates(secret *corev1.Secret) ([]*x509.Certificate, error) {
+	data, exists := secret.Data[resources.KubeconfigSecretKey]
+	if !exists {
+		return nil, nil
+	}
+
+	kubeconfig, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*x509.Certificate
+	for _, authInfo := range kubeconfig.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+
+		certs, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, certs[0])
+	}
+
+	return result, nil
+}
+
+func signedByAny(cert *x509.Certificate, cas []*x509.Certificate) bool {
+	return slices.ContainsFunc(cas, func(ca *x509.Certificate) bool {
+		return cert.CheckSignatureFrom(ca) == nil
+	})
+}
+
+// controlPlaneSettled returns true if the control plane is healthy and all Deployments and
+// StatefulSets in the cluster namespace have been rolled out with the current revisions of
+// the Secrets they mount. Otherwise, it returns what the control plane is waiting for.
+func (r *Reconciler) controlPlaneSettled(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, string, error) {
+	if !cluster.Status.ExtendedHealth.ControlPlaneHealthy() {
+		return false, "Waiting for the control plane to become healthy", nil
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, "", fmt.Errorf("failed to list Deployments: %w", err)
+	}
+
+	for _, d := range deployments.Items {
+		replicas := ptr.Deref(d.Spec.Replicas, 1)
+		if d.Status.ObservedGeneration < d.Generation || d.Status.UpdatedReplicas != replicas || d.Status.ReadyReplicas != replicas {
+			return false, fmt.Sprintf("Waiting for Deployment %s to be rolled out", d.Name), nil
+		}
+
+		current, err := r.secretRevisionsCurrent(ctx, cluster, d.Spec.Template.Labels)
+		if err != nil {
+			return false, "", err
+		}
+		if !current {
+			return false, fmt.Sprintf("Waiting for Deployment %s to be updated", d.Name), nil
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, "", fmt.Errorf("failed to list StatefulSets: %w", err)
+	}
+
+	for _, s := range statefulSets.Items {
+		replicas := ptr.Deref(s.Spec.Replicas, 1)
+		if s.Status.ObservedGeneration < s.Generation || s.Status.UpdatedReplicas != replicas || s.Status.ReadyReplicas != replicas {
+			return false, fmt.Sprintf("Waiting for StatefulSet %s to be rolled out", s.Name), nil
+		}
+
+		current, err := r.secretRevisionsCurrent(ctx, cluster, s.Spec.Template.Labels)
+		if err != nil {
+			return false, "", err
+		}
+		if !current {
+			return false, fmt.Sprintf("Waiting for StatefulSet %s to be updated", s.Name), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// secretRevisionsCurrent returns true if the Secret revisions recorded in the given pod template
+// labels match the current revisions of the Secrets, i.e. the cluster controller has already
+// updated the pod template after the Secrets have changed.
+func (r *Reconciler) secretRevisionsCurrent(ctx context.Context, cluster *kubermaticv1.Cluster, labels map[string]string) (bool, error) {
+	for key, revision := range labels {
+		name, found := strings.CutSuffix(key, secretRevisionLabelSuffix)
+		if !found {
+			continue
+		}
+
+		secret, err := r.getSecret(ctx, cluster, name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if secret.ResourceVersion != revision {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootcarotationcontroller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testNamespace  = "cluster-test"
+	testRotationID = "2024-01"
+)
+
+var healthy = kubermaticv1.ExtendedClusterHealth{
+	Etcd:       kubermaticv1.HealthStatusUp,
+	Controller: kubermaticv1.HealthStatusUp,
+	Apiserver:  kubermaticv1.HealthStatusUp,
+	Scheduler:  kubermaticv1.HealthStatusUp,
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(_ context.Context, _ *kubermaticv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
+
+type rotationTest struct {
+	t          *testing.T
+	ctx        context.Context
+	seedClient ctrlruntimeclient.Client
+	userClient ctrlruntimeclient.Client
+	reconciler *Reconciler
+}
+
+func TestRootCARotation(t *testing.T) {
+	oldCA, err := triple.NewCA("old-ca")
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	oldCAPEM := triple.EncodeCertPEM(oldCA.Cert)
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Annotations: map[string]string{kubermaticv1.RootCARotationAnnotation: testRotationID},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName:  testNamespace,
+			ExtendedHealth: healthy,
+		},
+	}
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName, Namespace: testNamespace},
+		Data: map[string][]byte{
+			resources.CACertSecretKey:        oldCAPEM,
+			resources.CAKeySecretKey:         triple.EncodePrivateKeyPEM(oldCA.Key),
+			resources.CATrustBundleSecretKey: oldCAPEM,
+		},
+	}
+
+	apiserver := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverDeploymentName, Namespace: testNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas: 1,
+			ReadyReplicas:   1,
+		},
+	}
+
+	machineDeployment := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: metav1.NamespaceSystem},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+		Status: clusterv1alpha1.MachineDeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			AvailableReplicas: 1,
+		},
+	}
+
+	// a Node created by the MachineDeployment rollout and an edge Node not managed by KKP
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "workers-abcde",
+			Namespace:   metav1.NamespaceSystem,
+			Annotations: map[string]string{kubermaticv1.RootCARotationAnnotation: testRotationID},
+		},
+		Status: clusterv1alpha1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "worker-1"},
+		},
+	}
+	workerNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}
+	edgeNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "edge-1"}}
+
+	userScheme := fake.NewScheme()
+	utilruntime.Must(clusterv1alpha1.AddToScheme(userScheme))
+
+	test := &rotationTest{
+		t:          t,
+		ctx:        context.Background(),
+		seedClient: fake.NewClientBuilder().WithObjects(cluster, caSecret, apiserver).Build(),
+		userClient: fake.NewClientBuilder().WithScheme(userScheme).WithObjects(clusterInfo(t, oldCAPEM), machineDeployment, machine, workerNode, edgeNode).Build(),
+	}
+	test.reconciler = &Reconciler{
+		Client:                  test.seedClient,
+		log:                     kubermaticlog.Logger,
+		userClusterConnProvider: &fakeClientProvider{client: test.userClient},
+		recorder:                record.NewFakeRecorder(100),
+	}
+
+	test.updateRevisions()
+
+	// the rotation is started
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseAddingNewCA, "")
+
+	// the new CA is added to the trust bundle, but the control plane has not picked it up yet
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseAddingNewCA, "Waiting for Deployment apiserver to be updated")
+
+	newCAPEM := test.secret(resources.RootCARotationSecretName).Data[resources.CACertSecretKey]
+	test.expectCASecret(oldCAPEM, append(bytes.Clone(oldCAPEM), newCAPEM...))
+
+	test.updateRevisions()
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes, "")
+
+	// nodes are not refreshed before they can bootstrap with the new CA
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes, "Waiting for ConfigMap kube-public/cluster-info to contain the new CA")
+
+	if err := test.userClient.Update(test.ctx, clusterInfo(t, append(bytes.Clone(oldCAPEM), newCAPEM...))); err != nil {
+		t.Fatalf("Failed to update cluster-info: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	test.get(test.userClient, ctrlruntimeclient.ObjectKeyFromObject(machineDeployment), md)
+	md.Status.UpdatedReplicas = 0
+	if err := test.userClient.Update(test.ctx, md); err != nil {
+		t.Fatalf("Failed to update MachineDeployment: %v", err)
+	}
+
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes, "Waiting for MachineDeployment workers to be rolled out")
+
+	test.get(test.userClient, ctrlruntimeclient.ObjectKeyFromObject(machineDeployment), md)
+	if md.Spec.Template.Annotations[kubermaticv1.RootCARotationAnnotation] != testRotationID {
+		t.Fatalf("Expected MachineDeployment to be rolled out, but its template annotations are %v", md.Spec.Template.Annotations)
+	}
+
+	md.Status.UpdatedReplicas = 1
+	if err := test.userClient.Update(test.ctx, md); err != nil {
+		t.Fatalf("Failed to update MachineDeployment: %v", err)
+	}
+
+	// Nodes not managed by a MachineDeployment must be confirmed by the admin
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes, "Waiting for Node edge-1 to be refreshed; Nodes not managed by a MachineDeployment must be updated to trust the new CA and annotated with kubermatic.k8c.io/rotate-root-ca="+testRotationID)
+
+	test.get(test.userClient, ctrlruntimeclient.ObjectKeyFromObject(edgeNode), edgeNode)
+	edgeNode.Annotations = map[string]string{kubermaticv1.RootCARotationAnnotation: testRotationID}
+	if err := test.userClient.Update(test.ctx, edgeNode); err != nil {
+		t.Fatalf("Failed to update Node: %v", err)
+	}
+
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseReissuingCertificates, "")
+
+	// the new CA becomes the signing CA, but a certificate signed by the old CA is left
+	leafSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverTLSSecretName, Namespace: testNamespace},
+		Data:       leafCertificateData(t, oldCA),
+	}
+	if err := test.seedClient.Create(test.ctx, leafSecret); err != nil {
+		t.Fatalf("Failed to create Secret: %v", err)
+	}
+
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseReissuingCertificates, "Waiting for certificate apiserver-tls.crt in Secret apiserver-tls to be re-issued")
+	test.expectCASecret(newCAPEM, append(bytes.Clone(newCAPEM), oldCAPEM...))
+
+	newCA, err := triple.ParseRSAKeyPair(newCAPEM, test.secret(resources.CASecretName).Data[resources.CAKeySecretKey])
+	if err != nil {
+		t.Fatalf("Failed to parse new CA: %v", err)
+	}
+
+	leafSecret.Data = leafCertificateData(t, newCA)
+	if err := test.seedClient.Update(test.ctx, leafSecret); err != nil {
+		t.Fatalf("Failed to update Secret: %v", err)
+	}
+
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseReissuingCertificates, "Waiting for Deployment apiserver to be updated")
+
+	test.updateRevisions()
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseRemovingOldCA, "")
+
+	// the old CA is removed
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseRemovingOldCA, "Waiting for Deployment apiserver to be updated")
+	test.expectCASecret(newCAPEM, newCAPEM)
+
+	err = test.seedClient.Get(test.ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.RootCARotationSecretName}, &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected Secret %s to be deleted, but got: %v", resources.RootCARotationSecretName, err)
+	}
+
+	test.updateRevisions()
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseCompleted, "")
+
+	// a completed rotation is not started again
+	test.reconcile(kubermaticv1.ClusterRootCARotationPhaseCompleted, "")
+	test.expectCASecret(newCAPEM, newCAPEM)
+}
+
+func TestRootCARotationWaitsForHealthyControlPlane(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Annotations: map[string]string{kubermaticv1.RootCARotationAnnotation: testRotationID},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: testNamespace,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Etcd:       kubermaticv1.HealthStatusDown,
+				Controller: kubermaticv1.HealthStatusUp,
+				Apiserver:  kubermaticv1.HealthStatusUp,
+				Scheduler:  kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithObjects(cluster).Build()
+	r := &Reconciler{
+		Client:   client,
+		log:      kubermaticlog.Logger,
+		recorder: record.NewFakeRecorder(10),
+	}
+
+	result, err := r.reconcile(context.Background(), r.log, cluster)
+	if err != nil {
+		t.Fatalf("Reconciling failed: %v", err)
+	}
+
+	if result == nil || result.RequeueAfter <= 0 {
+		t.Fatal("Expected reconciling to be requeued")
+	}
+
+	if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(cluster), cluster); err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	if cluster.Status.RootCARotation != nil {
+		t.Fatalf("Expected rotation not to be started, but got status %+v", cluster.Status.RootCARotation)
+	}
+}
+
+// reconcile runs the reconciler once and checks the resulting phase and message.
+func (rt *rotationTest) reconcile(expectedPhase kubermaticv1.ClusterRootCARotationPhase, expectedMessage string) {
+	rt.t.Helper()
+
+	cluster := &kubermaticv1.Cluster{}
+	rt.get(rt.seedClient, types.NamespacedName{Name: "test"}, cluster)
+
+	if _, err := rt.reconciler.reconcile(rt.ctx, rt.reconciler.log, cluster); err != nil {
+		rt.t.Fatalf("Reconciling failed: %v", err)
+	}
+
+	rt.get(rt.seedClient, types.NamespacedName{Name: "test"}, cluster)
+
+	status := cluster.Status.RootCARotation
+	if status == nil {
+		rt.t.Fatal("Expected rotation status to be set")
+	}
+
+	if status.ID != testRotationID {
+		rt.t.Errorf("Expected rotation ID %q, got %q", testRotationID, status.ID)
+	}
+
+	if status.Phase != expectedPhase {
+		rt.t.Fatalf("Expected phase %q, got %q (%s)", expectedPhase, status.Phase, status.Message)
+	}
+
+	if status.Message != expectedMessage {
+		rt.t.Fatalf("Expected message %q, got %q", expectedMessage, status.Message)
+	}
+}
+
+// updateRevisions simulates the cluster controller updating the pod templates
+// after the Secrets they mount have changed.
+func (rt *rotationTest) updateRevisions() {
+	rt.t.Helper()
+
+	deployment := &appsv1.Deployment{}
+	rt.get(rt.seedClient, types.NamespacedName{Namespace: testNamespace, Name: resources.ApiserverDeploymentName}, deployment)
+
+	deployment.Spec.Template.Labels = map[string]string{
+		resources.CASecretName + secretRevisionLabelSuffix: rt.secret(resources.CASecretName).ResourceVersion,
+	}
+
+	if err := rt.seedClient.Update(rt.ctx, deployment); err != nil {
+		rt.t.Fatalf("Failed to update Deployment: %v", err)
+	}
+}
+
+func (rt *rotationTest) expectCASecret(expectedCert, expectedBundle []byte) {
+	rt.t.Helper()
+
+	secret := rt.secret(resources.CASecretName)
+
+	if !bytes.Equal(secret.Data[resources.CACertSecretKey], expectedCert) {
+		rt.t.Error("CA Secret does not contain the expected signing CA")
+	}
+
+	if !bytes.Equal(secret.Data[resources.CATrustBundleSecretKey], expectedBundle) {
+		rt.t.Error("CA Secret does not contain the expected trust bundle")
+	}
+}
+
+func (rt *rotationTest) secret(name string) *corev1.Secret {
+	rt.t.Helper()
+
+	secret := &corev1.Secret{}
+	rt.get(rt.seedClient, types.NamespacedName{Namespace: testNamespace, Name: name}, secret)
+
+	return secret
+}
+
+func (rt *rotationTest) get(client ctrlruntimeclient.Client, key types.NamespacedName, obj ctrlruntimeclient.Object) {
+	rt.t.Helper()
+
+	if err := client.Get(rt.ctx, key, obj); err != nil {
+		rt.t.Fatalf("Failed to get %s: %v", key, err)
+	}
+}
+
+func clusterInfo(t *testing.T, caBundle []byte) *corev1.ConfigMap {
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"": {
+				Server:                   "https://example.com",
+				CertificateAuthorityData: caBundle,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode kubeconfig: %v", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ClusterInfoConfigMapName, Namespace: metav1.NamespacePublic},
+		Data: map[string]string{
+			resources.KubeconfigSecretKey: string(kubeconfig),
+		},
+	}
+}
+
+func leafCertificateData(t *testing.T, ca *triple.KeyPair) map[string][]byte {
+	kp, err := triple.NewClientKeyPair(ca, "apiserver", nil)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	return map[string][]byte{
+		resources.ApiserverTLSCertSecretKey: triple.EncodeCertPEM(kp.Cert),
+		resources.ApiserverTLSKeySecretKey:  triple.EncodePrivateKeyPEM(kp.Key),
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package rootcarotationcontroller contains a controller that rotates the root CA
+of a user cluster once the cluster is annotated with
+`kubermatic.k8c.io/rotate-root-ca=<id>`.
+
+The rotation goes through multiple phases, which are tracked in the cluster
+status. A phase is only left once its changes have been rolled out to all
+control plane components and the control plane is healthy again:
+
+  - AddingNewCA: a new CA is created and added to the trust bundle of the
+    root CA, so that the control plane, kubeconfigs and nodes trust both CAs.
+  - RefreshingNodes: the kube-controller-manager signs kubelet certificates
+    with the new CA and all MachineDeployments are rolled out, so that
+    operating-system-manager provisions nodes that trust both CAs. Nodes that
+    are not managed by a MachineDeployment (e.g. edge nodes) block the rotation
+    until the cluster admin has updated them and annotated them with
+    `kubermatic.k8c.io/rotate-root-ca=<id>`.
+  - ReissuingCertificates: the new CA becomes the signing CA and all
+    certificates and kubeconfigs signed by the old CA are re-issued.
+  - RemovingOldCA: the old CA is removed from the trust bundle.
+*/
+package rootcarotationcontroller
@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usersshkeycacontroller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/sshca"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ControllerName is the name of this controller.
+	ControllerName = "kkp-user-ssh-key-ca-controller"
+
+	// DefaultCertificateValidity is the lifetime of SSH certificates if the
+	// cluster does not configure one.
+	DefaultCertificateValidity = 8 * time.Hour
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	workerName string
+	recorder   record.EventRecorder
+	log        *zap.SugaredLogger
+	versions   kubermatic.Versions
+	now        func() time.Time
+}
+
+func Add(mgr manager.Manager, numWorkers int, workerName string, log *zap.SugaredLogger, versions kubermatic.Versions) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		workerName: workerName,
+		recorder:   mgr.GetEventRecorderFor(ControllerName),
+		log:        log.Named(ControllerName),
+		versions:   versions,
+		now:        time.Now,
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: numWorkers,
+		}).
+		For(&kubermaticv1.Cluster{}).
+		Watches(
+			&corev1.Secret{},
+			controllerutil.EnqueueClusterForNamespacedObject(mgr.GetClient()),
+			builder.WithPredicates(predicateutil.ByName(resources.UserSSHKeyCertificateRequestsSecretName, resources.UserSSHKeyCertificatesSecretName)),
+		).
+		Build(reconciler)
+
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cluster.DeletionTimestamp != nil || !certificateAuthorityEnabled(cluster) || cluster.Status.NamespaceName == "" {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionNone,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, cluster)
+		},
+	)
+
+	if result == nil || err != nil {
+		result = &reconcile.Result{}
+	}
+
+	if err != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	namespace := cluster.Status.NamespaceName
+
+	if err := reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretReconcilerFactory{sshca.SecretReconciler()}, namespace, r); err != nil {
+		return nil, fmt.Errorf("failed to reconcile SSH CA secret: %w", err)
+	}
+
+	caSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.UserSSHKeyCASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get SSH CA secret: %w", err)
+	}
+
+	ca, err := sshca.SignerFromSecret(caSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := map[string]sshca.CertificateRequest{}
+
+	requestsSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.UserSSHKeyCertificateRequestsSecretName}, requestsSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get certificate requests: %w", err)
+		}
+	} else if requests, err = sshca.RequestsFromSecret(requestsSecret); err != nil {
+		return nil, err
+	}
+
+	existing := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.UserSSHKeyCertificatesSecretName}, existing); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get certificates: %w", err)
+	}
+
+	now := r.now()
+	validity := certificateValidity(cluster)
+
+	data := map[string][]byte{
+		resources.UserSSHKeyCAEntrySecretKey: sshca.PublicKey(ca.PublicKey()),
+	}
+
+	var next time.Duration
+	for name, request := range requests {
+		// expired keys are not requested by the master anymore, but the
+		// request might not have been updated yet
+		if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+			continue
+		}
+
+		cert, renewAt, err := certificateFor(existing.Data[name], request, ca, fmt.Sprintf("%s/%s", cluster.Name, name), now, validity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue certificate for UserSSHKey %s: %w", name, err)
+		}
+
+		data[name] = []byte(cert)
+
+		if renewAt.IsZero() {
+			continue
+		}
+
+		if renewIn := renewAt.Sub(now); next == 0 || renewIn < next {
+			next = renewIn
+		}
+	}
+
+	if err := reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretReconcilerFactory{certificatesSecretReconciler(data)}, namespace, r); err != nil {
+		return nil, fmt.Errorf("failed to reconcile certificates secret: %w", err)
+	}
+
+	return &reconcile.Result{RequeueAfter: next}, nil
+}
+
+func certificateAuthorityEnabled(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.UserSSHKeyCertificateAuthority != nil && cluster.Spec.UserSSHKeyCertificateAuthority.Enabled
+}
+
+func certificateValidity(cluster *kubermaticv1.Cluster) time.Duration {
+	if settings := cluster.Spec.UserSSHKeyCertificateAuthority; settings != nil && settings.CertificateValidity != nil && settings.CertificateValidity.Duration > 0 {
+		return settings.CertificateValidity.Duration
+	}
+
+	return DefaultCertificateValidity
+}
+
+// certificateFor returns the existing certificate if it still matches the
+// request and is not yet due for renewal, or otherwise a newly issued one. It
+// also returns the point in time at which the certificate needs to be renewed,
+// or a zero time if the certificate already lasts until the key itself expires.
+func certificateFor(existing []byte, request sshca.CertificateRequest, ca ssh.Signer, keyID string, now time.Time, validity time.Duration) (string, time.Time, error) {
+	if cert, err := sshca.ParseCertificate(string(existing)); err == nil && sshca.CertifiesKey(cert, request.PublicKey) && bytes.Equal(cert.SignatureKey.Marshal(), ca.PublicKey().Marshal()) {
+		expiresAt := time.Unix(int64(cert.ValidBefore), 0)
+		if lastsUntilKeyExpiry(request, expiresAt) {
+			return string(existing), time.Time{}, nil
+		}
+
+		if renewAt := renewalTime(expiresAt, validity); now.Before(renewAt) {
+			return string(existing), renewAt, nil
+		}
+	}
+
+	// never issue certificates that outlive their key
+	if request.ExpiresAt != nil {
+		if remaining := request.ExpiresAt.Sub(now); remaining < validity {
+			validity = remaining
+		}
+	}
+
+	cert, expiresAt, err := sshca.IssueCertificate(ca, request.PublicKey, keyID, now, validity)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if lastsUntilKeyExpiry(request, expiresAt) {
+		return cert, time.Time{}, nil
+	}
+
+	return cert, renewalTime(expiresAt, validity), nil
+}
+
+// renewalTime returns the point in time after which a certificate expiring at
+// the given time should be replaced, which is once two thirds of its validity
+// have passed.
+func renewalTime(expiresAt time.Time, validity time.Duration) time.Time {
+	return expiresAt.Add(-validity / 3)
+}
+
+// lastsUntilKeyExpiry returns true if a certificate expiring at the given time
+// cannot be extended anymore because its key expires at the same time.
+func lastsUntilKeyExpiry(request sshca.CertificateRequest, certExpiresAt time.Time) bool {
+	return request.ExpiresAt != nil && certExpiresAt.Unix() >= request.ExpiresAt.Unix()
+}
+
+func certificatesSecretReconciler(data map[string][]byte) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.UserSSHKeyCertificatesSecretName, func(existing *corev1.Secret) (*corev1.Secret, error) {
+			existing.Data = data
+			existing.Type = corev1.SecretTypeOpaque
+
+			return existing, nil
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usersshkeycacontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/sshca"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAID/rGpPdKsleLgb0cCshBa/pjo2cZ7sSsepAtuKrxw8k test"
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	keyExpiry := now.Add(time.Hour)
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: kubermaticv1.ClusterSpec{
+			UserSSHKeyCertificateAuthority: &kubermaticv1.UserSSHKeyCertificateAuthoritySettings{
+				Enabled: true,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test-cluster",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithObjects(cluster).Build()
+	r := &Reconciler{
+		Client: client,
+		now:    func() time.Time { return now },
+	}
+
+	setRequests := func(requests map[string]sshca.CertificateRequest) {
+		t.Helper()
+
+		if err := reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretReconcilerFactory{sshca.RequestsSecretReconciler(requests)}, cluster.Status.NamespaceName, client); err != nil {
+			t.Fatalf("Failed to write certificate requests: %v", err)
+		}
+	}
+
+	getCertificates := func() map[string][]byte {
+		t.Helper()
+
+		secret := &corev1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.UserSSHKeyCertificatesSecretName}, secret); err != nil {
+			t.Fatalf("Failed to get certificates Secret: %v", err)
+		}
+
+		return secret.Data
+	}
+
+	setRequests(map[string]sshca.CertificateRequest{
+		"permanent-key": {PublicKey: publicKey},
+		"expiring-key":  {PublicKey: publicKey, ExpiresAt: &keyExpiry},
+	})
+
+	result, err := r.reconcile(ctx, cluster)
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	// the expiring key's certificate lasts until the key expires, so only the
+	// permanent key's certificate needs renewal
+	if expected := DefaultCertificateValidity * 2 / 3; result.RequeueAfter != expected {
+		t.Errorf("Expected requeue after %v, but got %v.", expected, result.RequeueAfter)
+	}
+
+	certificates := getCertificates()
+	if len(certificates) != 3 {
+		t.Fatalf("Expected the CA entry and two certificates, but got %v.", certificates)
+	}
+
+	cert, err := sshca.ParseCertificate(string(certificates["expiring-key"]))
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	if validBefore := int64(cert.ValidBefore); validBefore != keyExpiry.Unix() {
+		t.Errorf("Expected certificate to expire with its key at %d, but got %d.", keyExpiry.Unix(), validBefore)
+	}
+
+	// still valid certificates must not be reissued
+	if _, err := r.reconcile(ctx, cluster); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if renewed := getCertificates(); string(renewed["permanent-key"]) != string(certificates["permanent-key"]) {
+		t.Error("Expected certificate to not be renewed.")
+	}
+
+	// certificates are renewed once two thirds of their validity have passed
+	now = now.Add(DefaultCertificateValidity * 3 / 4)
+	if _, err := r.reconcile(ctx, cluster); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if renewed := getCertificates(); string(renewed["permanent-key"]) == string(certificates["permanent-key"]) {
+		t.Error("Expected certificate to be renewed.")
+	}
+
+	// keys that are not requested anymore must not keep their certificate
+	setRequests(map[string]sshca.CertificateRequest{})
+
+	if _, err := r.reconcile(ctx, cluster); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if certificates := getCertificates(); len(certificates) != 1 || certificates[resources.UserSSHKeyCAEntrySecretKey] == nil {
+		t.Fatalf("Expected only the CA entry to remain, but got %v.", certificates)
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package usersshkeycacontroller contains a controller that manages the SSH certificate
+authority of clusters with the UserSSHKey certificate authority mode enabled.
+
+The CA's private key never leaves the seed: the master's usersshkey-synchronizer
+only publishes the public keys of the UserSSHKeys whose owners have access to the
+cluster's project in a requests Secret in the cluster namespace. This controller
+signs them with the cluster's CA and stores the short-lived certificates, together
+with the CA's authorized_keys entry, in a second Secret, from where the master
+copies them into the UserSSHKey status. Certificates are renewed until their key is
+no longer requested, which happens as soon as the owner loses access to the project.
+*/
+package usersshkeycacontroller
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -67,6 +68,7 @@ const (
 type UserClusterMLA struct {
 	Logging                           bool
 	Monitoring                        bool
+	Tracing                           bool
 	MLAGatewayURL                     string
 	MonitoringAgentScrapeConfigPrefix string
 }
@@ -211,7 +213,7 @@ func Add(
 	var clusterObj ctrlruntimeclient.Object = &kubermaticv1.Cluster{}
 
 	// Watch cluster if user cluster MLA is enabled so that controller can get resource requirements for user cluster MLA components.
-	if r.userClusterMLA.Monitoring || r.userClusterMLA.Logging {
+	if r.userClusterMLA.Monitoring || r.userClusterMLA.Logging || r.userClusterMLA.Tracing {
 		clusterPredicate := predicate.Funcs{
 			// For Update event, only trigger reconciliation when Resource Requirements change.
 			UpdateFunc: func(event event.UpdateEvent) bool {
@@ -324,6 +326,10 @@ func (r *reconciler) caCert(ctx context.Context) (*triple.KeyPair, error) {
 	return resources.GetClusterRootCA(ctx, r.namespace, r.seedClient)
 }
 
+func (r *reconciler) rootCABundle(ctx context.Context) ([]*x509.Certificate, error) {
+	return resources.GetClusterRootCABundle(ctx, r.namespace, r.seedClient)
+}
+
 func (r *reconciler) openVPNCA(ctx context.Context) (*resources.ECDSAKeyPair, error) {
 	return resources.GetOpenVPNCA(ctx, r.namespace, r.seedClient)
 }
@@ -363,14 +369,14 @@ func (r *reconciler) cloudConfig(ctx context.Context, secretName string) ([]byte
 	return value, nil
 }
 
-func (r *reconciler) mlaReconcileData(ctx context.Context) (monitoring, logging *corev1.ResourceRequirements, monitoringReplicas *int32, err error) {
+func (r *reconciler) mlaReconcileData(ctx context.Context) (monitoring, logging, tracing *corev1.ResourceRequirements, monitoringReplicas *int32, err error) {
 	cluster := &kubermaticv1.Cluster{}
 	if err = r.seedClient.Get(ctx, types.NamespacedName{
 		Name: r.clusterName,
 	}, cluster); err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to get cluster: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get cluster: %w", err)
 	}
-	return cluster.Spec.MLA.MonitoringResources, cluster.Spec.MLA.LoggingResources, cluster.Spec.MLA.MonitoringReplicas, nil
+	return cluster.Spec.MLA.MonitoringResources, cluster.Spec.MLA.LoggingResources, cluster.Spec.MLA.TracingResources, cluster.Spec.MLA.MonitoringReplicas, nil
 }
 
 func (r *reconciler) setupNetworkingData(cluster *kubermaticv1.Cluster, data *reconcileData) (err error) {
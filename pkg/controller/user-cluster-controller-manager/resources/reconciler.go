@@ -19,6 +19,7 @@ package resources
 import (
 	"context"
 	"crypto/sha1"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"strings"
@@ -49,6 +50,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/mla"
 	mlaloggingagent "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/mla/logging-agent"
 	mlamonitoringagent "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/mla/monitoring-agent"
+	mlatracingagent "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/mla/tracing-agent"
 	nodelocaldns "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/node-local-dns"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/openvpn"
 	operatingsystemmanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/operating-system-manager"
@@ -80,6 +82,11 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 		return fmt.Errorf("failed to get caCert: %w", err)
 	}
 
+	rootCABundle, err := r.rootCABundle(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rootCABundle: %w", err)
+	}
+
 	userSSHKeys, err := r.userSSHKeys(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get userSSHKeys: %w", err)
@@ -92,6 +99,7 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 
 	data := reconcileData{
 		caCert:       caCert,
+		rootCABundle: rootCABundle,
 		userSSHKeys:  userSSHKeys,
 		ccmMigration: r.ccmMigration || r.ccmMigrationCompleted,
 		cluster:      cluster,
@@ -127,12 +135,12 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 		}
 	}
 
-	if r.userClusterMLA.Monitoring || r.userClusterMLA.Logging {
+	if r.userClusterMLA.Monitoring || r.userClusterMLA.Logging || r.userClusterMLA.Tracing {
 		data.mlaGatewayCACert, err = r.mlaGatewayCA(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get MLA Gateway CA cert: %w", err)
 		}
-		data.monitoringRequirements, data.loggingRequirements, data.monitoringReplicas, err = r.mlaReconcileData(ctx)
+		data.monitoringRequirements, data.loggingRequirements, data.tracingRequirements, data.monitoringReplicas, err = r.mlaReconcileData(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get MLA resource requirements: %w", err)
 		}
@@ -264,14 +272,19 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 			return err
 		}
 	}
+	if !r.userClusterMLA.Tracing {
+		if err := r.ensureTracingAgentIsRemoved(ctx); err != nil {
+			return err
+		}
+	}
 
-	if r.opaIntegration || r.userClusterMLA.Logging || r.userClusterMLA.Monitoring {
+	if r.opaIntegration || r.userClusterMLA.Logging || r.userClusterMLA.Monitoring || r.userClusterMLA.Tracing {
 		if err := r.healthCheck(ctx); err != nil {
 			return err
 		}
 	}
 
-	if !r.userClusterMLA.Logging && !r.userClusterMLA.Monitoring {
+	if !r.userClusterMLA.Logging && !r.userClusterMLA.Monitoring && !r.userClusterMLA.Tracing {
 		if err := r.ensureMLAIsRemoved(ctx); err != nil {
 			return err
 		}
@@ -305,9 +318,13 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 }
 
 func (r *reconciler) ensureAPIServices(ctx context.Context, data reconcileData) error {
-	caCert := triple.EncodeCertPEM(data.caCert.Cert)
+	var caBundle []byte
+	for _, caCert := range data.rootCABundle {
+		caBundle = append(caBundle, triple.EncodeCertPEM(caCert)...)
+	}
+
 	creators := []kkpreconciling.NamedAPIServiceReconcilerFactory{
-		metricsserver.APIServiceReconciler(caCert),
+		metricsserver.APIServiceReconciler(caBundle),
 	}
 
 	if err := kkpreconciling.ReconcileAPIServices(ctx, creators, metav1.NamespaceNone, r.Client); err != nil {
@@ -384,6 +401,11 @@ func (r *reconciler) reconcileServiceAccounts(ctx context.Context, data reconcil
 			mlamonitoringagent.ServiceAccountReconciler(),
 		)
 	}
+	if r.userClusterMLA.Tracing {
+		creators = append(creators,
+			mlatracingagent.ServiceAccountReconciler(),
+		)
+	}
 
 	if len(creators) != 0 {
 		if err := reconciling.ReconcileServiceAccounts(ctx, creators, resources.UserClusterMLANamespace, r.Client); err != nil {
@@ -564,6 +586,9 @@ func (r *reconciler) reconcileClusterRoles(ctx context.Context, data reconcileDa
 	if r.userClusterMLA.Monitoring {
 		creators = append(creators, mlamonitoringagent.ClusterRoleReconciler())
 	}
+	if r.userClusterMLA.Tracing {
+		creators = append(creators, mlatracingagent.ClusterRoleReconciler())
+	}
 
 	if err := reconciling.ReconcileClusterRoles(ctx, creators, "", r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile ClusterRoles: %w", err)
@@ -608,6 +633,10 @@ func (r *reconciler) reconcileClusterRoleBindings(ctx context.Context, data reco
 		creators = append(creators, mlamonitoringagent.ClusterRoleBindingReconciler())
 	}
 
+	if r.userClusterMLA.Tracing {
+		creators = append(creators, mlatracingagent.ClusterRoleBindingReconciler())
+	}
+
 	if r.isKonnectivityEnabled {
 		creators = append(creators, konnectivity.ClusterRoleBindingReconciler())
 	}
@@ -741,6 +770,15 @@ func (r *reconciler) reconcileServices(ctx context.Context, data reconcileData)
 		}
 	}
 
+	if r.userClusterMLA.Tracing {
+		creators := []reconciling.NamedServiceReconcilerFactory{
+			mlatracingagent.ServiceReconciler(),
+		}
+		if err := reconciling.ReconcileServices(ctx, creators, resources.UserClusterMLANamespace, r.Client); err != nil {
+			return fmt.Errorf("failed to reconcile Services in namespace %s: %w", resources.UserClusterMLANamespace, err)
+		}
+	}
+
 	return nil
 }
 
@@ -765,7 +803,7 @@ func (r *reconciler) reconcileEndpoints(ctx context.Context, data reconcileData)
 
 func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData) error {
 	creators := []reconciling.NamedConfigMapReconcilerFactory{
-		machinecontroller.ClusterInfoConfigMapReconciler(r.clusterURL.String(), data.caCert.Cert),
+		machinecontroller.ClusterInfoConfigMapReconciler(r.clusterURL.String(), data.rootCABundle),
 	}
 
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, metav1.NamespacePublic, r.Client); err != nil {
@@ -845,9 +883,27 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 			return fmt.Errorf("failed to reconcile ConfigMap in namespace %s: %w", resources.UserClusterMLANamespace, err)
 		}
 	}
+	if r.userClusterMLA.Tracing {
+		creators = []reconciling.NamedConfigMapReconcilerFactory{
+			mlatracingagent.ConfigMapReconciler(r.tracingAgentConfig()),
+		}
+		if err := reconciling.ReconcileConfigMaps(ctx, creators, resources.UserClusterMLANamespace, r.Client); err != nil {
+			return fmt.Errorf("failed to reconcile ConfigMap in namespace %s: %w", resources.UserClusterMLANamespace, err)
+		}
+	}
 	return nil
 }
 
+func (r *reconciler) tracingAgentConfig() mlatracingagent.Config {
+	return mlatracingagent.Config{
+		MLAGatewayURL: r.userClusterMLA.MLAGatewayURL + "/v1/traces",
+		TLSCertFile:   fmt.Sprintf("%s/%s", resources.MLATracingAgentClientCertMountPath, resources.MLATracingAgentClientCertSecretKey),
+		TLSKeyFile:    fmt.Sprintf("%s/%s", resources.MLATracingAgentClientCertMountPath, resources.MLATracingAgentClientKeySecretKey),
+		TLSCACertFile: fmt.Sprintf("%s/%s", resources.MLATracingAgentClientCertMountPath, resources.MLAGatewayCACertKey),
+		ClusterName:   r.clusterName,
+	}
+}
+
 func (r *reconciler) reconcileSecrets(ctx context.Context, data reconcileData) error {
 	creators := []reconciling.NamedSecretReconcilerFactory{}
 	if !r.isKonnectivityEnabled {
@@ -935,6 +991,14 @@ func (r *reconciler) reconcileSecrets(ctx context.Context, data reconcileData) e
 			return fmt.Errorf("failed to reconcile Secrets in namespace %s: %w", resources.UserClusterMLANamespace, err)
 		}
 	}
+	if r.userClusterMLA.Tracing {
+		creators = []reconciling.NamedSecretReconcilerFactory{
+			mlatracingagent.ClientCertificateReconciler(data.mlaGatewayCACert),
+		}
+		if err := reconciling.ReconcileSecrets(ctx, creators, resources.UserClusterMLANamespace, r.Client); err != nil {
+			return fmt.Errorf("failed to reconcile Secrets in namespace %s: %w", resources.UserClusterMLANamespace, err)
+		}
+	}
 
 	creators = []reconciling.NamedSecretReconcilerFactory{
 		cloudinitsettings.SecretReconciler(),
@@ -993,7 +1057,7 @@ func (r *reconciler) reconcileNamespaces(ctx context.Context, data reconcileData
 		creators = append(creators, gatekeeper.NamespaceReconciler)
 		creators = append(creators, gatekeeper.KubeSystemLabeler)
 	}
-	if r.userClusterMLA.Logging || r.userClusterMLA.Monitoring {
+	if r.userClusterMLA.Logging || r.userClusterMLA.Monitoring || r.userClusterMLA.Tracing {
 		creators = append(creators, mla.NamespaceReconciler)
 	}
 
@@ -1060,6 +1124,15 @@ func (r *reconciler) reconcileDeployments(ctx context.Context, data reconcileDat
 		}
 	}
 
+	if r.userClusterMLA.Tracing {
+		creators := []reconciling.NamedDeploymentReconcilerFactory{
+			mlatracingagent.DeploymentReconciler(r.tracingAgentConfig(), data.tracingRequirements, r.imageRewriter),
+		}
+		if err := reconciling.ReconcileDeployments(ctx, creators, resources.UserClusterMLANamespace, r.Client); err != nil {
+			return fmt.Errorf("failed to reconcile Deployments in namespace %s: %w", resources.UserClusterMLANamespace, err)
+		}
+	}
+
 	if r.isKonnectivityEnabled {
 		creators := []reconciling.NamedDeploymentReconcilerFactory{
 			konnectivity.DeploymentReconciler(data.clusterVersion, r.konnectivityServerHost, r.konnectivityServerPort, r.konnectivityKeepaliveTime, r.imageRewriter),
@@ -1122,6 +1195,7 @@ func (r *reconciler) reconcilePodDisruptionBudgets(ctx context.Context) error {
 
 type reconcileData struct {
 	caCert            *triple.KeyPair
+	rootCABundle      []*x509.Certificate
 	openVPNCACert     *resources.ECDSAKeyPair
 	mlaGatewayCACert  *resources.ECDSAKeyPair
 	userSSHKeys       map[string][]byte
@@ -1135,6 +1209,7 @@ type reconcileData struct {
 	ccmMigration                bool
 	monitoringRequirements      *corev1.ResourceRequirements
 	loggingRequirements         *corev1.ResourceRequirements
+	tracingRequirements         *corev1.ResourceRequirements
 	gatekeeperCtrlRequirements  *corev1.ResourceRequirements
 	gatekeeperAuditRequirements *corev1.ResourceRequirements
 	monitoringReplicas          *int32
@@ -1198,6 +1273,7 @@ func (r *reconciler) healthCheck(ctx context.Context) error {
 		auditGatekeeperHealth kubermaticv1.HealthStatus
 		monitoringHealth      kubermaticv1.HealthStatus
 		loggingHealth         kubermaticv1.HealthStatus
+		tracingHealth         kubermaticv1.HealthStatus
 	)
 
 	if r.opaIntegration {
@@ -1221,6 +1297,13 @@ func (r *reconciler) healthCheck(ctx context.Context) error {
 		}
 	}
 
+	if r.userClusterMLA.Tracing {
+		tracingHealth, err = r.getMLATracingHealth(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	return helper.UpdateClusterStatus(ctx, r.seedClient, cluster, func(c *kubermaticv1.Cluster) {
 		if r.opaIntegration {
 			c.Status.ExtendedHealth.GatekeeperController = &ctrlGatekeeperHealth
@@ -1234,6 +1317,10 @@ func (r *reconciler) healthCheck(ctx context.Context) error {
 		if r.userClusterMLA.Logging {
 			c.Status.ExtendedHealth.Logging = &loggingHealth
 		}
+
+		if r.userClusterMLA.Tracing {
+			c.Status.ExtendedHealth.Tracing = &tracingHealth
+		}
 	})
 }
 
@@ -1271,6 +1358,17 @@ func (r *reconciler) getMLAMonitoringHealth(ctx context.Context) (health kuberma
 	return health, nil
 }
 
+func (r *reconciler) getMLATracingHealth(ctx context.Context) (kubermaticv1.HealthStatus, error) {
+	tracingHealth, err := resources.HealthyDeployment(ctx,
+		r.Client,
+		types.NamespacedName{Namespace: resources.UserClusterMLANamespace, Name: resources.MLATracingAgentDeploymentName},
+		1)
+	if err != nil {
+		return kubermaticv1.HealthStatusDown, fmt.Errorf("failed to get dep health %s: %w", resources.MLATracingAgentDeploymentName, err)
+	}
+	return tracingHealth, nil
+}
+
 func (r *reconciler) getMLALoggingHealth(ctx context.Context) (kubermaticv1.HealthStatus, error) {
 	loggingHealth, err := resources.HealthyDaemonSet(ctx,
 		r.Client,
@@ -1285,7 +1383,7 @@ func (r *reconciler) getMLALoggingHealth(ctx context.Context) (kubermaticv1.Heal
 func (r *reconciler) ensureLoggingAgentIsRemoved(ctx context.Context) error {
 	for _, resource := range mlaloggingagent.ResourcesOnDeletion() {
 		err := r.Client.Delete(ctx, resource)
-		if errC := r.cleanUpMLAHealthStatus(ctx, true, false, err); errC != nil {
+		if errC := r.cleanUpMLAHealthStatus(ctx, true, false, false, err); errC != nil {
 			return fmt.Errorf("failed to update mla logging health status in cluster: %w", errC)
 		}
 		if err != nil && !apierrors.IsNotFound(err) {
@@ -1308,7 +1406,7 @@ func (r *reconciler) ensureLegacyPromtailIsRemoved(ctx context.Context) error {
 func (r *reconciler) ensureUserClusterMonitoringAgentIsRemoved(ctx context.Context) error {
 	for _, resource := range mlamonitoringagent.ResourcesOnDeletion() {
 		err := r.Client.Delete(ctx, resource)
-		if errC := r.cleanUpMLAHealthStatus(ctx, false, true, err); errC != nil {
+		if errC := r.cleanUpMLAHealthStatus(ctx, false, true, false, err); errC != nil {
 			return fmt.Errorf("failed to update mla monitoring health status in cluster: %w", errC)
 		}
 		if err != nil && !apierrors.IsNotFound(err) {
@@ -1318,6 +1416,19 @@ func (r *reconciler) ensureUserClusterMonitoringAgentIsRemoved(ctx context.Conte
 	return nil
 }
 
+func (r *reconciler) ensureTracingAgentIsRemoved(ctx context.Context) error {
+	for _, resource := range mlatracingagent.ResourcesOnDeletion() {
+		err := r.Client.Delete(ctx, resource)
+		if errC := r.cleanUpMLAHealthStatus(ctx, false, false, true, err); errC != nil {
+			return fmt.Errorf("failed to update mla tracing health status in cluster: %w", errC)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to ensure tracing agent is removed/not present: %w", err)
+		}
+	}
+	return nil
+}
+
 func (r *reconciler) ensureLegacyPrometheusIsRemoved(ctx context.Context) error {
 	for _, resource := range mlamonitoringagent.LegacyResourcesOnDeletion() {
 		err := r.Client.Delete(ctx, resource)
@@ -1331,7 +1442,7 @@ func (r *reconciler) ensureLegacyPrometheusIsRemoved(ctx context.Context) error
 func (r *reconciler) ensureMLAIsRemoved(ctx context.Context) error {
 	for _, resource := range mla.ResourcesOnDeletion() {
 		err := r.Client.Delete(ctx, resource)
-		if errC := r.cleanUpMLAHealthStatus(ctx, true, true, err); errC != nil {
+		if errC := r.cleanUpMLAHealthStatus(ctx, true, true, true, err); errC != nil {
 			return fmt.Errorf("failed to update mla health status in cluster: %w", errC)
 		}
 		if err != nil && !apierrors.IsNotFound(err) {
@@ -1437,7 +1548,7 @@ func (r *reconciler) cleanUpOPAHealthStatus(ctx context.Context, errC error) err
 	})
 }
 
-func (r *reconciler) cleanUpMLAHealthStatus(ctx context.Context, logging, monitoring bool, errC error) error {
+func (r *reconciler) cleanUpMLAHealthStatus(ctx context.Context, logging, monitoring, tracing bool, errC error) error {
 	cluster, err := r.getCluster(ctx)
 	if err != nil {
 		return fmt.Errorf("failed getting cluster for cluster health check: %w", err)
@@ -1460,5 +1571,12 @@ func (r *reconciler) cleanUpMLAHealthStatus(ctx context.Context, logging, monito
 				c.Status.ExtendedHealth.Monitoring = &down
 			}
 		}
+
+		if !r.userClusterMLA.Tracing && tracing {
+			c.Status.ExtendedHealth.Tracing = nil
+			if errC != nil && !apierrors.IsNotFound(errC) {
+				c.Status.ExtendedHealth.Tracing = &down
+			}
+		}
 	})
 }
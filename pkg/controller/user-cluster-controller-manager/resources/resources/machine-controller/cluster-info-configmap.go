@@ -29,8 +29,9 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
-// ClusterInfoConfigMapReconciler returns the func to create/update the ConfigMap.
-func ClusterInfoConfigMapReconciler(url string, caCert *x509.Certificate) reconciling.NamedConfigMapReconcilerFactory {
+// ClusterInfoConfigMapReconciler returns the func to create/update the ConfigMap. The kubeconfig in it
+// trusts all given CAs, so that nodes can bootstrap while the root CA of the cluster is being rotated.
+func ClusterInfoConfigMapReconciler(url string, caBundle []*x509.Certificate) reconciling.NamedConfigMapReconcilerFactory {
 	return func() (string, reconciling.ConfigMapReconciler) {
 		return resources.ClusterInfoConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Data == nil {
@@ -39,11 +40,16 @@ func ClusterInfoConfigMapReconciler(url string, caCert *x509.Certificate) reconc
 
 			cm.Labels = resources.BaseAppLabels(Name, nil)
 
+			var caData []byte
+			for _, caCert := range caBundle {
+				caData = append(caData, triple.EncodeCertPEM(caCert)...)
+			}
+
 			kubeconfig := clientcmdapi.Config{}
 			kubeconfig.Clusters = map[string]*clientcmdapi.Cluster{
 				"": {
 					Server:                   url,
-					CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+					CertificateAuthorityData: caData,
 				},
 			}
 
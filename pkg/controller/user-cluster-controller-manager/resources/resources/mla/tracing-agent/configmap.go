@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracingagent
+
+import (
+	"bytes"
+	"text/template"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type Config struct {
+	MLAGatewayURL string
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSCACertFile string
+	ClusterName   string
+}
+
+func renderConfig(config Config) (string, error) {
+	t, err := template.New("collector").Parse(configTemplate)
+	if err != nil {
+		return "", err
+	}
+	configBuf := bytes.Buffer{}
+	if err := t.Execute(&configBuf, config); err != nil {
+		return "", err
+	}
+	return configBuf.String(), nil
+}
+
+func ConfigMapReconciler(config Config) reconciling.NamedConfigMapReconcilerFactory {
+	return func() (string, reconciling.ConfigMapReconciler) {
+		return resources.MLATracingAgentConfigMapName, func(configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			if configMap.Data == nil {
+				configMap.Data = map[string]string{}
+			}
+			rendered, err := renderConfig(config)
+			if err != nil {
+				return nil, err
+			}
+			configMap.Data[configFileName] = rendered
+			configMap.Labels = resources.BaseAppLabels(appName, nil)
+			return configMap, nil
+		}
+	}
+}
+
+const (
+	configTemplate = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+
+processors:
+  memory_limiter:
+    check_interval: 1s
+    limit_percentage: 80
+    spike_limit_percentage: 25
+  k8sattributes:
+    auth_type: serviceAccount
+    passthrough: false
+    extract:
+      metadata:
+      - k8s.namespace.name
+      - k8s.pod.name
+      - k8s.deployment.name
+      - k8s.node.name
+  resource:
+    attributes:
+    - key: k8s.cluster.name
+      value: {{ .ClusterName }}
+      action: upsert
+  batch:
+    send_batch_size: 8192
+    timeout: 5s
+
+exporters:
+  otlphttp:
+    traces_endpoint: {{ .MLAGatewayURL }}
+    tls:
+      cert_file: {{ .TLSCertFile }}
+      key_file: {{ .TLSKeyFile }}
+      ca_file: {{ .TLSCACertFile }}
+
+extensions:
+  health_check:
+    endpoint: 0.0.0.0:13133
+
+service:
+  extensions:
+  - health_check
+  pipelines:
+    traces:
+      receivers:
+      - otlp
+      processors:
+      - memory_limiter
+      - k8sattributes
+      - resource
+      - batch
+      exporters:
+      - otlphttp
+`
+)
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracingagent
+
+import (
+	"crypto/sha1"
+	"fmt"
+
+	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	imageName     = "otel/opentelemetry-collector-contrib"
+	tag           = "0.109.0"
+	appName       = "mla-tracing-agent"
+	containerName = "otel-collector"
+
+	configVolumeName       = "config-volume"
+	configPath             = "/etc/otelcol"
+	configFileName         = "config.yaml"
+	certificatesVolumeName = "certificates"
+
+	configHashAnnotation = "mla.k8c.io/config-hash"
+
+	otlpGRPCPortName = "otlp-grpc"
+	otlpGRPCPort     = 4317
+	otlpHTTPPortName = "otlp-http"
+	otlpHTTPPort     = 4318
+	healthPort       = 13133
+)
+
+var (
+	controllerLabels = map[string]string{
+		common.NameLabel:      resources.MLATracingAgentDeploymentName,
+		common.InstanceLabel:  resources.MLATracingAgentDeploymentName,
+		common.ComponentLabel: resources.MLAComponentName,
+	}
+	defaultResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+		},
+	}
+)
+
+func DeploymentReconciler(config Config, overrides *corev1.ResourceRequirements, imageRewriter registry.ImageRewriter) reconciling.NamedDeploymentReconcilerFactory {
+	return func() (string, reconciling.DeploymentReconciler) {
+		return resources.MLATracingAgentDeploymentName, func(deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+			deployment.Labels = resources.BaseAppLabels(appName, map[string]string{})
+
+			deployment.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: controllerLabels,
+			}
+			deployment.Spec.Replicas = ptr.To[int32](2)
+
+			// the collector does not reload its configuration, so roll the pods whenever it changes
+			rendered, err := renderConfig(config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render collector config: %w", err)
+			}
+			configHash := sha1.New()
+			configHash.Write([]byte(rendered))
+
+			kubernetes.EnsureLabels(&deployment.Spec.Template, controllerLabels)
+			kubernetes.EnsureAnnotations(&deployment.Spec.Template, map[string]string{
+				configHashAnnotation: fmt.Sprintf("%x", configHash.Sum(nil)),
+			})
+
+			deployment.Spec.Template.Spec.ServiceAccountName = resources.MLATracingAgentServiceAccountName
+			deployment.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{
+				RunAsUser:    ptr.To[int64](65534),
+				RunAsGroup:   ptr.To[int64](65534),
+				FSGroup:      ptr.To[int64](65534),
+				RunAsNonRoot: ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			}
+			deployment.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:            containerName,
+					Image:           registry.Must(imageRewriter(fmt.Sprintf("%s/%s:%s", resources.RegistryDocker, imageName, tag))),
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args: []string{
+						fmt.Sprintf("--config=%s/%s", configPath, configFileName),
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          otlpGRPCPortName,
+							ContainerPort: otlpGRPCPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+						{
+							Name:          otlpHTTPPortName,
+							ContainerPort: otlpHTTPPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					Env: []corev1.EnvVar{
+						{
+							// used by the k8sattributes processor
+							Name: "KUBE_NODE_NAME",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{
+									FieldPath: "spec.nodeName",
+								},
+							},
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      configVolumeName,
+							MountPath: configPath,
+						},
+						{
+							Name:      certificatesVolumeName,
+							MountPath: resources.MLATracingAgentClientCertMountPath,
+						},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+						ReadOnlyRootFilesystem:   ptr.To(true),
+						AllowPrivilegeEscalation: ptr.To(false),
+					},
+					LivenessProbe: &corev1.Probe{
+						PeriodSeconds:       5,
+						TimeoutSeconds:      4,
+						FailureThreshold:    3,
+						InitialDelaySeconds: 15,
+						SuccessThreshold:    1,
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   "/",
+								Port:   intstr.FromInt(healthPort),
+								Scheme: corev1.URISchemeHTTP,
+							},
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						PeriodSeconds:       5,
+						TimeoutSeconds:      4,
+						FailureThreshold:    3,
+						InitialDelaySeconds: 5,
+						SuccessThreshold:    1,
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   "/",
+								Port:   intstr.FromInt(healthPort),
+								Scheme: corev1.URISchemeHTTP,
+							},
+						},
+					},
+				},
+			}
+
+			deployment.Spec.Template.Spec.Volumes = []corev1.Volume{
+				{
+					Name: configVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.MLATracingAgentConfigMapName,
+							},
+						},
+					},
+				},
+				{
+					Name: certificatesVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  resources.MLATracingAgentCertificatesSecretName,
+							DefaultMode: ptr.To[int32](0400),
+						},
+					},
+				},
+			}
+
+			defResourceRequirements := map[string]*corev1.ResourceRequirements{
+				containerName: defaultResourceRequirements.DeepCopy(),
+			}
+			if overrides == nil {
+				err = resources.SetResourceRequirements(deployment.Spec.Template.Spec.Containers, defResourceRequirements, nil, deployment.Annotations)
+			} else {
+				overridesRequirements := map[string]*corev1.ResourceRequirements{
+					containerName: overrides.DeepCopy(),
+				}
+				err = resources.SetResourceRequirements(deployment.Spec.Template.Spec.Containers, defResourceRequirements, overridesRequirements, deployment.Annotations)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to set resource requirements: %w", err)
+			}
+
+			return deployment, nil
+		}
+	}
+}
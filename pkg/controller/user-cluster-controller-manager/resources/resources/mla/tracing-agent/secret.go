@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracingagent
+
+import (
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/reconciler/pkg/reconciling"
+)
+
+func ClientCertificateReconciler(ca *resources.ECDSAKeyPair) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.MLATracingAgentCertificatesSecretName,
+			certificates.GetECDSAClientCertificateReconciler(
+				resources.MLATracingAgentCertificatesSecretName,
+				resources.MLATracingAgentCertificateCommonName,
+				[]string{},
+				resources.MLATracingAgentClientCertSecretKey,
+				resources.MLATracingAgentClientKeySecretKey,
+				func() (*resources.ECDSAKeyPair, error) { return ca, nil })
+	}
+}
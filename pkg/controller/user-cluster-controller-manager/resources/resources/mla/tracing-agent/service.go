@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracingagent
+
+import (
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ServiceReconciler exposes the OTLP receivers of the collector, so that workloads in the
+// user cluster can send their traces to mla-tracing-agent.mla-system.svc.
+func ServiceReconciler() reconciling.NamedServiceReconcilerFactory {
+	return func() (string, reconciling.ServiceReconciler) {
+		return resources.MLATracingAgentServiceName, func(s *corev1.Service) (*corev1.Service, error) {
+			s.Labels = resources.BaseAppLabels(appName, nil)
+			s.Spec.Type = corev1.ServiceTypeClusterIP
+			s.Spec.Selector = controllerLabels
+			s.Spec.Ports = []corev1.ServicePort{
+				{
+					Name:       otlpGRPCPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       otlpGRPCPort,
+					TargetPort: intstr.FromString(otlpGRPCPortName),
+				},
+				{
+					Name:       otlpHTTPPortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       otlpHTTPPort,
+					TargetPort: intstr.FromString(otlpHTTPPortName),
+				},
+			}
+
+			return s, nil
+		}
+	}
+}
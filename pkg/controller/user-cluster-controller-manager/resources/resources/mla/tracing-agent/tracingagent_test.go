@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracingagent
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+func testConfig() Config {
+	return Config{
+		MLAGatewayURL: "https://mla.example.com:30443/v1/traces",
+		TLSCertFile:   "/etc/ssl/mla/tls.crt",
+		TLSKeyFile:    "/etc/ssl/mla/tls.key",
+		TLSCACertFile: "/etc/ssl/mla/ca.crt",
+		ClusterName:   "test-cluster",
+	}
+}
+
+func noopRewriter(image string) (string, error) {
+	return image, nil
+}
+
+func TestRenderConfig(t *testing.T) {
+	rendered, err := renderConfig(testConfig())
+	if err != nil {
+		t.Fatalf("Failed to render config: %v", err)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(rendered), &config); err != nil {
+		t.Fatalf("Rendered config is not valid YAML: %v", err)
+	}
+
+	for _, expected := range []string{
+		"traces_endpoint: https://mla.example.com:30443/v1/traces",
+		"cert_file: /etc/ssl/mla/tls.crt",
+		"value: test-cluster",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("Expected rendered config to contain %q", expected)
+		}
+	}
+}
+
+func TestDeploymentReconciler(t *testing.T) {
+	reconcile := func(config Config, overrides *corev1.ResourceRequirements) *appsv1.Deployment {
+		_, reconciler := DeploymentReconciler(config, overrides, noopRewriter)()
+
+		deployment, err := reconciler(&appsv1.Deployment{})
+		if err != nil {
+			t.Fatalf("Failed to reconcile Deployment: %v", err)
+		}
+
+		return deployment
+	}
+
+	deployment := reconcile(testConfig(), nil)
+
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) != 1 {
+		t.Fatalf("Expected exactly one container, got %d", len(containers))
+	}
+	if memory := containers[0].Resources.Requests[corev1.ResourceMemory]; memory.Cmp(resource.MustParse("128Mi")) != 0 {
+		t.Errorf("Expected default memory request of 128Mi, got %s", memory.String())
+	}
+
+	hash := deployment.Spec.Template.Annotations[configHashAnnotation]
+	if hash == "" {
+		t.Fatal("Expected Pod template to carry a config hash")
+	}
+
+	changedConfig := testConfig()
+	changedConfig.MLAGatewayURL = "https://other.example.com:30443/v1/traces"
+
+	if reconcile(changedConfig, nil).Spec.Template.Annotations[configHashAnnotation] == hash {
+		t.Error("Expected config hash to change when the config changes")
+	}
+
+	overridden := reconcile(testConfig(), &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	})
+	if memory := overridden.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory]; memory.Cmp(resource.MustParse("512Mi")) != 0 {
+		t.Errorf("Expected overridden memory request of 512Mi, got %s", memory.String())
+	}
+}
+
+func TestServiceMatchesDeployment(t *testing.T) {
+	_, serviceReconciler := ServiceReconciler()()
+	service, err := serviceReconciler(&corev1.Service{})
+	if err != nil {
+		t.Fatalf("Failed to reconcile Service: %v", err)
+	}
+
+	_, deploymentReconciler := DeploymentReconciler(testConfig(), nil, noopRewriter)()
+	deployment, err := deploymentReconciler(&appsv1.Deployment{})
+	if err != nil {
+		t.Fatalf("Failed to reconcile Deployment: %v", err)
+	}
+
+	for key, value := range service.Spec.Selector {
+		if deployment.Spec.Template.Labels[key] != value {
+			t.Errorf("Service selector %s=%s does not match the Pod labels", key, value)
+		}
+	}
+
+	ports := map[string]bool{}
+	for _, port := range deployment.Spec.Template.Spec.Containers[0].Ports {
+		ports[port.Name] = true
+	}
+	for _, port := range service.Spec.Ports {
+		if !ports[port.TargetPort.String()] {
+			t.Errorf("Service port %s targets unknown container port %s", port.Name, port.TargetPort.String())
+		}
+	}
+}
@@ -73,10 +73,18 @@ func DaemonSetReconciler(versions kubermatic.Versions, imageRewriter registry.Im
 							Name:      "home",
 							MountPath: "/home",
 						},
+						{
+							Name:      "sshd-config",
+							MountPath: "/etc/ssh",
+						},
 					},
 				},
 			}
 
+			// the host PID namespace is required to reload sshd after configuring the
+			// SSH certificate authority of the cluster
+			ds.Spec.Template.Spec.HostPID = true
+
 			ds.Spec.Template.Spec.Tolerations = []corev1.Toleration{
 				{
 					Effect:   corev1.TaintEffectNoSchedule,
@@ -107,6 +115,15 @@ func DaemonSetReconciler(versions kubermatic.Versions, imageRewriter registry.Im
 						},
 					},
 				},
+				{
+					Name: "sshd-config",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: "/etc/ssh",
+							Type: &hostPathType,
+						},
+					},
+				},
 			}
 
 			ds.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{
@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotfallbackcontroller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	userclustercontrollermanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager"
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// This controller scales up on-demand MachineDeployments for spot machines that could not be provisioned.
+	controllerName = "kkp-spot-fallback-controller"
+
+	fallbackSuffix = "-on-demand"
+
+	machineDeploymentAnnotationPrefix = "machinedeployment.clusters.k8s.io/"
+
+	// autoscalerAnnotationPrefix is the prefix of the min/max size annotations of the cluster-autoscaler.
+	autoscalerAnnotationPrefix = "cluster.k8s.io/cluster-api-autoscaler-node-group-"
+)
+
+type reconciler struct {
+	log             *zap.SugaredLogger
+	client          ctrlruntimeclient.Client
+	recorder        record.EventRecorder
+	clusterIsPaused userclustercontrollermanager.IsPausedChecker
+	now             func() time.Time
+}
+
+func Add(log *zap.SugaredLogger, mgr manager.Manager, clusterIsPaused userclustercontrollermanager.IsPausedChecker) error {
+	log = log.Named(controllerName)
+
+	r := &reconciler{
+		log:             log,
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(controllerName),
+		clusterIsPaused: clusterIsPaused,
+		now:             time.Now,
+	}
+
+	// enqueues the MachineDeployments whose selector matches the Machine
+	enqueueMachineDeployments := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a ctrlruntimeclient.Object) []reconcile.Request {
+		mdList := &clusterv1alpha1.MachineDeploymentList{}
+		if err := r.client.List(ctx, mdList, ctrlruntimeclient.InNamespace(a.GetNamespace())); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list MachineDeployments: %w", err))
+			return []reconcile.Request{}
+		}
+
+		requests := []reconcile.Request{}
+		for _, md := range mdList.Items {
+			selector, err := metav1.LabelSelectorAsSelector(&md.Spec.Selector)
+			if err != nil || !selector.Matches(labels.Set(a.GetLabels())) {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: md.Name, Namespace: md.Namespace}})
+		}
+
+		return requests
+	})
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&clusterv1alpha1.MachineDeployment{}).
+		Watches(&clusterv1alpha1.Machine{}, enqueueMachineDeployments).
+		Build(r)
+
+	return err
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("machinedeployment", request.NamespacedName)
+	log.Debug("Reconciling")
+
+	paused, err := r.clusterIsPaused(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check cluster pause status: %w", err)
+	}
+	if paused {
+		return reconcile.Result{}, nil
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := r.client.Get(ctx, request.NamespacedName, md); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get MachineDeployment: %w", err)
+	}
+
+	// on-demand twins are managed by this controller and the deletion of the spot
+	// MachineDeployment takes care of its twin via the owner reference
+	if _, ok := md.Labels[spot.FallbackForLabel]; ok || md.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, md)
+	if err != nil {
+		r.recorder.Event(md, corev1.EventTypeWarning, "SpotFallbackFailed", err.Error())
+	}
+
+	return result, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment) (reconcile.Result, error) {
+	timeout, enabled, err := spot.FallbackTimeout(md)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if enabled {
+		enabled, err = spot.IsSpotMachineDeployment(md)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to decode provider spec: %w", err)
+		}
+	}
+
+	if !enabled {
+		return reconcile.Result{}, r.deleteFallback(ctx, log, md)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&md.Spec.Selector)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("invalid selector: %w", err)
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := r.client.List(ctx, machines, ctrlruntimeclient.InNamespace(md.Namespace), ctrlruntimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	// count the machines that have not joined the cluster in time and requeue
+	// when the next pending machine is going to reach the timeout
+	var (
+		unprovisioned int32
+		requeueAfter  time.Duration
+	)
+
+	now := r.now()
+	for _, machine := range machines.Items {
+		if machine.DeletionTimestamp != nil || machine.Status.NodeRef != nil {
+			continue
+		}
+
+		remaining := machine.CreationTimestamp.Add(timeout).Sub(now)
+		if remaining <= 0 {
+			unprovisioned++
+		} else if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	if err := r.reconcileFallback(ctx, log, md, unprovisioned); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func fallbackName(md *clusterv1alpha1.MachineDeployment) string {
+	return md.Name + fallbackSuffix
+}
+
+// fallbackMachineDeployment returns the on-demand twin of the given spot MachineDeployment. The
+// twin uses its own selector, so that the MachineSets of both MachineDeployments do not adopt
+// each other's machines.
+func fallbackMachineDeployment(md *clusterv1alpha1.MachineDeployment, replicas int32) (*clusterv1alpha1.MachineDeployment, error) {
+	providerSpec, err := spot.OnDemandProviderSpec(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create on-demand provider spec: %w", err)
+	}
+
+	selectorLabels := map[string]string{spot.FallbackForLabel: md.Name}
+
+	templateLabels := maps.Clone(md.Spec.Template.Labels)
+	for key := range md.Spec.Selector.MatchLabels {
+		delete(templateLabels, key)
+	}
+	if templateLabels == nil {
+		templateLabels = map[string]string{}
+	}
+	maps.Copy(templateLabels, selectorLabels)
+
+	twin := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fallbackName(md),
+			Namespace:   md.Namespace,
+			Labels:      selectorLabels,
+			Annotations: maps.Clone(md.Annotations),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(md, clusterv1alpha1.SchemeGroupVersion.WithKind("MachineDeployment")),
+			},
+		},
+		Spec: *md.Spec.DeepCopy(),
+	}
+
+	// the revision annotations are managed by the MachineDeployment controller and the twin
+	// must not be scaled by the cluster-autoscaler, as its size is owned by this controller
+	maps.DeleteFunc(twin.Annotations, func(key, _ string) bool {
+		return key == spot.FallbackTimeoutAnnotation ||
+			strings.HasPrefix(key, machineDeploymentAnnotationPrefix) ||
+			strings.HasPrefix(key, autoscalerAnnotationPrefix)
+	})
+
+	twin.Spec.Replicas = ptr.To(replicas)
+	twin.Spec.Selector = metav1.LabelSelector{MatchLabels: selectorLabels}
+	twin.Spec.Template.Labels = templateLabels
+	twin.Spec.Template.Spec.ProviderSpec = providerSpec
+
+	return twin, nil
+}
+
+func (r *reconciler) reconcileFallback(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment, replicas int32) error {
+	existing := &clusterv1alpha1.MachineDeployment{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: md.Namespace, Name: fallbackName(md)}, existing)
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get on-demand MachineDeployment: %w", err)
+	}
+
+	if apierrors.IsNotFound(err) {
+		if replicas == 0 {
+			return nil
+		}
+
+		twin, err := fallbackMachineDeployment(md, replicas)
+		if err != nil {
+			return err
+		}
+
+		log.Infow("Creating on-demand MachineDeployment for unprovisioned spot machines", "replicas", replicas)
+		r.recorder.Eventf(md, corev1.EventTypeNormal, "SpotFallback", "%d spot machine(s) have not been provisioned in time, scaling up on-demand MachineDeployment %s.", replicas, twin.Name)
+
+		return r.client.Create(ctx, twin)
+	}
+
+	twin, err := fallbackMachineDeployment(md, replicas)
+	if err != nil {
+		return err
+	}
+
+	// keep the selector, it is immutable
+	twin.Spec.Selector = existing.Spec.Selector
+
+	if ptr.Deref(existing.Spec.Replicas, 0) != replicas {
+		log.Infow("Scaling on-demand MachineDeployment", "replicas", replicas)
+		r.recorder.Eventf(md, corev1.EventTypeNormal, "SpotFallback", "Scaling on-demand MachineDeployment %s to %d replica(s).", twin.Name, replicas)
+	}
+
+	oldTwin := existing.DeepCopy()
+	existing.Labels = twin.Labels
+	existing.Annotations = twin.Annotations
+	existing.Spec = twin.Spec
+
+	return r.client.Patch(ctx, existing, ctrlruntimeclient.MergeFrom(oldTwin))
+}
+
+func (r *reconciler) deleteFallback(ctx context.Context, log *zap.SugaredLogger, md *clusterv1alpha1.MachineDeployment) error {
+	twin := &clusterv1alpha1.MachineDeployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: md.Namespace, Name: fallbackName(md)}, twin); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	log.Info("Spot fallback is disabled, deleting on-demand MachineDeployment")
+
+	return ctrlruntimeclient.IgnoreNotFound(r.client.Delete(ctx, twin))
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotfallbackcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+	aws "k8c.io/machine-controller/pkg/cloudprovider/provider/aws/types"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var testScheme = fake.NewScheme()
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(testScheme); err != nil {
+		panic(fmt.Sprintf("failed to add clusterv1alpha1 to scheme: %v", err))
+	}
+}
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func getMachineDeployment(t *testing.T, fallbackTimeout string) *clusterv1alpha1.MachineDeployment {
+	cloudProviderSpec, err := json.Marshal(&aws.RawConfig{IsSpotInstance: ptr.To(true)})
+	if err != nil {
+		t.Fatalf("Failed to encode cloud provider spec: %v", err)
+	}
+
+	config, err := json.Marshal(&providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderAWS,
+		CloudProviderSpec: runtime.RawExtension{Raw: cloudProviderSpec},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode provider config: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceSystem,
+			Name:      "spot",
+			Annotations: map[string]string{
+				autoscalerAnnotationPrefix + "min-size": "1",
+				autoscalerAnnotationPrefix + "max-size": "5",
+			},
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](3),
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"machine": "md-spot"}},
+		},
+	}
+	md.Spec.Template.Labels = map[string]string{"machine": "md-spot"}
+	md.Spec.Template.Spec.ProviderSpec = clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: config}}
+
+	if fallbackTimeout != "" {
+		md.Annotations[spot.FallbackTimeoutAnnotation] = fallbackTimeout
+	}
+
+	return md
+}
+
+func getMachine(name string, age time.Duration, provisioned bool) *clusterv1alpha1.Machine {
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         metav1.NamespaceSystem,
+			Name:              name,
+			Labels:            map[string]string{"machine": "md-spot"},
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		},
+	}
+
+	if provisioned {
+		machine.Status.NodeRef = &corev1.ObjectReference{Name: name}
+	}
+
+	return machine
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name             string
+		fallbackTimeout  string
+		machines         []ctrlruntimeclient.Object
+		existingReplicas *int32
+		expectedReplicas *int32
+		expectedRequeue  time.Duration
+	}{
+		{
+			name:            "no fallback while machines are within the timeout",
+			fallbackTimeout: "10m",
+			machines: []ctrlruntimeclient.Object{
+				getMachine("a", 5*time.Minute, false),
+				getMachine("b", time.Hour, true),
+			},
+			expectedRequeue: 5 * time.Minute,
+		},
+		{
+			name:            "fallback for machines past the timeout",
+			fallbackTimeout: "10m",
+			machines: []ctrlruntimeclient.Object{
+				getMachine("a", 15*time.Minute, false),
+				getMachine("b", 20*time.Minute, false),
+				getMachine("c", time.Hour, true),
+			},
+			expectedReplicas: ptr.To[int32](2),
+		},
+		{
+			name:            "fallback is scaled down once spot machines are provisioned",
+			fallbackTimeout: "10m",
+			machines: []ctrlruntimeclient.Object{
+				getMachine("a", time.Hour, true),
+			},
+			existingReplicas: ptr.To[int32](2),
+			expectedReplicas: ptr.To[int32](0),
+		},
+		{
+			name: "fallback is removed when it is disabled",
+			machines: []ctrlruntimeclient.Object{
+				getMachine("a", time.Hour, false),
+			},
+			existingReplicas: ptr.To[int32](1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			md := getMachineDeployment(t, tc.fallbackTimeout)
+			objects := append([]ctrlruntimeclient.Object{md}, tc.machines...)
+
+			if tc.existingReplicas != nil {
+				twin, err := fallbackMachineDeployment(md, *tc.existingReplicas)
+				if err != nil {
+					t.Fatalf("Failed to create fallback MachineDeployment: %v", err)
+				}
+				objects = append(objects, twin)
+			}
+
+			client := fake.
+				NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(objects...).
+				Build()
+
+			r := &reconciler{
+				log:      zap.NewNop().Sugar(),
+				client:   client,
+				recorder: &record.FakeRecorder{},
+				clusterIsPaused: func(ctx context.Context) (bool, error) {
+					return false, nil
+				},
+				now: func() time.Time { return now },
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: md.Namespace, Name: md.Name}}
+			result, err := r.Reconcile(ctx, request)
+			if err != nil {
+				t.Fatalf("Reconciling failed: %v", err)
+			}
+
+			if result.RequeueAfter != tc.expectedRequeue {
+				t.Errorf("Expected requeue after %v, got %v", tc.expectedRequeue, result.RequeueAfter)
+			}
+
+			twin := &clusterv1alpha1.MachineDeployment{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: md.Namespace, Name: fallbackName(md)}, twin)
+
+			if tc.expectedReplicas == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("Expected no fallback MachineDeployment, got err=%v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to get fallback MachineDeployment: %v", err)
+			}
+
+			if replicas := ptr.Deref(twin.Spec.Replicas, 0); replicas != *tc.expectedReplicas {
+				t.Errorf("Expected %d fallback replicas, got %d", *tc.expectedReplicas, replicas)
+			}
+
+			isSpot, err := spot.IsSpotMachineDeployment(twin)
+			if err != nil {
+				t.Fatalf("Failed to check fallback MachineDeployment: %v", err)
+			}
+			if isSpot {
+				t.Error("Expected fallback MachineDeployment to use on-demand instances")
+			}
+
+			if twin.Spec.Template.Labels["machine"] != "" {
+				t.Error("Expected fallback machines to not match the spot MachineDeployment's selector")
+			}
+
+			for key := range twin.Annotations {
+				if strings.HasPrefix(key, autoscalerAnnotationPrefix) {
+					t.Errorf("Expected fallback MachineDeployment to not be scaled by the cluster-autoscaler, but it has annotation %q", key)
+				}
+			}
+		})
+	}
+}
@@ -24,5 +24,12 @@ This secret in turn is synchronized based on a secret in the seed namespace via
 in the usercluster controller manager and that seed namespace secret is synchronized based on the
 usersshkeys custom resources in the master cluster via a controller running in the master controller
 manager.
+
+If the cluster has the SSH certificate authority mode enabled, the secret does not contain any user keys,
+but only the public key of the cluster's SSH CA. The agent configures it as sshd's `TrustedUserCAKeys` via
+a drop-in in `/etc/ssh/sshd_config.d` and reloads sshd, so that the authorized_keys files are left empty.
+The certificates are issued for a single principal, which an `AuthorizedPrincipalsFile` accepts for every
+managed user. On nodes whose sshd_config does not include the drop-in directory, the agent falls back to
+a `cert-authority` entry in the authorized_keys files instead.
 */
 package usersshkeysagent
@@ -0,0 +1,306 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usersshkeysagent
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/sshca"
+)
+
+const (
+	// DefaultSSHDConfigPath is the directory holding the sshd configuration of the node.
+	DefaultSSHDConfigPath = "/etc/ssh"
+
+	// sshdDropInDir is the directory of the sshd configuration drop-ins, relative to the
+	// sshd configuration directory.
+	sshdDropInDir = "sshd_config.d"
+
+	// trustedUserCADropIn configures the cluster's SSH CA. sshd uses the first value it
+	// obtains for each keyword, so the drop-in is ordered before the ones of the OS.
+	trustedUserCADropIn = "10-kubermatic-user-ca.conf"
+
+	// trustedUserCADir holds the CA public key and the principals files, relative to the
+	// sshd configuration directory.
+	trustedUserCADir = "kubermatic-user-ca"
+)
+
+// updateTrustedUserCAKeys configures sshd to trust certificates issued by the given CA via
+// TrustedUserCAKeys, or removes that configuration if no CA is given. The certificates are
+// issued for the sshca.Principal, which is accepted for every managed user through an
+// AuthorizedPrincipalsFile. sshd is reloaded if the configuration changed. It returns false
+// if the sshd_config of the node does not include drop-ins, in which case the CA has to be
+// trusted via the authorized_keys files instead.
+func (r *Reconciler) updateTrustedUserCAKeys(caPublicKey []byte) (bool, error) {
+	dropInPath := filepath.Join(r.sshdConfigPath, sshdDropInDir, trustedUserCADropIn)
+	caDir := filepath.Join(r.sshdConfigPath, trustedUserCADir)
+
+	supported, err := dropInsSupported(r.sshdConfigPath)
+	if err != nil {
+		return false, err
+	}
+
+	if len(caPublicKey) == 0 || !supported {
+		removed, err := removePaths(dropInPath, caDir)
+		if err != nil {
+			return false, err
+		}
+
+		if removed {
+			r.log.Infow("Removed TrustedUserCAKeys configuration", "file", dropInPath)
+			if err := r.reloadSSHD(); err != nil {
+				return false, fmt.Errorf("failed to reload sshd: %w", err)
+			}
+		}
+
+		return false, nil
+	}
+
+	principalsDir := filepath.Join(caDir, "principals")
+	config := fmt.Sprintf("# Managed by the KKP user-ssh-keys-agent.\nTrustedUserCAKeys %s\nAuthorizedPrincipalsFile %s\n",
+		filepath.Join(caDir, "ca.pub"), filepath.Join(principalsDir, "%u"))
+
+	files := map[string][]byte{
+		dropInPath:                     []byte(config),
+		filepath.Join(caDir, "ca.pub"): append(bytes.TrimSpace(caPublicKey), '\n'),
+	}
+
+	// The principals files also accept the user name, which sshd would accept for other
+	// trusted CAs if no AuthorizedPrincipalsFile was configured.
+	for _, user := range r.users() {
+		files[filepath.Join(principalsDir, user)] = []byte(fmt.Sprintf("%s\n%s\n", user, sshca.Principal))
+	}
+
+	changed, err := writeFiles(files)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := removeUnknownFiles(principalsDir, files)
+	if err != nil {
+		return false, err
+	}
+
+	if changed || removed {
+		r.log.Infow("Updated TrustedUserCAKeys configuration", "file", dropInPath)
+		if err := r.reloadSSHD(); err != nil {
+			return false, fmt.Errorf("failed to reload sshd: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// users returns the names of the users whose authorized_keys files are managed.
+func (r *Reconciler) users() []string {
+	users := make([]string, 0, len(r.authorizedKeysPath))
+	for _, path := range r.authorizedKeysPath {
+		// <home>/.ssh/authorized_keys
+		users = append(users, filepath.Base(filepath.Dir(filepath.Dir(path))))
+	}
+
+	sort.Strings(users)
+
+	return users
+}
+
+// dropInsSupported returns true if the sshd_config in the given directory includes
+// the drop-in directory. Older distributions do not include it by default.
+func dropInsSupported(sshdConfigPath string) (bool, error) {
+	config, err := os.Open(filepath.Join(sshdConfigPath, "sshd_config"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to read sshd_config: %w", err)
+	}
+	defer config.Close()
+
+	includes := []string{
+		filepath.Join(sshdDropInDir, "*.conf"),
+		filepath.Join(sshdConfigPath, sshdDropInDir, "*.conf"),
+		filepath.Join(DefaultSSHDConfigPath, sshdDropInDir, "*.conf"),
+	}
+
+	scanner := bufio.NewScanner(config)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "Include") {
+			continue
+		}
+
+		for _, include := range fields[1:] {
+			for _, expected := range includes {
+				if include == expected {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read sshd_config: %w", err)
+	}
+
+	return false, nil
+}
+
+// writeFiles writes the given files that do not have the expected content yet, readable
+// by all users and only writable by the owner, as sshd requires.
+func writeFiles(files map[string][]byte) (bool, error) {
+	changed := false
+	for path, content := range files {
+		existing, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return false, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return false, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// removeUnknownFiles removes all files from the given directory that are not listed
+// in the given files.
+func removeUnknownFiles(dir string, files map[string][]byte) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+
+	removed := false
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if _, ok := files[path]; ok {
+			continue
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			return false, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+
+		removed = true
+	}
+
+	return removed, nil
+}
+
+func removePaths(paths ...string) (bool, error) {
+	removed := false
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return false, fmt.Errorf("failed to describe %s: %w", path, err)
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			return false, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+
+		removed = true
+	}
+
+	return removed, nil
+}
+
+// reloadSSHD sends SIGHUP to the listening sshd processes found in the given proc
+// filesystem, which makes them reload their configuration. Processes handling a
+// connection are children of the listener and keep their configuration. If sshd is
+// socket-activated, there is no listener and every connection reads the configuration
+// anyway.
+func reloadSSHD(procPath string) error {
+	entries, err := os.ReadDir(procPath)
+	if err != nil {
+		return fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		name, ppid, err := processInfo(procPath, pid)
+		if err != nil || name != "sshd" {
+			continue
+		}
+
+		if parentName, _, err := processInfo(procPath, ppid); err == nil && parentName == "sshd" {
+			continue
+		}
+
+		if err := syscall.Kill(pid, syscall.SIGHUP); err != nil {
+			return fmt.Errorf("failed to signal sshd process %d: %w", pid, err)
+		}
+	}
+
+	return nil
+}
+
+// processInfo returns the name and the parent process ID of the given process.
+func processInfo(procPath string, pid int) (string, int, error) {
+	status, err := os.ReadFile(filepath.Join(procPath, strconv.Itoa(pid), "status"))
+	if err != nil {
+		return "", 0, err
+	}
+
+	var (
+		name string
+		ppid int
+	)
+
+	for _, line := range strings.Split(string(status), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "Name":
+			name = strings.TrimSpace(value)
+		case "PPid":
+			if ppid, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return "", 0, fmt.Errorf("invalid parent process ID: %w", err)
+			}
+		}
+	}
+
+	return name, ppid, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usersshkeysagent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const testCAPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFakeCAKey"
+
+func TestReconcileTrustedUserCAKeys(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		sshdConfig             string
+		expectedAuthorizedKeys string
+		expectedDropIn         bool
+	}{
+		{
+			name:                   "sshd_config includes drop-ins",
+			sshdConfig:             "Include /etc/ssh/sshd_config.d/*.conf\nPasswordAuthentication no\n",
+			expectedAuthorizedKeys: "",
+			expectedDropIn:         true,
+		},
+		{
+			name:                   "sshd_config does not include drop-ins",
+			sshdConfig:             "PasswordAuthentication no\n",
+			expectedAuthorizedKeys: `cert-authority,principals="kubermatic" ` + testCAPublicKey,
+			expectedDropIn:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			sshPath := filepath.Join(tmpDir, "home", "ubuntu", ".ssh")
+			if err := os.MkdirAll(sshPath, 0700); err != nil {
+				t.Fatalf("Failed to create .ssh dir: %v", err)
+			}
+
+			authorizedKeysPath := filepath.Join(sshPath, "authorized_keys")
+			if err := os.WriteFile(authorizedKeysPath, []byte("ssh-rsa static_key\n"), 0600); err != nil {
+				t.Fatalf("Failed to create authorized_keys file: %v", err)
+			}
+
+			sshdConfigPath := filepath.Join(tmpDir, "etc", "ssh")
+			if err := os.MkdirAll(filepath.Join(sshdConfigPath, sshdDropInDir), 0755); err != nil {
+				t.Fatalf("Failed to create sshd config dir: %v", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(sshdConfigPath, "sshd_config"), []byte(tc.sshdConfig), 0644); err != nil {
+				t.Fatalf("Failed to create sshd_config: %v", err)
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resources.UserSSHKeys,
+					Namespace: metav1.NamespaceSystem,
+				},
+				Data: map[string][]byte{
+					resources.UserSSHKeyCAEntrySecretKey: []byte(testCAPublicKey),
+				},
+			}
+
+			client := fake.NewClientBuilder().WithObjects(secret).Build()
+
+			reloads := 0
+			r := Reconciler{
+				Client:             client,
+				log:                kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				authorizedKeysPath: []string{authorizedKeysPath},
+				sshdConfigPath:     sshdConfigPath,
+				reloadSSHD: func() error {
+					reloads++
+					return nil
+				},
+			}
+
+			reconcileKeys := func() {
+				t.Helper()
+
+				if _, err := r.Reconcile(context.Background(), reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: resources.UserSSHKeys, Namespace: metav1.NamespaceSystem}}); err != nil {
+					t.Fatalf("Failed to reconcile: %v", err)
+				}
+			}
+
+			reconcileKeys()
+
+			authorizedKeys, err := readAuthorizedKeysFile(authorizedKeysPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if authorizedKeys != tc.expectedAuthorizedKeys {
+				t.Fatalf("Expected authorized_keys %q, got %q", tc.expectedAuthorizedKeys, authorizedKeys)
+			}
+
+			dropInPath := filepath.Join(sshdConfigPath, sshdDropInDir, trustedUserCADropIn)
+			dropIn, err := os.ReadFile(dropInPath)
+			if !tc.expectedDropIn {
+				if err == nil {
+					t.Fatalf("Expected no drop-in, got %q", dropIn)
+				}
+				if reloads != 0 {
+					t.Fatalf("Expected sshd not to be reloaded, but it was reloaded %d times", reloads)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to read drop-in: %v", err)
+			}
+
+			caDir := filepath.Join(sshdConfigPath, trustedUserCADir)
+			if !strings.Contains(string(dropIn), "TrustedUserCAKeys "+filepath.Join(caDir, "ca.pub")) {
+				t.Fatalf("Drop-in does not configure TrustedUserCAKeys: %q", dropIn)
+			}
+
+			principals, err := os.ReadFile(filepath.Join(caDir, "principals", "ubuntu"))
+			if err != nil {
+				t.Fatalf("Failed to read principals file: %v", err)
+			}
+
+			if string(principals) != "ubuntu\nkubermatic\n" {
+				t.Fatalf("Unexpected principals %q", principals)
+			}
+
+			if reloads != 1 {
+				t.Fatalf("Expected sshd to be reloaded once, but it was reloaded %d times", reloads)
+			}
+
+			// an unchanged configuration must not reload sshd
+			reconcileKeys()
+			if reloads != 1 {
+				t.Fatalf("Expected sshd not to be reloaded again, but it was reloaded %d times", reloads)
+			}
+
+			// disabling the CA mode removes the configuration
+			secret.Data = map[string][]byte{"key-test": []byte("ssh-rsa test_user_ssh_key")}
+			if err := client.Update(context.Background(), secret); err != nil {
+				t.Fatalf("Failed to update Secret: %v", err)
+			}
+
+			reconcileKeys()
+
+			if _, err := os.Stat(dropInPath); err == nil {
+				t.Fatal("Expected drop-in to be removed")
+			}
+
+			if _, err := os.Stat(caDir); err == nil {
+				t.Fatal("Expected CA directory to be removed")
+			}
+
+			if reloads != 2 {
+				t.Fatalf("Expected sshd to be reloaded after removing the configuration, but it was reloaded %d times", reloads)
+			}
+
+			if authorizedKeys, _ := readAuthorizedKeysFile(authorizedKeysPath); authorizedKeys != "ssh-rsa test_user_ssh_key" {
+				t.Fatalf("Expected the static key in authorized_keys, got %q", authorizedKeys)
+			}
+		})
+	}
+}
+
+func TestProcessInfo(t *testing.T) {
+	procPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(procPath, "42"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	status := "Name:\tsshd\nUmask:\t0022\nState:\tS (sleeping)\nPid:\t42\nPPid:\t1\n"
+	if err := os.WriteFile(filepath.Join(procPath, "42", "status"), []byte(status), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	name, ppid, err := processInfo(procPath, 42)
+	if err != nil {
+		t.Fatalf("Failed to read process info: %v", err)
+	}
+
+	if name != "sshd" || ppid != 1 {
+		t.Fatalf("Expected sshd with parent 1, got %q with parent %d", name, ppid)
+	}
+}
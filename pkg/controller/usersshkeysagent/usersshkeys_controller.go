@@ -30,6 +30,7 @@ import (
 
 	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/sshca"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -54,6 +55,8 @@ type Reconciler struct {
 	ctrlruntimeclient.Client
 	log                *zap.SugaredLogger
 	authorizedKeysPath []string
+	sshdConfigPath     string
+	reloadSSHD         func() error
 	events             chan event.GenericEvent
 }
 
@@ -61,12 +64,17 @@ func Add(
 	mgr manager.Manager,
 	log *zap.SugaredLogger,
 	authorizedKeysPaths []string,
+	sshdConfigPath string,
 ) error {
 	reconciler := &Reconciler{
 		Client:             mgr.GetClient(),
 		log:                log,
 		authorizedKeysPath: authorizedKeysPaths,
-		events:             make(chan event.GenericEvent),
+		sshdConfigPath:     sshdConfigPath,
+		reloadSSHD: func() error {
+			return reloadSSHD("/proc")
+		},
+		events: make(chan event.GenericEvent),
 	}
 
 	if err := reconciler.watchAuthorizedKeys(authorizedKeysPaths); err != nil {
@@ -104,7 +112,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, fmt.Errorf("failed to fetch user ssh keys: %w", err)
 	}
 
-	if err := r.updateAuthorizedKeys(secret.Data); err != nil {
+	sshKeys := map[string][]byte{}
+	for name, key := range secret.Data {
+		sshKeys[name] = key
+	}
+
+	// In the SSH certificate authority mode, the Secret only contains the CA, which is
+	// trusted via sshd's TrustedUserCAKeys, so that no keys remain in authorized_keys.
+	caPublicKey := sshKeys[resources.UserSSHKeyCAEntrySecretKey]
+	delete(sshKeys, resources.UserSSHKeyCAEntrySecretKey)
+
+	trusted, err := r.updateTrustedUserCAKeys(caPublicKey)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile TrustedUserCAKeys: %w", err)
+	}
+
+	if len(caPublicKey) > 0 && !trusted {
+		sshKeys[resources.UserSSHKeyCAEntrySecretKey] = sshca.AuthorizedKeysEntry(caPublicKey)
+	}
+
+	if err := r.updateAuthorizedKeys(sshKeys); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile user ssh keys: %w", err)
 	}
 
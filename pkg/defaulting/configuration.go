@@ -60,6 +60,7 @@ const (
 	DefaultEnvoyDockerRepository                  = "docker.io/envoyproxy/envoy-distroless"
 	DefaultUserClusterScrapeAnnotationPrefix      = "monitoring.kubermatic.io"
 	DefaultMaximumParallelReconciles              = 10
+	DefaultCertificateRenewalThreshold            = 80
 	DefaultS3Endpoint                             = "s3.amazonaws.com"
 
 	// DefaultCloudProviderReconciliationInterval is the time in between deep cloud provider reconciliations
@@ -398,6 +399,11 @@ func DefaultConfiguration(config *kubermaticv1.KubermaticConfiguration, logger *
 		logger.Debugw("Defaulting field", "field", "seedController.maximumParallelReconciles", "value", configCopy.Spec.SeedController.MaximumParallelReconciles)
 	}
 
+	if configCopy.Spec.SeedController.CertificateRenewalThreshold == 0 {
+		configCopy.Spec.SeedController.CertificateRenewalThreshold = DefaultCertificateRenewalThreshold
+		logger.Debugw("Defaulting field", "field", "seedController.certificateRenewalThreshold", "value", configCopy.Spec.SeedController.CertificateRenewalThreshold)
+	}
+
 	if configCopy.Spec.SeedController.Replicas == nil {
 		configCopy.Spec.SeedController.Replicas = ptr.To[int32](DefaultSeedControllerMgrReplicas)
 		logger.Debugw("Defaulting field", "field", "seedController.replicas", "value", *configCopy.Spec.SeedController.Replicas)
@@ -0,0 +1,151 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package cost
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+// costQuery sums up the estimated hourly cost of every cluster over all hours
+// of the report period.
+const costQuery = `sum by (project, cluster, currency) (sum_over_time(kubermatic_cluster_estimated_hourly_cost[%s:1h]))`
+
+// PrometheusAPI is the subset of the Prometheus API used for the cost report.
+type PrometheusAPI interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...promv1.Option) (model.Value, promv1.Warnings, error)
+}
+
+// Entry is the estimated cost of a single cluster during the report period.
+type Entry struct {
+	ProjectID     string  `json:"project-id"`
+	ClusterID     string  `json:"cluster-id"`
+	Currency      string  `json:"currency"`
+	EstimatedCost float64 `json:"estimated-cost"`
+}
+
+// Query returns the estimated cost of all clusters between from and to, based
+// on the kubermatic_cluster_estimated_hourly_cost metric of the seed-controller-manager.
+// Clusters without a pricing catalog have no cost estimate and are not included.
+func Query(ctx context.Context, api PrometheusAPI, from, to time.Time) ([]Entry, error) {
+	hours := int(to.Sub(from).Hours())
+	if hours < 1 {
+		return nil, fmt.Errorf("report period must be at least one hour, got %v", to.Sub(from))
+	}
+
+	value, _, err := api.Query(ctx, fmt.Sprintf(costQuery, model.Duration(time.Duration(hours)*time.Hour)), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query estimated costs: %w", err)
+	}
+
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("expected a vector, got %s", value.Type())
+	}
+
+	entries := make([]Entry, 0, len(vector))
+	for _, sample := range vector {
+		entries = append(entries, Entry{
+			ProjectID:     string(sample.Metric["project"]),
+			ClusterID:     string(sample.Metric["cluster"]),
+			Currency:      string(sample.Metric["currency"]),
+			EstimatedCost: float64(sample.Value),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ProjectID != entries[j].ProjectID {
+			return entries[i].ProjectID < entries[j].ProjectID
+		}
+
+		return entries[i].ClusterID < entries[j].ClusterID
+	})
+
+	return entries, nil
+}
+
+// Write writes the entries in the given report format.
+func Write(w io.Writer, format kubermaticv1.MeteringReportFormat, entries []Entry) error {
+	switch format {
+	case kubermaticv1.MeteringReportFormatJSON:
+		return json.NewEncoder(w).Encode(entries)
+
+	case kubermaticv1.MeteringReportFormatCSV, "":
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"project-id", "cluster-id", "currency", "estimated-cost"}); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := writer.Write([]string{
+				entry.ProjectID,
+				entry.ClusterID,
+				entry.Currency,
+				strconv.FormatFloat(entry.EstimatedCost, 'f', 2, 64),
+			}); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		return writer.Error()
+
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// ObjectName returns the name of the report object in the S3 bucket, next to
+// the reports of the metering tool using the same output directory and prefix.
+func ObjectName(outputDir, outputPrefix string, format kubermaticv1.MeteringReportFormat, from, to time.Time) string {
+	if format == "" {
+		format = kubermaticv1.MeteringReportFormatCSV
+	}
+
+	return fmt.Sprintf("%s/%s-cost-%s-%s.%s", outputDir, outputPrefix, from.Format(time.DateOnly), to.Format(time.DateOnly), format)
+}
+
+// Period returns the report period ending at now: either the previous
+// calendar month or the given number of days.
+func Period(now time.Time, lastMonth bool, days int) (time.Time, time.Time) {
+	if lastMonth {
+		to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return to.AddDate(0, -1, 0), to
+	}
+
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return to.AddDate(0, 0, -days), to
+}
@@ -0,0 +1,112 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2024 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package cost
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+type fakePrometheus struct {
+	query  string
+	ts     time.Time
+	vector model.Vector
+}
+
+func (p *fakePrometheus) Query(_ context.Context, query string, ts time.Time, _ ...promv1.Option) (model.Value, promv1.Warnings, error) {
+	p.query = query
+	p.ts = ts
+
+	return p.vector, nil, nil
+}
+
+func TestQueryAndWrite(t *testing.T) {
+	prom := &fakePrometheus{
+		vector: model.Vector{
+			{
+				Metric: model.Metric{"project": "project-b", "cluster": "cluster-1", "currency": "EUR"},
+				Value:  84,
+			},
+			{
+				Metric: model.Metric{"project": "project-a", "cluster": "cluster-2", "currency": "EUR"},
+				Value:  12.345,
+			},
+		},
+	}
+
+	now := time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)
+	from, to := Period(now, false, 7)
+
+	entries, err := Query(context.Background(), prom, from, to)
+	if err != nil {
+		t.Fatalf("Failed to query costs: %v", err)
+	}
+
+	expectedQuery := `sum by (project, cluster, currency) (sum_over_time(kubermatic_cluster_estimated_hourly_cost[1w:1h]))`
+	if prom.query != expectedQuery {
+		t.Errorf("Expected query %q, got %q.", expectedQuery, prom.query)
+	}
+
+	if !prom.ts.Equal(to) {
+		t.Errorf("Expected query at %v, got %v.", to, prom.ts)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Write(buf, kubermaticv1.MeteringReportFormatCSV, entries); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"project-id,cluster-id,currency,estimated-cost",
+		"project-a,cluster-2,EUR,12.35",
+		"project-b,cluster-1,EUR,84.00",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("Expected report\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	name := ObjectName("weekly", "europe", "", from, to)
+	if name != "weekly/europe-cost-2024-03-08-2024-03-15.csv" {
+		t.Errorf("Unexpected object name %q.", name)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)
+
+	from, to := Period(now, true, 0)
+	if !from.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the previous month, got %v to %v.", from, to)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -41,12 +42,17 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// costContainerName is the name of the container writing the cost report.
+const costContainerName = "estimated-cost"
+
 func cronJobName(reportName string) string {
 	return "metering-" + reportName
 }
 
 // CronJobReconciler returns the func to create/update the metering report cronjob.
-func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, getRegistry registry.ImageRewriter, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
+// Next to the metering tool, the job runs the metering-cost-report of the KKP
+// image, which writes the estimated cluster costs into the same output directory.
+func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfiguration, caBundleName string, getRegistry registry.ImageRewriter, seed *kubermaticv1.Seed, cfg *kubermaticv1.KubermaticConfiguration, versions kubermatic.Versions) reconciling.NamedCronJobReconcilerFactory {
 	return func() (string, reconciling.CronJobReconciler) {
 		return cronJobName(reportName), func(job *batchv1.CronJob) (*batchv1.CronJob, error) {
 			var args []string
@@ -65,6 +71,9 @@ func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfigu
 				args = append(args, fmt.Sprintf("--last-number-of-days=%d", mrc.Interval))
 			}
 
+			// the cost report does not support report types
+			costArgs := append([]string{}, args...)
+
 			// needs to be last
 			args = append(args, mrc.Types...)
 
@@ -87,65 +96,69 @@ func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfigu
 				},
 			}
 
-			job.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{
+			env := []corev1.EnvVar{
 				{
-					Name:            reportName,
-					Image:           getMeteringImage(getRegistry),
-					ImagePullPolicy: corev1.PullIfNotPresent,
-					Args:            args,
-					Env: []corev1.EnvVar{
-						{
-							Name: "S3_ENDPOINT",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: Endpoint,
-								},
-							},
-						},
-						{
-							Name: "S3_BUCKET",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: Bucket,
-								},
+					Name: "S3_ENDPOINT",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
 							},
+							Key: Endpoint,
 						},
-						{
-							Name: "ACCESS_KEY_ID",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: AccessKey,
-								},
+					},
+				},
+				{
+					Name: "S3_BUCKET",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
 							},
+							Key: Bucket,
 						},
-						{
-							Name: "SECRET_ACCESS_KEY",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: SecretKey,
-								},
+					},
+				},
+				{
+					Name: "ACCESS_KEY_ID",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
 							},
+							Key: AccessKey,
 						},
 					},
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      "ca-bundle",
-							MountPath: "/opt/ca-bundle/",
-							ReadOnly:  true,
+				},
+				{
+					Name: "SECRET_ACCESS_KEY",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
+							},
+							Key: SecretKey,
 						},
 					},
+				},
+			}
+
+			volumeMounts := []corev1.VolumeMount{
+				{
+					Name:      "ca-bundle",
+					MountPath: "/opt/ca-bundle/",
+					ReadOnly:  true,
+				},
+			}
+
+			job.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:            reportName,
+					Image:           getMeteringImage(getRegistry),
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args:            args,
+					Env:             env,
+					VolumeMounts:    volumeMounts,
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
 							corev1.ResourceCPU:    resource.MustParse("100m"),
@@ -153,6 +166,21 @@ func CronJobReconciler(reportName string, mrc kubermaticv1.MeteringReportConfigu
 						},
 					},
 				},
+				{
+					Name:            costContainerName,
+					Image:           cfg.Spec.SeedController.DockerRepository + ":" + versions.Kubermatic,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"metering-cost-report"},
+					Args:            costArgs,
+					Env:             env,
+					VolumeMounts:    volumeMounts,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+							corev1.ResourceMemory: resource.MustParse("32Mi"),
+						},
+					},
+				},
 			}
 
 			job.Spec.JobTemplate.Spec.Template.Spec.Volumes = []corev1.Volume{
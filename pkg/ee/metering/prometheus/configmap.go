@@ -66,6 +66,7 @@ rule_files:
   - /etc/config/rules
   - /etc/config/alerts
 scrape_configs:
+  # also provides the kubermatic_cluster_estimated_* metrics for the cost report
   - honor_labels: true
     job_name: seed-controller-manager
     kubernetes_sd_configs:
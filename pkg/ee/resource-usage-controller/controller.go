@@ -35,16 +35,21 @@ import (
 	userclustercontrollermanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager"
 	"k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	machinevalidation "k8c.io/kubermatic/v2/pkg/ee/validation/machine"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/pricing"
 	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -78,6 +83,7 @@ func Add(log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterName s
 	_, err := builder.ControllerManagedBy(userMgr).
 		Named(controllerName).
 		For(&clusterv1alpha1.Machine{}, builder.WithPredicates(predicate.ByNamespace(metav1.NamespaceSystem))).
+		Watches(&clusterv1alpha1.MachineDeployment{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(predicate.ByNamespace(metav1.NamespaceSystem))).
 		Build(r)
 
 	return err
@@ -117,6 +123,7 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 func (r *reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster, machines *clusterv1alpha1.MachineList) error {
 	resourceUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
+	instanceTypes := []string{}
 	for _, machine := range machines.Items {
 		resourceDetails, err := machinevalidation.GetMachineResourceUsage(ctx, r.userClient, &machine, r.caBundle)
 		if err != nil {
@@ -126,11 +133,95 @@ func (r *reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluste
 		resourceUsage.CPU.Add(*resourceDetails.Cpu())
 		resourceUsage.Memory.Add(*resourceDetails.Memory())
 		resourceUsage.Storage.Add(*resourceDetails.Storage())
+
+		// machines whose instance type cannot be determined are counted as unpriced
+		instanceType, err := machinevalidation.GetMachineInstanceType(ctx, r.userClient, &machine)
+		if err != nil {
+			r.log.Debugw("Failed to determine instance type", "machine", machine.Name, zap.Error(err))
+		}
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+
+	// Cost estimation is best effort and must never prevent the resource usage,
+	// which resource quotas rely on, from being updated.
+	costEstimate, err := r.estimateCosts(ctx, cluster, instanceTypes)
+	if err != nil {
+		r.log.Errorw("Failed to estimate costs", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "CostEstimationFailed", err.Error())
 	}
 
 	cluster.Status.ResourceUsage = resourceUsage
+	cluster.Status.CostEstimate = costEstimate
 
 	return kubermaticv1helper.UpdateClusterStatus(ctx, r.seedClient, cluster, func(c *kubermaticv1.Cluster) {
 		c.Status.ResourceUsage = resourceUsage
+		c.Status.CostEstimate = costEstimate
 	})
 }
+
+// estimateCosts calculates the costs of the given instance types and of the
+// cluster's MachineDeployments, based on the pricing catalog the
+// seed-controller-manager provides in the cluster namespace. If no catalog
+// exists, no estimate is returned.
+func (r *reconciler) estimateCosts(ctx context.Context, cluster *kubermaticv1.Cluster, instanceTypes []string) (*kubermaticv1.ClusterCostEstimate, error) {
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.PricingCatalogConfigMapName}
+	if err := r.seedClient.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pricing catalog: %w", err)
+	}
+
+	catalog, err := pricing.CatalogFromConfigMap(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := pricing.Estimate(catalog, instanceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate.MachineDeployments, err = r.estimateMachineDeploymentCosts(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimate, nil
+}
+
+// estimateMachineDeploymentCosts calculates the costs of each MachineDeployment at
+// its configured number of replicas, so that the costs are known before the
+// machines have been created.
+func (r *reconciler) estimateMachineDeploymentCosts(ctx context.Context, catalog *kubermaticv1.PricingCatalog) (map[string]kubermaticv1.MachineDeploymentCostEstimate, error) {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := r.userClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	var result map[string]kubermaticv1.MachineDeploymentCostEstimate
+	for _, md := range machineDeployments.Items {
+		template := &clusterv1alpha1.Machine{Spec: md.Spec.Template.Spec}
+
+		instanceType, err := machinevalidation.GetMachineInstanceType(ctx, r.userClient, template)
+		if err != nil {
+			r.log.Debugw("Failed to determine instance type", "machinedeployment", md.Name, zap.Error(err))
+			continue
+		}
+
+		estimate, priced, err := pricing.EstimateMachineDeployment(catalog, instanceType, ptr.Deref(md.Spec.Replicas, 1))
+		if err != nil {
+			return nil, fmt.Errorf("MachineDeployment %q: %w", md.Name, err)
+		}
+
+		if priced {
+			if result == nil {
+				result = map[string]kubermaticv1.MachineDeploymentCostEstimate{}
+			}
+			result[md.Name] = *estimate
+		}
+	}
+
+	return result, nil
+}
@@ -31,12 +31,16 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/pricing"
 	"k8c.io/kubermatic/v2/pkg/test/diff"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/test/generator"
 	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
@@ -48,7 +52,11 @@ func TestReconcile(t *testing.T) {
 		name                  string
 		cluster               *kubermaticv1.Cluster
 		machines              []*clusterv1alpha1.Machine
+		machineDeployments    []*clusterv1alpha1.MachineDeployment
+		pricingCatalog        *kubermaticv1.PricingCatalog
+		brokenPricingCatalog  bool
 		expectedResourceUsage *kubermaticv1.ResourceDetails
+		expectedCostEstimate  *kubermaticv1.ClusterCostEstimate
 	}{
 		{
 			name:     "scenario 1: calculate resource usage from one machine",
@@ -99,6 +107,74 @@ func TestReconcile(t *testing.T) {
 				Storage: getQuantity("0"),
 			},
 		},
+		{
+			name:    "scenario 5: estimate costs based on the pricing catalog",
+			cluster: generator.GenDefaultCluster(),
+			machines: []*clusterv1alpha1.Machine{
+				genFakeMachineWithInstanceType("m1", "2", "4G", "10G", "medium"),
+				genFakeMachineWithInstanceType("m2", "2", "4G", "10G", "medium"),
+				genFakeMachineWithInstanceType("m3", "8", "16G", "10G", "huge")},
+			pricingCatalog: &kubermaticv1.PricingCatalog{
+				Currency: "EUR",
+				InstanceTypes: map[string]string{
+					"medium": "0.05",
+				},
+			},
+			expectedResourceUsage: &kubermaticv1.ResourceDetails{
+				CPU:     getQuantity("12"),
+				Memory:  getQuantity("24G"),
+				Storage: getQuantity("30G"),
+			},
+			expectedCostEstimate: &kubermaticv1.ClusterCostEstimate{
+				Currency:         "EUR",
+				Hourly:           "0.10",
+				Monthly:          "73.00",
+				UnpricedMachines: 1,
+			},
+		},
+		{
+			name:    "scenario 6: estimate costs of MachineDeployments at their configured replicas",
+			cluster: generator.GenDefaultCluster(),
+			machines: []*clusterv1alpha1.Machine{
+				genFakeMachineWithInstanceType("m1", "2", "4G", "10G", "medium")},
+			machineDeployments: []*clusterv1alpha1.MachineDeployment{
+				genFakeMachineDeployment("workers", "medium", 3),
+				genFakeMachineDeployment("gpu", "huge", 1)},
+			pricingCatalog: &kubermaticv1.PricingCatalog{
+				Currency: "EUR",
+				InstanceTypes: map[string]string{
+					"medium": "0.05",
+				},
+			},
+			expectedResourceUsage: &kubermaticv1.ResourceDetails{
+				CPU:     getQuantity("2"),
+				Memory:  getQuantity("4G"),
+				Storage: getQuantity("10G"),
+			},
+			expectedCostEstimate: &kubermaticv1.ClusterCostEstimate{
+				Currency: "EUR",
+				Hourly:   "0.05",
+				Monthly:  "36.50",
+				MachineDeployments: map[string]kubermaticv1.MachineDeploymentCostEstimate{
+					"workers": {InstanceType: "medium", Replicas: 3, Hourly: "0.15", Monthly: "109.50"},
+				},
+			},
+		},
+		{
+			name: "scenario 7: a broken pricing catalog does not prevent resource usage updates",
+			cluster: func() *kubermaticv1.Cluster {
+				c := generator.GenDefaultCluster()
+				c.Status.ResourceUsage = kubermaticv1.NewResourceDetails(resource.MustParse("2"), resource.MustParse("1G"), resource.MustParse("2G"))
+				return c
+			}(),
+			machines:             []*clusterv1alpha1.Machine{genFakeMachineWithInstanceType("m1", "5", "5G", "10G", "medium")},
+			brokenPricingCatalog: true,
+			expectedResourceUsage: &kubermaticv1.ResourceDetails{
+				CPU:     getQuantity("5"),
+				Memory:  getQuantity("5G"),
+				Storage: getQuantity("10G"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -110,11 +186,33 @@ func TestReconcile(t *testing.T) {
 
 			seedClientBuilder := fake.NewClientBuilder().WithScheme(scheme)
 			seedClientBuilder.WithObjects(tc.cluster)
+			if tc.pricingCatalog != nil {
+				_, reconciler := pricing.ConfigMapReconciler(tc.pricingCatalog)()
+				cm, err := reconciler(&corev1.ConfigMap{})
+				if err != nil {
+					t.Fatalf("failed to create pricing catalog: %v", err)
+				}
+				cm.Name = resources.PricingCatalogConfigMapName
+				cm.Namespace = tc.cluster.Status.NamespaceName
+				seedClientBuilder.WithObjects(cm)
+			}
+			if tc.brokenPricingCatalog {
+				seedClientBuilder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resources.PricingCatalogConfigMapName,
+						Namespace: tc.cluster.Status.NamespaceName,
+					},
+					Data: map[string]string{resources.PricingCatalogConfigMapKey: "{"},
+				})
+			}
 
 			userClientBuilder := fake.NewClientBuilder().WithScheme(scheme)
 			for _, m := range tc.machines {
 				userClientBuilder.WithObjects(m)
 			}
+			for _, md := range tc.machineDeployments {
+				userClientBuilder.WithObjects(md)
+			}
 
 			seedClient := seedClientBuilder.Build()
 			userClient := userClientBuilder.Build()
@@ -125,7 +223,7 @@ func TestReconcile(t *testing.T) {
 				userClient:  userClient,
 				clusterName: tc.cluster.Name,
 				caBundle:    nil,
-				recorder:    &record.FakeRecorder{},
+				recorder:    record.NewFakeRecorder(10),
 				clusterIsPaused: func(c context.Context) (bool, error) {
 					return false, nil
 				},
@@ -146,6 +244,10 @@ func TestReconcile(t *testing.T) {
 			if !diff.SemanticallyEqual(tc.expectedResourceUsage, cluster.Status.ResourceUsage) {
 				t.Fatalf("Objects differ:\n%v", diff.ObjectDiff(tc.expectedResourceUsage, cluster.Status.ResourceUsage))
 			}
+
+			if !diff.SemanticallyEqual(tc.expectedCostEstimate, cluster.Status.CostEstimate) {
+				t.Fatalf("Cost estimates differ:\n%v", diff.ObjectDiff(tc.expectedCostEstimate, cluster.Status.CostEstimate))
+			}
 		})
 	}
 }
@@ -156,6 +258,29 @@ func genFakeMachine(name, cpu, memory, storage string) *clusterv1alpha1.Machine
 		nil, nil)
 }
 
+func genFakeMachineWithInstanceType(name, cpu, memory, storage, instanceType string) *clusterv1alpha1.Machine {
+	return generator.GenTestMachine(name,
+		fmt.Sprintf(`{"cloudProvider":"fake", "cloudProviderSpec":{"cpu":"%s","memory":"%s","storage":"%s","instanceType":"%s"}}`, cpu, memory, storage, instanceType),
+		nil, nil)
+}
+
+func genFakeMachineDeployment(name, instanceType string, replicas int32) *clusterv1alpha1.MachineDeployment {
+	machine := genFakeMachineWithInstanceType(name, "2", "4G", "10G", instanceType)
+
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Template: clusterv1alpha1.MachineTemplateSpec{
+				Spec: machine.Spec,
+			},
+		},
+	}
+}
+
 func getQuantity(q string) *resource.Quantity {
 	res := resource.MustParse(q)
 	return &res
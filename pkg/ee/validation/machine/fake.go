@@ -60,4 +60,6 @@ type FakeProviderSpec struct {
 	Cpu     string `json:"cpu"`
 	Memory  string `json:"memory"`
 	Storage string `json:"storage"`
+	// InstanceType is optional and only used to test cost estimations.
+	InstanceType string `json:"instanceType,omitempty"`
 }
@@ -26,6 +26,7 @@ package machine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -138,6 +139,85 @@ func GetMachineResourceUsage(ctx context.Context, userClient ctrlruntimeclient.C
 	return quotaUsage, err
 }
 
+// GetMachineInstanceType returns the name of the instance type (also called size, flavor
+// or machine type, depending on the provider) of the given machine. An empty string is
+// returned for providers that do not have the concept of instance types.
+func GetMachineInstanceType(ctx context.Context, userClient ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine) (string, error) {
+	config, err := types.GetConfig(machine.Spec.ProviderSpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to read machine.spec.providerSpec: %w", err)
+	}
+
+	configVarResolver := providerconfig.NewConfigVarResolver(ctx, userClient)
+
+	var instanceType types.ConfigVarString
+	switch config.CloudProvider {
+	case types.CloudProviderFake:
+		spec := &FakeProviderSpec{}
+		if err := json.Unmarshal(config.CloudProviderSpec.Raw, spec); err != nil {
+			return "", fmt.Errorf("error unmarshalling fake raw config: %w", err)
+		}
+		return spec.InstanceType, nil
+	case types.CloudProviderAWS:
+		rawConfig, err := awstypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting aws raw config: %w", err)
+		}
+		instanceType = rawConfig.InstanceType
+	case types.CloudProviderGoogle:
+		rawConfig, err := gcptypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting gcp raw config: %w", err)
+		}
+		instanceType = rawConfig.MachineType
+	case types.CloudProviderAzure:
+		rawConfig, err := azuretypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting azure raw config: %w", err)
+		}
+		instanceType = rawConfig.VMSize
+	case types.CloudProviderOpenstack:
+		rawConfig, err := openstacktypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting openstack raw config: %w", err)
+		}
+		instanceType = rawConfig.Flavor
+	case types.CloudProviderAlibaba:
+		rawConfig, err := alibabatypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting alibaba raw config: %w", err)
+		}
+		instanceType = rawConfig.InstanceType
+	case types.CloudProviderHetzner:
+		rawConfig, err := hetznertypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting hetzner raw config: %w", err)
+		}
+		instanceType = rawConfig.ServerType
+	case types.CloudProviderDigitalocean:
+		rawConfig, err := digitaloceantypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting digitalOcean raw config: %w", err)
+		}
+		instanceType = rawConfig.Size
+	case types.CloudProviderEquinixMetal, types.CloudProviderPacket:
+		rawConfig, err := equinixtypes.GetConfig(*config)
+		if err != nil {
+			return "", fmt.Errorf("error getting packet raw config: %w", err)
+		}
+		instanceType = rawConfig.InstanceType
+	default:
+		return "", nil
+	}
+
+	name, err := configVarResolver.GetConfigVarStringValue(instanceType)
+	if err != nil {
+		return "", fmt.Errorf("error getting instance type from machine config: %w", err)
+	}
+
+	return name, nil
+}
+
 func getAWSResourceRequirements(ctx context.Context, userClient ctrlruntimeclient.Client, config *types.Config) (*ResourceDetails, error) {
 	configVarResolver := providerconfig.NewConfigVarResolver(ctx, userClient)
 	rawConfig, err := awstypes.GetConfig(*config)
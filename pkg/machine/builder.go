@@ -23,6 +23,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/machine/operatingsystem"
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
 	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
 	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
 
@@ -48,6 +49,7 @@ type MachineBuilder struct {
 	cloudProviderSpec   interface{}
 	operatingSystemSpec interface{}
 	networkConfig       *providerconfig.NetworkConfig
+	spotMaxPrice        *string
 }
 
 func NewBuilder() *MachineBuilder {
@@ -88,6 +90,13 @@ func (b *MachineBuilder) WithNetworkConfig(networkConfig *providerconfig.Network
 	return b
 }
 
+// WithSpotInstances makes the machines use spot instances (AWS) or spot VMs (GCP). On AWS,
+// maxPrice limits the hourly price; when empty, the price is capped at the on-demand price.
+func (b *MachineBuilder) WithSpotInstances(maxPrice string) *MachineBuilder {
+	b.spotMaxPrice = &maxPrice
+	return b
+}
+
 func (b *MachineBuilder) AddSSHPublicKey(pubKeys ...string) *MachineBuilder {
 	b.sshPubKeys.Insert(pubKeys...).Delete("") // make sure to not add an empty key by accident
 	return b
@@ -127,7 +136,7 @@ func (b *MachineBuilder) BuildCloudProviderSpec() (interface{}, error) {
 		return nil, fmt.Errorf("failed to determine datacenter: %w", err)
 	}
 
-	return CompleteCloudProviderSpec(b.cloudProviderSpec, cloudProvider, b.cluster, datacenter, operatingSystem)
+	return b.completeCloudProviderSpec(cloudProvider, datacenter, operatingSystem)
 }
 
 func (b *MachineBuilder) BuildProviderConfig() (*providerconfig.Config, error) {
@@ -146,7 +155,7 @@ func (b *MachineBuilder) BuildProviderConfig() (*providerconfig.Config, error) {
 		return nil, fmt.Errorf("failed to determine datacenter: %w", err)
 	}
 
-	cloudProviderSpec, err := CompleteCloudProviderSpec(b.cloudProviderSpec, cloudProvider, b.cluster, datacenter, operatingSystem)
+	cloudProviderSpec, err := b.completeCloudProviderSpec(cloudProvider, datacenter, operatingSystem)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply cluster information to the provider spec: %w", err)
 	}
@@ -173,6 +182,25 @@ func (b *MachineBuilder) BuildProviderSpec() (*clusterv1alpha1.ProviderSpec, err
 	return CreateProviderSpec(providerConfig)
 }
 
+func (b *MachineBuilder) completeCloudProviderSpec(cloudProvider kubermaticv1.ProviderType, datacenter *kubermaticv1.Datacenter, operatingSystem providerconfig.OperatingSystem) (interface{}, error) {
+	cloudProviderSpec, err := CompleteCloudProviderSpec(b.cloudProviderSpec, cloudProvider, b.cluster, datacenter, operatingSystem)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.spotMaxPrice != nil {
+		if err := spot.Enable(cloudProviderSpec, *b.spotMaxPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := spot.ValidateAllowed(cloudProviderSpec, datacenter); err != nil {
+		return nil, err
+	}
+
+	return cloudProviderSpec, nil
+}
+
 func (b *MachineBuilder) determineCloudProvider() (kubermaticv1.ProviderType, error) {
 	var provider kubermaticv1.ProviderType
 
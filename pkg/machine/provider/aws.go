@@ -206,6 +206,8 @@ func CompleteAWSProviderSpec(config *aws.RawConfig, cluster *kubermaticv1.Cluste
 		if projectID, ok := cluster.Labels[kubermaticv1.ProjectIDLabelKey]; ok {
 			config.Tags["system/project"] = projectID
 		}
+
+		config.Tags = addResourceTags(config.Tags, cluster)
 	}
 
 	return config, nil
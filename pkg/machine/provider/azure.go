@@ -167,6 +167,8 @@ func CompleteAzureProviderSpec(config *azure.RawConfig, cluster *kubermaticv1.Cl
 		if projectID, ok := cluster.Labels[kubermaticv1.ProjectIDLabelKey]; ok {
 			config.Tags["system-project"] = projectID
 		}
+
+		config.Tags = addResourceTags(config.Tags, cluster)
 	}
 
 	return config, nil
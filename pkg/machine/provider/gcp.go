@@ -134,6 +134,10 @@ func CompleteGCPProviderSpec(config *gce.RawConfig, cluster *kubermaticv1.Cluste
 		}
 
 		config.Tags = sets.List(tags)
+
+		if len(cluster.Status.ResourceTags) > 0 {
+			config.Labels = addResourceTags(config.Labels, cluster)
+		}
 	}
 
 	if datacenter != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package provider
 
 import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -59,6 +60,22 @@ func mergeTags(existing []providerconfig.ConfigVarString, newTags []string) []pr
 	return existing
 }
 
+// addResourceTags adds the cluster's effective resource tags to the given tags, without
+// overwriting tags that have already been set explicitly. The result is never nil.
+func addResourceTags(tags map[string]string, cluster *kubermaticv1.Cluster) map[string]string {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	for key, value := range cluster.Status.ResourceTags {
+		if _, exists := tags[key]; !exists {
+			tags[key] = value
+		}
+	}
+
+	return tags
+}
+
 func IsConfigVarStringEmpty(val providerconfig.ConfigVarString) bool {
 	// Check if SecretKeyRef is empty.
 	if val.SecretKeyRef.ObjectReference.Namespace != "" ||
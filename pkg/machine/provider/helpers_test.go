@@ -121,3 +121,24 @@ func runProviderTestcases[T any](t *testing.T, cluster *kubermaticv1.Cluster, te
 		})
 	}
 }
+
+func TestAddResourceTags(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Status: kubermaticv1.ClusterStatus{
+			ResourceTags: map[string]string{
+				"env":  "prod",
+				"team": "a",
+			},
+		},
+	}
+
+	tags := addResourceTags(map[string]string{"team": "explicit"}, cluster)
+
+	expected := map[string]string{
+		"env":  "prod",
+		"team": "explicit",
+	}
+	if !diff.SemanticallyEqual(expected, tags) {
+		t.Fatalf("Tags differ:\n%v", diff.ObjectDiff(expected, tags))
+	}
+}
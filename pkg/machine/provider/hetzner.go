@@ -66,6 +66,16 @@ func (b *hetznerConfig) WithNetwork(network string) *hetznerConfig {
 	return b
 }
 
+func (b *hetznerConfig) WithFirewall(firewall string) *hetznerConfig {
+	b.Firewalls = append(b.Firewalls, providerconfig.ConfigVarString{Value: firewall})
+	return b
+}
+
+func (b *hetznerConfig) WithPlacementGroupPrefix(prefix string) *hetznerConfig {
+	b.PlacementGroupPrefix.Value = prefix
+	return b
+}
+
 func CompleteHetznerProviderSpec(config *hetzner.RawConfig, cluster *kubermaticv1.Cluster, datacenter *kubermaticv1.DatacenterSpecHetzner) (*hetzner.RawConfig, error) {
 	if cluster != nil && cluster.Spec.Cloud.Hetzner == nil {
 		return nil, fmt.Errorf("cannot use cluster to create Hetzner cloud spec as cluster uses %q", cluster.Spec.Cloud.ProviderName)
@@ -82,6 +92,20 @@ func CompleteHetznerProviderSpec(config *hetzner.RawConfig, cluster *kubermaticv
 				Value: cluster.Spec.Cloud.Hetzner.Network,
 			}}
 		}
+
+		if len(config.Firewalls) == 0 && cluster.Spec.Cloud.Hetzner.Firewall != "" {
+			config.Firewalls = []providerconfig.ConfigVarString{{
+				Value: cluster.Spec.Cloud.Hetzner.Firewall,
+			}}
+		}
+
+		if config.PlacementGroupPrefix.Value == "" {
+			config.PlacementGroupPrefix.Value = cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix
+		}
+
+		if len(cluster.Status.ResourceTags) > 0 {
+			config.Labels = addResourceTags(config.Labels, cluster)
+		}
 	}
 
 	if datacenter != nil {
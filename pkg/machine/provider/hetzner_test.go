@@ -31,6 +31,8 @@ func TestHetznerConfigBuilder(t *testing.T) {
 		WithImage("image").
 		WithLocation("location").
 		WithNetwork("network").
+		WithFirewall("firewall").
+		WithPlacementGroupPrefix("prefix").
 		Build()
 
 	// ... then randomly check whether the functions actually did anything
@@ -94,4 +96,27 @@ func TestCompleteHetznerProviderSpec(t *testing.T) {
 	}
 
 	runProviderTestcases(t, goodCluster, testcases)
+
+	t.Run("should apply the firewall and placement group of the cluster", func(t *testing.T) {
+		cluster := genCluster(kubermaticv1.CloudSpec{
+			ProviderName: string(kubermaticv1.HetznerCloudProvider),
+			Hetzner: &kubermaticv1.HetznerCloudSpec{
+				Firewall:             "kubernetes-test",
+				PlacementGroupPrefix: "kubernetes-test",
+			},
+		})
+
+		config, err := CompleteHetznerProviderSpec(nil, cluster, &kubermaticv1.DatacenterSpecHetzner{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(config.Firewalls) != 1 || config.Firewalls[0].Value != "kubernetes-test" {
+			t.Errorf("Expected firewall kubernetes-test, got %v", config.Firewalls)
+		}
+
+		if config.PlacementGroupPrefix.Value != "kubernetes-test" {
+			t.Errorf("Expected placement group prefix kubernetes-test, got %q", config.PlacementGroupPrefix.Value)
+		}
+	})
 }
@@ -144,6 +144,8 @@ func CompleteOpenstackProviderSpec(config *openstack.RawConfig, cluster *kuberma
 		if projectID, ok := cluster.Labels[kubermaticv1.ProjectIDLabelKey]; ok {
 			config.Tags["system-project"] = projectID
 		}
+
+		config.Tags = addResourceTags(config.Tags, cluster)
 	}
 
 	return config, nil
@@ -0,0 +1,221 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spot contains helpers to configure machines to use spot (AWS) or
+// preemptible/spot (GCP) capacity and to convert them back to on-demand capacity.
+package spot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+	aws "k8c.io/machine-controller/pkg/cloudprovider/provider/aws/types"
+	gce "k8c.io/machine-controller/pkg/cloudprovider/provider/gce/types"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// FallbackTimeoutAnnotation on a spot MachineDeployment enables the on-demand fallback: machines
+	// that have not joined the cluster within this duration are replaced by on-demand machines.
+	FallbackTimeoutAnnotation = "kubermatic.k8c.io/spot-fallback-timeout"
+
+	// FallbackForLabel is put on the on-demand MachineDeployments created by the fallback and
+	// contains the name of the spot MachineDeployment.
+	FallbackForLabel = "kubermatic.k8c.io/spot-fallback-for"
+
+	// DefaultFallbackTimeout is the fallback timeout used for spot MachineDeployments created from
+	// the initial MachineDeployment request.
+	DefaultFallbackTimeout = 10 * time.Minute
+
+	gcpSpotProvisioningModel = "SPOT"
+)
+
+// ErrNotAllowed is returned for machines using spot capacity in datacenters that do not allow it.
+var ErrNotAllowed = errors.New("spot instances are not allowed in this datacenter")
+
+// Enable configures the given cloud provider spec to use spot capacity. On AWS, an empty maxPrice
+// caps the price at the on-demand price; on GCP, the maxPrice is ignored.
+func Enable(spec interface{}, maxPrice string) error {
+	switch s := spec.(type) {
+	case *aws.RawConfig:
+		s.IsSpotInstance = ptr.To(true)
+		s.SpotInstanceConfig = &aws.SpotInstanceConfig{
+			MaxPrice: providerconfig.ConfigVarString{Value: maxPrice},
+		}
+	case *gce.RawConfig:
+		s.Preemptible.Value = ptr.To(true)
+		s.ProvisioningModel = &providerconfig.ConfigVarString{Value: gcpSpotProvisioningModel}
+	default:
+		return fmt.Errorf("spot instances are not supported for %T", spec)
+	}
+
+	return nil
+}
+
+// Disable configures the given cloud provider spec to use on-demand capacity. Specs of providers
+// without spot support are left untouched.
+func Disable(spec interface{}) {
+	switch s := spec.(type) {
+	case *aws.RawConfig:
+		s.IsSpotInstance = ptr.To(false)
+		s.SpotInstanceConfig = nil
+	case *gce.RawConfig:
+		s.Preemptible.Value = ptr.To(false)
+		s.ProvisioningModel = nil
+	}
+}
+
+// Enabled returns true if the given cloud provider spec uses spot capacity.
+func Enabled(spec interface{}) bool {
+	switch s := spec.(type) {
+	case *aws.RawConfig:
+		return ptr.Deref(s.IsSpotInstance, false)
+	case *gce.RawConfig:
+		return ptr.Deref(s.Preemptible.Value, false) ||
+			(s.ProvisioningModel != nil && s.ProvisioningModel.Value == gcpSpotProvisioningModel)
+	}
+
+	return false
+}
+
+// Allowed returns true if the datacenter allows machines to use spot capacity.
+func Allowed(datacenter *kubermaticv1.Datacenter) bool {
+	if datacenter == nil {
+		return true
+	}
+
+	var allowed *bool
+	switch {
+	case datacenter.Spec.AWS != nil:
+		allowed = datacenter.Spec.AWS.AllowSpotInstances
+	case datacenter.Spec.GCP != nil:
+		allowed = datacenter.Spec.GCP.AllowSpotInstances
+	}
+
+	return ptr.Deref(allowed, true)
+}
+
+// ValidateAllowed returns an error if the given cloud provider spec uses spot capacity, but the
+// datacenter does not allow it.
+func ValidateAllowed(spec interface{}, datacenter *kubermaticv1.Datacenter) error {
+	if !Allowed(datacenter) && Enabled(spec) {
+		return ErrNotAllowed
+	}
+
+	return nil
+}
+
+// ValidateProviderSpecAllowed returns an error if the given machine provider spec uses spot
+// capacity, but spot capacity is not allowed.
+func ValidateProviderSpecAllowed(providerSpec clusterv1alpha1.ProviderSpec, allowed bool) error {
+	if allowed {
+		return nil
+	}
+
+	_, spec, err := DecodeProviderSpec(providerSpec)
+	if err != nil {
+		return err
+	}
+
+	if Enabled(spec) {
+		return ErrNotAllowed
+	}
+
+	return nil
+}
+
+// DecodeProviderSpec returns the typed cloud provider spec for the given machine provider spec.
+// For cloud providers without spot support, nil is returned.
+func DecodeProviderSpec(providerSpec clusterv1alpha1.ProviderSpec) (*providerconfig.Config, interface{}, error) {
+	config, err := providerconfig.GetConfig(providerSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var spec interface{}
+	switch config.CloudProvider {
+	case providerconfig.CloudProviderAWS:
+		spec = &aws.RawConfig{}
+	case providerconfig.CloudProviderGoogle:
+		spec = &gce.RawConfig{}
+	default:
+		return config, nil, nil
+	}
+
+	if err := json.Unmarshal(config.CloudProviderSpec.Raw, spec); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode cloud provider spec: %w", err)
+	}
+
+	return config, spec, nil
+}
+
+// IsSpotMachineDeployment returns true if the MachineDeployment creates spot machines.
+func IsSpotMachineDeployment(md *clusterv1alpha1.MachineDeployment) (bool, error) {
+	_, spec, err := DecodeProviderSpec(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return Enabled(spec), nil
+}
+
+// OnDemandProviderSpec returns a copy of the given provider spec that uses on-demand capacity.
+func OnDemandProviderSpec(providerSpec clusterv1alpha1.ProviderSpec) (clusterv1alpha1.ProviderSpec, error) {
+	config, spec, err := DecodeProviderSpec(providerSpec)
+	if err != nil {
+		return providerSpec, err
+	}
+
+	if spec == nil {
+		return providerSpec, nil
+	}
+
+	Disable(spec)
+
+	config.CloudProviderSpec.Raw, err = json.Marshal(spec)
+	if err != nil {
+		return providerSpec, fmt.Errorf("failed to encode cloud provider spec: %w", err)
+	}
+
+	encoded, err := json.Marshal(config)
+	if err != nil {
+		return providerSpec, fmt.Errorf("failed to encode provider config: %w", err)
+	}
+
+	return clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: encoded}}, nil
+}
+
+// FallbackTimeout returns the fallback timeout configured on the MachineDeployment, if any.
+func FallbackTimeout(md *clusterv1alpha1.MachineDeployment) (time.Duration, bool, error) {
+	value, ok := md.Annotations[FallbackTimeoutAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s annotation: %w", FallbackTimeoutAnnotation, err)
+	}
+
+	return timeout, true, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spot
+
+import (
+	"encoding/json"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+	aws "k8c.io/machine-controller/pkg/cloudprovider/provider/aws/types"
+	azure "k8c.io/machine-controller/pkg/cloudprovider/provider/azure/types"
+	gce "k8c.io/machine-controller/pkg/cloudprovider/provider/gce/types"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestEnableDisable(t *testing.T) {
+	testCases := []struct {
+		name        string
+		spec        interface{}
+		errExpected bool
+	}{
+		{
+			name: "AWS",
+			spec: &aws.RawConfig{},
+		},
+		{
+			name: "GCP",
+			spec: &gce.RawConfig{},
+		},
+		{
+			name:        "Azure is not supported",
+			spec:        &azure.RawConfig{},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Enable(tc.spec, "0.5")
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err=%v, got err=%v", tc.errExpected, err)
+			}
+
+			if Enabled(tc.spec) == tc.errExpected {
+				t.Fatalf("Expected spot to be enabled=%v", !tc.errExpected)
+			}
+
+			Disable(tc.spec)
+
+			if Enabled(tc.spec) {
+				t.Fatal("Expected spot to be disabled")
+			}
+		})
+	}
+}
+
+func TestValidateAllowed(t *testing.T) {
+	spotSpec := &aws.RawConfig{IsSpotInstance: ptr.To(true)}
+
+	testCases := []struct {
+		name        string
+		spec        interface{}
+		datacenter  *kubermaticv1.Datacenter
+		errExpected bool
+	}{
+		{
+			name:       "spot is allowed by default",
+			spec:       spotSpec,
+			datacenter: &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{AWS: &kubermaticv1.DatacenterSpecAWS{}}},
+		},
+		{
+			name:        "spot is forbidden by the datacenter",
+			spec:        spotSpec,
+			datacenter:  &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{AWS: &kubermaticv1.DatacenterSpecAWS{AllowSpotInstances: ptr.To(false)}}},
+			errExpected: true,
+		},
+		{
+			name:       "on-demand is always allowed",
+			spec:       &aws.RawConfig{},
+			datacenter: &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{AWS: &kubermaticv1.DatacenterSpecAWS{AllowSpotInstances: ptr.To(false)}}},
+		},
+		{
+			name:        "preemptible VMs are forbidden by the datacenter",
+			spec:        &gce.RawConfig{Preemptible: providerconfig.ConfigVarBool{Value: ptr.To(true)}},
+			datacenter:  &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{GCP: &kubermaticv1.DatacenterSpecGCP{AllowSpotInstances: ptr.To(false)}}},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateAllowed(tc.spec, tc.datacenter); (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err=%v, got err=%v", tc.errExpected, err)
+			}
+		})
+	}
+}
+
+func TestOnDemandProviderSpec(t *testing.T) {
+	cloudProviderSpec, err := json.Marshal(&aws.RawConfig{
+		InstanceType:       providerconfig.ConfigVarString{Value: "t3.medium"},
+		IsSpotInstance:     ptr.To(true),
+		SpotInstanceConfig: &aws.SpotInstanceConfig{MaxPrice: providerconfig.ConfigVarString{Value: "0.5"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode cloud provider spec: %v", err)
+	}
+
+	config, err := json.Marshal(&providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderAWS,
+		CloudProviderSpec: runtime.RawExtension{Raw: cloudProviderSpec},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode provider config: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Spec.Template.Spec.ProviderSpec = clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: config}}
+
+	isSpot, err := IsSpotMachineDeployment(md)
+	if err != nil {
+		t.Fatalf("Failed to check MachineDeployment: %v", err)
+	}
+	if !isSpot {
+		t.Fatal("Expected MachineDeployment to use spot instances")
+	}
+
+	md.Spec.Template.Spec.ProviderSpec, err = OnDemandProviderSpec(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		t.Fatalf("Failed to create on-demand provider spec: %v", err)
+	}
+
+	_, spec, err := DecodeProviderSpec(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		t.Fatalf("Failed to decode provider spec: %v", err)
+	}
+
+	awsSpec := spec.(*aws.RawConfig)
+	if Enabled(awsSpec) || awsSpec.SpotInstanceConfig != nil {
+		t.Error("Expected on-demand provider spec to not use spot instances")
+	}
+	if awsSpec.InstanceType.Value != "t3.medium" {
+		t.Errorf("Expected instance type to be kept, got %q", awsSpec.InstanceType.Value)
+	}
+}
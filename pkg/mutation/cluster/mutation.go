@@ -68,6 +68,13 @@ func MutateCreate(newCluster *kubermaticv1.Cluster, config *kubermaticv1.Kuberma
 		}
 	}
 
+	// Existing clusters must opt in, as the firewalls created by KKP restrict inbound traffic.
+	if newCluster.Spec.Cloud.Hetzner != nil || newCluster.Spec.Cloud.Digitalocean != nil {
+		if _, ok := newCluster.Spec.Features[kubermaticv1.ClusterFeatureManagedCloudResources]; !ok {
+			newCluster.Spec.Features[kubermaticv1.ClusterFeatureManagedCloudResources] = true
+		}
+	}
+
 	if newCluster.Spec.ClusterNetwork.KonnectivityEnabled == nil { //nolint:staticcheck
 		newCluster.Spec.ClusterNetwork.KonnectivityEnabled = ptr.To(true) //nolint:staticcheck
 	}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+	mcproviderconfig "k8c.io/machine-controller/pkg/providerconfig"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InsufficientQuotaError is returned by CapacityChecker implementations if a quota
+// does not leave enough room for a cluster.
+type InsufficientQuotaError struct {
+	// Resource is a human readable name of the limited resource, e.g. "vCPUs".
+	Resource string
+	// Requested is the amount of the resource that is needed.
+	Requested int
+	// Available is the amount of the resource that is still available.
+	Available int
+}
+
+func (e *InsufficientQuotaError) Error() string {
+	return fmt.Sprintf("insufficient %s quota: %d requested, but only %d available", e.Resource, e.Requested, e.Available)
+}
+
+// IsInsufficientQuotaError returns true if the given error is or wraps an InsufficientQuotaError.
+func IsInsufficientQuotaError(err error) bool {
+	var quotaErr *InsufficientQuotaError
+	return errors.As(err, &quotaErr)
+}
+
+// CheckQuota returns an InsufficientQuotaError if requested exceeds the available part of
+// the given limit. Negative limits are treated as unlimited.
+func CheckQuota(resource string, requested, used, limit int) error {
+	if requested <= 0 || limit < 0 {
+		return nil
+	}
+
+	available := max(limit-used, 0)
+	if requested > available {
+		return &InsufficientQuotaError{
+			Resource:  resource,
+			Requested: requested,
+			Available: available,
+		}
+	}
+
+	return nil
+}
+
+// NewCapacityRequest creates a CapacityRequest for the machines of a MachineDeployment.
+// userClusterClient is used to resolve providerSpec fields that reference Secrets or
+// ConfigMaps; it can be nil if the user cluster is not reachable yet, in which case
+// such fields are treated as unknown.
+func NewCapacityRequest(ctx context.Context, md *clusterv1alpha1.MachineDeployment, userClusterClient ctrlruntimeclient.Client) (CapacityRequest, error) {
+	config, err := providerconfig.GetConfig(md.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		return CapacityRequest{}, fmt.Errorf("failed to read providerSpec: %w", err)
+	}
+
+	replicas := 1
+	if md.Spec.Replicas != nil {
+		replicas = int(*md.Spec.Replicas)
+	}
+
+	request := CapacityRequest{
+		Replicas: replicas,
+		Config:   config,
+	}
+
+	if userClusterClient != nil {
+		request.resolver = mcproviderconfig.NewConfigVarResolver(ctx, userClusterClient)
+	}
+
+	return request, nil
+}
+
+// StringValue returns the value of a providerSpec field. False is returned if the
+// value is unknown, because it references a Secret or ConfigMap that cannot be read.
+func (r CapacityRequest) StringValue(configVar providerconfig.ConfigVarString) (string, bool, error) {
+	if r.resolver == nil {
+		if configVar.SecretKeyRef.Name != "" || configVar.ConfigMapKeyRef.Name != "" {
+			return "", false, nil
+		}
+
+		return configVar.Value, true, nil
+	}
+
+	value, err := r.resolver.GetConfigVarStringValue(configVar)
+	if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+// BoolValue works like StringValue for boolean fields. Unset fields are false.
+func (r CapacityRequest) BoolValue(configVar providerconfig.ConfigVarBool) (bool, bool, error) {
+	if r.resolver == nil {
+		if configVar.SecretKeyRef.Name != "" || configVar.ConfigMapKeyRef.Name != "" {
+			return false, false, nil
+		}
+
+		return configVar.Value != nil && *configVar.Value, true, nil
+	}
+
+	value, _, err := r.resolver.GetConfigVarBoolValue(configVar)
+	if err != nil {
+		return false, false, err
+	}
+
+	return value, true, nil
+}
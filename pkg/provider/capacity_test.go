@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckQuota(t *testing.T) {
+	testcases := []struct {
+		name      string
+		requested int
+		used      int
+		limit     int
+		expected  *InsufficientQuotaError
+	}{
+		{
+			name:      "enough room left",
+			requested: 2,
+			used:      6,
+			limit:     8,
+		},
+		{
+			name:      "unlimited quota",
+			requested: 100,
+			used:      6,
+			limit:     -1,
+		},
+		{
+			name:      "nothing requested",
+			requested: 0,
+			used:      10,
+			limit:     8,
+		},
+		{
+			name:      "quota exceeded",
+			requested: 3,
+			used:      6,
+			limit:     8,
+			expected:  &InsufficientQuotaError{Resource: "vCPUs", Requested: 3, Available: 2},
+		},
+		{
+			name:      "quota already overused",
+			requested: 1,
+			used:      10,
+			limit:     8,
+			expected:  &InsufficientQuotaError{Resource: "vCPUs", Requested: 1, Available: 0},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckQuota("vCPUs", tc.requested, tc.used, tc.limit)
+
+			if tc.expected == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			wrapped := fmt.Errorf("wrapped: %w", err)
+			if !IsInsufficientQuotaError(wrapped) {
+				t.Fatalf("Expected InsufficientQuotaError, got %v", err)
+			}
+
+			var quotaErr *InsufficientQuotaError
+			if errors.As(err, &quotaErr); *quotaErr != *tc.expected {
+				t.Fatalf("Expected %v, got %v", tc.expected, quotaErr)
+			}
+		})
+	}
+}
+
+func TestCapacityRequestStringValue(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-config",
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string][]byte{"instanceType": []byte("m5.large")},
+	}
+
+	reference := providerconfig.ConfigVarString{
+		SecretKeyRef: providerconfig.GlobalSecretKeySelector{
+			ObjectReference: corev1.ObjectReference{Name: secret.Name, Namespace: secret.Namespace},
+			Key:             "instanceType",
+		},
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(`{"cloudProvider":"aws"}`)}
+
+	ctx := context.Background()
+
+	// without a user cluster, references cannot be resolved
+	request, err := NewCapacityRequest(ctx, md, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	if _, known, err := request.StringValue(reference); err != nil || known {
+		t.Fatalf("Expected reference to be unknown, got known=%v, err=%v", known, err)
+	}
+
+	if value, known, err := request.StringValue(providerconfig.ConfigVarString{Value: "t3.small"}); err != nil || !known || value != "t3.small" {
+		t.Fatalf("Expected plain value, got %q, known=%v, err=%v", value, known, err)
+	}
+
+	request, err = NewCapacityRequest(ctx, md, fake.NewClientBuilder().WithObjects(secret).Build())
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	if value, known, err := request.StringValue(reference); err != nil || !known || value != "m5.large" {
+		t.Fatalf("Expected reference to be resolved, got %q, known=%v, err=%v", value, known, err)
+	}
+}
@@ -27,7 +27,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/smithy-go"
 
@@ -40,9 +43,12 @@ const (
 )
 
 type ClientSet struct {
-	EC2 *ec2.Client
-	EKS *eks.Client
-	IAM *iam.Client
+	EC2           *ec2.Client
+	EKS           *eks.Client
+	ELB           *elb.Client
+	ELBV2         *elbv2.Client
+	IAM           *iam.Client
+	ServiceQuotas *servicequotas.Client
 }
 
 func ValidateCredentials(ctx context.Context, accessKeyID, secretAccessKey string) error {
@@ -113,15 +119,39 @@ func getClientSet(ctx context.Context, accessKeyID, secretAccessKey, assumeRoleA
 				o.BaseEndpoint = &endpoint
 			}
 		}),
+		ELB: elb.NewFromConfig(cfg, func(o *elb.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = &endpoint
+			}
+		}),
+		ELBV2: elbv2.NewFromConfig(cfg, func(o *elbv2.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = &endpoint
+			}
+		}),
 		IAM: iam.NewFromConfig(cfg, func(o *iam.Options) {
 			if endpoint != "" {
 				o.BaseEndpoint = &endpoint
 			}
 		}),
+		ServiceQuotas: servicequotas.NewFromConfig(cfg, func(o *servicequotas.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = &endpoint
+			}
+		}),
 	}, nil
 }
 
-var notFoundErrors = sets.New("NoSuchEntity", "InvalidVpcID.NotFound", "InvalidRouteTableID.NotFound", "InvalidGroup.NotFound")
+var notFoundErrors = sets.New(
+	"NoSuchEntity",
+	"InvalidVpcID.NotFound",
+	"InvalidRouteTableID.NotFound",
+	"InvalidGroup.NotFound",
+	"InvalidInstanceID.NotFound",
+	"InvalidVolume.NotFound",
+	"InvalidSubnetID.NotFound",
+	"LoadBalancerNotFound",
+)
 
 func isNotFound(err error) bool {
 	var aerr smithy.APIError
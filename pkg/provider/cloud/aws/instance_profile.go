@@ -112,7 +112,7 @@ func ensureInstanceProfile(ctx context.Context, client *iam.Client, cluster *kub
 	// create missing profile
 	createProfileInput := &iam.CreateInstanceProfileInput{
 		InstanceProfileName: ptr.To(profileName),
-		Tags:                []iamtypes.Tag{iamOwnershipTag(cluster.Name)},
+		Tags:                append(iamResourceTags(cluster.Status.ResourceTags), iamOwnershipTag(cluster.Name)),
 	}
 
 	output, err := client.CreateInstanceProfile(ctx, createProfileInput)
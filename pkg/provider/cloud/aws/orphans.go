@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+)
+
+var _ provider.OrphanScanner = &AmazonEC2{}
+
+const (
+	// classicLoadBalancerType is the resource type used for classic ELBs,
+	// which are identified by their name.
+	classicLoadBalancerType = "classic-load-balancer"
+	// loadBalancerType is the resource type used for application and network
+	// load balancers, which are identified by their ARN.
+	loadBalancerType = "load-balancer"
+
+	// ownedTagValue is the value of the kubernetes.io/cluster tag on resources
+	// that were created for the cluster, e.g. by the cloud-controller-manager
+	// or the EBS CSI driver. Shared resources carry another (or no) value.
+	ownedTagValue = "owned"
+
+	// elbDescribeTagsLimit is the maximum number of load balancers whose tags
+	// can be described in a single request.
+	elbDescribeTagsLimit = 20
+)
+
+// orphanDeletionOrder defines the order in which owned resources must be
+// deleted; instances and load balancers keep volumes, security groups and
+// subnets in use, and route tables cannot be deleted while associated to a
+// subnet. Only these types are ever reported.
+var orphanDeletionOrder = map[string]int{
+	string(ec2types.ResourceTypeInstance):      0,
+	classicLoadBalancerType:                    1,
+	loadBalancerType:                           1,
+	string(ec2types.ResourceTypeVolume):        2,
+	string(ec2types.ResourceTypeSecurityGroup): 3,
+	string(ec2types.ResourceTypeSubnet):        4,
+	string(ec2types.ResourceTypeRouteTable):    5,
+}
+
+// ownerCluster returns the name of the cluster that owns a resource with the
+// given tag, or an empty string if the tag does not indicate ownership. Next to
+// KKP's own ownership tag, the kubernetes.io/cluster tag with the value "owned"
+// is considered. The latter is also used by EKS, kOps and others, so callers
+// must only act on clusters known to KKP.
+func ownerCluster(key, value string) string {
+	if clusterName, ok := strings.CutPrefix(key, ownershipTagPrefix); ok {
+		return clusterName
+	}
+
+	if clusterName, ok := strings.CutPrefix(key, kubernetesClusterTagPrefix); ok && value == ownedTagValue {
+		return clusterName
+	}
+
+	return ""
+}
+
+// ListClusterResources returns all EC2 resources and load balancers in the
+// datacenter's region that are owned by a cluster, see ownerCluster. Resources
+// that are merely shared with a cluster, like the VPC, the default route table
+// or subnets KKP tags, are never returned.
+func (a *AmazonEC2) ListClusterResources(ctx context.Context, cloud kubermaticv1.CloudSpec) ([]provider.ClusterResource, error) {
+	client, err := a.getClientSet(ctx, cloud)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	result, err := listEC2ClusterResources(ctx, client.EC2)
+	if err != nil {
+		return nil, err
+	}
+
+	classicLoadBalancers, err := listClassicLoadBalancerClusterResources(ctx, client.ELB)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, classicLoadBalancers...)
+
+	loadBalancers, err := listLoadBalancerClusterResources(ctx, client.ELBV2)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, loadBalancers...)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return orphanDeletionOrder[result[i].Type] < orphanDeletionOrder[result[j].Type]
+	})
+
+	return result, nil
+}
+
+func listEC2ClusterResources(ctx context.Context, client *ec2.Client) ([]provider.ClusterResource, error) {
+	resourceTypes := []string{}
+	for resourceType := range orphanDeletionOrder {
+		if resourceType != classicLoadBalancerType && resourceType != loadBalancerType {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+
+	paginator := ec2.NewDescribeTagsPaginator(client, &ec2.DescribeTagsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   ptr.To("key"),
+				Values: []string{ownershipTagPrefix + "*", kubernetesClusterTagPrefix + "*"},
+			},
+			{
+				Name:   ptr.To("resource-type"),
+				Values: resourceTypes,
+			},
+		},
+	})
+
+	// a resource can carry both ownership tags
+	seen := sets.New[string]()
+
+	result := []provider.ClusterResource{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		for _, tag := range page.Tags {
+			resourceID := ptr.Deref(tag.ResourceId, "")
+			clusterName := ownerCluster(ptr.Deref(tag.Key, ""), ptr.Deref(tag.Value, ""))
+
+			if _, ok := orphanDeletionOrder[string(tag.ResourceType)]; !ok || resourceID == "" || clusterName == "" || seen.Has(resourceID) {
+				continue
+			}
+			seen.Insert(resourceID)
+
+			result = append(result, provider.ClusterResource{
+				Cluster: clusterName,
+				Type:    string(tag.ResourceType),
+				ID:      resourceID,
+				Owned:   true,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func listClassicLoadBalancerClusterResources(ctx context.Context, client *elb.Client) ([]provider.ClusterResource, error) {
+	names := []string{}
+
+	paginator := elb.NewDescribeLoadBalancersPaginator(client, &elb.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list classic load balancers: %w", err)
+		}
+
+		for _, lb := range page.LoadBalancerDescriptions {
+			names = append(names, ptr.Deref(lb.LoadBalancerName, ""))
+		}
+	}
+
+	result := []provider.ClusterResource{}
+	for start := 0; start < len(names); start += elbDescribeTagsLimit {
+		chunk := names[start:min(start+elbDescribeTagsLimit, len(names))]
+
+		out, err := client.DescribeTags(ctx, &elb.DescribeTagsInput{LoadBalancerNames: chunk})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list classic load balancer tags: %w", err)
+		}
+
+		for _, description := range out.TagDescriptions {
+			for _, tag := range description.Tags {
+				if clusterName := ownerCluster(ptr.Deref(tag.Key, ""), ptr.Deref(tag.Value, "")); clusterName != "" {
+					result = append(result, provider.ClusterResource{
+						Cluster: clusterName,
+						Type:    classicLoadBalancerType,
+						ID:      ptr.Deref(description.LoadBalancerName, ""),
+						Owned:   true,
+					})
+					break
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func listLoadBalancerClusterResources(ctx context.Context, client *elbv2.Client) ([]provider.ClusterResource, error) {
+	arns := []string{}
+
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(client, &elbv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list load balancers: %w", err)
+		}
+
+		for _, lb := range page.LoadBalancers {
+			arns = append(arns, ptr.Deref(lb.LoadBalancerArn, ""))
+		}
+	}
+
+	result := []provider.ClusterResource{}
+	for start := 0; start < len(arns); start += elbDescribeTagsLimit {
+		chunk := arns[start:min(start+elbDescribeTagsLimit, len(arns))]
+
+		out, err := client.DescribeTags(ctx, &elbv2.DescribeTagsInput{ResourceArns: chunk})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list load balancer tags: %w", err)
+		}
+
+		for _, description := range out.TagDescriptions {
+			for _, tag := range description.Tags {
+				if clusterName := ownerCluster(ptr.Deref(tag.Key, ""), ptr.Deref(tag.Value, "")); clusterName != "" {
+					result = append(result, provider.ClusterResource{
+						Cluster: clusterName,
+						Type:    loadBalancerType,
+						ID:      ptr.Deref(description.ResourceArn, ""),
+						Owned:   true,
+					})
+					break
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (a *AmazonEC2) DeleteClusterResource(ctx context.Context, cloud kubermaticv1.CloudSpec, resource provider.ClusterResource) error {
+	client, err := a.getClientSet(ctx, cloud)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	switch resource.Type {
+	case string(ec2types.ResourceTypeInstance):
+		_, err = client.EC2.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: []string{resource.ID}})
+	case classicLoadBalancerType:
+		_, err = client.ELB.DeleteLoadBalancer(ctx, &elb.DeleteLoadBalancerInput{LoadBalancerName: &resource.ID})
+	case loadBalancerType:
+		_, err = client.ELBV2.DeleteLoadBalancer(ctx, &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: &resource.ID})
+	case string(ec2types.ResourceTypeVolume):
+		_, err = client.EC2.DeleteVolume(ctx, &ec2.DeleteVolumeInput{VolumeId: &resource.ID})
+	case string(ec2types.ResourceTypeSecurityGroup):
+		_, err = client.EC2.DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{GroupId: &resource.ID})
+	case string(ec2types.ResourceTypeSubnet):
+		_, err = client.EC2.DeleteSubnet(ctx, &ec2.DeleteSubnetInput{SubnetId: &resource.ID})
+	case string(ec2types.ResourceTypeRouteTable):
+		_, err = client.EC2.DeleteRouteTable(ctx, &ec2.DeleteRouteTableInput{RouteTableId: &resource.ID})
+	default:
+		return fmt.Errorf("deleting resources of type %q is not supported", resource.Type)
+	}
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
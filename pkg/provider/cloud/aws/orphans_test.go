@@ -0,0 +1,146 @@
+//go:build integration
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/utils/ptr"
+)
+
+func findClusterResource(resources []provider.ClusterResource, id string) *provider.ClusterResource {
+	for i, resource := range resources {
+		if resource.ID == id {
+			return &resources[i]
+		}
+	}
+
+	return nil
+}
+
+func TestListAndDeleteClusterResources(t *testing.T) {
+	ctx := context.Background()
+	cs := getTestClientSet(ctx, t)
+
+	defaultVPC, err := getDefaultVPC(ctx, cs.EC2)
+	if err != nil {
+		t.Fatalf("getDefaultVPC should not have errored, but returned %v", err)
+	}
+
+	defaultRT, err := getDefaultRouteTable(ctx, cs.EC2, *defaultVPC.VpcId)
+	if err != nil {
+		t.Fatalf("getDefaultRouteTable should not have errored, but returned %v", err)
+	}
+
+	cluster := makeCluster(&kubermaticv1.AWSCloudSpec{
+		VPCID:        *defaultVPC.VpcId,
+		RouteTableID: *defaultRT.RouteTableId,
+	})
+
+	cluster, err = reconcileSecurityGroup(ctx, cs.EC2, cluster, testClusterUpdater(cluster))
+	if err != nil {
+		t.Fatalf("reconcileSecurityGroup should not have errored, but returned %v", err)
+	}
+
+	cluster, err = reconcileClusterTags(ctx, cs.EC2, cluster, testClusterUpdater(cluster))
+	if err != nil {
+		t.Fatalf("reconcileClusterTags should not have errored, but returned %v", err)
+	}
+
+	// volumes created by the EBS CSI driver are only tagged as owned via the
+	// generic kubernetes.io/cluster tag
+	volume, err := cs.EC2.CreateVolume(ctx, &ec2.CreateVolumeInput{
+		AvailabilityZone: ptr.To(os.Getenv(awsRegionEnvName) + "a"),
+		Size:             ptr.To[int32](1),
+		TagSpecifications: []ec2types.TagSpecification{{
+			ResourceType: ec2types.ResourceTypeVolume,
+			Tags: []ec2types.Tag{{
+				Key:   ptr.To(kubernetesClusterTagPrefix + cluster.Name),
+				Value: ptr.To(ownedTagValue),
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CreateVolume should not have errored, but returned %v", err)
+	}
+
+	prov := &AmazonEC2{
+		dc:        &kubermaticv1.DatacenterSpecAWS{},
+		clientSet: cs,
+	}
+
+	resources, err := prov.ListClusterResources(ctx, cluster.Spec.Cloud)
+	if err != nil {
+		t.Fatalf("ListClusterResources should not have errored, but returned %v", err)
+	}
+
+	group := findClusterResource(resources, cluster.Spec.Cloud.AWS.SecurityGroupID)
+	if group == nil {
+		t.Fatalf("security group %q should have been listed, but was not", cluster.Spec.Cloud.AWS.SecurityGroupID)
+	}
+	if group.Cluster != cluster.Name || !group.Owned {
+		t.Fatalf("security group should be owned by cluster %q, but is %+v", cluster.Name, *group)
+	}
+
+	ownedVolume := findClusterResource(resources, *volume.VolumeId)
+	if ownedVolume == nil {
+		t.Fatalf("volume %q should have been listed, but was not", *volume.VolumeId)
+	}
+	if ownedVolume.Cluster != cluster.Name || !ownedVolume.Owned {
+		t.Fatalf("volume should be owned by cluster %q, but is %+v", cluster.Name, *ownedVolume)
+	}
+
+	// KKP only tags the route table as shared with the cluster, so it must
+	// never be considered
+	if routeTable := findClusterResource(resources, cluster.Spec.Cloud.AWS.RouteTableID); routeTable != nil {
+		t.Fatalf("route table should not have been listed, but got %+v", *routeTable)
+	}
+
+	for _, resource := range []provider.ClusterResource{*ownedVolume, *group} {
+		if err := prov.DeleteClusterResource(ctx, cluster.Spec.Cloud, resource); err != nil {
+			t.Fatalf("DeleteClusterResource should not have errored, but returned %v", err)
+		}
+	}
+
+	resources, err = prov.ListClusterResources(ctx, cluster.Spec.Cloud)
+	if err != nil {
+		t.Fatalf("ListClusterResources should not have errored, but returned %v", err)
+	}
+
+	if resource := findClusterResource(resources, group.ID); resource != nil {
+		t.Errorf("security group %q should have been deleted, but was still listed", group.ID)
+	}
+
+	if resource := findClusterResource(resources, ownedVolume.ID); resource != nil {
+		t.Errorf("volume %q should have been deleted, but was still listed", ownedVolume.ID)
+	}
+
+	// the default route table must survive
+	if _, err := getDefaultRouteTable(ctx, cs.EC2, *defaultVPC.VpcId); err != nil {
+		t.Errorf("route table should still exist, but getting it returned %v", err)
+	}
+}
@@ -71,6 +71,7 @@ func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.Secr
 }
 
 var _ provider.ReconcilingCloudProvider = &AmazonEC2{}
+var _ provider.CapacityChecker = &AmazonEC2{}
 
 func (a *AmazonEC2) getClientSet(ctx context.Context, cloud kubermaticv1.CloudSpec) (*ClientSet, error) {
 	if a.clientSet != nil {
@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	awstypes "k8c.io/machine-controller/pkg/cloudprovider/provider/aws/types"
+)
+
+const (
+	// securityGroupsQuotaCode is the code of the "VPC security groups per Region" quota.
+	securityGroupsQuotaCode = "L-E79EC296"
+
+	// networkInterfacesQuotaCode is the code of the "Network interfaces per Region" quota.
+	networkInterfacesQuotaCode = "L-DF5E4CA3"
+)
+
+// vcpuQuotaCodes maps instance families to the code of the quota limiting the number of
+// vCPUs of running On-Demand instances of this family. Families sharing a quota share a code.
+var vcpuQuotaCodes = map[string]string{
+	"a":   "L-1216C47A",
+	"c":   "L-1216C47A",
+	"d":   "L-1216C47A",
+	"h":   "L-1216C47A",
+	"i":   "L-1216C47A",
+	"m":   "L-1216C47A",
+	"r":   "L-1216C47A",
+	"t":   "L-1216C47A",
+	"z":   "L-1216C47A",
+	"f":   "L-74FC7D96",
+	"g":   "L-DB2E81BA",
+	"vt":  "L-DB2E81BA",
+	"p":   "L-417A185B",
+	"x":   "L-7295265B",
+	"inf": "L-1945791B",
+	"dl":  "L-6E869C2A",
+	"trn": "L-2C3B7624",
+	"hpc": "L-F7808C92",
+	"u":   "L-43DA4232",
+}
+
+// vcpuQuotaCode returns the quota code for the given instance type, e.g. "m5.large".
+func vcpuQuotaCode(instanceType ec2types.InstanceType) string {
+	family := strings.ToLower(string(instanceType))
+	if idx := strings.IndexFunc(family, func(r rune) bool { return r < 'a' || r > 'z' }); idx >= 0 {
+		family = family[:idx]
+	}
+
+	return vcpuQuotaCodes[family]
+}
+
+// getServiceQuota returns the applied value of a quota. If AWS does not know an applied
+// value for the quota, false is returned.
+func getServiceQuota(ctx context.Context, client *servicequotas.Client, serviceCode, quotaCode string) (int, bool, error) {
+	output, err := client.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err != nil {
+		var notFound *servicequotastypes.NoSuchResourceException
+		if errors.As(err, &notFound) {
+			return 0, false, nil
+		}
+
+		return 0, false, fmt.Errorf("failed to get quota %s/%s: %w", serviceCode, quotaCode, err)
+	}
+
+	if output.Quota == nil || output.Quota.Value == nil {
+		return 0, false, nil
+	}
+
+	return int(*output.Quota.Value), true, nil
+}
+
+// CheckCapacity verifies that the On-Demand vCPU quota of the requested instance family and the
+// network interface quota leave enough room for the machines and, if KKP needs to create a
+// security group for the cluster, that the security group quota leaves room for it.
+// Machines get auto-assigned public IPs, which do not count against the Elastic IP quota, so
+// that quota is not checked.
+func (a *AmazonEC2) CheckCapacity(ctx context.Context, cloud kubermaticv1.CloudSpec, request provider.CapacityRequest) error {
+	client, err := a.getClientSet(ctx, cloud)
+	if err != nil {
+		return fmt.Errorf("failed to get API client: %w", err)
+	}
+
+	var errs []error
+
+	if request.Config != nil {
+		if err := checkVCPUQuota(ctx, client, request); err != nil {
+			errs = append(errs, err)
+		}
+
+		// every machine needs its primary network interface
+		if err := checkNetworkInterfaceQuota(ctx, client, request.Replicas); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if cloud.AWS.SecurityGroupID == "" {
+		if err := checkSecurityGroupQuota(ctx, client); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkVCPUQuota(ctx context.Context, client *ClientSet, request provider.CapacityRequest) error {
+	rawConfig, err := awstypes.GetConfig(*request.Config)
+	if err != nil {
+		return fmt.Errorf("failed to decode providerSpec: %w", err)
+	}
+
+	// spot instances are limited by separate quotas and can be reclaimed anyway
+	if rawConfig.IsSpotInstance != nil && *rawConfig.IsSpotInstance {
+		return nil
+	}
+
+	instanceTypeName, known, err := request.StringValue(rawConfig.InstanceType)
+	if err != nil {
+		return fmt.Errorf("failed to determine instance type: %w", err)
+	}
+	if !known {
+		return nil
+	}
+
+	instanceType := ec2types.InstanceType(instanceTypeName)
+	quotaCode := vcpuQuotaCode(instanceType)
+	if quotaCode == "" {
+		return nil
+	}
+
+	limit, found, err := getServiceQuota(ctx, client.ServiceQuotas, "ec2", quotaCode)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	types, err := client.EC2.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []ec2types.InstanceType{instanceType},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe instance type %q: %w", instanceType, err)
+	}
+	if len(types.InstanceTypes) == 0 || types.InstanceTypes[0].VCpuInfo == nil {
+		return fmt.Errorf("instance type %q does not exist", instanceType)
+	}
+
+	requested := request.Replicas * int(aws.ToInt32(types.InstanceTypes[0].VCpuInfo.DefaultVCpus))
+
+	used, err := getUsedOnDemandVCPUs(ctx, client.EC2, quotaCode)
+	if err != nil {
+		return err
+	}
+
+	return provider.CheckQuota(fmt.Sprintf("On-Demand vCPUs (quota %s)", quotaCode), requested, used, limit)
+}
+
+// getUsedOnDemandVCPUs sums up the vCPUs of all running On-Demand instances counted against the given quota.
+func getUsedOnDemandVCPUs(ctx context.Context, client *ec2.Client, quotaCode string) (int, error) {
+	used := 0
+
+	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("instance-state-name"),
+			Values: []string{string(ec2types.InstanceStateNamePending), string(ec2types.InstanceStateNameRunning)},
+		}},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list instances: %w", err)
+		}
+
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.InstanceLifecycle != "" || instance.CpuOptions == nil || vcpuQuotaCode(instance.InstanceType) != quotaCode {
+					continue
+				}
+
+				used += int(aws.ToInt32(instance.CpuOptions.CoreCount) * aws.ToInt32(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+	}
+
+	return used, nil
+}
+
+func checkSecurityGroupQuota(ctx context.Context, client *ClientSet) error {
+	limit, found, err := getServiceQuota(ctx, client.ServiceQuotas, "vpc", securityGroupsQuotaCode)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	used := 0
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(client.EC2, &ec2.DescribeSecurityGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list security groups: %w", err)
+		}
+
+		used += len(page.SecurityGroups)
+	}
+
+	return provider.CheckQuota("security groups", 1, used, limit)
+}
+
+func checkNetworkInterfaceQuota(ctx context.Context, client *ClientSet, requested int) error {
+	limit, found, err := getServiceQuota(ctx, client.ServiceQuotas, "vpc", networkInterfacesQuotaCode)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	used := 0
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(client.EC2, &ec2.DescribeNetworkInterfacesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list network interfaces: %w", err)
+		}
+
+		used += len(page.NetworkInterfaces)
+	}
+
+	return provider.CheckQuota("network interfaces", requested, used, limit)
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestVCPUQuotaCode(t *testing.T) {
+	testcases := map[ec2types.InstanceType]string{
+		"t3.medium":    "L-1216C47A",
+		"m5a.2xlarge":  "L-1216C47A",
+		"g4dn.xlarge":  "L-DB2E81BA",
+		"vt1.3xlarge":  "L-DB2E81BA",
+		"inf2.xlarge":  "L-1945791B",
+		"p4d.24xlarge": "L-417A185B",
+		"mac1.metal":   "",
+		"":             "",
+	}
+
+	for instanceType, expected := range testcases {
+		if code := vcpuQuotaCode(instanceType); code != expected {
+			t.Errorf("Expected quota code %q for %q, got %q", expected, instanceType, code)
+		}
+	}
+}
@@ -155,7 +155,7 @@ func ensureRole(ctx context.Context, client *iam.Client, cluster *kubermaticv1.C
 		createRoleInput := &iam.CreateRoleInput{
 			AssumeRolePolicyDocument: ptr.To(assumeRolePolicy),
 			RoleName:                 ptr.To(roleName),
-			Tags:                     []iamtypes.Tag{iamOwnershipTag(cluster.Name)},
+			Tags:                     append(iamResourceTags(cluster.Status.ResourceTags), iamOwnershipTag(cluster.Name)),
 		}
 
 		output, err := client.CreateRole(ctx, createRoleInput)
@@ -111,9 +111,10 @@ func reconcileSecurityGroup(ctx context.Context, client *ec2.Client, cluster *ku
 			Description: ptr.To(fmt.Sprintf("Security group for the Kubernetes cluster %s", cluster.Name)),
 			TagSpecifications: []ec2types.TagSpecification{{
 				ResourceType: ec2types.ResourceTypeSecurityGroup,
-				Tags: []ec2types.Tag{
+				Tags: append(
+					ec2ResourceTags(cluster.Status.ResourceTags),
 					ec2OwnershipTag(cluster.Name),
-				},
+				),
 			}},
 		})
 		if err != nil {
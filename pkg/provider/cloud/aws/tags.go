@@ -27,6 +27,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 )
 
@@ -51,6 +52,41 @@ func iamOwnershipTag(clusterName string) iamtypes.Tag {
 	}
 }
 
+// ec2ResourceTags returns the user-defined resource tags of the cluster, sorted by key.
+func ec2ResourceTags(tags map[string]string) []ec2types.Tag {
+	result := []ec2types.Tag{}
+	for _, key := range sets.List(sets.KeySet(tags)) {
+		result = append(result, ec2types.Tag{
+			Key:   ptr.To(key),
+			Value: ptr.To(tags[key]),
+		})
+	}
+
+	return result
+}
+
+// iamResourceTags returns the user-defined resource tags of the cluster, sorted by key.
+func iamResourceTags(tags map[string]string) []iamtypes.Tag {
+	result := []iamtypes.Tag{}
+	for _, key := range sets.List(sets.KeySet(tags)) {
+		result = append(result, iamtypes.Tag{
+			Key:   ptr.To(key),
+			Value: ptr.To(tags[key]),
+		})
+	}
+
+	return result
+}
+
+func ec2TagMap(tags []ec2types.Tag) map[string]string {
+	result := map[string]string{}
+	for _, tag := range tags {
+		result[ptr.Deref(tag.Key, "")] = ptr.Deref(tag.Value, "")
+	}
+
+	return result
+}
+
 func reconcileClusterTags(ctx context.Context, client *ec2.Client, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
 	// tagging happens after a successful reconciliation, so we can rely on these fields not being empty
 	resourceIDs := []string{
@@ -80,9 +116,50 @@ func reconcileClusterTags(ctx context.Context, client *ec2.Client, cluster *kube
 			cluster.Spec.Cloud.AWS.SecurityGroupID, cluster.Spec.Cloud.AWS.RouteTableID, subnetIDs, err)
 	}
 
+	if err := reconcileSecurityGroupResourceTags(ctx, client, cluster); err != nil {
+		return cluster, err
+	}
+
 	return cluster, nil
 }
 
+// reconcileSecurityGroupResourceTags applies the cluster's resource tags to its security
+// group, but only if the group is owned by KKP. Tags that have been removed from the cluster
+// are not removed from the group, as KKP cannot know whether they have been set by someone else.
+func reconcileSecurityGroupResourceTags(ctx context.Context, client *ec2.Client, cluster *kubermaticv1.Cluster) error {
+	if len(cluster.Status.ResourceTags) == 0 {
+		return nil
+	}
+
+	groupID := cluster.Spec.Cloud.AWS.SecurityGroupID
+
+	out, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		GroupIds: []string{groupID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get security group %s: %w", groupID, err)
+	}
+
+	if len(out.SecurityGroups) == 0 || !hasEC2Tag(ec2OwnershipTag(cluster.Name), out.SecurityGroups[0].Tags) {
+		return nil
+	}
+
+	missing := provider.MissingResourceTags(cluster.Status.ResourceTags, ec2TagMap(out.SecurityGroups[0].Tags))
+	if len(missing) == 0 {
+		return nil
+	}
+
+	_, err = client.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{groupID},
+		Tags:      ec2ResourceTags(missing),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply resource tags to security group %s: %w", groupID, err)
+	}
+
+	return nil
+}
+
 func cleanUpTags(ctx context.Context, client *ec2.Client, cluster *kubermaticv1.Cluster) error {
 	// Instead of trying to keep track of all the things we might have
 	// tagged, we instead simply list all tagged resources. It's a few
@@ -52,7 +52,7 @@ func reconcileAvailabilitySet(ctx context.Context, clients *ClientSet, location
 		})
 	}
 
-	target, err := targetAvailabilitySet(cluster.Spec.Cloud, location, cluster.Name)
+	target, err := targetAvailabilitySet(cluster.Spec.Cloud, location, resourceTags(cluster))
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,8 @@ func reconcileAvailabilitySet(ctx context.Context, clients *ClientSet, location
 	// - SKU name
 	// - fault domain count
 	// - update domain count
-	if !((availabilitySet.SKU != nil && availabilitySet.SKU.Name != nil && *availabilitySet.SKU.Name == *target.SKU.Name) && availabilitySet.Properties != nil &&
+	// - resource tags
+	if !(hasTags(availabilitySet.Tags, target.Tags) && (availabilitySet.SKU != nil && availabilitySet.SKU.Name != nil && *availabilitySet.SKU.Name == *target.SKU.Name) && availabilitySet.Properties != nil &&
 		(availabilitySet.Properties.PlatformFaultDomainCount != nil && *availabilitySet.Properties.PlatformFaultDomainCount == *target.Properties.PlatformFaultDomainCount) &&
 		(availabilitySet.Properties.PlatformUpdateDomainCount != nil && *availabilitySet.Properties.PlatformUpdateDomainCount == *target.Properties.PlatformUpdateDomainCount)) {
 		if err := ensureAvailabilitySet(ctx, clients.AvailabilitySets, cluster.Spec.Cloud, target); err != nil {
@@ -79,7 +80,7 @@ func reconcileAvailabilitySet(ctx context.Context, clients *ClientSet, location
 	})
 }
 
-func targetAvailabilitySet(cloud kubermaticv1.CloudSpec, location string, clusterName string) (*armcompute.AvailabilitySet, error) {
+func targetAvailabilitySet(cloud kubermaticv1.CloudSpec, location string, tags map[string]*string) (*armcompute.AvailabilitySet, error) {
 	faultDomainCount, err := getRegionFaultDomainCount(location)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get region fault domain count: %w", err)
@@ -91,9 +92,7 @@ func targetAvailabilitySet(cloud kubermaticv1.CloudSpec, location string, cluste
 		SKU: &armcompute.SKU{
 			Name: ptr.To("Aligned"),
 		},
-		Tags: map[string]*string{
-			clusterTagKey: ptr.To(clusterName),
-		},
+		Tags: tags,
 		Properties: &armcompute.AvailabilitySetProperties{
 			PlatformFaultDomainCount:  ptr.To[int32](faultDomainCount),
 			PlatformUpdateDomainCount: ptr.To[int32](20),
@@ -80,6 +80,14 @@ type AvailabilitySetClient interface {
 	Delete(ctx context.Context, resourceGroupName string, availabilitySetName string, options *armcompute.AvailabilitySetsClientDeleteOptions) (armcompute.AvailabilitySetsClientDeleteResponse, error)
 }
 
+// PrivateEndpointClient is the subset of functions we need from armnetwork.PrivateEndpointsClient;
+// this interface is purely here for allowing unit tests.
+type PrivateEndpointClient interface {
+	BeginCreateOrUpdate(ctx context.Context, resourceGroupName string, privateEndpointName string, parameters armnetwork.PrivateEndpoint, options *armnetwork.PrivateEndpointsClientBeginCreateOrUpdateOptions) (*runtime.Poller[armnetwork.PrivateEndpointsClientCreateOrUpdateResponse], error)
+	Get(ctx context.Context, resourceGroupName string, privateEndpointName string, options *armnetwork.PrivateEndpointsClientGetOptions) (armnetwork.PrivateEndpointsClientGetResponse, error)
+	BeginDelete(ctx context.Context, resourceGroupName string, privateEndpointName string, options *armnetwork.PrivateEndpointsClientBeginDeleteOptions) (*runtime.Poller[armnetwork.PrivateEndpointsClientDeleteResponse], error)
+}
+
 // ClientSet provides a set of Azure service clients that are necessary to reconcile resources needed by KKP.
 type ClientSet struct {
 	Groups           ResourceGroupClient
@@ -88,6 +96,7 @@ type ClientSet struct {
 	RouteTables      RouteTableClient
 	SecurityGroups   SecurityGroupClient
 	AvailabilitySets AvailabilitySetClient
+	PrivateEndpoints PrivateEndpointClient
 }
 
 // GetClientSet returns a ClientSet using the passed credentials as authorization.
@@ -127,6 +136,11 @@ func GetClientSet(credentials Credentials) (*ClientSet, error) {
 		return nil, err
 	}
 
+	privateEndpointsClient, err := getPrivateEndpointsClient(credential, credentials.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ClientSet{
 		Groups:           groupsClient,
 		Networks:         networksClient,
@@ -134,6 +148,7 @@ func GetClientSet(credentials Credentials) (*ClientSet, error) {
 		RouteTables:      routeTablesClient,
 		SecurityGroups:   securityGroupsClient,
 		AvailabilitySets: availabilitySetsClient,
+		PrivateEndpoints: privateEndpointsClient,
 	}, nil
 }
 
@@ -228,6 +243,10 @@ func getAvailabilitySetClient(credentials *azidentity.ClientSecretCredential, su
 	return armcompute.NewAvailabilitySetsClient(subscriptionID, credentials, nil)
 }
 
+func getPrivateEndpointsClient(credentials *azidentity.ClientSecretCredential, subscriptionID string) (*armnetwork.PrivateEndpointsClient, error) {
+	return armnetwork.NewPrivateEndpointsClient(subscriptionID, credentials, nil)
+}
+
 func getSizesClient(credentials *azidentity.ClientSecretCredential, subscriptionID string) (*armcompute.VirtualMachineSizesClient, error) {
 	return armcompute.NewVirtualMachineSizesClient(subscriptionID, credentials, nil)
 }
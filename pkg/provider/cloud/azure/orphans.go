@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/utils/ptr"
+)
+
+const (
+	resourceTypeResourceGroup   = "Microsoft.Resources/resourceGroups"
+	resourceTypeVirtualNetwork  = "Microsoft.Network/virtualNetworks"
+	resourceTypeSecurityGroup   = "Microsoft.Network/networkSecurityGroups"
+	resourceTypeRouteTable      = "Microsoft.Network/routeTables"
+	resourceTypeAvailabilitySet = "Microsoft.Compute/availabilitySets"
+)
+
+var _ provider.OrphanScanner = &Azure{}
+
+// orphanDeletionOrder mirrors the order in CleanUpCloudProvider; the virtual
+// network takes the cluster subnet with it, which in turn references the
+// security group and route table.
+var orphanDeletionOrder = map[string]int{
+	resourceTypeVirtualNetwork:  0,
+	resourceTypeSecurityGroup:   1,
+	resourceTypeRouteTable:      2,
+	resourceTypeAvailabilitySet: 3,
+	resourceTypeResourceGroup:   4,
+}
+
+// ListClusterResources returns all resources and resource groups in the
+// subscription that KKP created for a cluster.
+func (a *Azure) ListClusterResources(ctx context.Context, cloud kubermaticv1.CloudSpec) ([]provider.ClusterResource, error) {
+	credentials, err := GetCredentialsForCluster(cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := credentials.ToAzureCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	resourcesClient, err := armresources.NewClient(credentials.SubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resources client: %w", err)
+	}
+
+	groupsClient, err := getGroupsClient(credential, credentials.SubscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource groups client: %w", err)
+	}
+
+	filter := ptr.To(fmt.Sprintf("tagName eq '%s'", clusterTagKey))
+	result := []provider.ClusterResource{}
+
+	resourcePager := resourcesClient.NewListPager(&armresources.ClientListOptions{Filter: filter})
+	for resourcePager.More() {
+		page, err := resourcePager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+
+		for _, resource := range page.Value {
+			if clusterResource := toClusterResource(resource.ID, resource.Type, resource.Tags); clusterResource != nil {
+				result = append(result, *clusterResource)
+			}
+		}
+	}
+
+	groupPager := groupsClient.NewListPager(&armresources.ResourceGroupsClientListOptions{Filter: filter})
+	for groupPager.More() {
+		page, err := groupPager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource groups: %w", err)
+		}
+
+		for _, group := range page.Value {
+			if clusterResource := toClusterResource(group.ID, group.Type, group.Tags); clusterResource != nil {
+				result = append(result, *clusterResource)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return orphanDeletionRank(result[i].Type) < orphanDeletionRank(result[j].Type)
+	})
+
+	return result, nil
+}
+
+// toClusterResource returns the resource if it was created by KKP, i.e. it is of
+// a type KKP creates and it is named after the cluster in its cluster tag. The
+// generic cluster tag alone is not enough, as other tools might use it as well.
+func toClusterResource(id, resourceType *string, tags map[string]*string) *provider.ClusterResource {
+	clusterName := ptr.Deref(tags[clusterTagKey], "")
+	if clusterName == "" || ptr.Deref(id, "") == "" {
+		return nil
+	}
+
+	if orphanDeletionRank(ptr.Deref(resourceType, "")) == len(orphanDeletionOrder) {
+		return nil
+	}
+
+	resourceID, err := arm.ParseResourceID(*id)
+	if err != nil || resourceID.Name != resourceNamePrefix+clusterName {
+		return nil
+	}
+
+	return &provider.ClusterResource{
+		Cluster: clusterName,
+		Type:    ptr.Deref(resourceType, ""),
+		ID:      *id,
+		Owned:   true,
+	}
+}
+
+func orphanDeletionRank(resourceType string) int {
+	for knownType, rank := range orphanDeletionOrder {
+		if strings.EqualFold(knownType, resourceType) {
+			return rank
+		}
+	}
+
+	return len(orphanDeletionOrder)
+}
+
+func (a *Azure) DeleteClusterResource(ctx context.Context, cloud kubermaticv1.CloudSpec, resource provider.ClusterResource) error {
+	credentials, err := GetCredentialsForCluster(cloud, a.secretKeySelector)
+	if err != nil {
+		return err
+	}
+
+	clientSet, err := GetClientSet(credentials)
+	if err != nil {
+		return err
+	}
+
+	resourceID, err := arm.ParseResourceID(resource.ID)
+	if err != nil {
+		return fmt.Errorf("invalid resource ID: %w", err)
+	}
+
+	pollOptions := &runtime.PollUntilDoneOptions{
+		Frequency: 5 * time.Second,
+	}
+
+	switch {
+	case strings.EqualFold(resource.Type, resourceTypeResourceGroup):
+		future, err := clientSet.Groups.BeginDelete(ctx, resourceID.Name, nil)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		_, err = future.PollUntilDone(ctx, pollOptions)
+		return err
+
+	case strings.EqualFold(resource.Type, resourceTypeVirtualNetwork):
+		future, err := clientSet.Networks.BeginDelete(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		_, err = future.PollUntilDone(ctx, pollOptions)
+		return err
+
+	case strings.EqualFold(resource.Type, resourceTypeSecurityGroup):
+		future, err := clientSet.SecurityGroups.BeginDelete(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		_, err = future.PollUntilDone(ctx, pollOptions)
+		return err
+
+	case strings.EqualFold(resource.Type, resourceTypeRouteTable):
+		future, err := clientSet.RouteTables.BeginDelete(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		_, err = future.PollUntilDone(ctx, pollOptions)
+		return err
+
+	case strings.EqualFold(resource.Type, resourceTypeAvailabilitySet):
+		_, err := clientSet.AvailabilitySets.Delete(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+		return ignoreNotFound(err)
+	}
+
+	return fmt.Errorf("deleting resources of type %q is not supported", resource.Type)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubermaticresources "k8c.io/kubermatic/v2/pkg/resources"
+
+	"k8s.io/utils/ptr"
+)
+
+func privateEndpointName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name + "-apiserver"
+}
+
+// reconcilePrivateEndpoint ensures a private endpoint in the cluster subnet that is connected to the
+// datacenter's Private Link Service, through which private clusters reach their control plane. The IP
+// of the endpoint is stored in an annotation on the cluster.
+func reconcilePrivateEndpoint(ctx context.Context, clients *ClientSet, location string, privateLinkServiceID string, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if privateLinkServiceID == "" {
+		return nil, errors.New("datacenter has no private link service configured")
+	}
+
+	// add the finalizer before creating the endpoint, so it cannot be leaked
+	cluster, err := update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerPrivateEndpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resourceGroup := cluster.Spec.Cloud.Azure.ResourceGroup
+	name := privateEndpointName(cluster)
+
+	endpoint, err := clients.PrivateEndpoints.Get(ctx, resourceGroup, name, &armnetwork.PrivateEndpointsClientGetOptions{
+		Expand: ptr.To("networkInterfaces"),
+	})
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	if err != nil || !hasTags(endpoint.Tags, resourceTags(cluster)) {
+		subnet, err := clients.Subnets.Get(ctx, getResourceGroup(cluster.Spec.Cloud), cluster.Spec.Cloud.Azure.VNetName, cluster.Spec.Cloud.Azure.SubnetName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subnet %q: %w", cluster.Spec.Cloud.Azure.SubnetName, err)
+		}
+
+		target := targetPrivateEndpoint(cluster, location, privateLinkServiceID, subnet.ID)
+		if err := ensurePrivateEndpoint(ctx, clients, resourceGroup, target); err != nil {
+			return nil, err
+		}
+
+		endpoint, err = clients.PrivateEndpoints.Get(ctx, resourceGroup, name, &armnetwork.PrivateEndpointsClientGetOptions{
+			Expand: ptr.To("networkInterfaces"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get private endpoint %q: %w", name, err)
+		}
+	}
+
+	ip := privateEndpointIP(&endpoint.PrivateEndpoint)
+	if ip == "" {
+		return nil, fmt.Errorf("private endpoint %q has no IP address yet", name)
+	}
+
+	return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		if updatedCluster.Annotations == nil {
+			updatedCluster.Annotations = map[string]string{}
+		}
+		updatedCluster.Annotations[kubermaticresources.PrivateEndpointIPAnnotation] = ip
+	})
+}
+
+func targetPrivateEndpoint(cluster *kubermaticv1.Cluster, location string, privateLinkServiceID string, subnetID *string) *armnetwork.PrivateEndpoint {
+	return &armnetwork.PrivateEndpoint{
+		Name:     ptr.To(privateEndpointName(cluster)),
+		Location: ptr.To(location),
+		Tags:     resourceTags(cluster),
+		Properties: &armnetwork.PrivateEndpointProperties{
+			Subnet: &armnetwork.Subnet{
+				ID: subnetID,
+			},
+			PrivateLinkServiceConnections: []*armnetwork.PrivateLinkServiceConnection{
+				{
+					Name: ptr.To(privateEndpointName(cluster)),
+					Properties: &armnetwork.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: ptr.To(privateLinkServiceID),
+					},
+				},
+			},
+		},
+	}
+}
+
+// privateEndpointIP returns the first private IP of the network interfaces attached to the endpoint.
+func privateEndpointIP(endpoint *armnetwork.PrivateEndpoint) string {
+	if endpoint.Properties == nil {
+		return ""
+	}
+
+	for _, iface := range endpoint.Properties.NetworkInterfaces {
+		if iface == nil || iface.Properties == nil {
+			continue
+		}
+
+		for _, config := range iface.Properties.IPConfigurations {
+			if config != nil && config.Properties != nil && config.Properties.PrivateIPAddress != nil {
+				return *config.Properties.PrivateIPAddress
+			}
+		}
+	}
+
+	return ""
+}
+
+// ensurePrivateEndpoint will create or update an Azure private endpoint. The call is idempotent.
+func ensurePrivateEndpoint(ctx context.Context, clients *ClientSet, resourceGroup string, endpoint *armnetwork.PrivateEndpoint) error {
+	future, err := clients.PrivateEndpoints.BeginCreateOrUpdate(ctx, resourceGroup, *endpoint.Name, *endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create or update private endpoint %q: %w", *endpoint.Name, err)
+	}
+
+	_, err = future.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: 5 * time.Second,
+	})
+
+	return err
+}
+
+func deletePrivateEndpoint(ctx context.Context, clients *ClientSet, cluster *kubermaticv1.Cluster) error {
+	future, err := clients.PrivateEndpoints.BeginDelete(ctx, cluster.Spec.Cloud.Azure.ResourceGroup, privateEndpointName(cluster), nil)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+
+	_, err = future.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: 5 * time.Second,
+	})
+
+	return err
+}
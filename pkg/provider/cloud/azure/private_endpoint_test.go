@@ -0,0 +1,255 @@
+//go:build integration
+
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	kubermaticresources "k8c.io/kubermatic/v2/pkg/resources"
+
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testPrivateLinkServiceID = "/subscriptions/sub/resourceGroups/seed/providers/Microsoft.Network/privateLinkServices/nodeport-proxy-private"
+	testPrivateEndpointIP    = "10.0.0.5"
+	testSubnetID             = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"
+)
+
+func TestReconcilePrivateEndpoint(t *testing.T) {
+	credentials, err := getFakeCredentials()
+	if err != nil {
+		t.Fatalf("failed to generate credentials: %v", err)
+	}
+
+	testcases := []struct {
+		name                 string
+		privateLinkServiceID string
+		existingEndpoint     func(cluster *kubermaticv1.Cluster) *armnetwork.PrivateEndpoint
+		expectedError        bool
+		expectedCreateCalls  int
+	}{
+		{
+			name:          "no-private-link-service",
+			expectedError: true,
+		},
+		{
+			name:                 "new-endpoint",
+			privateLinkServiceID: testPrivateLinkServiceID,
+			expectedCreateCalls:  1,
+		},
+		{
+			name:                 "existing-endpoint",
+			privateLinkServiceID: testPrivateLinkServiceID,
+			existingEndpoint: func(cluster *kubermaticv1.Cluster) *armnetwork.PrivateEndpoint {
+				return withIP(targetPrivateEndpoint(cluster, testLocation, testPrivateLinkServiceID, ptr.To(testSubnetID)), testPrivateEndpointIP)
+			},
+			expectedCreateCalls: 0,
+		},
+		{
+			name:                 "existing-endpoint-with-outdated-tags",
+			privateLinkServiceID: testPrivateLinkServiceID,
+			existingEndpoint: func(cluster *kubermaticv1.Cluster) *armnetwork.PrivateEndpoint {
+				endpoint := withIP(targetPrivateEndpoint(cluster, testLocation, testPrivateLinkServiceID, ptr.To(testSubnetID)), testPrivateEndpointIP)
+				endpoint.Tags = nil
+				return endpoint
+			},
+			expectedCreateCalls: 1,
+		},
+		{
+			name:                 "existing-endpoint-without-ip",
+			privateLinkServiceID: testPrivateLinkServiceID,
+			existingEndpoint: func(cluster *kubermaticv1.Cluster) *armnetwork.PrivateEndpoint {
+				return targetPrivateEndpoint(cluster, testLocation, testPrivateLinkServiceID, ptr.To(testSubnetID))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cluster := makeCluster("x5gq8wlzc7", &kubermaticv1.AzureCloudSpec{
+				ResourceGroup: "rg",
+				VNetName:      "vnet",
+				SubnetName:    "subnet",
+			}, credentials)
+
+			endpoints := &fakePrivateEndpointsClient{ip: testPrivateEndpointIP}
+			if tc.existingEndpoint != nil {
+				endpoints.endpoint = tc.existingEndpoint(cluster)
+			}
+
+			clientSet := &ClientSet{
+				Subnets:          &fakeSubnetsClient{},
+				PrivateEndpoints: endpoints,
+			}
+
+			cluster, err := reconcilePrivateEndpoint(ctx, clientSet, testLocation, tc.privateLinkServiceID, cluster, testClusterUpdater(cluster))
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected reconcilePrivateEndpoint to fail, but succeeded without error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected reconcilePrivateEndpoint to succeed, but failed with error: %v", err)
+			}
+
+			if endpoints.createCalls != tc.expectedCreateCalls {
+				t.Errorf("expected %d calls to BeginCreateOrUpdate, got %d", tc.expectedCreateCalls, endpoints.createCalls)
+			}
+
+			if !kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateEndpoint) {
+				t.Errorf("expected cluster to have the %s finalizer", FinalizerPrivateEndpoint)
+			}
+
+			if ip := cluster.Annotations[kubermaticresources.PrivateEndpointIPAnnotation]; ip != testPrivateEndpointIP {
+				t.Errorf("expected private endpoint IP annotation to be %q, got %q", testPrivateEndpointIP, ip)
+			}
+
+			connections := endpoints.endpoint.Properties.PrivateLinkServiceConnections
+			if len(connections) != 1 || *connections[0].Properties.PrivateLinkServiceID != testPrivateLinkServiceID {
+				t.Errorf("expected private endpoint to be connected to %q", testPrivateLinkServiceID)
+			}
+
+			if subnet := endpoints.endpoint.Properties.Subnet; subnet == nil || *subnet.ID != testSubnetID {
+				t.Errorf("expected private endpoint to be placed in subnet %q", testSubnetID)
+			}
+
+			if !hasOwnershipTag(endpoints.endpoint.Tags, cluster) {
+				t.Error("expected private endpoint to have the ownership tag")
+			}
+		})
+	}
+}
+
+func TestDeletePrivateEndpoint(t *testing.T) {
+	credentials, err := getFakeCredentials()
+	if err != nil {
+		t.Fatalf("failed to generate credentials: %v", err)
+	}
+
+	cluster := makeCluster("x5gq8wlzc7", &kubermaticv1.AzureCloudSpec{ResourceGroup: "rg"}, credentials)
+
+	endpoints := &fakePrivateEndpointsClient{
+		endpoint: targetPrivateEndpoint(cluster, testLocation, testPrivateLinkServiceID, ptr.To(testSubnetID)),
+	}
+	clientSet := &ClientSet{PrivateEndpoints: endpoints}
+
+	if err := deletePrivateEndpoint(context.Background(), clientSet, cluster); err != nil {
+		t.Fatalf("failed to delete private endpoint: %v", err)
+	}
+	if endpoints.endpoint != nil {
+		t.Fatal("expected private endpoint to be deleted")
+	}
+
+	// deleting a non-existing endpoint is not an error
+	if err := deletePrivateEndpoint(context.Background(), clientSet, cluster); err != nil {
+		t.Fatalf("failed to delete non-existing private endpoint: %v", err)
+	}
+}
+
+func withIP(endpoint *armnetwork.PrivateEndpoint, ip string) *armnetwork.PrivateEndpoint {
+	endpoint.Properties.NetworkInterfaces = []*armnetwork.Interface{
+		{
+			Properties: &armnetwork.InterfacePropertiesFormat{
+				IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+					{
+						Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+							PrivateIPAddress: ptr.To(ip),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return endpoint
+}
+
+// donePollingHandler is a PollingHandler for operations that completed immediately.
+type donePollingHandler[T any] struct{}
+
+func (donePollingHandler[T]) Done() bool {
+	return true
+}
+
+func (donePollingHandler[T]) Poll(context.Context) (*http.Response, error) {
+	return nil, nil
+}
+
+func (donePollingHandler[T]) Result(context.Context, *T) error {
+	return nil
+}
+
+func donePoller[T any]() (*runtime.Poller[T], error) {
+	return runtime.NewPoller[T](nil, runtime.Pipeline{}, &runtime.NewPollerOptions[T]{
+		Handler: donePollingHandler[T]{},
+	})
+}
+
+type fakePrivateEndpointsClient struct {
+	endpoint    *armnetwork.PrivateEndpoint
+	ip          string
+	createCalls int
+}
+
+func (c *fakePrivateEndpointsClient) BeginCreateOrUpdate(_ context.Context, _ string, _ string, parameters armnetwork.PrivateEndpoint, _ *armnetwork.PrivateEndpointsClientBeginCreateOrUpdateOptions) (*runtime.Poller[armnetwork.PrivateEndpointsClientCreateOrUpdateResponse], error) {
+	c.createCalls++
+	c.endpoint = withIP(&parameters, c.ip)
+
+	return donePoller[armnetwork.PrivateEndpointsClientCreateOrUpdateResponse]()
+}
+
+func (c *fakePrivateEndpointsClient) Get(_ context.Context, _ string, privateEndpointName string, _ *armnetwork.PrivateEndpointsClientGetOptions) (armnetwork.PrivateEndpointsClientGetResponse, error) {
+	if c.endpoint == nil || *c.endpoint.Name != privateEndpointName {
+		return armnetwork.PrivateEndpointsClientGetResponse{}, &azcore.ResponseError{StatusCode: http.StatusNotFound}
+	}
+
+	return armnetwork.PrivateEndpointsClientGetResponse{PrivateEndpoint: *c.endpoint}, nil
+}
+
+func (c *fakePrivateEndpointsClient) BeginDelete(_ context.Context, _ string, privateEndpointName string, _ *armnetwork.PrivateEndpointsClientBeginDeleteOptions) (*runtime.Poller[armnetwork.PrivateEndpointsClientDeleteResponse], error) {
+	if c.endpoint == nil || *c.endpoint.Name != privateEndpointName {
+		return nil, &azcore.ResponseError{StatusCode: http.StatusNotFound}
+	}
+	c.endpoint = nil
+
+	return donePoller[armnetwork.PrivateEndpointsClientDeleteResponse]()
+}
+
+type fakeSubnetsClient struct {
+	SubnetClient
+}
+
+func (c *fakeSubnetsClient) Get(_ context.Context, _ string, _ string, _ string, _ *armnetwork.SubnetsClientGetOptions) (armnetwork.SubnetsClientGetResponse, error) {
+	return armnetwork.SubnetsClientGetResponse{
+		Subnet: armnetwork.Subnet{ID: ptr.To(testSubnetID)},
+	}, nil
+}
@@ -49,6 +49,8 @@ const (
 	FinalizerResourceGroup = "kubermatic.k8c.io/cleanup-azure-resource-group"
 	// FinalizerAvailabilitySet will instruct the deletion of the availability set.
 	FinalizerAvailabilitySet = "kubermatic.k8c.io/cleanup-azure-availability-set"
+	// FinalizerPrivateEndpoint will instruct the deletion of the private endpoint of private clusters.
+	FinalizerPrivateEndpoint = "kubermatic.k8c.io/cleanup-azure-private-endpoint"
 
 	denyAllTCPSecGroupRuleName   = "deny_all_tcp"
 	denyAllUDPSecGroupRuleName   = "deny_all_udp"
@@ -164,6 +166,19 @@ func (a *Azure) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.
 	}
 
 	logger := a.log.With("cluster", cluster.Name)
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerPrivateEndpoint) {
+		logger.Infow("deleting private endpoint", "privateEndpoint", privateEndpointName(cluster))
+		if err := deletePrivateEndpoint(ctx, clientSet, cluster); err != nil {
+			return cluster, fmt.Errorf("failed to delete private endpoint %q: %w", privateEndpointName(cluster), err)
+		}
+		cluster, err = update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPrivateEndpoint)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if kuberneteshelper.HasFinalizer(cluster, FinalizerSecurityGroup) {
 		logger.Infow("deleting security group", "group", cluster.Spec.Cloud.Azure.SecurityGroup)
 		if err := deleteSecurityGroup(ctx, clientSet, cluster.Spec.Cloud); err != nil {
@@ -257,7 +272,7 @@ func (a *Azure) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Clus
 }
 
 func (*Azure) ClusterNeedsReconciling(cluster *kubermaticv1.Cluster) bool {
-	return false
+	return cluster.Spec.IsPrivateCluster() && cluster.Annotations[resources.PrivateEndpointIPAnnotation] == ""
 }
 
 func (a *Azure) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, force bool, setTags bool) (*kubermaticv1.Cluster, error) {
@@ -326,6 +341,14 @@ func (a *Azure) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Clus
 		}
 	}
 
+	if cluster.Spec.IsPrivateCluster() && (force || cluster.Annotations[resources.PrivateEndpointIPAnnotation] == "") {
+		logger.Infow("reconciling private endpoint", "privateEndpoint", privateEndpointName(cluster))
+		cluster, err = reconcilePrivateEndpoint(ctx, clientSet, location, a.dc.PrivateLinkServiceID, cluster, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cluster, nil
 }
 
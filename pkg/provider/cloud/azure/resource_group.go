@@ -19,6 +19,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
@@ -42,7 +43,7 @@ func reconcileResourceGroup(ctx context.Context, clients *ClientSet, location st
 		cluster.Spec.Cloud.Azure.ResourceGroup = resourceGroupName(cluster)
 	}
 
-	_, err := clients.Groups.Get(ctx, cluster.Spec.Cloud.Azure.ResourceGroup, nil)
+	resourceGroup, err := clients.Groups.Get(ctx, cluster.Spec.Cloud.Azure.ResourceGroup, nil)
 	if err != nil && !isNotFound(err) {
 		return nil, err
 	}
@@ -52,6 +53,21 @@ func reconcileResourceGroup(ctx context.Context, clients *ClientSet, location st
 	// of the resource. Since there is nothing in the resource group we could compare to eventually reconcile, we
 	// skip all of that and return early if we found a resource group during our API call earlier.
 	if err == nil {
+		// The only exception are the resource tags, which are kept up-to-date on resource groups
+		// that are known to be owned by the cluster.
+		// Tags that were added to the resource group outside of KKP are preserved.
+		if kuberneteshelper.HasFinalizer(cluster, FinalizerResourceGroup) && !hasTags(resourceGroup.Tags, resourceTags(cluster)) {
+			tags := maps.Clone(resourceGroup.Tags)
+			if tags == nil {
+				tags = map[string]*string{}
+			}
+			maps.Copy(tags, resourceTags(cluster))
+
+			if err := ensureResourceGroup(ctx, clients.Groups, cluster.Spec.Cloud, location, tags); err != nil {
+				return nil, err
+			}
+		}
+
 		return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
 			updatedCluster.Spec.Cloud.Azure.ResourceGroup = cluster.Spec.Cloud.Azure.ResourceGroup
 			// this is a special case; because we cannot determine if a resource group was created by
@@ -63,7 +79,7 @@ func reconcileResourceGroup(ctx context.Context, clients *ClientSet, location st
 		})
 	}
 
-	if err = ensureResourceGroup(ctx, clients.Groups, cluster.Spec.Cloud, location, cluster.Name); err != nil {
+	if err = ensureResourceGroup(ctx, clients.Groups, cluster.Spec.Cloud, location, resourceTags(cluster)); err != nil {
 		return nil, err
 	}
 
@@ -74,13 +90,11 @@ func reconcileResourceGroup(ctx context.Context, clients *ClientSet, location st
 }
 
 // ensureResourceGroup will create or update an Azure resource group. The call is idempotent.
-func ensureResourceGroup(ctx context.Context, groupsClient ResourceGroupClient, cloud kubermaticv1.CloudSpec, location string, clusterName string) error {
+func ensureResourceGroup(ctx context.Context, groupsClient ResourceGroupClient, cloud kubermaticv1.CloudSpec, location string, tags map[string]*string) error {
 	parameters := armresources.ResourceGroup{
 		Name:     ptr.To(cloud.Azure.ResourceGroup),
 		Location: ptr.To(location),
-		Tags: map[string]*string{
-			clusterTagKey: ptr.To(clusterName),
-		},
+		Tags:     tags,
 	}
 	if _, err := groupsClient.CreateOrUpdate(ctx, cloud.Azure.ResourceGroup, parameters, nil); err != nil {
 		return fmt.Errorf("failed to create or update resource group %q: %w", cloud.Azure.ResourceGroup, err)
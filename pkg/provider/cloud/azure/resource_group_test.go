@@ -72,7 +72,8 @@ func TestReconcileResourceGroup(t *testing.T) {
 			expectedError:             false,
 			expectedResourceGroupName: "kubernetes-aeed12dy19",
 			expectedFirstCallCount:    1,
-			expectedSecondCallCount:   1,
+			// the resource group is owned by the cluster, so the removed tags are restored
+			expectedSecondCallCount: 2,
 		},
 		{
 			name:        "custom-nonexistent-resource-group",
@@ -182,6 +183,54 @@ func TestReconcileResourceGroup(t *testing.T) {
 	}
 }
 
+func TestReconcileResourceGroupPreservesForeignTags(t *testing.T) {
+	credentials, err := getFakeCredentials()
+	if err != nil {
+		t.Fatalf("failed to generate credentials: %v", err)
+	}
+
+	ctx := context.Background()
+
+	cluster := makeCluster("w8vbf2mxq1", &kubermaticv1.AzureCloudSpec{ResourceGroup: customExistingResourceGroup}, credentials)
+	cluster.Finalizers = []string{FinalizerResourceGroup}
+	cluster.Status.ResourceTags = map[string]string{"env": "prod"}
+
+	clientSet := getFakeClientSetWithGroupsClient(&armresources.ResourceGroup{
+		Name:     ptr.To(customExistingResourceGroup),
+		Location: ptr.To(testLocation),
+		Tags: map[string]*string{
+			clusterTagKey: ptr.To(cluster.Name),
+			"env":         ptr.To("dev"),
+			"owner":       ptr.To("someone-else"),
+		},
+	}, fakeClientModeOkay)
+
+	if _, err := reconcileResourceGroup(ctx, clientSet, testLocation, cluster, testClusterUpdater(cluster)); err != nil {
+		t.Fatalf("failed to reconcile resource group: %v", err)
+	}
+
+	fakeClient := clientSet.Groups.(*fakeGroupsClient)
+	if fakeClient.CreateOrUpdateCalledCount != 1 {
+		t.Fatalf("expected 1 call to CreateOrUpdate, got %d", fakeClient.CreateOrUpdateCalledCount)
+	}
+
+	expected := map[string]string{
+		clusterTagKey: cluster.Name,
+		"env":         "prod",
+		"owner":       "someone-else",
+	}
+
+	tags := fakeClient.Group.Tags
+	if len(tags) != len(expected) {
+		t.Fatalf("expected tags %v, got %d tags", expected, len(tags))
+	}
+	for key, value := range expected {
+		if tags[key] == nil || *tags[key] != value {
+			t.Errorf("expected tag %q to be %q, got %v", key, value, tags[key])
+		}
+	}
+}
+
 const customExistingResourceGroup = "custom-existing-resource-group"
 
 type fakeGroupsClient struct {
@@ -62,7 +62,7 @@ func reconcileSecurityGroup(ctx context.Context, clients *ClientSet, location st
 		NodePorts()
 	nodePortsAllowedIPRanges := kubermaticresources.GetNodePortsAllowedIPRanges(cluster, cluster.Spec.Cloud.Azure.NodePortsAllowedIPRanges, cluster.Spec.Cloud.Azure.NodePortsAllowedIPRange)
 
-	target := targetSecurityGroup(cluster.Spec.Cloud, location, cluster.Name, lowPort, highPort, nodePortsAllowedIPRanges.GetIPv4CIDRs(), nodePortsAllowedIPRanges.GetIPv6CIDRs())
+	target := targetSecurityGroup(cluster.Spec.Cloud, location, resourceTags(cluster), lowPort, highPort, nodePortsAllowedIPRanges.GetIPv4CIDRs(), nodePortsAllowedIPRanges.GetIPv6CIDRs())
 
 	// check for attributes of the existing security group and return early if all values are already
 	// as expected. Since there are a lot of pointers in the network.SecurityGroup struct, we need to
@@ -70,8 +70,10 @@ func reconcileSecurityGroup(ctx context.Context, clients *ClientSet, location st
 	//
 	// Attributes we check:
 	// - defined security rules
+	// - resource tags
 	if !(securityGroup.Properties != nil && securityGroup.Properties.SecurityRules != nil &&
-		compareSecurityRules(securityGroup.Properties.SecurityRules, target.Properties.SecurityRules)) {
+		compareSecurityRules(securityGroup.Properties.SecurityRules, target.Properties.SecurityRules) &&
+		hasTags(securityGroup.Tags, target.Tags)) {
 		if err := ensureSecurityGroup(ctx, clients, cluster.Spec.Cloud, target); err != nil {
 			return cluster, err
 		}
@@ -83,7 +85,7 @@ func reconcileSecurityGroup(ctx context.Context, clients *ClientSet, location st
 	})
 }
 
-func targetSecurityGroup(cloud kubermaticv1.CloudSpec, location string, clusterName string, portRangeLow int, portRangeHigh int,
+func targetSecurityGroup(cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, portRangeLow int, portRangeHigh int,
 	nodePortsIPv4CIDRs []string, nodePortsIPv6CIDRs []string) *armnetwork.SecurityGroup {
 	inbound := armnetwork.SecurityRuleDirectionInbound
 	outbound := armnetwork.SecurityRuleDirectionOutbound
@@ -94,9 +96,7 @@ func targetSecurityGroup(cloud kubermaticv1.CloudSpec, location string, clusterN
 	securityGroup := &armnetwork.SecurityGroup{
 		Name:     ptr.To(cloud.Azure.SecurityGroup),
 		Location: ptr.To(location),
-		Tags: map[string]*string{
-			clusterTagKey: ptr.To(clusterName),
-		},
+		Tags:     tags,
 		Properties: &armnetwork.SecurityGroupPropertiesFormat{
 			Subnets: []*armnetwork.Subnet{
 				{
@@ -27,6 +27,8 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
+
+	"k8s.io/utils/ptr"
 )
 
 func ignoreNotFound(err error) error {
@@ -62,6 +64,29 @@ func hasOwnershipTag(tags map[string]*string, cluster *kubermaticv1.Cluster) boo
 	return false
 }
 
+// resourceTags returns the tags that should be set on all resources owned by the cluster,
+// i.e. the cluster's effective resource tags and the ownership tag.
+func resourceTags(cluster *kubermaticv1.Cluster) map[string]*string {
+	tags := map[string]*string{}
+	for key, value := range cluster.Status.ResourceTags {
+		tags[key] = ptr.To(value)
+	}
+	tags[clusterTagKey] = ptr.To(cluster.Name)
+
+	return tags
+}
+
+// hasTags returns true if all expected tags are set to the expected values in actual.
+func hasTags(actual map[string]*string, expected map[string]*string) bool {
+	for key, value := range expected {
+		if current, ok := actual[key]; !ok || !isEqualString(current, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func GetVMSize(ctx context.Context, credentials Credentials, location, vmName string) (*provider.NodeCapacity, error) {
 	credential, err := credentials.ToAzureCredential()
 	if err != nil {
@@ -67,7 +67,7 @@ func reconcileVNet(ctx context.Context, clients *ClientSet, location string, clu
 	if cluster.IsIPv6Only() || cluster.IsDualStack() {
 		cidrs = append(cidrs, defaultVNetCIDRIPv6)
 	}
-	target := targetVnet(cluster.Spec.Cloud, location, cluster.Name, cidrs)
+	target := targetVnet(cluster.Spec.Cloud, location, resourceTags(cluster), cidrs)
 
 	// check for attributes of the existing VNET and return early if all values are already
 	// as expected. Since there are a lot of pointers in the network.VirtualNetwork struct, we need to
@@ -75,8 +75,10 @@ func reconcileVNet(ctx context.Context, clients *ClientSet, location string, clu
 	//
 	// Attributes we check:
 	// - Address space CIDR
+	// - resource tags
 	if !(vnet.Properties != nil && vnet.Properties.AddressSpace != nil &&
-		reflect.DeepEqual(vnet.Properties.AddressSpace.AddressPrefixes, target.Properties.AddressSpace.AddressPrefixes)) {
+		reflect.DeepEqual(vnet.Properties.AddressSpace.AddressPrefixes, target.Properties.AddressSpace.AddressPrefixes) &&
+		hasTags(vnet.Tags, target.Tags)) {
 		if err := ensureVNet(ctx, clients, cluster.Spec.Cloud, target); err != nil {
 			return nil, err
 		}
@@ -88,7 +90,7 @@ func reconcileVNet(ctx context.Context, clients *ClientSet, location string, clu
 	})
 }
 
-func targetVnet(cloud kubermaticv1.CloudSpec, location string, clusterName string, cidrs []string) *armnetwork.VirtualNetwork {
+func targetVnet(cloud kubermaticv1.CloudSpec, location string, tags map[string]*string, cidrs []string) *armnetwork.VirtualNetwork {
 	cidrPointers := []*string{}
 	for _, cidr := range cidrs {
 		cidrPointers = append(cidrPointers, ptr.To(cidr))
@@ -97,9 +99,7 @@ func targetVnet(cloud kubermaticv1.CloudSpec, location string, clusterName strin
 	return &armnetwork.VirtualNetwork{
 		Name:     ptr.To(cloud.Azure.VNetName),
 		Location: ptr.To(location),
-		Tags: map[string]*string{
-			clusterTagKey: ptr.To(clusterName),
-		},
+		Tags:     tags,
 		Properties: &armnetwork.VirtualNetworkPropertiesFormat{
 			AddressSpace: &armnetwork.AddressSpace{AddressPrefixes: cidrPointers},
 		},
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	"k8s.io/utils/ptr"
+)
+
+func firewallName(cluster *kubermaticv1.Cluster) string {
+	return "kubernetes-" + cluster.Name
+}
+
+// clusterTag is the tag the machine-controller sets on all droplets of the cluster, see
+// CompleteDigitaloceanProviderSpec. The firewall is applied to all droplets with this tag.
+func clusterTag(cluster *kubermaticv1.Cluster) string {
+	return "kubernetes-cluster-" + cluster.Name
+}
+
+func reconcileFirewall(ctx context.Context, client *godo.Client, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	// a firewall that was not created by KKP must not be managed
+	firewallID := cluster.Spec.Cloud.Digitalocean.FirewallID
+	if firewallID != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerFirewall) {
+		return cluster, nil
+	}
+
+	// firewalls can only reference existing tags
+	if _, _, err := client.Tags.Create(ctx, &godo.TagCreateRequest{Name: clusterTag(cluster)}); err != nil {
+		return nil, fmt.Errorf("failed to create tag %q: %w", clusterTag(cluster), err)
+	}
+
+	target := targetFirewall(cluster)
+
+	var firewall *godo.Firewall
+	if firewallID != "" {
+		var (
+			resp *godo.Response
+			err  error
+		)
+		firewall, resp, err = client.Firewalls.Get(ctx, firewallID)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return nil, fmt.Errorf("failed to get firewall %q: %w", firewallID, err)
+		}
+	}
+
+	switch {
+	case firewall == nil:
+		created, _, err := client.Firewalls.Create(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create firewall %q: %w", target.Name, err)
+		}
+		firewallID = created.ID
+	case !firewallUpToDate(firewall, target):
+		if _, _, err := client.Firewalls.Update(ctx, firewallID, target); err != nil {
+			return nil, fmt.Errorf("failed to update firewall %q: %w", firewallID, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Spec.Cloud.Digitalocean.FirewallID = firewallID
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerFirewall)
+	})
+}
+
+// targetFirewall returns the firewall for the droplets of the cluster. DigitalOcean firewalls deny
+// all traffic that is not explicitly allowed, including traffic in the private network, so traffic
+// between the droplets of the cluster and all outbound traffic have to be allowed explicitly.
+func targetFirewall(cluster *kubermaticv1.Cluster) *godo.FirewallRequest {
+	anyAddresses := []string{resources.IPv4MatchAnyCIDR, resources.IPv6MatchAnyCIDR}
+	clusterSources := &godo.Sources{Tags: []string{clusterTag(cluster)}}
+
+	lowPort, highPort := resources.NewTemplateDataBuilder().
+		WithNodePortRange(cluster.Spec.ComponentsOverride.Apiserver.NodePortRange).
+		WithCluster(cluster).
+		Build().
+		NodePorts()
+	nodePorts := fmt.Sprintf("%d-%d", lowPort, highPort)
+	nodePortsAllowedIPRanges := resources.GetNodePortsAllowedIPRanges(cluster, cluster.Spec.Cloud.Digitalocean.NodePortsAllowedIPRanges, "")
+	nodePortSources := &godo.Sources{Addresses: nodePortsAllowedIPRanges.CIDRBlocks}
+
+	return &godo.FirewallRequest{
+		Name: firewallName(cluster),
+		Tags: []string{clusterTag(cluster)},
+		InboundRules: []godo.InboundRule{
+			{Protocol: "icmp", Sources: &godo.Sources{Addresses: anyAddresses}},
+			{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: anyAddresses}},
+			{Protocol: "tcp", PortRange: nodePorts, Sources: nodePortSources},
+			{Protocol: "udp", PortRange: nodePorts, Sources: nodePortSources},
+			{Protocol: "icmp", Sources: clusterSources},
+			{Protocol: "tcp", PortRange: "all", Sources: clusterSources},
+			{Protocol: "udp", PortRange: "all", Sources: clusterSources},
+		},
+		OutboundRules: []godo.OutboundRule{
+			{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: anyAddresses}},
+			{Protocol: "tcp", PortRange: "all", Destinations: &godo.Destinations{Addresses: anyAddresses}},
+			{Protocol: "udp", PortRange: "all", Destinations: &godo.Destinations{Addresses: anyAddresses}},
+		},
+	}
+}
+
+// firewallUpToDate compares the rules and tags of an existing firewall with the target. The API
+// returns "0" as port range for rules that apply to all ports, which is normalized before comparing.
+func firewallUpToDate(firewall *godo.Firewall, target *godo.FirewallRequest) bool {
+	inbound := func(rules []godo.InboundRule) []string {
+		keys := []string{}
+		for _, rule := range rules {
+			sources := ptr.Deref(rule.Sources, godo.Sources{})
+			keys = append(keys, firewallRuleKey(rule.Protocol, rule.PortRange, sources.Addresses, sources.Tags))
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	outbound := func(rules []godo.OutboundRule) []string {
+		keys := []string{}
+		for _, rule := range rules {
+			destinations := ptr.Deref(rule.Destinations, godo.Destinations{})
+			keys = append(keys, firewallRuleKey(rule.Protocol, rule.PortRange, destinations.Addresses, destinations.Tags))
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	return firewall.Name == target.Name &&
+		reflect.DeepEqual(firewall.Tags, target.Tags) &&
+		reflect.DeepEqual(inbound(firewall.InboundRules), inbound(target.InboundRules)) &&
+		reflect.DeepEqual(outbound(firewall.OutboundRules), outbound(target.OutboundRules))
+}
+
+func firewallRuleKey(protocol, portRange string, addresses, tags []string) string {
+	if portRange == "" || portRange == "0" {
+		portRange = "all"
+	}
+
+	addresses = append([]string{}, addresses...)
+	sort.Strings(addresses)
+	tags = append([]string{}, tags...)
+	sort.Strings(tags)
+
+	return strings.Join([]string{protocol, portRange, strings.Join(addresses, ","), strings.Join(tags, ",")}, "|")
+}
+
+func deleteFirewall(ctx context.Context, client *godo.Client, firewallID string) error {
+	if firewallID == "" {
+		return nil
+	}
+
+	resp, err := client.Firewalls.Delete(ctx, firewallID)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return err
+	}
+
+	return nil
+}
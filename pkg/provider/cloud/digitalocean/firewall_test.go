@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFirewallUpToDate(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{IPFamily: kubermaticv1.IPFamilyIPv4},
+			Cloud: kubermaticv1.CloudSpec{
+				Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{},
+			},
+		},
+	}
+
+	target := targetFirewall(cluster)
+
+	// the API returns "0" as port range for rules covering all ports
+	existing := &godo.Firewall{
+		Name: target.Name,
+		Tags: target.Tags,
+	}
+	for i := len(target.InboundRules) - 1; i >= 0; i-- {
+		rule := target.InboundRules[i]
+		if rule.PortRange == "all" {
+			rule.PortRange = "0"
+		}
+		existing.InboundRules = append(existing.InboundRules, rule)
+	}
+	existing.OutboundRules = append(existing.OutboundRules, target.OutboundRules...)
+
+	if !firewallUpToDate(existing, target) {
+		t.Error("Expected firewall with equivalent rules to be up to date")
+	}
+
+	cluster.Spec.Cloud.Digitalocean.NodePortsAllowedIPRanges = &kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}}
+	if firewallUpToDate(existing, targetFirewall(cluster)) {
+		t.Error("Expected firewall to be outdated after the node port ranges changed")
+	}
+}
@@ -22,25 +22,39 @@ import (
 	"fmt"
 
 	"github.com/digitalocean/godo"
+	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+const (
+	// FinalizerFirewall will instruct the deletion of the firewall.
+	FinalizerFirewall = "kubermatic.k8c.io/cleanup-digitalocean-firewall"
+	// FinalizerVPC will instruct the deletion of the VPC.
+	FinalizerVPC = "kubermatic.k8c.io/cleanup-digitalocean-vpc"
+)
+
 type digitalocean struct {
+	dc                *kubermaticv1.DatacenterSpecDigitalocean
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	log               *zap.SugaredLogger
 }
 
 // NewCloudProvider creates a new digitalocean provider.
-func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
+func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
 	return &digitalocean{
+		dc:                dc.Spec.Digitalocean,
 		secretKeySelector: secretKeyGetter,
+		log:               log.Logger,
 	}
 }
 
-var _ provider.CloudProvider = &digitalocean{}
+var _ provider.ReconcilingCloudProvider = &digitalocean{}
 
 func (do *digitalocean) DefaultCloudSpec(ctx context.Context, spec *kubermaticv1.ClusterSpec) error {
 	return nil
@@ -67,11 +81,95 @@ func (do *digitalocean) ValidateCloudSpec(ctx context.Context, spec kubermaticv1
 	return ValidateCredentials(ctx, token)
 }
 
-func (do *digitalocean) InitializeCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+func (do *digitalocean) InitializeCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return do.reconcileCluster(ctx, cluster, update, false)
+}
+
+// ReconcileCluster enforces the existence and configuration of the cluster's VPC and firewall.
+func (do *digitalocean) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return do.reconcileCluster(ctx, cluster, update, true)
+}
+
+func (*digitalocean) ClusterNeedsReconciling(cluster *kubermaticv1.Cluster) bool {
+	return false
+}
+
+func (do *digitalocean) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, force bool) (*kubermaticv1.Cluster, error) {
+	// existing clusters must opt in, as the firewall denies all inbound traffic that is not explicitly allowed
+	if !cluster.Spec.Features[kubermaticv1.ClusterFeatureManagedCloudResources] {
+		return cluster, nil
+	}
+
+	token, err := GetCredentialsForCluster(cluster.Spec.Cloud, do.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := getClient(ctx, token)
+	logger := do.log.With("cluster", cluster.Name)
+
+	if force || cluster.Spec.Cloud.Digitalocean.VPCID == "" {
+		logger.Infow("reconciling VPC", "vpc", vpcName(cluster))
+		cluster, err = reconcileVPC(ctx, client, do.dc.Region, cluster, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if force || cluster.Spec.Cloud.Digitalocean.FirewallID == "" {
+		logger.Infow("reconciling firewall", "firewall", firewallName(cluster))
+		cluster, err = reconcileFirewall(ctx, client, cluster, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cluster, nil
 }
 
-func (do *digitalocean) CleanUpCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+// CleanUpCloudProvider removes all resources that KKP created for the cluster.
+func (do *digitalocean) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasAnyFinalizer(cluster, FinalizerFirewall, FinalizerVPC) {
+		return cluster, nil
+	}
+
+	token, err := GetCredentialsForCluster(cluster.Spec.Cloud, do.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := getClient(ctx, token)
+	logger := do.log.With("cluster", cluster.Name)
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerFirewall) {
+		logger.Infow("deleting firewall", "firewall", cluster.Spec.Cloud.Digitalocean.FirewallID)
+		if err := deleteFirewall(ctx, client, cluster.Spec.Cloud.Digitalocean.FirewallID); err != nil {
+			return nil, fmt.Errorf("failed to delete firewall %q: %w", cluster.Spec.Cloud.Digitalocean.FirewallID, err)
+		}
+
+		cluster, err = update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerFirewall)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// a VPC can only be deleted once all droplets in it are gone
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerVPC) {
+		logger.Infow("deleting VPC", "vpc", cluster.Spec.Cloud.Digitalocean.VPCID)
+		if err := deleteVPC(ctx, client, cluster.Spec.Cloud.Digitalocean.VPCID); err != nil {
+			return nil, fmt.Errorf("failed to delete VPC %q: %w", cluster.Spec.Cloud.Digitalocean.VPCID, err)
+		}
+
+		cluster, err = update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerVPC)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cluster, nil
 }
 
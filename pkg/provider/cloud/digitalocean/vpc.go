@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func vpcName(cluster *kubermaticv1.Cluster) string {
+	return "kubernetes-" + cluster.Name
+}
+
+// reconcileVPC ensures the private network of the cluster exists. DigitalOcean picks a free
+// IP range for the VPC when none is requested, so it cannot overlap with other VPCs of the account.
+func reconcileVPC(ctx context.Context, client *godo.Client, region string, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	// a VPC that was not created by KKP must not be managed
+	vpcID := cluster.Spec.Cloud.Digitalocean.VPCID
+	if vpcID != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerVPC) {
+		return cluster, nil
+	}
+
+	var vpc *godo.VPC
+	if vpcID != "" {
+		var (
+			resp *godo.Response
+			err  error
+		)
+		vpc, resp, err = client.VPCs.Get(ctx, vpcID)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return nil, fmt.Errorf("failed to get VPC %q: %w", vpcID, err)
+		}
+	}
+
+	if vpc == nil {
+		// VPC names are unique per account, so a VPC created by a previous, interrupted
+		// reconciliation has to be adopted instead of creating a new one
+		name := vpcName(cluster)
+
+		existing, err := getVPCByName(ctx, client, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get VPC %q: %w", name, err)
+		}
+
+		if existing == nil {
+			existing, _, err = client.VPCs.Create(ctx, &godo.VPCCreateRequest{
+				Name:        name,
+				RegionSlug:  region,
+				Description: fmt.Sprintf("Private network of Kubernetes cluster %s", cluster.Name),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create VPC %q: %w", name, err)
+			}
+		}
+
+		vpcID = existing.ID
+	}
+
+	return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Spec.Cloud.Digitalocean.VPCID = vpcID
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerVPC)
+	})
+}
+
+func getVPCByName(ctx context.Context, client *godo.Client, name string) (*godo.VPC, error) {
+	opts := &godo.ListOptions{PerPage: 200}
+
+	for {
+		vpcs, resp, err := client.VPCs.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vpc := range vpcs {
+			if vpc.Name == name {
+				return vpc, nil
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			return nil, nil
+		}
+
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opts.Page = page + 1
+	}
+}
+
+func deleteVPC(ctx context.Context, client *godo.Client, vpcID string) error {
+	if vpcID == "" {
+		return nil
+	}
+
+	resp, err := client.VPCs.Delete(ctx, vpcID)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return err
+	}
+
+	return nil
+}
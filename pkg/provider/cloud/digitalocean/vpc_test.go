@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digitalocean
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/digitalocean/godo"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileVPC(t *testing.T) {
+	testCases := []struct {
+		name          string
+		vpcID         string
+		finalizer     bool
+		existingVPCs  []*godo.VPC
+		expectedVPCID string
+		expectCreate  bool
+	}{
+		{
+			name:          "creates a VPC for a new cluster",
+			expectedVPCID: "created",
+			expectCreate:  true,
+		},
+		{
+			name:          "adopts the VPC of an interrupted reconciliation",
+			existingVPCs:  []*godo.VPC{{ID: "other", Name: "other"}, {ID: "leftover", Name: "kubernetes-test"}},
+			expectedVPCID: "leftover",
+		},
+		{
+			name:          "keeps an existing VPC",
+			vpcID:         "leftover",
+			finalizer:     true,
+			existingVPCs:  []*godo.VPC{{ID: "leftover", Name: "kubernetes-test"}},
+			expectedVPCID: "leftover",
+		},
+		{
+			name:          "recreates a deleted VPC",
+			vpcID:         "deleted",
+			finalizer:     true,
+			expectedVPCID: "created",
+			expectCreate:  true,
+		},
+		{
+			name:          "ignores a VPC that was not created by KKP",
+			vpcID:         "foreign",
+			expectedVPCID: "foreign",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			created := false
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /v2/vpcs", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(t, w, map[string]interface{}{"vpcs": tc.existingVPCs, "links": map[string]interface{}{}})
+			})
+			mux.HandleFunc("GET /v2/vpcs/{id}", func(w http.ResponseWriter, r *http.Request) {
+				for _, vpc := range tc.existingVPCs {
+					if vpc.ID == r.PathValue("id") {
+						writeJSON(t, w, map[string]interface{}{"vpc": vpc})
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+				writeJSON(t, w, map[string]interface{}{"id": "not_found", "message": "not found"})
+			})
+			mux.HandleFunc("POST /v2/vpcs", func(w http.ResponseWriter, r *http.Request) {
+				request := &godo.VPCCreateRequest{}
+				if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+					t.Fatalf("Failed to decode request: %v", err)
+				}
+				if request.Name != "kubernetes-test" || request.RegionSlug != "fra1" {
+					t.Errorf("Unexpected VPC create request: %+v", request)
+				}
+				created = true
+				writeJSON(t, w, map[string]interface{}{"vpc": &godo.VPC{ID: "created", Name: request.Name}})
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client, err := godo.New(server.Client(), godo.SetBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{VPCID: tc.vpcID},
+					},
+				},
+			}
+			if tc.finalizer {
+				kuberneteshelper.AddFinalizer(cluster, FinalizerVPC)
+			}
+
+			update := func(_ context.Context, _ string, modify func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error) {
+				modify(cluster)
+				return cluster, nil
+			}
+
+			cluster, err = reconcileVPC(context.Background(), client, "fra1", cluster, update)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if created != tc.expectCreate {
+				t.Errorf("Expected VPC creation to be %v, got %v", tc.expectCreate, created)
+			}
+			if cluster.Spec.Cloud.Digitalocean.VPCID != tc.expectedVPCID {
+				t.Errorf("Expected VPC ID %q, got %q", tc.expectedVPCID, cluster.Spec.Cloud.Digitalocean.VPCID)
+			}
+			if expected := tc.vpcID != "foreign"; kuberneteshelper.HasFinalizer(cluster, FinalizerVPC) != expected {
+				t.Errorf("Expected VPC finalizer to be present: %v", expected)
+			}
+		})
+	}
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+}
@@ -23,14 +23,31 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 )
 
-type fakeCloudProvider struct{}
+type fakeCloudProvider struct {
+	// maxMachines is the machine quota of the fake account, -1 means unlimited.
+	maxMachines int
+	// unresponsive makes capacity checks block until their context is done.
+	unresponsive bool
+}
 
 // NewCloudProvider creates a new fake cloud provider.
 func NewCloudProvider() provider.CloudProvider {
-	return &fakeCloudProvider{}
+	return &fakeCloudProvider{maxMachines: -1}
+}
+
+// NewCloudProviderWithQuota creates a new fake cloud provider whose account
+// only has room for the given number of machines.
+func NewCloudProviderWithQuota(maxMachines int) provider.CapacityChecker {
+	return &fakeCloudProvider{maxMachines: maxMachines}
+}
+
+// NewUnresponsiveCloudProvider creates a new fake cloud provider whose capacity
+// checks never finish on their own.
+func NewUnresponsiveCloudProvider() provider.CapacityChecker {
+	return &fakeCloudProvider{maxMachines: -1, unresponsive: true}
 }
 
-var _ provider.CloudProvider = &fakeCloudProvider{}
+var _ provider.CapacityChecker = &fakeCloudProvider{}
 
 func (p *fakeCloudProvider) DefaultCloudSpec(_ context.Context, _ *kubermaticv1.ClusterSpec) error {
 	return nil
@@ -52,3 +69,13 @@ func (p *fakeCloudProvider) CleanUpCloudProvider(_ context.Context, cluster *kub
 func (p *fakeCloudProvider) ValidateCloudSpecUpdate(_ context.Context, _ kubermaticv1.CloudSpec, _ kubermaticv1.CloudSpec) error {
 	return nil
 }
+
+// CheckCapacity verifies that the requested machines fit into the fake account's quota.
+func (p *fakeCloudProvider) CheckCapacity(ctx context.Context, _ kubermaticv1.CloudSpec, request provider.CapacityRequest) error {
+	if p.unresponsive {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return provider.CheckQuota("machines", request.Replicas, 0, p.maxMachines)
+}
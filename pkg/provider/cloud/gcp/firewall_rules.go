@@ -51,7 +51,7 @@ func reconcileFirewallRules(ctx context.Context, cluster *kubermaticv1.Cluster,
 		NodePorts()
 
 	firewallService := compute.NewFirewallsService(svc)
-	tag := fmt.Sprintf("kubernetes-cluster-%s", cluster.Name)
+	tag := clusterNetworkTagPrefix + cluster.Name
 	selfRuleName := fmt.Sprintf(selfRuleNamePattern, cluster.Name)
 	icmpRuleName := fmt.Sprintf(icmpRuleNamePattern, cluster.Name)
 	icmpIPv6RuleName := fmt.Sprintf(icmpIPv6RuleNamePattern, cluster.Name)
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"google.golang.org/api/compute/v1"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// The cluster's resource tags are applied as labels to the resources that support them,
+// i.e. the address and forwarding rule of the private endpoint. Firewall rules and routes
+// cannot be labelled in GCP. Resource tags are validated against the GCP label format
+// before they are accepted on a cluster or datacenter.
+
+// updatedLabels returns the existing labels merged with the cluster's resource tags, or
+// nil if all resource tags are applied already. Labels that are not managed by KKP are
+// preserved.
+func updatedLabels(existing map[string]string, cluster *kubermaticv1.Cluster) map[string]string {
+	missing := provider.MissingResourceTags(cluster.Status.ResourceTags, existing)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	labels := maps.Clone(existing)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	maps.Copy(labels, missing)
+
+	return labels
+}
+
+// reconcileAddressLabels applies the cluster's resource tags to an existing address.
+func reconcileAddressLabels(ctx context.Context, svc *compute.Service, projectID, region string, address *compute.Address, cluster *kubermaticv1.Cluster) error {
+	labels := updatedLabels(address.Labels, cluster)
+	if labels == nil {
+		return nil
+	}
+
+	op, err := svc.Addresses.SetLabels(projectID, region, address.Name, &compute.RegionSetLabelsRequest{
+		Labels:           labels,
+		LabelFingerprint: address.LabelFingerprint,
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to set labels on address %s: %w", address.Name, err)
+	}
+
+	return waitForRegionOperation(ctx, svc, projectID, region, op)
+}
+
+// reconcileForwardingRuleLabels applies the cluster's resource tags to an existing forwarding rule.
+func reconcileForwardingRuleLabels(ctx context.Context, svc *compute.Service, projectID, region string, rule *compute.ForwardingRule, cluster *kubermaticv1.Cluster) error {
+	labels := updatedLabels(rule.Labels, cluster)
+	if labels == nil {
+		return nil
+	}
+
+	op, err := svc.ForwardingRules.SetLabels(projectID, region, rule.Name, &compute.RegionSetLabelsRequest{
+		Labels:           labels,
+		LabelFingerprint: rule.LabelFingerprint,
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to set labels on forwarding rule %s: %w", rule.Name, err)
+	}
+
+	return waitForRegionOperation(ctx, svc, projectID, region, op)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"maps"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+func TestUpdatedLabels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing map[string]string
+		tags     map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no resource tags",
+			existing: map[string]string{"foo": "bar"},
+		},
+		{
+			name:     "all tags applied already",
+			existing: map[string]string{"foo": "bar", "env": "prod"},
+			tags:     map[string]string{"env": "prod"},
+		},
+		{
+			name:     "new tags are added and foreign labels preserved",
+			existing: map[string]string{"foo": "bar", "env": "dev"},
+			tags:     map[string]string{"env": "prod", "team": "a"},
+			expected: map[string]string{"foo": "bar", "env": "prod", "team": "a"},
+		},
+		{
+			name:     "resource without labels",
+			tags:     map[string]string{"env": "prod"},
+			expected: map[string]string{"env": "prod"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Status: kubermaticv1.ClusterStatus{
+					ResourceTags: tc.tags,
+				},
+			}
+
+			labels := updatedLabels(tc.existing, cluster)
+			if !maps.Equal(labels, tc.expected) {
+				t.Fatalf("Expected labels %v, got %v", tc.expected, labels)
+			}
+			if tc.expected == nil && labels != nil {
+				t.Fatalf("Expected no update, got %v", labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	firewallResourceType = "firewall"
+
+	// clusterNetworkTagPrefix is the prefix of the network tag that all
+	// firewall rules of a cluster target.
+	clusterNetworkTagPrefix = "kubernetes-cluster-"
+)
+
+var _ provider.OrphanScanner = &gcp{}
+
+// ListClusterResources returns the firewall rules created for any cluster in
+// the project of the service account.
+func (g *gcp) ListClusterResources(ctx context.Context, cloud kubermaticv1.CloudSpec) ([]provider.ClusterResource, error) {
+	serviceAccount, err := GetCredentialsForCluster(cloud, g.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, projectID, err := ConnectToComputeService(ctx, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []provider.ClusterResource{}
+	req := svc.Firewalls.List(projectID).Filter(`name eq "firewall-.*"`)
+	err = req.Pages(ctx, func(list *compute.FirewallList) error {
+		for _, firewall := range list.Items {
+			if clusterName := firewallClusterName(firewall); clusterName != "" {
+				result = append(result, provider.ClusterResource{
+					Cluster: clusterName,
+					Type:    firewallResourceType,
+					ID:      firewall.Name,
+					Owned:   true,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	return result, nil
+}
+
+// firewallClusterName returns the name of the cluster a firewall rule was
+// created for, or an empty string if the rule was not created by KKP.
+func firewallClusterName(firewall *compute.Firewall) string {
+	for _, tag := range firewall.TargetTags {
+		clusterName, found := strings.CutPrefix(tag, clusterNetworkTagPrefix)
+		if found && strings.HasPrefix(firewall.Name, fmt.Sprintf("firewall-%s-", clusterName)) {
+			return clusterName
+		}
+	}
+
+	return ""
+}
+
+func (g *gcp) DeleteClusterResource(ctx context.Context, cloud kubermaticv1.CloudSpec, resource provider.ClusterResource) error {
+	if resource.Type != firewallResourceType {
+		return fmt.Errorf("deleting resources of type %q is not supported", resource.Type)
+	}
+
+	serviceAccount, err := GetCredentialsForCluster(cloud, g.secretKeySelector)
+	if err != nil {
+		return err
+	}
+
+	svc, projectID, err := ConnectToComputeService(ctx, serviceAccount)
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.Firewalls.Delete(projectID, resource.ID).Context(ctx).Do()
+	if err != nil && !isHTTPError(err, http.StatusNotFound) {
+		return fmt.Errorf("failed to delete firewall rule %s: %w", resource.ID, err)
+	}
+
+	return nil
+}
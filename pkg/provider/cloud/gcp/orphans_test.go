@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestFirewallClusterName(t *testing.T) {
+	tests := []struct {
+		name     string
+		firewall *compute.Firewall
+		expected string
+	}{
+		{
+			name: "firewall rule of a cluster",
+			firewall: &compute.Firewall{
+				Name:       "firewall-abcdefghij-nodeport",
+				TargetTags: []string{"kubernetes-cluster-abcdefghij"},
+			},
+			expected: "abcdefghij",
+		},
+		{
+			name: "firewall rule with a foreign name",
+			firewall: &compute.Firewall{
+				Name:       "firewall-allow-ssh",
+				TargetTags: []string{"kubernetes-cluster-abcdefghij"},
+			},
+			expected: "",
+		},
+		{
+			name: "firewall rule without cluster tag",
+			firewall: &compute.Firewall{
+				Name:       "firewall-abcdefghij-self",
+				TargetTags: []string{"web"},
+			},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if clusterName := firewallClusterName(test.firewall); clusterName != test.expected {
+				t.Errorf("Expected cluster name %q, got %q.", test.expected, clusterName)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"google.golang.org/api/compute/v1"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+)
+
+const (
+	privateEndpointNamePattern     = "kubernetes-%s-apiserver"
+	privateEndpointRuleNamePattern = "firewall-%s-private-endpoint"
+)
+
+// reconcilePrivateEndpoint ensures a Private Service Connect endpoint in the cluster network that
+// targets the datacenter's service attachment, through which private clusters reach their control
+// plane. The endpoint consists of an internal address and a forwarding rule; an egress firewall rule
+// allows the nodes to reach it. The IP of the endpoint is stored in an annotation on the cluster.
+func reconcilePrivateEndpoint(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, svc *compute.Service, projectID, region, serviceAttachment string) (*kubermaticv1.Cluster, error) {
+	if serviceAttachment == "" {
+		return nil, errors.New("datacenter has no private service attachment configured")
+	}
+
+	// add the finalizer before creating any resources, so they cannot be leaked
+	cluster, err := update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.AddFinalizer(cluster, privateEndpointCleanupFinalizer)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s finalizer: %w", privateEndpointCleanupFinalizer, err)
+	}
+
+	name := fmt.Sprintf(privateEndpointNamePattern, cluster.Name)
+
+	address, err := svc.Addresses.Get(projectID, region, name).Context(ctx).Do()
+	switch {
+	case isHTTPError(err, http.StatusNotFound):
+		op, err := svc.Addresses.Insert(projectID, region, &compute.Address{
+			Name:        name,
+			AddressType: "INTERNAL",
+			Subnetwork:  cluster.Spec.Cloud.GCP.Subnetwork,
+			Labels:      cluster.Status.ResourceTags,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create address %s: %w", name, err)
+		}
+		if err := waitForRegionOperation(ctx, svc, projectID, region, op); err != nil {
+			return nil, fmt.Errorf("failed to create address %s: %w", name, err)
+		}
+
+		address, err = svc.Addresses.Get(projectID, region, name).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address %s: %w", name, err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get address %s: %w", name, err)
+	default:
+		if err := reconcileAddressLabels(ctx, svc, projectID, region, address, cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := svc.ForwardingRules.Get(projectID, region, name).Context(ctx).Do()
+	switch {
+	case isHTTPError(err, http.StatusNotFound):
+		op, err := svc.ForwardingRules.Insert(projectID, region, &compute.ForwardingRule{
+			Name:      name,
+			Network:   cluster.Spec.Cloud.GCP.Network,
+			IPAddress: address.SelfLink,
+			Target:    serviceAttachment,
+			Labels:    cluster.Status.ResourceTags,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create forwarding rule %s: %w", name, err)
+		}
+		if err := waitForRegionOperation(ctx, svc, projectID, region, op); err != nil {
+			return nil, fmt.Errorf("failed to create forwarding rule %s: %w", name, err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get forwarding rule %s: %w", name, err)
+	default:
+		if err := reconcileForwardingRuleLabels(ctx, svc, projectID, region, rule, cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := reconcilePrivateEndpointFirewall(ctx, cluster, svc, projectID, address.Address); err != nil {
+		return nil, err
+	}
+
+	return update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[resources.PrivateEndpointIPAnnotation] = address.Address
+	})
+}
+
+// reconcilePrivateEndpointFirewall allows egress traffic from the cluster nodes to the private endpoint,
+// in case the network denies egress traffic by default.
+func reconcilePrivateEndpointFirewall(ctx context.Context, cluster *kubermaticv1.Cluster, svc *compute.Service, projectID, ip string) error {
+	firewallService := compute.NewFirewallsService(svc)
+	ruleName := fmt.Sprintf(privateEndpointRuleNamePattern, cluster.Name)
+
+	firewall := &compute.Firewall{
+		Name:              ruleName,
+		Network:           cluster.Spec.Cloud.GCP.Network,
+		Direction:         "EGRESS",
+		TargetTags:        []string{clusterNetworkTagPrefix + cluster.Name},
+		DestinationRanges: []string{ip + "/32"},
+		Allowed:           []*compute.FirewallAllowed{{IPProtocol: "tcp"}},
+	}
+
+	existingFirewall, err := firewallService.Get(projectID, ruleName).Context(ctx).Do()
+	switch {
+	case isHTTPError(err, http.StatusNotFound):
+		if _, err = firewallService.Insert(projectID, firewall).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("failed to create new firewall %s for cluster %s, %w", ruleName, cluster.Name, err)
+		}
+	case err == nil:
+		if !reflect.DeepEqual(existingFirewall.DestinationRanges, firewall.DestinationRanges) ||
+			!reflect.DeepEqual(existingFirewall.TargetTags, firewall.TargetTags) {
+			if _, err = firewallService.Patch(projectID, ruleName, firewall).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("failed to patch firewall %s for cluster %s, %w", ruleName, cluster.Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("failed to get firewall %s for cluster %s, %w", ruleName, cluster.Name, err)
+	}
+
+	return nil
+}
+
+func deletePrivateEndpoint(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, svc *compute.Service, projectID, region string) (*kubermaticv1.Cluster, error) {
+	if !kuberneteshelper.HasFinalizer(cluster, privateEndpointCleanupFinalizer) {
+		return cluster, nil
+	}
+
+	ruleName := fmt.Sprintf(privateEndpointRuleNamePattern, cluster.Name)
+	if _, err := svc.Firewalls.Delete(projectID, ruleName).Context(ctx).Do(); err != nil && !isHTTPError(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("failed to delete firewall rule %s: %w", ruleName, err)
+	}
+
+	// the forwarding rule has to be gone before the address it uses can be released
+	name := fmt.Sprintf(privateEndpointNamePattern, cluster.Name)
+	op, err := svc.ForwardingRules.Delete(projectID, region, name).Context(ctx).Do()
+	switch {
+	case err == nil:
+		if err := waitForRegionOperation(ctx, svc, projectID, region, op); err != nil {
+			return nil, fmt.Errorf("failed to delete forwarding rule %s: %w", name, err)
+		}
+	case !isHTTPError(err, http.StatusNotFound):
+		return nil, fmt.Errorf("failed to delete forwarding rule %s: %w", name, err)
+	}
+
+	if _, err := svc.Addresses.Delete(projectID, region, name).Context(ctx).Do(); err != nil && !isHTTPError(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("failed to delete address %s: %w", name, err)
+	}
+
+	cluster, err = update(ctx, cluster.Name, func(cluster *kubermaticv1.Cluster) {
+		kuberneteshelper.RemoveFinalizer(cluster, privateEndpointCleanupFinalizer)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove %s finalizer: %w", privateEndpointCleanupFinalizer, err)
+	}
+
+	return cluster, nil
+}
+
+func waitForRegionOperation(ctx context.Context, svc *compute.Service, projectID, region string, op *compute.Operation) error {
+	op, err := svc.RegionOperations.Wait(projectID, region, op.Name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return fmt.Errorf("operation %s failed: %s", op.Name, op.Error.Errors[0].Message)
+	}
+
+	if op.Status != "DONE" {
+		return fmt.Errorf("operation %s is still %s", op.Name, op.Status)
+	}
+
+	return nil
+}
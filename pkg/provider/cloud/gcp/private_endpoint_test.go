@@ -0,0 +1,336 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testProject           = "kkp-test"
+	testRegion            = "europe-west3"
+	testServiceAttachment = "projects/seed/regions/europe-west3/serviceAttachments/nodeport-proxy-private"
+	testEndpointIP        = "10.0.0.7"
+)
+
+// fakeComputeServer is a minimal in-memory implementation of the parts of the
+// compute API that are used to manage private endpoints.
+type fakeComputeServer struct {
+	lock      sync.Mutex
+	objects   map[string]map[string]any
+	setLabels int
+}
+
+func newFakeComputeServer(t *testing.T) (*fakeComputeServer, *compute.Service) {
+	fake := &fakeComputeServer{
+		objects: map[string]map[string]any{},
+	}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	svc, err := compute.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to create compute service: %v", err)
+	}
+
+	return fake, svc
+}
+
+func (f *fakeComputeServer) object(collection, name string) map[string]any {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.objects[collection+"/"+name]
+}
+
+func (f *fakeComputeServer) put(collection, name string, obj map[string]any) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.objects[collection+"/"+name] = obj
+}
+
+func (f *fakeComputeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/projects/%s/", testProject))
+	done := map[string]any{"name": "operation", "status": "DONE"}
+
+	switch {
+	case strings.HasSuffix(path, "/wait"):
+		writeJSON(w, http.StatusOK, done)
+		return
+
+	case strings.HasSuffix(path, "/setLabels"):
+		key := strings.TrimSuffix(path, "/setLabels")
+		obj, ok := f.objects[key]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		request := &compute.RegionSetLabelsRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			writeJSON(w, http.StatusBadRequest, nil)
+			return
+		}
+		obj["labels"] = request.Labels
+		f.setLabels++
+
+		writeJSON(w, http.StatusOK, done)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		obj, ok := f.objects[path]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+
+	case http.MethodPost:
+		obj := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			writeJSON(w, http.StatusBadRequest, nil)
+			return
+		}
+		if strings.HasSuffix(path, "/addresses") {
+			obj["address"] = testEndpointIP
+		}
+		f.objects[path+"/"+obj["name"].(string)] = obj
+		writeJSON(w, http.StatusOK, done)
+
+	case http.MethodPatch:
+		obj, ok := f.objects[path]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		patch := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeJSON(w, http.StatusBadRequest, nil)
+			return
+		}
+		maps.Copy(obj, patch)
+		writeJSON(w, http.StatusOK, done)
+
+	case http.MethodDelete:
+		if _, ok := f.objects[path]; !ok {
+			writeNotFound(w)
+			return
+		}
+		delete(f.objects, path)
+		writeJSON(w, http.StatusOK, done)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]any{
+		"error": map[string]any{"code": http.StatusNotFound, "message": "not found"},
+	})
+}
+
+func testPrivateCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "zr8k2m4n6p",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				GCP: &kubermaticv1.GCPCloudSpec{
+					Network:    "global/networks/default",
+					Subnetwork: "regions/europe-west3/subnetworks/default",
+				},
+			},
+			PrivateCluster: &kubermaticv1.PrivateClusterSettings{Enabled: true},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			ResourceTags: map[string]string{"env": "prod"},
+		},
+	}
+}
+
+func testClusterUpdater(cluster *kubermaticv1.Cluster) provider.ClusterUpdater {
+	return func(_ context.Context, _ string, patcher func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error) {
+		patcher(cluster)
+		return cluster, nil
+	}
+}
+
+func TestReconcilePrivateEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no service attachment", func(t *testing.T) {
+		_, svc := newFakeComputeServer(t)
+		cluster := testPrivateCluster()
+
+		if _, err := reconcilePrivateEndpoint(ctx, cluster, testClusterUpdater(cluster), svc, testProject, testRegion, ""); err == nil {
+			t.Fatal("Expected an error, but got none")
+		}
+	})
+
+	t.Run("new endpoint", func(t *testing.T) {
+		fake, svc := newFakeComputeServer(t)
+		cluster := testPrivateCluster()
+
+		cluster, err := reconcilePrivateEndpoint(ctx, cluster, testClusterUpdater(cluster), svc, testProject, testRegion, testServiceAttachment)
+		if err != nil {
+			t.Fatalf("Failed to reconcile private endpoint: %v", err)
+		}
+
+		if !kuberneteshelper.HasFinalizer(cluster, privateEndpointCleanupFinalizer) {
+			t.Errorf("Expected cluster to have the %s finalizer", privateEndpointCleanupFinalizer)
+		}
+		if ip := cluster.Annotations[resources.PrivateEndpointIPAnnotation]; ip != testEndpointIP {
+			t.Errorf("Expected private endpoint IP annotation to be %q, got %q", testEndpointIP, ip)
+		}
+
+		name := fmt.Sprintf(privateEndpointNamePattern, cluster.Name)
+		regionPath := fmt.Sprintf("regions/%s", testRegion)
+
+		address := fake.object(regionPath+"/addresses", name)
+		if address == nil {
+			t.Fatal("Expected address to be created")
+		}
+		if address["addressType"] != "INTERNAL" || address["subnetwork"] != cluster.Spec.Cloud.GCP.Subnetwork {
+			t.Errorf("Expected an internal address in the cluster subnetwork, got %v", address)
+		}
+		if labels, _ := address["labels"].(map[string]any); labels["env"] != "prod" {
+			t.Errorf("Expected address to be labelled with the resource tags, got %v", address["labels"])
+		}
+
+		rule := fake.object(regionPath+"/forwardingRules", name)
+		if rule == nil {
+			t.Fatal("Expected forwarding rule to be created")
+		}
+		if rule["target"] != testServiceAttachment {
+			t.Errorf("Expected forwarding rule to target %q, got %v", testServiceAttachment, rule["target"])
+		}
+		if labels, _ := rule["labels"].(map[string]any); labels["env"] != "prod" {
+			t.Errorf("Expected forwarding rule to be labelled with the resource tags, got %v", rule["labels"])
+		}
+
+		firewall := fake.object("global/firewalls", fmt.Sprintf(privateEndpointRuleNamePattern, cluster.Name))
+		if firewall == nil {
+			t.Fatal("Expected firewall rule to be created")
+		}
+		if ranges, _ := firewall["destinationRanges"].([]any); len(ranges) != 1 || ranges[0] != testEndpointIP+"/32" {
+			t.Errorf("Expected firewall rule to allow egress to the endpoint, got %v", firewall["destinationRanges"])
+		}
+
+		if fake.setLabels != 0 {
+			t.Errorf("Expected no labels to be updated on new resources, got %d updates", fake.setLabels)
+		}
+	})
+
+	t.Run("existing endpoint with outdated labels", func(t *testing.T) {
+		fake, svc := newFakeComputeServer(t)
+		cluster := testPrivateCluster()
+
+		name := fmt.Sprintf(privateEndpointNamePattern, cluster.Name)
+		regionPath := fmt.Sprintf("regions/%s", testRegion)
+		fake.put(regionPath+"/addresses", name, map[string]any{
+			"name":    name,
+			"address": testEndpointIP,
+			"labels":  map[string]any{"owner": "someone-else"},
+		})
+		fake.put(regionPath+"/forwardingRules", name, map[string]any{
+			"name":   name,
+			"target": testServiceAttachment,
+			"labels": map[string]any{"env": "prod"},
+		})
+
+		cluster, err := reconcilePrivateEndpoint(ctx, cluster, testClusterUpdater(cluster), svc, testProject, testRegion, testServiceAttachment)
+		if err != nil {
+			t.Fatalf("Failed to reconcile private endpoint: %v", err)
+		}
+
+		if ip := cluster.Annotations[resources.PrivateEndpointIPAnnotation]; ip != testEndpointIP {
+			t.Errorf("Expected private endpoint IP annotation to be %q, got %q", testEndpointIP, ip)
+		}
+
+		// only the address is missing the resource tags
+		if fake.setLabels != 1 {
+			t.Errorf("Expected 1 label update, got %d", fake.setLabels)
+		}
+
+		labels, _ := fake.object(regionPath+"/addresses", name)["labels"].(map[string]string)
+		if labels["env"] != "prod" || labels["owner"] != "someone-else" {
+			t.Errorf("Expected resource tags to be merged into the existing labels, got %v", labels)
+		}
+	})
+}
+
+func TestDeletePrivateEndpoint(t *testing.T) {
+	ctx := context.Background()
+	fake, svc := newFakeComputeServer(t)
+
+	cluster := testPrivateCluster()
+	cluster.Finalizers = []string{privateEndpointCleanupFinalizer}
+
+	name := fmt.Sprintf(privateEndpointNamePattern, cluster.Name)
+	regionPath := fmt.Sprintf("regions/%s", testRegion)
+	fake.put(regionPath+"/addresses", name, map[string]any{"name": name})
+	fake.put(regionPath+"/forwardingRules", name, map[string]any{"name": name})
+	fake.put("global/firewalls", fmt.Sprintf(privateEndpointRuleNamePattern, cluster.Name), map[string]any{"name": name})
+
+	cluster, err := deletePrivateEndpoint(ctx, cluster, testClusterUpdater(cluster), svc, testProject, testRegion)
+	if err != nil {
+		t.Fatalf("Failed to delete private endpoint: %v", err)
+	}
+
+	if len(fake.objects) != 0 {
+		t.Errorf("Expected all resources to be deleted, but found %v", fake.objects)
+	}
+	if kuberneteshelper.HasFinalizer(cluster, privateEndpointCleanupFinalizer) {
+		t.Errorf("Expected the %s finalizer to be removed", privateEndpointCleanupFinalizer)
+	}
+
+	// deleting again must not fail, even though nothing exists anymore
+	cluster.Finalizers = []string{privateEndpointCleanupFinalizer}
+	if _, err := deletePrivateEndpoint(ctx, cluster, testClusterUpdater(cluster), svc, testProject, testRegion); err != nil {
+		t.Fatalf("Failed to delete non-existing private endpoint: %v", err)
+	}
+}
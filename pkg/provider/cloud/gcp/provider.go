@@ -46,19 +46,22 @@ const (
 	firewallICMPCleanupFinalizer     = "kubermatic.k8c.io/cleanup-gcp-firewall-icmp"
 	firewallNodePortCleanupFinalizer = "kubermatic.k8c.io/cleanup-gcp-firewall-nodeport"
 	routesCleanupFinalizer           = "kubermatic.k8c.io/cleanup-gcp-routes"
+	privateEndpointCleanupFinalizer  = "kubermatic.k8c.io/cleanup-gcp-private-endpoint"
 
 	k8sNodeRouteTag          = "k8s-node-route"
 	k8sNodeRoutePrefixRegexp = "kubernetes-.*"
 )
 
 type gcp struct {
+	dc                *kubermaticv1.DatacenterSpecGCP
 	secretKeySelector provider.SecretKeySelectorValueFunc
 	log               *zap.SugaredLogger
 }
 
 // NewCloudProvider creates a new gcp provider.
-func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
+func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
 	return &gcp{
+		dc:                dc.Spec.GCP,
 		secretKeySelector: secretKeyGetter,
 		log:               log.Logger,
 	}
@@ -77,7 +80,7 @@ func (g *gcp) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluste
 }
 
 func (*gcp) ClusterNeedsReconciling(cluster *kubermaticv1.Cluster) bool {
-	return false
+	return cluster.Spec.IsPrivateCluster() && cluster.Annotations[resources.PrivateEndpointIPAnnotation] == ""
 }
 
 func (g *gcp) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, force, setTags bool) (*kubermaticv1.Cluster, error) {
@@ -114,6 +117,14 @@ func (g *gcp) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluste
 			return nil, fmt.Errorf("failed to add %s finalizer: %w", routesCleanupFinalizer, err)
 		}
 	}
+
+	if cluster.Spec.IsPrivateCluster() && (force || cluster.Annotations[resources.PrivateEndpointIPAnnotation] == "") {
+		cluster, err = reconcilePrivateEndpoint(ctx, cluster, update, svc, projectID, g.dc.Region, g.dc.PrivateServiceAttachment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cluster, nil
 }
 
@@ -150,7 +161,7 @@ func (g *gcp) ValidateCloudSpec(ctx context.Context, spec kubermaticv1.CloudSpec
 	return nil
 }
 
-// CleanUpCloudProvider removes firewall rules and related finalizer.
+// CleanUpCloudProvider removes the private endpoint, firewall rules and related finalizers.
 func (g *gcp) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
 	serviceAccount, err := GetCredentialsForCluster(cluster.Spec.Cloud, g.secretKeySelector)
 	if err != nil {
@@ -162,6 +173,11 @@ func (g *gcp) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cl
 		return nil, err
 	}
 
+	cluster, err = deletePrivateEndpoint(ctx, cluster, update, svc, projectID, g.dc.Region)
+	if err != nil {
+		return nil, err
+	}
+
 	return deleteFirewallRules(ctx, cluster, update, g.log, svc, projectID)
 }
 
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	"k8s.io/utils/ptr"
+)
+
+func reconcileFirewall(ctx context.Context, client *hcloud.Client, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	// a firewall that was not created by KKP must not be managed
+	if cluster.Spec.Cloud.Hetzner.Firewall != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerFirewall) {
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+
+	rules, err := firewallRules(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	firewall, _, err := client.Firewall.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall %q: %w", name, err)
+	}
+
+	switch {
+	case firewall == nil:
+		if _, _, err := client.Firewall.Create(ctx, hcloud.FirewallCreateOpts{
+			Name:   name,
+			Labels: resourceLabels(cluster),
+			Rules:  rules,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create firewall %q: %w", name, err)
+		}
+	case !firewallRulesEqual(firewall.Rules, rules):
+		if _, _, err := client.Firewall.SetRules(ctx, firewall, hcloud.FirewallSetRulesOpts{Rules: rules}); err != nil {
+			return nil, fmt.Errorf("failed to update rules of firewall %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Spec.Cloud.Hetzner.Firewall = name
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerFirewall)
+	})
+}
+
+// firewallRules returns the inbound rules of the cluster firewall. Hetzner firewalls only apply
+// to the public interfaces, so traffic within the cluster network is not affected. Outbound
+// traffic is allowed as long as no outbound rules exist.
+func firewallRules(cluster *kubermaticv1.Cluster) ([]hcloud.FirewallRule, error) {
+	anyIPs, err := parseCIDRs([]string{resources.IPv4MatchAnyCIDR, resources.IPv6MatchAnyCIDR})
+	if err != nil {
+		return nil, err
+	}
+
+	nodePortsAllowedIPRanges := resources.GetNodePortsAllowedIPRanges(cluster, cluster.Spec.Cloud.Hetzner.NodePortsAllowedIPRanges, "")
+	nodePortIPs, err := parseCIDRs(nodePortsAllowedIPRanges.CIDRBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	lowPort, highPort := resources.NewTemplateDataBuilder().
+		WithNodePortRange(cluster.Spec.ComponentsOverride.Apiserver.NodePortRange).
+		WithCluster(cluster).
+		Build().
+		NodePorts()
+	nodePorts := fmt.Sprintf("%d-%d", lowPort, highPort)
+
+	return []hcloud.FirewallRule{
+		{
+			Direction: hcloud.FirewallRuleDirectionIn,
+			SourceIPs: anyIPs,
+			Protocol:  hcloud.FirewallRuleProtocolICMP,
+		},
+		{
+			Direction: hcloud.FirewallRuleDirectionIn,
+			SourceIPs: anyIPs,
+			Protocol:  hcloud.FirewallRuleProtocolTCP,
+			Port:      ptr.To("22"),
+		},
+		{
+			Direction: hcloud.FirewallRuleDirectionIn,
+			SourceIPs: nodePortIPs,
+			Protocol:  hcloud.FirewallRuleProtocolTCP,
+			Port:      ptr.To(nodePorts),
+		},
+		{
+			Direction: hcloud.FirewallRuleDirectionIn,
+			SourceIPs: nodePortIPs,
+			Protocol:  hcloud.FirewallRuleProtocolUDP,
+			Port:      ptr.To(nodePorts),
+		},
+	}, nil
+}
+
+func parseCIDRs(cidrs []string) ([]net.IPNet, error) {
+	ipNets := []net.IPNet{}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		ipNets = append(ipNets, *ipNet)
+	}
+
+	return ipNets, nil
+}
+
+// firewallRulesEqual compares the relevant fields of two sets of firewall rules, ignoring their order.
+func firewallRulesEqual(a, b []hcloud.FirewallRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	keysA := firewallRuleKeys(a)
+	keysB := firewallRuleKeys(b)
+	for i := range keysA {
+		if keysA[i] != keysB[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func firewallRuleKeys(rules []hcloud.FirewallRule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		keys = append(keys, strings.Join([]string{
+			string(rule.Direction),
+			string(rule.Protocol),
+			ptr.Deref(rule.Port, ""),
+			ipNetsKey(rule.SourceIPs),
+			ipNetsKey(rule.DestinationIPs),
+		}, "|"))
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func ipNetsKey(ipNets []net.IPNet) string {
+	cidrs := make([]string, 0, len(ipNets))
+	for _, ipNet := range ipNets {
+		cidrs = append(cidrs, ipNet.String())
+	}
+	sort.Strings(cidrs)
+
+	return strings.Join(cidrs, ",")
+}
+
+func deleteFirewall(ctx context.Context, client *hcloud.Client, name string) error {
+	firewall, _, err := client.Firewall.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if firewall == nil {
+		return nil
+	}
+
+	_, err = client.Firewall.Delete(ctx, firewall)
+
+	return err
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestFirewallRules(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{IPFamily: kubermaticv1.IPFamilyIPv4},
+			Cloud: kubermaticv1.CloudSpec{
+				Hetzner: &kubermaticv1.HetznerCloudSpec{
+					NodePortsAllowedIPRanges: &kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				},
+			},
+		},
+	}
+
+	rules, err := firewallRules(cluster)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var nodePortRules int
+	for _, rule := range rules {
+		if rule.Port == nil || *rule.Port != "30000-32767" {
+			continue
+		}
+		nodePortRules++
+
+		if len(rule.SourceIPs) != 1 || rule.SourceIPs[0].String() != "10.0.0.0/8" {
+			t.Errorf("Expected node port rule to only allow 10.0.0.0/8, got %v", rule.SourceIPs)
+		}
+	}
+
+	if nodePortRules != 2 {
+		t.Errorf("Expected a TCP and a UDP node port rule, got %d", nodePortRules)
+	}
+}
+
+func TestFirewallRulesEqual(t *testing.T) {
+	ips, err := parseCIDRs([]string{"0.0.0.0/0", "::/0"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ssh := hcloud.FirewallRule{Direction: hcloud.FirewallRuleDirectionIn, SourceIPs: ips, Protocol: hcloud.FirewallRuleProtocolTCP, Port: ptr.To("22")}
+	icmp := hcloud.FirewallRule{Direction: hcloud.FirewallRuleDirectionIn, SourceIPs: ips, Protocol: hcloud.FirewallRuleProtocolICMP}
+
+	sshReversedIPs := ssh
+	sshReversedIPs.SourceIPs = []net.IPNet{ips[1], ips[0]}
+
+	if !firewallRulesEqual([]hcloud.FirewallRule{ssh, icmp}, []hcloud.FirewallRule{icmp, sshReversedIPs}) {
+		t.Error("Expected rules in different order to be equal")
+	}
+
+	otherPort := ssh
+	otherPort.Port = ptr.To("2222")
+	if firewallRulesEqual([]hcloud.FirewallRule{ssh, icmp}, []hcloud.FirewallRule{icmp, otherPort}) {
+		t.Error("Expected rules with different ports to not be equal")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	defaultNetworkCIDR = "192.168.0.0/16"
+	defaultSubnetCIDR  = "192.168.0.0/17"
+)
+
+func reconcileNetwork(ctx context.Context, client *hcloud.Client, datacenter string, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	// a network that was not created by KKP must not be managed
+	if cluster.Spec.Cloud.Hetzner.Network != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerNetwork) {
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+
+	network, _, err := client.Network.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network %q: %w", name, err)
+	}
+
+	if network == nil {
+		// subnets are bound to the network zone of the datacenter the machines are created in
+		dc, _, err := client.Datacenter.GetByName(ctx, datacenter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get datacenter %q: %w", datacenter, err)
+		}
+		if dc == nil {
+			return nil, fmt.Errorf("datacenter %q not found", datacenter)
+		}
+
+		_, networkRange, _ := net.ParseCIDR(defaultNetworkCIDR)
+		_, subnetRange, _ := net.ParseCIDR(defaultSubnetCIDR)
+
+		_, _, err = client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+			Name:    name,
+			IPRange: networkRange,
+			Subnets: []hcloud.NetworkSubnet{{
+				Type:        hcloud.NetworkSubnetTypeCloud,
+				IPRange:     subnetRange,
+				NetworkZone: dc.Location.NetworkZone,
+			}},
+			Labels: resourceLabels(cluster),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create network %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Spec.Cloud.Hetzner.Network = name
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerNetwork)
+	})
+}
+
+func deleteNetwork(ctx context.Context, client *hcloud.Client, name string) error {
+	network, _, err := client.Network.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if network == nil {
+		return nil
+	}
+
+	_, err = client.Network.Delete(ctx, network)
+
+	return err
+}
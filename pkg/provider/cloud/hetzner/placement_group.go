@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+// reconcilePlacementGroup ensures a spread placement group for the cluster. Since a spread
+// placement group holds at most 10 servers, the machine-controller creates further groups
+// named "<prefix>-<suffix>" as needed, which are removed together with the initial one.
+func reconcilePlacementGroup(ctx context.Context, client *hcloud.Client, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	// placement groups that were not created by KKP must not be managed
+	if cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix != "" && !kuberneteshelper.HasFinalizer(cluster, FinalizerPlacementGroup) {
+		return cluster, nil
+	}
+
+	name := resourceName(cluster)
+
+	placementGroup, _, err := client.PlacementGroup.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement group %q: %w", name, err)
+	}
+
+	if placementGroup == nil {
+		if _, _, err := client.PlacementGroup.Create(ctx, hcloud.PlacementGroupCreateOpts{
+			Name:   name,
+			Labels: resourceLabels(cluster),
+			Type:   hcloud.PlacementGroupTypeSpread,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create placement group %q: %w", name, err)
+		}
+	}
+
+	return update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+		updatedCluster.Spec.Cloud.Hetzner.PlacementGroupPrefix = name
+		kuberneteshelper.AddFinalizer(updatedCluster, FinalizerPlacementGroup)
+	})
+}
+
+func deletePlacementGroups(ctx context.Context, client *hcloud.Client, prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+
+	placementGroups, err := client.PlacementGroup.All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, placementGroup := range placementGroups {
+		if placementGroup.Name != prefix && !strings.HasPrefix(placementGroup.Name, prefix+"-") {
+			continue
+		}
+
+		if _, err := client.PlacementGroup.Delete(ctx, placementGroup); err != nil {
+			return fmt.Errorf("failed to delete placement group %q: %w", placementGroup.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -23,24 +23,45 @@ import (
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/hcloud"
+	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+const (
+	resourceNamePrefix = "kubernetes-"
+
+	// clusterLabelKey is the label set on all resources created by KKP for a cluster.
+	clusterLabelKey = "kubernetes-cluster"
+
+	// FinalizerNetwork will instruct the deletion of the network.
+	FinalizerNetwork = "kubermatic.k8c.io/cleanup-hetzner-network"
+	// FinalizerFirewall will instruct the deletion of the firewall.
+	FinalizerFirewall = "kubermatic.k8c.io/cleanup-hetzner-firewall"
+	// FinalizerPlacementGroup will instruct the deletion of the placement groups.
+	FinalizerPlacementGroup = "kubermatic.k8c.io/cleanup-hetzner-placement-group"
+)
+
 type hetzner struct {
+	dc                *kubermaticv1.DatacenterSpecHetzner
 	secretKeySelector provider.SecretKeySelectorValueFunc
+	log               *zap.SugaredLogger
 }
 
 // NewCloudProvider creates a new hetzner provider.
-func NewCloudProvider(secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
+func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.SecretKeySelectorValueFunc) provider.CloudProvider {
 	return &hetzner{
+		dc:                dc.Spec.Hetzner,
 		secretKeySelector: secretKeyGetter,
+		log:               log.Logger,
 	}
 }
 
-var _ provider.CloudProvider = &hetzner{}
+var _ provider.ReconcilingCloudProvider = &hetzner{}
 
 // DefaultCloudSpec.
 func (h *hetzner) DefaultCloudSpec(_ context.Context, _ *kubermaticv1.ClusterSpec) error {
@@ -74,16 +95,133 @@ func (h *hetzner) ValidateCloudSpec(ctx context.Context, spec kubermaticv1.Cloud
 	return err
 }
 
-// InitializeCloudProvider.
-func (h *hetzner) InitializeCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+// InitializeCloudProvider creates the network, firewall and placement group for clusters with
+// the managedCloudResources feature.
+func (h *hetzner) InitializeCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return h.reconcileCluster(ctx, cluster, update, false)
+}
+
+// ReconcileCluster enforces the existence and configuration of the cluster's network, firewall and placement group.
+func (h *hetzner) ReconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	return h.reconcileCluster(ctx, cluster, update, true)
+}
+
+func (*hetzner) ClusterNeedsReconciling(cluster *kubermaticv1.Cluster) bool {
+	return false
+}
+
+func (h *hetzner) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater, force bool) (*kubermaticv1.Cluster, error) {
+	// existing clusters must opt in, as the firewall denies all inbound traffic that is not explicitly allowed
+	if !cluster.Spec.Features[kubermaticv1.ClusterFeatureManagedCloudResources] {
+		return cluster, nil
+	}
+
+	token, err := GetCredentialsForCluster(cluster.Spec.Cloud, h.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := hcloud.NewClient(hcloud.WithToken(token))
+	logger := h.log.With("cluster", cluster.Name)
+
+	// a network configured on the datacenter is used by the machines if the cluster has none
+	if (force || cluster.Spec.Cloud.Hetzner.Network == "") && h.dc.Network == "" {
+		logger.Infow("reconciling network", "network", resourceName(cluster))
+		cluster, err = reconcileNetwork(ctx, client, h.dc.Datacenter, cluster, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if force || cluster.Spec.Cloud.Hetzner.Firewall == "" {
+		logger.Infow("reconciling firewall", "firewall", resourceName(cluster))
+		cluster, err = reconcileFirewall(ctx, client, cluster, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if force || cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix == "" {
+		logger.Infow("reconciling placement group", "placementGroup", resourceName(cluster))
+		cluster, err = reconcilePlacementGroup(ctx, client, cluster, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cluster, nil
 }
 
-// CleanUpCloudProvider.
-func (h *hetzner) CleanUpCloudProvider(_ context.Context, cluster *kubermaticv1.Cluster, _ provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+// CleanUpCloudProvider removes all resources that KKP created for the cluster.
+func (h *hetzner) CleanUpCloudProvider(ctx context.Context, cluster *kubermaticv1.Cluster, update provider.ClusterUpdater) (*kubermaticv1.Cluster, error) {
+	token, err := GetCredentialsForCluster(cluster.Spec.Cloud, h.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	client := hcloud.NewClient(hcloud.WithToken(token))
+	logger := h.log.With("cluster", cluster.Name)
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerFirewall) {
+		logger.Infow("deleting firewall", "firewall", cluster.Spec.Cloud.Hetzner.Firewall)
+		if err := deleteFirewall(ctx, client, cluster.Spec.Cloud.Hetzner.Firewall); err != nil {
+			return nil, fmt.Errorf("failed to delete firewall %q: %w", cluster.Spec.Cloud.Hetzner.Firewall, err)
+		}
+
+		cluster, err = update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerFirewall)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerPlacementGroup) {
+		logger.Infow("deleting placement groups", "placementGroupPrefix", cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix)
+		if err := deletePlacementGroups(ctx, client, cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix); err != nil {
+			return nil, fmt.Errorf("failed to delete placement groups %q: %w", cluster.Spec.Cloud.Hetzner.PlacementGroupPrefix, err)
+		}
+
+		cluster, err = update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerPlacementGroup)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if kuberneteshelper.HasFinalizer(cluster, FinalizerNetwork) {
+		logger.Infow("deleting network", "network", cluster.Spec.Cloud.Hetzner.Network)
+		if err := deleteNetwork(ctx, client, cluster.Spec.Cloud.Hetzner.Network); err != nil {
+			return nil, fmt.Errorf("failed to delete network %q: %w", cluster.Spec.Cloud.Hetzner.Network, err)
+		}
+
+		cluster, err = update(ctx, cluster.Name, func(updatedCluster *kubermaticv1.Cluster) {
+			kuberneteshelper.RemoveFinalizer(updatedCluster, FinalizerNetwork)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cluster, nil
 }
 
+func resourceName(cluster *kubermaticv1.Cluster) string {
+	return resourceNamePrefix + cluster.Name
+}
+
+// resourceLabels returns the labels set on all resources created by KKP for the cluster.
+func resourceLabels(cluster *kubermaticv1.Cluster) map[string]string {
+	labels := map[string]string{}
+	for key, value := range cluster.Status.ResourceTags {
+		labels[key] = value
+	}
+	labels[clusterLabelKey] = cluster.Name
+
+	return labels
+}
+
 // ValidateCloudSpecUpdate verifies whether an update of cloud spec is valid and permitted.
 func (h *hetzner) ValidateCloudSpecUpdate(_ context.Context, _ kubermaticv1.CloudSpec, _ kubermaticv1.CloudSpec) error {
 	return nil
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileClusterRequiresFeature(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud: kubermaticv1.CloudSpec{
+				Hetzner: &kubermaticv1.HetznerCloudSpec{},
+			},
+		},
+	}
+
+	// the cluster has no credentials, so any call to the Hetzner API would fail
+	dc := &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{Hetzner: &kubermaticv1.DatacenterSpecHetzner{}}}
+	prov := NewCloudProvider(dc, nil).(provider.ReconcilingCloudProvider)
+
+	updated, err := prov.ReconcileCluster(context.Background(), cluster, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated != cluster {
+		t.Error("Expected cluster without the managedCloudResources feature to be left untouched")
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	osrouters "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	ossecuritygroups "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	osnetworks "github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	osports "github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	ossubnets "github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	routerResourceType        = "router"
+	subnetResourceType        = "subnet"
+	networkResourceType       = "network"
+	securityGroupResourceType = "security-group"
+)
+
+var _ provider.OrphanScanner = &Provider{}
+
+// ListClusterResources returns all networking resources that KKP created for
+// any cluster, as recognized by their name. Resources are returned in the
+// order in which they can be deleted: routers, subnets, networks and finally
+// security groups.
+func (os *Provider) ListClusterResources(ctx context.Context, cloud kubermaticv1.CloudSpec) ([]provider.ClusterResource, error) {
+	netClient, err := os.getClientFunc(ctx, cloud, os.dc, os.secretKeySelector, os.caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []provider.ClusterResource{}
+	appendResource := func(resourceType, id, name string) {
+		clusterName, found := strings.CutPrefix(name, resourceNamePrefix)
+		if found && clusterName != "" {
+			result = append(result, provider.ClusterResource{
+				Cluster: clusterName,
+				Type:    resourceType,
+				ID:      id,
+				Owned:   true,
+			})
+		}
+	}
+
+	routers, err := listRouters(netClient, osrouters.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routers: %w", err)
+	}
+	for _, router := range routers {
+		appendResource(routerResourceType, router.ID, router.Name)
+	}
+
+	subnets, err := getAllSubnets(netClient, ossubnets.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+	for _, subnet := range subnets {
+		appendResource(subnetResourceType, subnet.ID, strings.TrimSuffix(subnet.Name, "-ipv6"))
+	}
+
+	networks, err := getAllNetworks(netClient, osnetworks.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	for _, network := range networks {
+		if !network.External {
+			appendResource(networkResourceType, network.ID, network.Name)
+		}
+	}
+
+	securityGroups, err := getSecurityGroups(netClient, ossecuritygroups.ListOpts{Description: securityGroupDescription})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
+	}
+	for _, group := range securityGroups {
+		appendResource(securityGroupResourceType, group.ID, group.Name)
+	}
+
+	return result, nil
+}
+
+func (os *Provider) DeleteClusterResource(ctx context.Context, cloud kubermaticv1.CloudSpec, resource provider.ClusterResource) error {
+	netClient, err := os.getClientFunc(ctx, cloud, os.dc, os.secretKeySelector, os.caBundle)
+	if err != nil {
+		return err
+	}
+
+	switch resource.Type {
+	case routerResourceType:
+		err = deleteRouterWithInterfaces(netClient, resource.ID)
+	case subnetResourceType:
+		err = deleteSubnet(netClient, resource.ID)
+	case networkResourceType:
+		err = osnetworks.Delete(netClient, resource.ID).ExtractErr()
+	case securityGroupResourceType:
+		err = ossecuritygroups.Delete(netClient, resource.ID).ExtractErr()
+	default:
+		return fmt.Errorf("deleting resources of type %q is not supported", resource.Type)
+	}
+
+	if err != nil && !isNotFoundErr(err) {
+		return err
+	}
+
+	return nil
+}
+
+// deleteRouterWithInterfaces detaches all subnets from a router before deleting it.
+func deleteRouterWithInterfaces(netClient *gophercloud.ServiceClient, routerID string) error {
+	allPages, err := osports.List(netClient, osports.ListOpts{DeviceID: routerID}).AllPages()
+	if err != nil {
+		return fmt.Errorf("failed to list router ports: %w", err)
+	}
+
+	ports, err := osports.ExtractPorts(allPages)
+	if err != nil {
+		return fmt.Errorf("failed to list router ports: %w", err)
+	}
+
+	for _, port := range ports {
+		if !strings.HasPrefix(port.DeviceOwner, "network:router_interface") && port.DeviceOwner != "network:ha_router_replicated_interface" {
+			continue
+		}
+
+		res := osrouters.RemoveInterface(netClient, routerID, osrouters.RemoveInterfaceOpts{PortID: port.ID})
+		if res.Err != nil && !isNotFoundErr(res.Err) {
+			return fmt.Errorf("failed to detach port %s: %w", port.ID, res.Err)
+		}
+	}
+
+	return deleteRouter(netClient, routerID)
+}
@@ -98,6 +98,7 @@ func NewCloudProvider(
 }
 
 var _ provider.ReconcilingCloudProvider = &Provider{}
+var _ provider.CapacityChecker = &Provider{}
 
 // DefaultCloudSpec adds defaults to the cloud spec.
 func (os *Provider) DefaultCloudSpec(ctx context.Context, spec *kubermaticv1.ClusterSpec) error {
@@ -272,6 +273,11 @@ func (os *Provider) reconcileCluster(ctx context.Context, cluster *kubermaticv1.
 			return nil, err
 		}
 	}
+	if force {
+		if err := reconcileResourceTags(netClient, cluster); err != nil {
+			return nil, err
+		}
+	}
 	return cluster, nil
 }
 
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	oslimits "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/limits"
+	osflavors "github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	ostokens "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	osquotas "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	openstacktypes "k8c.io/machine-controller/pkg/cloudprovider/provider/openstack/types"
+)
+
+// CheckCapacity verifies that the compute quotas (instances, vCPUs, RAM) and network quotas
+// (networks, subnets, routers, security groups, ports, floating IPs) of the project leave
+// enough room for the resources KKP creates for the cluster and the requested machines.
+func (os *Provider) CheckCapacity(ctx context.Context, cloud kubermaticv1.CloudSpec, request provider.CapacityRequest) error {
+	creds, err := GetCredentialsForCluster(cloud, os.secretKeySelector)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	netClient, err := os.getClientFunc(ctx, cloud, os.dc, os.secretKeySelector, os.caBundle)
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs      []error
+		ports     int
+		floatIPs  int
+		rawConfig *openstacktypes.RawConfig
+	)
+
+	if request.Config != nil {
+		rawConfig, err = openstacktypes.GetConfig(*request.Config)
+		if err != nil {
+			return fmt.Errorf("failed to decode providerSpec: %w", err)
+		}
+
+		ports = request.Replicas
+
+		floatingIPPool, _, err := request.StringValue(rawConfig.FloatingIPPool)
+		if err != nil {
+			return fmt.Errorf("failed to determine floating IP pool: %w", err)
+		}
+		if floatingIPPool != "" || cloud.Openstack.FloatingIPPool != "" {
+			floatIPs = request.Replicas
+		}
+
+		flavor, known, err := request.StringValue(rawConfig.Flavor)
+		if err != nil {
+			return fmt.Errorf("failed to determine flavor: %w", err)
+		}
+
+		if known {
+			computeClient, err := getComputeClient(os.dc.AuthURL, os.dc.Region, creds, os.caBundle)
+			if err != nil {
+				return fmt.Errorf("failed to create compute client: %w", err)
+			}
+
+			if err := checkComputeQuota(computeClient, flavor, request.Replicas); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	projectID := creds.ProjectID
+	if projectID == "" {
+		if projectID, err = getTokenProjectID(netClient); err != nil {
+			return err
+		}
+	}
+
+	quotas, err := osquotas.GetDetail(netClient, projectID).Extract()
+	if err != nil {
+		// the quota details API is an optional extension
+		if isNotFoundErr(err) {
+			return errors.Join(errs...)
+		}
+
+		return fmt.Errorf("failed to get network quotas: %w", err)
+	}
+
+	required := []struct {
+		resource  string
+		requested int
+		quota     osquotas.QuotaDetail
+	}{
+		{resource: "networks", requested: boolToInt(cloud.Openstack.Network == ""), quota: quotas.Network},
+		{resource: "subnets", requested: boolToInt(cloud.Openstack.SubnetID == ""), quota: quotas.Subnet},
+		{resource: "routers", requested: boolToInt(cloud.Openstack.RouterID == ""), quota: quotas.Router},
+		{resource: "security groups", requested: boolToInt(cloud.Openstack.SecurityGroups == ""), quota: quotas.SecurityGroup},
+		{resource: "ports", requested: ports, quota: quotas.Port},
+		{resource: "floating IPs", requested: floatIPs, quota: quotas.FloatingIP},
+	}
+
+	for _, r := range required {
+		if err := provider.CheckQuota(r.resource, r.requested, r.quota.Used+r.quota.Reserved, r.quota.Limit); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkComputeQuota(computeClient *gophercloud.ServiceClient, flavorName string, replicas int) error {
+	flavor, err := getFlavorByName(computeClient, flavorName)
+	if err != nil {
+		return err
+	}
+
+	limits, err := oslimits.Get(computeClient, nil).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to get compute limits: %w", err)
+	}
+
+	absolute := limits.Absolute
+
+	return errors.Join(
+		provider.CheckQuota("instances", replicas, absolute.TotalInstancesUsed, absolute.MaxTotalInstances),
+		provider.CheckQuota("vCPUs", replicas*flavor.VCPUs, absolute.TotalCoresUsed, absolute.MaxTotalCores),
+		provider.CheckQuota("RAM (MB)", replicas*flavor.RAM, absolute.TotalRAMUsed, absolute.MaxTotalRAMSize),
+	)
+}
+
+func getFlavorByName(computeClient *gophercloud.ServiceClient, name string) (*osflavors.Flavor, error) {
+	allPages, err := osflavors.ListDetail(computeClient, osflavors.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flavors: %w", err)
+	}
+
+	flavors, err := osflavors.ExtractFlavors(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract flavors: %w", err)
+	}
+
+	for i, flavor := range flavors {
+		if strings.EqualFold(flavor.Name, name) || flavor.ID == name {
+			return &flavors[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot find flavor %q", name)
+}
+
+// getTokenProjectID returns the ID of the project the client's token is scoped to.
+func getTokenProjectID(client *gophercloud.ServiceClient) (string, error) {
+	result, ok := client.GetAuthResult().(interface {
+		ExtractProject() (*ostokens.Project, error)
+	})
+	if !ok {
+		return "", errors.New("cannot determine project of the authentication token")
+	}
+
+	project, err := result.ExtractProject()
+	if err != nil {
+		return "", fmt.Errorf("failed to extract project from token: %w", err)
+	}
+	if project == nil {
+		return "", errors.New("authentication token is not scoped to a project")
+	}
+
+	return project.ID, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
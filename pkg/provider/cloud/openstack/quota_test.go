@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	ostesthelper "github.com/gophercloud/gophercloud/testhelper"
+	osfakeclient "github.com/gophercloud/gophercloud/testhelper/client"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func TestCheckCapacityNetworkQuotas(t *testing.T) {
+	const quotas = `{"quota": {
+		"network":        {"used": 9,  "reserved": 0, "limit": 10},
+		"subnet":         {"used": 9,  "reserved": 0, "limit": 10},
+		"router":         {"used": 10, "reserved": 0, "limit": 10},
+		"security_group": {"used": 9,  "reserved": 1, "limit": 10},
+		"port":           {"used": 0,  "reserved": 0, "limit": -1},
+		"floatingip":     {"used": 0,  "reserved": 0, "limit": -1}
+	}}`
+
+	testCases := []struct {
+		name    string
+		spec    kubermaticv1.OpenstackCloudSpec
+		wantErr bool
+	}{
+		{
+			name: "cluster resources exceed the quotas",
+			spec: kubermaticv1.OpenstackCloudSpec{
+				Username:  "user",
+				Password:  "password",
+				Domain:    "domain",
+				ProjectID: "project-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "existing resources do not count against the quotas",
+			spec: kubermaticv1.OpenstackCloudSpec{
+				Username:       "user",
+				Password:       "password",
+				Domain:         "domain",
+				ProjectID:      "project-id",
+				Network:        "network",
+				SubnetID:       "subnet",
+				RouterID:       "router",
+				SecurityGroups: "security-group",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ostesthelper.SetupHTTP()
+			defer ostesthelper.TeardownHTTP()
+
+			ostesthelper.Mux.HandleFunc("/quotas/project-id/details.json", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, quotas)
+			})
+
+			os := &Provider{
+				dc: &kubermaticv1.DatacenterSpecOpenstack{},
+				getClientFunc: func(ctx context.Context, cluster kubermaticv1.CloudSpec, dc *kubermaticv1.DatacenterSpecOpenstack, secretKeySelector provider.SecretKeySelectorValueFunc, caBundle *x509.CertPool) (*gophercloud.ServiceClient, error) {
+					return osfakeclient.ServiceClient(), nil
+				},
+			}
+
+			err := os.CheckCapacity(context.Background(), kubermaticv1.CloudSpec{Openstack: &tc.spec}, provider.CapacityRequest{})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error = %v, got %v", tc.wantErr, err)
+			}
+
+			if err != nil && !provider.IsInsufficientQuotaError(err) {
+				t.Fatalf("Expected InsufficientQuotaError, got %v", err)
+			}
+		})
+	}
+}
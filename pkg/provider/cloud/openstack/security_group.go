@@ -82,6 +82,9 @@ func deleteSecurityGroup(netClient *gophercloud.ServiceClient, sgName string) er
 	return nil
 }
 
+// securityGroupDescription is used for all security groups created by KKP.
+const securityGroupDescription = "Contains security rules for the Kubernetes worker nodes"
+
 type securityGroupSpec struct {
 	name           string
 	ipv4Rules      bool
@@ -102,7 +105,7 @@ func ensureSecurityGroup(netClient *gophercloud.ServiceClient, req securityGroup
 	case 0:
 		gres := ossecuritygroups.Create(netClient, ossecuritygroups.CreateOpts{
 			Name:        req.name,
-			Description: "Contains security rules for the Kubernetes worker nodes",
+			Description: securityGroupDescription,
 		})
 		if gres.Err != nil {
 			return "", gres.Err
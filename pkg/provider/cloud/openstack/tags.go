@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
+	ossecuritygroups "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// taggedResource is a Neutron resource that is owned by the cluster and that
+// should carry the cluster's resource tags.
+type taggedResource struct {
+	// kind is the Neutron resource type as used in the tags API, e.g. "networks".
+	kind string
+	id   string
+	tags []string
+}
+
+// reconcileResourceTags applies the cluster's resource tags to all networking resources
+// owned by the cluster. OpenStack only supports plain string tags, so each tag is stored
+// as "key=value". Tags whose key has been removed from the cluster are left untouched,
+// as KKP cannot know whether they have been set by someone else.
+func reconcileResourceTags(netClient *gophercloud.ServiceClient, cluster *kubermaticv1.Cluster) error {
+	if len(cluster.Status.ResourceTags) == 0 {
+		return nil
+	}
+
+	resources, err := ownedResources(netClient, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range resources {
+		tags := mergeResourceTags(res.tags, cluster.Status.ResourceTags)
+		if sets.New(tags...).Equal(sets.New(res.tags...)) {
+			continue
+		}
+
+		if _, err := attributestags.ReplaceAll(netClient, res.kind, res.id, attributestags.ReplaceAllOpts{Tags: tags}).Extract(); err != nil {
+			return fmt.Errorf("failed to set tags on %s %s: %w", res.kind, res.id, err)
+		}
+	}
+
+	return nil
+}
+
+func ownedResources(netClient *gophercloud.ServiceClient, cluster *kubermaticv1.Cluster) ([]taggedResource, error) {
+	spec := cluster.Spec.Cloud.Openstack
+
+	var resources []taggedResource
+
+	if kubernetes.HasFinalizer(cluster, NetworkCleanupFinalizer) && spec.Network != "" {
+		network, err := getNetworkByName(netClient, spec.Network, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network %q: %w", spec.Network, err)
+		}
+		resources = append(resources, taggedResource{kind: "networks", id: network.ID, tags: network.Tags})
+	}
+
+	for finalizer, subnetID := range map[string]string{
+		SubnetCleanupFinalizer:     spec.SubnetID,
+		IPv6SubnetCleanupFinalizer: spec.IPv6SubnetID,
+	} {
+		if kubernetes.HasFinalizer(cluster, finalizer) && subnetID != "" {
+			subnet, err := getSubnetByID(netClient, subnetID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get subnet %q: %w", subnetID, err)
+			}
+			resources = append(resources, taggedResource{kind: "subnets", id: subnet.ID, tags: subnet.Tags})
+		}
+	}
+
+	if kubernetes.HasFinalizer(cluster, RouterCleanupFinalizer) && spec.RouterID != "" {
+		router, err := getRouterByID(netClient, spec.RouterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get router %q: %w", spec.RouterID, err)
+		}
+		resources = append(resources, taggedResource{kind: "routers", id: router.ID, tags: router.Tags})
+	}
+
+	// only the default security group is known to be created by KKP
+	sgName := resourceNamePrefix + cluster.Name
+	if kubernetes.HasFinalizer(cluster, SecurityGroupCleanupFinalizer) && slices.Contains(splitString(spec.SecurityGroups), sgName) {
+		groups, err := getSecurityGroups(netClient, ossecuritygroups.ListOpts{Name: sgName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get security group %q: %w", sgName, err)
+		}
+		for _, group := range groups {
+			resources = append(resources, taggedResource{kind: "security-groups", id: group.ID, tags: group.Tags})
+		}
+	}
+
+	return resources, nil
+}
+
+// mergeResourceTags returns the existing tags, with all "key=value" tags for the
+// given resource tags set to their current value. The result is sorted.
+func mergeResourceTags(existing []string, resourceTags map[string]string) []string {
+	result := sets.New[string]()
+
+	for _, tag := range existing {
+		key, _, _ := strings.Cut(tag, "=")
+		if _, ok := resourceTags[key]; !ok {
+			result.Insert(tag)
+		}
+	}
+
+	for key, value := range resourceTags {
+		result.Insert(key + "=" + value)
+	}
+
+	return sets.List(result)
+}
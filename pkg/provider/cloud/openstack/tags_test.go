@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+)
+
+func TestMergeResourceTags(t *testing.T) {
+	testcases := []struct {
+		name         string
+		existing     []string
+		resourceTags map[string]string
+		expected     []string
+	}{
+		{
+			name:         "no existing tags",
+			resourceTags: map[string]string{"env": "prod", "team": "a"},
+			expected:     []string{"env=prod", "team=a"},
+		},
+		{
+			name:         "foreign tags are kept",
+			existing:     []string{"foreign", "other=value"},
+			resourceTags: map[string]string{"env": "prod"},
+			expected:     []string{"env=prod", "foreign", "other=value"},
+		},
+		{
+			name:         "changed values are replaced",
+			existing:     []string{"env=staging", "team=a"},
+			resourceTags: map[string]string{"env": "prod", "team": "a"},
+			expected:     []string{"env=prod", "team=a"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := mergeResourceTags(tc.existing, tc.resourceTags)
+			if !diff.SemanticallyEqual(tc.expected, result) {
+				t.Fatalf("Tags differ:\n%v", diff.ObjectDiff(tc.expected, result))
+			}
+		})
+	}
+}
@@ -47,7 +47,7 @@ func Provider(
 	caBundle *x509.CertPool,
 ) (provider.CloudProvider, error) {
 	if datacenter.Spec.Digitalocean != nil {
-		return digitalocean.NewCloudProvider(secretKeyGetter), nil
+		return digitalocean.NewCloudProvider(datacenter, secretKeyGetter), nil
 	}
 	if datacenter.Spec.BringYourOwn != nil {
 		return bringyourown.NewCloudProvider(), nil
@@ -68,7 +68,7 @@ func Provider(
 		return packet.NewCloudProvider(secretKeyGetter), nil
 	}
 	if datacenter.Spec.Hetzner != nil {
-		return hetzner.NewCloudProvider(secretKeyGetter), nil
+		return hetzner.NewCloudProvider(datacenter, secretKeyGetter), nil
 	}
 	if datacenter.Spec.VMwareCloudDirector != nil {
 		return vmwareclouddirector.NewCloudProvider(datacenter, secretKeyGetter)
@@ -77,7 +77,7 @@ func Provider(
 		return vsphere.NewCloudProvider(datacenter, secretKeyGetter, caBundle)
 	}
 	if datacenter.Spec.GCP != nil {
-		return gcp.NewCloudProvider(secretKeyGetter), nil
+		return gcp.NewCloudProvider(datacenter, secretKeyGetter), nil
 	}
 	if datacenter.Spec.Fake != nil {
 		return fake.NewCloudProvider(), nil
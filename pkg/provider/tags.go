@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"maps"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+// EffectiveResourceTags merges the resource tags configured on the datacenter, the
+// project and the cluster into the set of tags that should be applied to the cluster's
+// cloud resources. Tags on the project override those on the datacenter and tags on the
+// cluster override both. Both datacenter and project are optional. Returns nil if no
+// tags are configured at all.
+func EffectiveResourceTags(datacenter *kubermaticv1.Datacenter, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster) map[string]string {
+	var result map[string]string
+
+	merge := func(tags map[string]string) {
+		if len(tags) == 0 {
+			return
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		maps.Copy(result, tags)
+	}
+
+	if datacenter != nil {
+		merge(datacenter.Spec.ResourceTags)
+	}
+	if project != nil {
+		merge(project.Spec.ResourceTags)
+	}
+	if cluster != nil {
+		merge(cluster.Spec.ResourceTags)
+	}
+
+	return result
+}
+
+// MissingResourceTags returns the subset of wanted tags that are either not present in
+// existing or have a different value there.
+func MissingResourceTags(wanted, existing map[string]string) map[string]string {
+	var result map[string]string
+
+	for key, value := range wanted {
+		if current, ok := existing[key]; !ok || current != value {
+			if result == nil {
+				result = map[string]string{}
+			}
+			result[key] = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"maps"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+func TestEffectiveResourceTags(t *testing.T) {
+	testcases := []struct {
+		name       string
+		datacenter map[string]string
+		project    map[string]string
+		cluster    map[string]string
+		expected   map[string]string
+	}{
+		{
+			name:     "no tags at all",
+			expected: nil,
+		},
+		{
+			name:       "only datacenter tags",
+			datacenter: map[string]string{"env": "prod"},
+			expected:   map[string]string{"env": "prod"},
+		},
+		{
+			name:       "project overrides datacenter",
+			datacenter: map[string]string{"env": "prod", "dc": "eu"},
+			project:    map[string]string{"env": "staging", "team": "a"},
+			expected:   map[string]string{"env": "staging", "dc": "eu", "team": "a"},
+		},
+		{
+			name:       "cluster overrides project and datacenter",
+			datacenter: map[string]string{"env": "prod"},
+			project:    map[string]string{"env": "staging", "team": "a"},
+			cluster:    map[string]string{"team": "b", "cost-center": "42"},
+			expected:   map[string]string{"env": "staging", "team": "b", "cost-center": "42"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{ResourceTags: tc.datacenter}}
+			project := &kubermaticv1.Project{Spec: kubermaticv1.ProjectSpec{ResourceTags: tc.project}}
+			cluster := &kubermaticv1.Cluster{Spec: kubermaticv1.ClusterSpec{ResourceTags: tc.cluster}}
+
+			result := EffectiveResourceTags(dc, project, cluster)
+			if !maps.Equal(result, tc.expected) {
+				t.Fatalf("Expected %v, got %v.", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMissingResourceTags(t *testing.T) {
+	wanted := map[string]string{"a": "1", "b": "2", "c": "3"}
+	existing := map[string]string{"a": "1", "b": "old", "d": "4"}
+
+	expected := map[string]string{"b": "2", "c": "3"}
+	if result := MissingResourceTags(wanted, existing); !maps.Equal(result, expected) {
+		t.Fatalf("Expected %v, got %v.", expected, result)
+	}
+
+	if result := MissingResourceTags(wanted, wanted); result != nil {
+		t.Fatalf("Expected no missing tags, got %v.", result)
+	}
+}
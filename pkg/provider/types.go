@@ -26,6 +26,7 @@ import (
 	apiv2 "k8c.io/kubermatic/v2/pkg/api/v2"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	ksemver "k8c.io/kubermatic/v2/pkg/semver"
+	mcproviderconfig "k8c.io/machine-controller/pkg/providerconfig"
 	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
 
 	corev1 "k8s.io/api/core/v1"
@@ -92,6 +93,58 @@ type ReconcilingCloudProvider interface {
 	ClusterNeedsReconciling(*kubermaticv1.Cluster) bool
 }
 
+// OrphanScanner is a cloud provider that can list the resources it created or
+// tagged for clusters, so that resources outliving their cluster can be found.
+type OrphanScanner interface {
+	CloudProvider
+
+	// ListClusterResources returns all resources that belong to any cluster and
+	// are visible using the credentials of the given CloudSpec. Resources are
+	// returned in an order in which they can safely be deleted.
+	ListClusterResources(ctx context.Context, cloud kubermaticv1.CloudSpec) ([]ClusterResource, error)
+
+	// DeleteClusterResource deletes a resource returned by ListClusterResources.
+	// Resources the cluster does not own are released instead, by removing the
+	// cluster's tag from them.
+	DeleteClusterResource(ctx context.Context, cloud kubermaticv1.CloudSpec, resource ClusterResource) error
+}
+
+// ClusterResource is a cloud resource that belongs to a cluster.
+type ClusterResource struct {
+	// Cluster is the name of the cluster the resource belongs to.
+	Cluster string
+	// Type is the provider-specific kind of resource, e.g. "security-group".
+	Type string
+	// ID identifies the resource at the cloud provider.
+	ID string
+	// Owned is true if the resource was created for the cluster, instead of
+	// just being tagged with the cluster's name.
+	Owned bool
+}
+
+// CapacityChecker is a cloud provider that can verify that the account used by a
+// cluster has enough quota left for the cluster's cloud resources and its machines.
+type CapacityChecker interface {
+	CloudProvider
+
+	// CheckCapacity returns an *InsufficientQuotaError (possibly joined with others)
+	// if creating the cluster's own cloud resources (those that are not configured in
+	// the CloudSpec yet) and the requested machines would exceed a quota of the account.
+	CheckCapacity(ctx context.Context, cloud kubermaticv1.CloudSpec, request CapacityRequest) error
+}
+
+// CapacityRequest describes the machines that are about to be created for a cluster.
+type CapacityRequest struct {
+	// Replicas is the number of machines.
+	Replicas int
+	// Config is the machine-controller providerSpec of the machines.
+	Config *providerconfig.Config
+
+	// resolver resolves providerSpec fields referencing Secrets or ConfigMaps
+	// in the user cluster; nil if the user cluster is not reachable yet.
+	resolver *mcproviderconfig.ConfigVarResolver
+}
+
 // ClusterUpdater defines a function to persist an update to a cluster.
 type ClusterUpdater func(context.Context, string, func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error)
 
@@ -108,13 +108,18 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 
 	// External Name
 	externalName := ""
-	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
+	switch {
+	case m.cluster.Spec.IsPrivateCluster():
+		// Private clusters are only reachable via the private endpoint that the cloud
+		// provider creates in the cluster network. Until it exists, the address stays empty.
+		externalName = m.cluster.Annotations[resources.PrivateEndpointIPAnnotation]
+	case m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer:
 		if frontProxyLBServiceIP != "" {
 			externalName = frontProxyLBServiceIP
 		} else {
 			externalName = frontProxyLBServiceHostname
 		}
-	} else {
+	default:
 		externalName = fmt.Sprintf("%s.%s.%s", m.cluster.Name, subdomain, m.externalURL)
 	}
 
@@ -139,8 +144,10 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	// When using the Tunneling expose strategy we disable KAS endpoints
 	// reconciliation, and we reconcile them with the agent IPs in the user
 	// controller manager.
-	switch m.cluster.Spec.ExposeStrategy {
-	case kubermaticv1.ExposeStrategyLoadBalancer:
+	switch {
+	case m.cluster.Spec.IsPrivateCluster():
+		ip = externalName
+	case m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer:
 		if frontProxyLBServiceIP != "" {
 			ip = frontProxyLBServiceIP
 		} else if frontProxyLBServiceHostname != "" {
@@ -151,9 +158,9 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 				return nil, err
 			}
 		}
-	case kubermaticv1.ExposeStrategyNodePort:
-		fallthrough
-	case kubermaticv1.ExposeStrategyTunneling:
+	case m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyNodePort,
+		m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling,
+		m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway:
 		var err error
 		// Always lookup IP address, in case it changes (IP's on AWS LB's change)
 		ip, err = m.getExternalIP(externalName)
@@ -216,9 +223,10 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 		port = service.Spec.Ports[0].NodePort
 	}
 
-	// Use the nodeport value for KAS secure port when strategy is NodePort or
-	// LoadBalancer. This is because the same service will be accessed both
-	// locally and passing from nodeport proxy.
+	// Use the nodeport value for KAS secure port when strategy is NodePort,
+	// LoadBalancer or Gateway. This is because the same service will be accessed
+	// both locally and passing from nodeport proxy or the dedicated listener of
+	// the control plane Gateway.
 	if m.cluster.Status.Address.Port != port {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
 			c.Status.Address.Port = port
@@ -227,7 +235,10 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	}
 
 	// URL
-	url := fmt.Sprintf("https://%s", net.JoinHostPort(externalName, fmt.Sprintf("%d", port)))
+	url := ""
+	if externalName != "" {
+		url = fmt.Sprintf("https://%s", net.JoinHostPort(externalName, fmt.Sprintf("%d", port)))
+	}
 	if m.cluster.Status.Address.URL != url {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
 			c.Status.Address.URL = url
@@ -117,6 +117,7 @@ func TestSyncClusterAddress(t *testing.T) {
 		apiserverService     corev1.Service
 		frontproxyService    corev1.Service
 		exposeStrategy       kubermaticv1.ExposeStrategy
+		privateEndpointIP    *string
 		seedDNSOverwrite     string
 		expectedExternalName string
 		expectedIP           string
@@ -330,6 +331,25 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for Gateway expose strategy",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						}},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategyGateway,
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(32000),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:32000", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
 		{
 			name: "Verify properties for Tunneling expose strategy",
 			apiserverService: corev1.Service{
@@ -369,6 +389,46 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(6443),
 			expectedURL:          fmt.Sprintf("https://%s.%s.%s:6443", fakeClusterNameIPv6, fakeDCName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for private cluster",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						}},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategyNodePort,
+			privateEndpointIP:    ptr.To("10.1.0.5"),
+			expectedExternalName: "10.1.0.5",
+			expectedIP:           "10.1.0.5",
+			expectedPort:         int32(32000),
+			expectedURL:          "https://10.1.0.5:32000",
+		},
+		{
+			name: "Verify properties for private cluster without private endpoint",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						}},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategyNodePort,
+			privateEndpointIP:    ptr.To(""),
+			expectedExternalName: "",
+			expectedIP:           "",
+			expectedPort:         int32(32000),
+			expectedURL:          "",
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
@@ -488,6 +548,13 @@ func TestSyncClusterAddress(t *testing.T) {
 				},
 			}
 
+			if tc.privateEndpointIP != nil {
+				cluster.Spec.PrivateCluster = &kubermaticv1.PrivateClusterSettings{Enabled: true}
+				cluster.Annotations = map[string]string{
+					resources.PrivateEndpointIPAnnotation: *tc.privateEndpointIP,
+				}
+			}
+
 			apiserverService := &tc.apiserverService
 			apiserverService.Name = resources.ApiserverServiceName
 			apiserverService.Namespace = fakeClusterNamespaceName
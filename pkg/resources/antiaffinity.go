@@ -101,3 +101,34 @@ func podAffinityTerm(app string, topologyKey string) []corev1.PodAffinityTerm {
 		},
 	}
 }
+
+// ZoneTopologySpreadConstraints spreads same-kind control plane pods across availability zones,
+// according to the cluster's control plane topology. It returns nil if no topology is configured.
+func ZoneTopologySpreadConstraints(app string, topology *kubermaticv1.ControlPlaneTopologySettings) []corev1.TopologySpreadConstraint {
+	if topology == nil {
+		return nil
+	}
+
+	maxSkew := int32(1)
+	if topology.MaxSkew != nil {
+		maxSkew = *topology.MaxSkew
+	}
+
+	whenUnsatisfiable := corev1.ScheduleAnyway
+	if topology.ZoneSpread == kubermaticv1.AntiAffinityTypeRequired {
+		whenUnsatisfiable = corev1.DoNotSchedule
+	}
+
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           maxSkew,
+			TopologyKey:       TopologyKeyZone,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					AppLabelKey: app,
+				},
+			},
+		},
+	}
+}
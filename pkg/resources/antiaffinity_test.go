@@ -19,7 +19,10 @@ package resources
 import (
 	"testing"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestMerge(t *testing.T) {
@@ -44,3 +47,25 @@ func TestMerge(t *testing.T) {
 		t.Errorf("Merge failed, expected length of RequiredDuringSchedulingIgnoredDuringExecution to be 2, got %d", len(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution))
 	}
 }
+
+func TestZoneTopologySpreadConstraints(t *testing.T) {
+	if constraints := ZoneTopologySpreadConstraints("app", nil); constraints != nil {
+		t.Errorf("Expected no constraints without a topology, got %v", constraints)
+	}
+
+	constraints := ZoneTopologySpreadConstraints("app", &kubermaticv1.ControlPlaneTopologySettings{})
+	if len(constraints) != 1 {
+		t.Fatalf("Expected exactly one constraint, got %d", len(constraints))
+	}
+	if constraints[0].MaxSkew != 1 || constraints[0].WhenUnsatisfiable != corev1.ScheduleAnyway || constraints[0].TopologyKey != TopologyKeyZone {
+		t.Errorf("Expected a preferred zone constraint with a max skew of 1, got %+v", constraints[0])
+	}
+
+	constraints = ZoneTopologySpreadConstraints("app", &kubermaticv1.ControlPlaneTopologySettings{
+		ZoneSpread: kubermaticv1.AntiAffinityTypeRequired,
+		MaxSkew:    ptr.To[int32](2),
+	})
+	if constraints[0].MaxSkew != 2 || constraints[0].WhenUnsatisfiable != corev1.DoNotSchedule {
+		t.Errorf("Expected a required zone constraint with a max skew of 2, got %+v", constraints[0])
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	semverlib "github.com/Masterminds/semver/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+// minStructuredAuthenticationVersion is the first Kubernetes version that supports
+// the v1beta1 AuthenticationConfiguration.
+var minStructuredAuthenticationVersion = semverlib.MustParse("1.30.0")
+
+type authenticationData interface {
+	Cluster() *kubermaticv1.Cluster
+	CABundle() resources.CABundle
+	OIDCIssuerURL() string
+	OIDCIssuerClientID() string
+}
+
+// IsAuthenticationConfigurationEnabled returns true if the cluster configures structured
+// authentication and its kube-apiserver is recent enough to support it.
+func IsAuthenticationConfigurationEnabled(cluster *kubermaticv1.Cluster) bool {
+	if cluster.Spec.AuthenticationConfiguration == nil {
+		return false
+	}
+
+	version := cluster.Status.Versions.Apiserver.Semver()
+	if version == nil {
+		return false
+	}
+
+	return !version.LessThan(minStructuredAuthenticationVersion)
+}
+
+// AuthenticationConfigurationReconciler returns a function to create the ConfigMap containing
+// the AuthenticationConfiguration for the kube-apiserver. If the KKP-wide OIDC authentication is
+// enabled, the KKP issuer is appended as an additional authenticator, as the `--oidc-*` flags
+// cannot be combined with an AuthenticationConfiguration.
+func AuthenticationConfigurationReconciler(data authenticationData, enableOIDCAuthentication bool) reconciling.NamedConfigMapReconcilerFactory {
+	return func() (string, reconciling.ConfigMapReconciler) {
+		return resources.AuthenticationConfigurationConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			config := AuthenticationConfiguration(data.Cluster().Spec.AuthenticationConfiguration)
+
+			for i := range config.JWT {
+				if config.JWT[i].Issuer.CertificateAuthority == "" {
+					config.JWT[i].Issuer.CertificateAuthority = data.CABundle().String()
+				}
+			}
+
+			if enableOIDCAuthentication && data.OIDCIssuerURL() != "" && !hasIssuer(config, data.OIDCIssuerURL()) {
+				config.JWT = append(config.JWT, apiserverv1beta1.JWTAuthenticator{
+					Issuer: apiserverv1beta1.Issuer{
+						URL:                  data.OIDCIssuerURL(),
+						CertificateAuthority: data.CABundle().String(),
+						Audiences:            []string{data.OIDCIssuerClientID()},
+					},
+					ClaimMappings: apiserverv1beta1.ClaimMappings{
+						Username: apiserverv1beta1.PrefixedClaimOrExpression{
+							Claim:  "email",
+							Prefix: ptr.To(""),
+						},
+						Groups: apiserverv1beta1.PrefixedClaimOrExpression{
+							Claim:  "groups",
+							Prefix: ptr.To("oidc:"),
+						},
+					},
+				})
+			}
+
+			rawConfig, err := yaml.Marshal(config)
+			if err != nil {
+				return nil, err
+			}
+
+			cm.Data = map[string]string{
+				resources.AuthenticationConfigurationKeyName: string(rawConfig),
+			}
+
+			return cm, nil
+		}
+	}
+}
+
+func hasIssuer(config *apiserverv1beta1.AuthenticationConfiguration, issuerURL string) bool {
+	for _, authenticator := range config.JWT {
+		if authenticator.Issuer.URL == issuerURL {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthenticationConfiguration converts the authentication configuration of a cluster into
+// the versioned AuthenticationConfiguration understood by the kube-apiserver.
+func AuthenticationConfiguration(config *kubermaticv1.AuthenticationConfiguration) *apiserverv1beta1.AuthenticationConfiguration {
+	result := &apiserverv1beta1.AuthenticationConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiserver.config.k8s.io/v1beta1",
+			Kind:       "AuthenticationConfiguration",
+		},
+		JWT: []apiserverv1beta1.JWTAuthenticator{},
+	}
+
+	if config == nil {
+		return result
+	}
+
+	for _, authenticator := range config.JWT {
+		jwt := apiserverv1beta1.JWTAuthenticator{
+			Issuer: apiserverv1beta1.Issuer{
+				URL:                  authenticator.Issuer.URL,
+				CertificateAuthority: authenticator.Issuer.CertificateAuthority,
+				Audiences:            authenticator.Issuer.Audiences,
+				AudienceMatchPolicy:  apiserverv1beta1.AudienceMatchPolicyType(authenticator.Issuer.AudienceMatchPolicy),
+			},
+			ClaimMappings: apiserverv1beta1.ClaimMappings{
+				Username: prefixedClaimOrExpression(authenticator.ClaimMappings.Username),
+				Groups:   prefixedClaimOrExpression(authenticator.ClaimMappings.Groups),
+				UID: apiserverv1beta1.ClaimOrExpression{
+					Claim:      authenticator.ClaimMappings.UID.Claim,
+					Expression: authenticator.ClaimMappings.UID.Expression,
+				},
+			},
+		}
+
+		if authenticator.Issuer.DiscoveryURL != "" {
+			jwt.Issuer.DiscoveryURL = ptr.To(authenticator.Issuer.DiscoveryURL)
+		}
+
+		for _, extra := range authenticator.ClaimMappings.Extra {
+			jwt.ClaimMappings.Extra = append(jwt.ClaimMappings.Extra, apiserverv1beta1.ExtraMapping{
+				Key:             extra.Key,
+				ValueExpression: extra.ValueExpression,
+			})
+		}
+
+		for _, rule := range authenticator.ClaimValidationRules {
+			jwt.ClaimValidationRules = append(jwt.ClaimValidationRules, apiserverv1beta1.ClaimValidationRule{
+				Claim:         rule.Claim,
+				RequiredValue: rule.RequiredValue,
+				Expression:    rule.Expression,
+				Message:       rule.Message,
+			})
+		}
+
+		for _, rule := range authenticator.UserValidationRules {
+			jwt.UserValidationRules = append(jwt.UserValidationRules, apiserverv1beta1.UserValidationRule{
+				Expression: rule.Expression,
+				Message:    rule.Message,
+			})
+		}
+
+		result.JWT = append(result.JWT, jwt)
+	}
+
+	return result
+}
+
+func prefixedClaimOrExpression(in kubermaticv1.JWTPrefixedClaimOrExpression) apiserverv1beta1.PrefixedClaimOrExpression {
+	return apiserverv1beta1.PrefixedClaimOrExpression{
+		Claim:      in.Claim,
+		Prefix:     in.Prefix,
+		Expression: in.Expression,
+	}
+}
+
+// AuthenticationIssuerURLs returns the URLs the kube-apiserver needs to reach to fetch the
+// discovery information of all configured JWT issuers.
+func AuthenticationIssuerURLs(config *kubermaticv1.AuthenticationConfiguration) []string {
+	var urls []string
+
+	if config == nil {
+		return urls
+	}
+
+	for _, authenticator := range config.JWT {
+		if authenticator.Issuer.DiscoveryURL != "" {
+			urls = append(urls, authenticator.Issuer.DiscoveryURL)
+		} else {
+			urls = append(urls, authenticator.Issuer.URL)
+		}
+	}
+
+	return urls
+}
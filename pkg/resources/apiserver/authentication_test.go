@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"crypto/x509"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+type fakeCABundle struct{}
+
+func (fakeCABundle) CertPool() *x509.CertPool { return x509.NewCertPool() }
+func (fakeCABundle) String() string           { return "seed-ca-bundle" }
+
+type fakeAuthenticationData struct {
+	cluster *kubermaticv1.Cluster
+}
+
+func (d fakeAuthenticationData) Cluster() *kubermaticv1.Cluster { return d.cluster }
+func (d fakeAuthenticationData) CABundle() resources.CABundle   { return fakeCABundle{} }
+func (d fakeAuthenticationData) OIDCIssuerURL() string          { return "https://kkp.example.com/dex" }
+func (d fakeAuthenticationData) OIDCIssuerClientID() string     { return "kubermaticIssuer" }
+
+func TestAuthenticationConfigurationReconciler(t *testing.T) {
+	authenticator := func(url, ca string) kubermaticv1.JWTAuthenticator {
+		return kubermaticv1.JWTAuthenticator{
+			Issuer: kubermaticv1.JWTIssuer{
+				URL:                  url,
+				CertificateAuthority: ca,
+				Audiences:            []string{"kubernetes"},
+			},
+			ClaimMappings: kubermaticv1.JWTClaimMappings{
+				Username: kubermaticv1.JWTPrefixedClaimOrExpression{Claim: "sub", Prefix: ptr.To("")},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                     string
+		authenticators           []kubermaticv1.JWTAuthenticator
+		enableOIDCAuthentication bool
+		expectedIssuers          []string
+		expectedCAs              []string
+	}{
+		{
+			name: "CA bundle is defaulted",
+			authenticators: []kubermaticv1.JWTAuthenticator{
+				authenticator("https://idp.example.com", ""),
+				authenticator("https://token.ci.example.com", "ci-ca"),
+			},
+			expectedIssuers: []string{"https://idp.example.com", "https://token.ci.example.com"},
+			expectedCAs:     []string{"seed-ca-bundle", "ci-ca"},
+		},
+		{
+			name: "KKP issuer is appended",
+			authenticators: []kubermaticv1.JWTAuthenticator{
+				authenticator("https://idp.example.com", "idp-ca"),
+			},
+			enableOIDCAuthentication: true,
+			expectedIssuers:          []string{"https://idp.example.com", "https://kkp.example.com/dex"},
+			expectedCAs:              []string{"idp-ca", "seed-ca-bundle"},
+		},
+		{
+			name: "KKP issuer is not duplicated",
+			authenticators: []kubermaticv1.JWTAuthenticator{
+				authenticator("https://kkp.example.com/dex", "kkp-ca"),
+			},
+			enableOIDCAuthentication: true,
+			expectedIssuers:          []string{"https://kkp.example.com/dex"},
+			expectedCAs:              []string{"kkp-ca"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := fakeAuthenticationData{
+				cluster: &kubermaticv1.Cluster{
+					Spec: kubermaticv1.ClusterSpec{
+						AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+							JWT: tc.authenticators,
+						},
+					},
+				},
+			}
+
+			_, reconciler := AuthenticationConfigurationReconciler(data, tc.enableOIDCAuthentication)()
+			cm, err := reconciler(&corev1.ConfigMap{})
+			if err != nil {
+				t.Fatalf("Failed to reconcile ConfigMap: %v", err)
+			}
+
+			config := apiserverv1beta1.AuthenticationConfiguration{}
+			if err := yaml.Unmarshal([]byte(cm.Data[resources.AuthenticationConfigurationKeyName]), &config); err != nil {
+				t.Fatalf("Failed to parse AuthenticationConfiguration: %v", err)
+			}
+
+			if len(config.JWT) != len(tc.expectedIssuers) {
+				t.Fatalf("Expected %d authenticators, got %d", len(tc.expectedIssuers), len(config.JWT))
+			}
+
+			for i, jwt := range config.JWT {
+				if jwt.Issuer.URL != tc.expectedIssuers[i] {
+					t.Errorf("Expected issuer %d to be %q, got %q", i, tc.expectedIssuers[i], jwt.Issuer.URL)
+				}
+				if jwt.Issuer.CertificateAuthority != tc.expectedCAs[i] {
+					t.Errorf("Expected CA of issuer %d to be %q, got %q", i, tc.expectedCAs[i], jwt.Issuer.CertificateAuthority)
+				}
+			}
+		})
+	}
+}
@@ -92,6 +92,24 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 			volumes := getVolumes(data, enableEncryptionConfiguration, auditLogEnabled, auditWebhookBackendEnabled)
 			volumeMounts := getVolumeMounts(data.IsKonnectivityEnabled(), enableEncryptionConfiguration, auditWebhookBackendEnabled)
 
+			if IsAuthenticationConfigurationEnabled(data.Cluster()) {
+				volumes = append(volumes, corev1.Volume{
+					Name: resources.AuthenticationConfigurationConfigMapName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.AuthenticationConfigurationConfigMapName,
+							},
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.AuthenticationConfigurationConfigMapName,
+					MountPath: "/etc/kubernetes/authentication-configuration",
+					ReadOnly:  true,
+				})
+			}
+
 			version := data.Cluster().Status.Versions.Apiserver.Semver()
 			address := data.Cluster().Status.Address
 
@@ -267,12 +285,13 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 				)
 			}
 
-			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, overrides, dep.Annotations)
+			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, resources.ApplyControlPlaneSizing(defResourceRequirements, data.ControlPlaneSizingProfile()), overrides, dep.Annotations)
 			if err != nil {
 				return nil, fmt.Errorf("failed to set resource requirements: %w", err)
 			}
 
 			dep.Spec.Template.Spec.Affinity = resources.HostnameAntiAffinity(name, kubermaticv1.AntiAffinityTypePreferred)
+			dep.Spec.Template.Spec.TopologySpreadConstraints = resources.ZoneTopologySpreadConstraints(name, data.Cluster().Spec.ControlPlaneTopology)
 
 			return dep, nil
 		}
@@ -317,7 +336,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	serviceAccountKeyFile := filepath.Join("/etc/kubernetes/service-account-key", resources.ServiceAccountKeySecretKey)
 	flags := []string{
 		"--etcd-servers", strings.Join(etcdEndpoints, ","),
-		"--etcd-cafile", "/etc/etcd/pki/client/ca.crt",
+		"--etcd-cafile", "/etc/kubernetes/pki/ca/trust-bundle.crt",
 		"--etcd-certfile", filepath.Join("/etc/etcd/pki/client", resources.ApiserverEtcdClientCertificateCertSecretKey),
 		"--etcd-keyfile", filepath.Join("/etc/etcd/pki/client", resources.ApiserverEtcdClientCertificateKeySecretKey),
 		"--storage-backend", "etcd3",
@@ -340,7 +359,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		"--tls-private-key-file", "/etc/kubernetes/tls/apiserver-tls.key",
 		"--proxy-client-cert-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateCertSecretKey,
 		"--proxy-client-key-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateKeySecretKey,
-		"--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+		"--client-ca-file", "/etc/kubernetes/pki/ca/trust-bundle.crt",
 		"--kubelet-client-certificate", "/etc/kubernetes/kubelet/kubelet-client.crt",
 		"--kubelet-client-key", "/etc/kubernetes/kubelet/kubelet-client.key",
 	}
@@ -348,7 +367,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	// the "bring-your-own" provider does not support automatic TLS rotation in kubelets yet,
 	// and because of that certs might expire and kube-apiserver cannot validate the connection anymore.
 	if cluster.Spec.Cloud.BringYourOwn == nil && cluster.Spec.Cloud.Edge == nil {
-		flags = append(flags, "--kubelet-certificate-authority", "/etc/kubernetes/pki/ca/ca.crt")
+		flags = append(flags, "--kubelet-certificate-authority", "/etc/kubernetes/pki/ca/trust-bundle.crt")
 	}
 
 	flags = append(flags,
@@ -419,7 +438,12 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	}
 
 	oidcSettings := cluster.Spec.OIDC
-	if oidcSettings.IssuerURL != "" && oidcSettings.ClientID != "" {
+	if IsAuthenticationConfigurationEnabled(cluster) {
+		// the AuthenticationConfiguration cannot be combined with the --oidc-* flags and
+		// includes the KKP issuer if enableOIDCAuthentication is set
+		flags = append(flags, "--authentication-config",
+			"/etc/kubernetes/authentication-configuration/"+resources.AuthenticationConfigurationKeyName)
+	} else if oidcSettings.IssuerURL != "" && oidcSettings.ClientID != "" {
 		flags = append(flags,
 			"--oidc-ca-file", fmt.Sprintf("/etc/kubernetes/pki/ca-bundle/%s", resources.CABundleConfigMapKey),
 			"--oidc-issuer-url", oidcSettings.IssuerURL,
@@ -636,6 +660,10 @@ func getVolumes(data *resources.TemplateData, isEncryptionEnabled, isAuditEnable
 							Path: resources.CACertSecretKey,
 							Key:  resources.CACertSecretKey,
 						},
+						{
+							Path: resources.CATrustBundleSecretKey,
+							Key:  resources.CATrustBundleSecretKey,
+						},
 					},
 				},
 			},
@@ -30,7 +30,9 @@ import (
 )
 
 // ServiceReconciler returns the function to reconcile the external API server service.
-func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL string, apiServerServiceType *corev1.ServiceType) reconciling.NamedServiceReconcilerFactory {
+// The service of private clusters is only exposed on the private nodeport-proxy LoadBalancer,
+// which is reachable via the private endpoint in the cluster network.
+func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL string, apiServerServiceType *corev1.ServiceType, privateCluster bool) reconciling.NamedServiceReconcilerFactory {
 	return func() (string, reconciling.ServiceReconciler) {
 		return resources.ApiserverServiceName, func(se *corev1.Service) (*corev1.Service, error) {
 			if se.Annotations == nil {
@@ -59,10 +61,25 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL s
 				// We map the secure port to the internal name for SNI routing.
 				se.Annotations[nodeportproxy.PortHostMappingAnnotationKey] = fmt.Sprintf(`{"secure": %q}`, externalURL)
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				// The control plane Gateway routes to the APIServer via a dedicated TCP listener.
+				// We use a NodePort Service for the same reason as with exposeStrategy==LoadBalancer:
+				// it gives us a concurrency-safe allocation mechanism for a unique port, which is used
+				// for the listener. The nodeport-proxy is not involved.
+				se.Spec.Type = corev1.ServiceTypeNodePort
+				delete(se.Annotations, nodeportproxy.DefaultExposeAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				delete(se.Annotations, nodeportproxy.PortHostMappingAnnotationKey)
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", exposeStrategy)
 			}
 
+			if privateCluster {
+				se.Annotations[nodeportproxy.PrivateAnnotationKey] = "true"
+			} else {
+				delete(se.Annotations, nodeportproxy.PrivateAnnotationKey)
+			}
+
 			if apiServerServiceType != nil {
 				se.Spec.Type = *apiServerServiceType
 			}
@@ -20,8 +20,10 @@ import (
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -48,7 +50,7 @@ func TestServiceReconcilerRequiresExposeStrategy(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ServiceReconciler(tc.exposeStrategy, tc.internalService, nil)()
+			_, creator := ServiceReconciler(tc.exposeStrategy, tc.internalService, nil, false)()
 			_, err := creator(&corev1.Service{})
 			if (err != nil) != tc.errExpected {
 				t.Errorf("Expected err: %t, but got err %v", tc.errExpected, err)
@@ -147,7 +149,7 @@ func TestServiceReconcilerSetsPort(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ServiceReconciler(tc.exposeStrategy, tc.internalService, tc.expectedServiceType)()
+			_, creator := ServiceReconciler(tc.exposeStrategy, tc.internalService, tc.expectedServiceType, false)()
 			svc, err := creator(tc.inService)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -176,3 +178,50 @@ func TestServiceReconcilerSetsPort(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceReconcilerPrivateCluster(t *testing.T) {
+	testCases := []struct {
+		name            string
+		privateCluster  bool
+		expectedPrivate bool
+	}{
+		{
+			name:            "private cluster",
+			privateCluster:  true,
+			expectedPrivate: true,
+		},
+		{
+			name:            "public cluster",
+			privateCluster:  false,
+			expectedPrivate: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inService := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						nodeportproxy.PrivateAnnotationKey: "true",
+					},
+				},
+			}
+
+			_, creator := ServiceReconciler(kubermaticv1.ExposeStrategyNodePort, "", nil, tc.privateCluster)()
+			svc, err := creator(inService)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if svc.Spec.Type != corev1.ServiceTypeNodePort {
+				t.Errorf("Expected service type to be %q but was %q", corev1.ServiceTypeNodePort, svc.Spec.Type)
+			}
+			// Envoy must always create a listener, otherwise traffic over the private endpoint is dropped.
+			if svc.Annotations[nodeportproxy.DefaultExposeAnnotationKey] != nodeportproxy.NodePortType.String() {
+				t.Errorf("Expected service to be exposed by the nodeport-proxy, but annotation %q is %q", nodeportproxy.DefaultExposeAnnotationKey, svc.Annotations[nodeportproxy.DefaultExposeAnnotationKey])
+			}
+			if private := svc.Annotations[nodeportproxy.PrivateAnnotationKey] == "true"; private != tc.expectedPrivate {
+				t.Errorf("Expected private=%v, but annotation %q is %q", tc.expectedPrivate, nodeportproxy.PrivateAnnotationKey, svc.Annotations[nodeportproxy.PrivateAnnotationKey])
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	certificateSuffix = ".crt"
+	privateKeySuffix  = ".key"
+)
+
+// LeafCertificate is a certificate that is stored in a Secret together with its private key.
+type LeafCertificate struct {
+	SecretName string
+	// CertKey is the key of the certificate in the Secret's data.
+	CertKey string
+	// PrivateKeyKey is the key of the private key in the Secret's data.
+	PrivateKeyKey string
+	// AuthInfo is only set for client certificates embedded in a kubeconfig, which is
+	// stored under CertKey. It is the name of the kubeconfig user holding the certificate.
+	AuthInfo string
+	Cert     *x509.Certificate
+}
+
+// Name identifies the certificate within its Secret.
+func (c LeafCertificate) Name() string {
+	if c.AuthInfo == "" {
+		return c.CertKey
+	}
+
+	return c.CertKey + "/" + c.AuthInfo
+}
+
+// LifetimeElapsed returns the percentage of the certificate's validity period that has passed at
+// the given time. Older certificates used their CA's NotBefore, so for them this overestimates
+// the elapsed lifetime until they have been renewed once.
+func (c LeafCertificate) LifetimeElapsed(now time.Time) float64 {
+	lifetime := c.Cert.NotAfter.Sub(c.Cert.NotBefore)
+	if lifetime <= 0 {
+		return 100
+	}
+
+	return float64(now.Sub(c.Cert.NotBefore)) / float64(lifetime) * 100
+}
+
+// RenewalTime returns the point in time at which the given percentage of the certificate's
+// validity period has passed.
+func (c LeafCertificate) RenewalTime(threshold int) time.Time {
+	lifetime := c.Cert.NotAfter.Sub(c.Cert.NotBefore)
+	return c.Cert.NotBefore.Add(lifetime * time.Duration(threshold) / 100)
+}
+
+// SecretLeafCertificates returns all non-CA certificates in the Secret whose private key is stored
+// in the Secret as well. All control plane certificates follow the convention to store a certificate
+// as "<name>.crt" and its private key as "<name>.key". Client certificates embedded in a kubeconfig
+// are returned as well.
+func SecretLeafCertificates(secret *corev1.Secret) []LeafCertificate {
+	result := []LeafCertificate{}
+
+	for key, data := range secret.Data {
+		if key == resources.KubeconfigSecretKey {
+			result = append(result, kubeconfigLeafCertificates(secret.Name, key, data)...)
+			continue
+		}
+
+		if !strings.HasSuffix(key, certificateSuffix) {
+			continue
+		}
+
+		privateKeyKey := strings.TrimSuffix(key, certificateSuffix) + privateKeySuffix
+		if _, exists := secret.Data[privateKeyKey]; !exists {
+			continue
+		}
+
+		certs, err := triple.ParseCertsPEM(data)
+		if err != nil || certs[0].IsCA {
+			continue
+		}
+
+		result = append(result, LeafCertificate{
+			SecretName:    secret.Name,
+			CertKey:       key,
+			PrivateKeyKey: privateKeyKey,
+			Cert:          certs[0],
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+
+	return result
+}
+
+func kubeconfigLeafCertificates(secretName, key string, data []byte) []LeafCertificate {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil
+	}
+
+	result := []LeafCertificate{}
+	for name, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 || len(authInfo.ClientKeyData) == 0 {
+			continue
+		}
+
+		certs, err := triple.ParseCertsPEM(authInfo.ClientCertificateData)
+		if err != nil || certs[0].IsCA {
+			continue
+		}
+
+		result = append(result, LeafCertificate{
+			SecretName:    secretName,
+			CertKey:       key,
+			PrivateKeyKey: key,
+			AuthInfo:      name,
+			Cert:          certs[0],
+		})
+	}
+
+	return result
+}
+
+// SetSecretCertificate stores the given certificate and private key in the Secret, at the
+// location the certificate was loaded from.
+func SetSecretCertificate(secret *corev1.Secret, cert LeafCertificate, certPEM, keyPEM []byte) error {
+	if cert.AuthInfo == "" {
+		secret.Data[cert.CertKey] = certPEM
+		secret.Data[cert.PrivateKeyKey] = keyPEM
+
+		return nil
+	}
+
+	config, err := clientcmd.Load(secret.Data[cert.CertKey])
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	authInfo, ok := config.AuthInfos[cert.AuthInfo]
+	if !ok {
+		return fmt.Errorf("kubeconfig has no user %q", cert.AuthInfo)
+	}
+
+	authInfo.ClientCertificateData = certPEM
+	authInfo.ClientKeyData = keyPEM
+
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+
+	secret.Data[cert.CertKey] = data
+
+	return nil
+}
+
+// SignerKeyPair is a CA certificate together with its private key.
+type SignerKeyPair struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+// SecretSigner returns the CA stored in the Secret, or nil if the Secret does not contain both
+// the CA certificate and its private key.
+func SecretSigner(secret *corev1.Secret) (*SignerKeyPair, error) {
+	certPEM, hasCert := secret.Data[resources.CACertSecretKey]
+	keyPEM, hasKey := secret.Data[resources.CAKeySecretKey]
+	if !hasCert || !hasKey {
+		return nil, nil
+	}
+
+	certs, err := triple.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	if !certs[0].IsCA {
+		return nil, nil
+	}
+
+	key, err := triple.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA private key cannot be used for signing")
+	}
+
+	return &SignerKeyPair{Cert: certs[0], Key: signer}, nil
+}
+
+// RenewCertificate issues a replacement for the given certificate, with a new private key of the
+// same type and the same subject, SANs and key usages, valid for the given duration. Because nothing
+// but the validity and the key changes, the certificate reconcilers accept the renewed certificate.
+func RenewCertificate(cert *x509.Certificate, signer *SignerKeyPair, validity time.Duration) ([]byte, []byte, error) {
+	if err := cert.CheckSignatureFrom(signer.Cert); err != nil {
+		return nil, nil, fmt.Errorf("certificate was not issued by the given CA: %w", err)
+	}
+
+	key, keyPEM, err := newPrivateKeyLike(cert.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               cert.Subject,
+		DNSNames:              cert.DNSNames,
+		IPAddresses:           cert.IPAddresses,
+		URIs:                  cert.URIs,
+		EmailAddresses:        cert.EmailAddresses,
+		NotBefore:             now.Add(-triple.Backdate).UTC(),
+		NotAfter:              now.Add(validity).UTC(),
+		KeyUsage:              cert.KeyUsage,
+		ExtKeyUsage:           cert.ExtKeyUsage,
+		BasicConstraintsValid: cert.BasicConstraintsValid,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer.Cert, key.Public(), signer.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: triple.CertificateBlockType, Bytes: der}), keyPEM, nil
+}
+
+// newPrivateKeyLike creates a new private key of the same type and size as the given public key.
+func newPrivateKeyLike(publicKey crypto.PublicKey) (crypto.Signer, []byte, error) {
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		key, err := rsa.GenerateKey(rand.Reader, pub.N.BitLen())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate RSA private key: %w", err)
+		}
+
+		return key, triple.EncodePrivateKeyPEM(key), nil
+
+	case *ecdsa.PublicKey:
+		key, err := ecdsa.GenerateKey(pub.Curve, rand.Reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate ECDSA private key: %w", err)
+		}
+
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal ECDSA private key: %w", err)
+		}
+
+		return key, pem.EncodeToMemory(&pem.Block{Type: triple.ECPrivateKeyBlockType, Bytes: der}), nil
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
@@ -19,6 +19,7 @@ package certificates
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -67,13 +68,42 @@ type caReconcilerData interface {
 	Cluster() *kubermaticv1.Cluster
 }
 
-// RootCAReconciler returns a function to create a secret with the root ca.
+// RootCAReconciler returns a function to create a secret with the root ca and its trust bundle.
 func RootCAReconciler(data caReconcilerData) reconciling.NamedSecretReconcilerFactory {
 	return func() (string, reconciling.SecretReconciler) {
-		return resources.CASecretName, GetCAReconciler(fmt.Sprintf("root-ca.%s", data.Cluster().Status.Address.ExternalName))
+		caReconciler := GetCAReconciler(fmt.Sprintf("root-ca.%s", data.Cluster().Status.Address.ExternalName))
+
+		return resources.CASecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			se, err := caReconciler(se)
+			if err != nil {
+				return se, err
+			}
+
+			return se, ensureTrustBundle(se)
+		}
 	}
 }
 
+// ensureTrustBundle makes sure that the trust bundle in the secret contains the CA itself. Other
+// CAs are only added to the bundle by the root CA rotation, which must not be reverted here.
+func ensureTrustBundle(se *corev1.Secret) error {
+	certs, err := certutil.ParseCertsPEM(se.Data[resources.CACertSecretKey])
+	if err != nil {
+		return fmt.Errorf("certificate is not valid PEM-encoded: %w", err)
+	}
+
+	if bundle, exists := se.Data[resources.CATrustBundleSecretKey]; exists {
+		bundleCerts, err := certutil.ParseCertsPEM(bundle)
+		if err == nil && slices.ContainsFunc(bundleCerts, certs[0].Equal) {
+			return nil
+		}
+	}
+
+	se.Data[resources.CATrustBundleSecretKey] = se.Data[resources.CACertSecretKey]
+
+	return nil
+}
+
 // FrontProxyCAReconciler returns a function to create a secret with front proxy ca.
 func FrontProxyCAReconciler() reconciling.NamedSecretReconcilerFactory {
 	return func() (string, reconciling.SecretReconciler) {
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshca
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CertificateRequest describes a public key that the seed should issue SSH
+// certificates for.
+type CertificateRequest struct {
+	// PublicKey is the public key in authorized_keys format.
+	PublicKey string `json:"publicKey"`
+	// ExpiresAt is the expiry date of the key, which no certificate may outlive.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+const (
+	// Principal is the only principal of all issued certificates. Nodes accept it
+	// for every managed user, so that the seed does not need to know the users
+	// that exist on the nodes.
+	Principal = "kubermatic"
+
+	// clockSkew is subtracted from the start of a certificate's validity to
+	// account for nodes whose clocks are slightly behind.
+	clockSkew = 5 * time.Minute
+)
+
+// SecretReconciler returns a function to create the Secret holding the SSH
+// certificate authority of a cluster. An existing CA is never replaced.
+func SecretReconciler() reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.UserSSHKeyCASecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
+			}
+
+			if _, exists := se.Data[resources.UserSSHKeyCAPrivateKeySecretKey]; exists {
+				if _, err := SignerFromSecret(se); err != nil {
+					return se, err
+				}
+
+				return se, nil
+			}
+
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate SSH CA key: %w", err)
+			}
+
+			block, err := ssh.MarshalPrivateKey(privateKey, "kubermatic-user-ssh-ca")
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode SSH CA key: %w", err)
+			}
+
+			signer, err := ssh.NewSignerFromKey(privateKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create SSH CA signer: %w", err)
+			}
+
+			se.Data[resources.UserSSHKeyCAPrivateKeySecretKey] = pem.EncodeToMemory(block)
+			se.Data[resources.UserSSHKeyCAPublicKeySecretKey] = ssh.MarshalAuthorizedKey(signer.PublicKey())
+			se.Type = corev1.SecretTypeOpaque
+
+			return se, nil
+		}
+	}
+}
+
+// SignerFromSecret parses the SSH certificate authority stored in the given Secret.
+func SignerFromSecret(secret *corev1.Secret) (ssh.Signer, error) {
+	keyPEM, exists := secret.Data[resources.UserSSHKeyCAPrivateKeySecretKey]
+	if !exists {
+		return nil, errors.New("secret does not contain an SSH CA private key")
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("SSH CA private key is invalid: %w", err)
+	}
+
+	return signer, nil
+}
+
+// PublicKey returns the public key of the given CA in authorized_keys format,
+// as it is configured as sshd's TrustedUserCAKeys on the nodes.
+func PublicKey(ca ssh.PublicKey) []byte {
+	return bytes.TrimSpace(ssh.MarshalAuthorizedKey(ca))
+}
+
+// AuthorizedKeysEntry returns the authorized_keys line that makes sshd accept
+// user certificates issued by the given CA (in authorized_keys format) for
+// the Principal. It is used instead of sshd's TrustedUserCAKeys on nodes whose
+// sshd_config does not include drop-in configuration files.
+func AuthorizedKeysEntry(caPublicKey []byte) []byte {
+	return []byte(fmt.Sprintf("cert-authority,principals=%q %s", Principal, bytes.TrimSpace(caPublicKey)))
+}
+
+// IssueCertificate signs the given public key (in authorized_keys format) and
+// returns the resulting user certificate in authorized_keys format together
+// with its expiry date. The certificate is issued for the Principal, which the
+// nodes accept for every user.
+func IssueCertificate(ca ssh.Signer, publicKey string, keyID string, now time.Time, validity time.Duration) (string, time.Time, error) {
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	validBefore := now.Add(validity)
+
+	cert := &ssh.Certificate{
+		Key:             pubKey,
+		CertType:        ssh.UserCert,
+		KeyId:           keyID,
+		ValidPrincipals: []string{Principal},
+		ValidAfter:      uint64(now.Add(-clockSkew).Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+		Permissions: ssh.Permissions{
+			Extensions: map[string]string{
+				"permit-pty":              "",
+				"permit-port-forwarding":  "",
+				"permit-agent-forwarding": "",
+			},
+		},
+	}
+
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert))), time.Unix(int64(cert.ValidBefore), 0), nil
+}
+
+// RequestsSecretReconciler returns a function to create the Secret listing the
+// public keys, indexed by UserSSHKey name, that certificates should be issued for.
+func RequestsSecretReconciler(requests map[string]CertificateRequest) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.UserSSHKeyCertificateRequestsSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			data := map[string][]byte{}
+			for name, request := range requests {
+				encoded, err := json.Marshal(request)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode certificate request for %s: %w", name, err)
+				}
+
+				data[name] = encoded
+			}
+
+			se.Data = data
+			se.Type = corev1.SecretTypeOpaque
+
+			return se, nil
+		}
+	}
+}
+
+// RequestsFromSecret parses the certificate requests stored in the given Secret.
+func RequestsFromSecret(secret *corev1.Secret) (map[string]CertificateRequest, error) {
+	requests := map[string]CertificateRequest{}
+	for name, encoded := range secret.Data {
+		request := CertificateRequest{}
+		if err := json.Unmarshal(encoded, &request); err != nil {
+			return nil, fmt.Errorf("certificate request for %s is invalid: %w", name, err)
+		}
+
+		requests[name] = request
+	}
+
+	return requests, nil
+}
+
+// ParseCertificate parses an SSH certificate in authorized_keys format.
+func ParseCertificate(certificate string) (*ssh.Certificate, error) {
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	cert, ok := parsed.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.New("key is not a certificate")
+	}
+
+	return cert, nil
+}
+
+// CertifiesKey returns true if the given certificate was issued for the given
+// public key (in authorized_keys format) and the Principal.
+func CertifiesKey(cert *ssh.Certificate, publicKey string) bool {
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(cert.Key.Marshal(), pubKey.Marshal()) && slices.Equal(cert.ValidPrincipals, []string{Principal})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshca
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIssueCertificate(t *testing.T) {
+	_, reconciler := SecretReconciler()()
+
+	secret, err := reconciler(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+
+	// reconciling again must not replace the CA
+	original := string(secret.Data[resources.UserSSHKeyCAPrivateKeySecretKey])
+	if secret, err = reconciler(secret); err != nil {
+		t.Fatalf("Failed to reconcile existing CA: %v", err)
+	}
+	if string(secret.Data[resources.UserSSHKeyCAPrivateKeySecretKey]) != original {
+		t.Fatal("Existing CA has been replaced.")
+	}
+
+	ca, err := SignerFromSecret(secret)
+	if err != nil {
+		t.Fatalf("Failed to load CA: %v", err)
+	}
+
+	if entry := string(AuthorizedKeysEntry(PublicKey(ca.PublicKey()))); !strings.HasPrefix(entry, `cert-authority,principals="kubermatic" ssh-ed25519 `) {
+		t.Fatalf("Unexpected authorized_keys entry %q.", entry)
+	}
+
+	userKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate user key: %v", err)
+	}
+
+	sshUserKey, err := ssh.NewPublicKey(userKey)
+	if err != nil {
+		t.Fatalf("Failed to convert user key: %v", err)
+	}
+
+	now := time.Now()
+	encoded, expiresAt, err := IssueCertificate(ca, string(ssh.MarshalAuthorizedKey(sshUserKey)), "my-key", now, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to issue certificate: %v", err)
+	}
+
+	if expected := now.Add(time.Hour).Unix(); expiresAt.Unix() != expected {
+		t.Fatalf("Expected certificate to expire at %d, but got %d.", expected, expiresAt.Unix())
+	}
+
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(encoded))
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	cert, ok := parsed.(*ssh.Certificate)
+	if !ok {
+		t.Fatalf("Expected a certificate, but got %T.", parsed)
+	}
+
+	checker := ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool {
+			return string(auth.Marshal()) == string(ca.PublicKey().Marshal())
+		},
+	}
+
+	if err := checker.CheckCert(Principal, cert); err != nil {
+		t.Fatalf("Certificate is not valid: %v", err)
+	}
+
+	if !CertifiesKey(cert, string(ssh.MarshalAuthorizedKey(sshUserKey))) {
+		t.Fatal("Expected certificate to certify the user key.")
+	}
+
+	checker.Clock = func() time.Time { return now.Add(2 * time.Hour) }
+	if err := checker.CheckCert(Principal, cert); err == nil {
+		t.Fatal("Expected certificate to be expired.")
+	}
+}
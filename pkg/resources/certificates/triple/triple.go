@@ -44,6 +44,9 @@ const (
 	ECPrivateKeyBlockType = "EC PRIVATE KEY"
 	PrivateKeyBlockType   = "PRIVATE KEY"
 	CertificateBlockType  = "CERTIFICATE"
+	// Backdate is subtracted from the issuing time of certificates, so they are valid
+	// right away on hosts whose clocks are slightly behind.
+	Backdate = 5 * time.Minute
 )
 
 type KeyPair struct {
@@ -160,7 +163,7 @@ func newSignedCert(cfg certutil.Config, key crypto.Signer, caCert *x509.Certific
 		DNSNames:     cfg.AltNames.DNSNames,
 		IPAddresses:  cfg.AltNames.IPs,
 		SerialNumber: serial,
-		NotBefore:    caCert.NotBefore,
+		NotBefore:    time.Now().Add(-Backdate).UTC(),
 		NotAfter:     time.Now().Add(duration365d).UTC(),
 		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  cfg.Usages,
@@ -306,7 +309,7 @@ func NewSignedCert(cfg certutil.Config, key crypto.Signer, caCert *x509.Certific
 		DNSNames:     cfg.AltNames.DNSNames,
 		IPAddresses:  cfg.AltNames.IPs,
 		SerialNumber: serial,
-		NotBefore:    caCert.NotBefore,
+		NotBefore:    time.Now().Add(-Backdate).UTC(),
 		NotAfter:     time.Now().Add(duration365d).UTC(),
 		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  cfg.Usages,
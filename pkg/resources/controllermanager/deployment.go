@@ -18,6 +18,7 @@ package controllermanager
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	semverlib "github.com/Masterminds/semver/v3"
@@ -84,6 +85,22 @@ func DeploymentReconciler(data *resources.TemplateData) reconciling.NamedDeploym
 			volumes := getVolumes(data.IsKonnectivityEnabled())
 			volumeMounts := getVolumeMounts()
 
+			if signsWithRotationCA(data.Cluster()) {
+				volumes = append(volumes, corev1.Volume{
+					Name: resources.RootCARotationSecretName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: resources.RootCARotationSecretName,
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.RootCARotationSecretName,
+					MountPath: "/etc/kubernetes/pki/ca-rotation",
+					ReadOnly:  true,
+				})
+			}
+
 			if data.Cluster().Spec.Cloud.GCP != nil {
 				serviceAccountVolume := corev1.Volume{
 					Name: resources.GoogleServiceAccountVolumeName,
@@ -186,12 +203,13 @@ func DeploymentReconciler(data *resources.TemplateData) reconciling.NamedDeploym
 				defResourceRequirements[openvpnSidecar.Name] = openvpnSidecar.Resources.DeepCopy()
 			}
 
-			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
+			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, resources.ApplyControlPlaneSizing(defResourceRequirements, data.ControlPlaneSizingProfile()), resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
 			if err != nil {
 				return nil, fmt.Errorf("failed to set resource requirements: %w", err)
 			}
 
 			dep.Spec.Template.Spec.Affinity = resources.HostnameAntiAffinity(name, kubermaticv1.AntiAffinityTypePreferred)
+			dep.Spec.Template.Spec.TopologySpreadConstraints = resources.ZoneTopologySpreadConstraints(name, data.Cluster().Spec.ControlPlaneTopology)
 
 			dep.Spec.Template, err = apiserver.IsRunningWrapper(data, dep.Spec.Template, sets.New(name))
 			if err != nil {
@@ -215,12 +233,17 @@ func getFlags(data *resources.TemplateData, version *semverlib.Version) ([]strin
 		controllers = append(controllers, "-cloud-node-lifecycle", "-route", "-service")
 	}
 
+	signingCADir := "/etc/kubernetes/pki/ca"
+	if signsWithRotationCA(cluster) {
+		signingCADir = "/etc/kubernetes/pki/ca-rotation"
+	}
+
 	flags := []string{
 		"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 		"--service-account-private-key-file", "/etc/kubernetes/service-account-key/sa.key",
-		"--root-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
-		"--cluster-signing-cert-file", "/etc/kubernetes/pki/ca/ca.crt",
-		"--cluster-signing-key-file", "/etc/kubernetes/pki/ca/ca.key",
+		"--root-ca-file", "/etc/kubernetes/pki/ca/trust-bundle.crt",
+		"--cluster-signing-cert-file", filepath.Join(signingCADir, resources.CACertSecretKey),
+		"--cluster-signing-key-file", filepath.Join(signingCADir, resources.CAKeySecretKey),
 		"--controllers", strings.Join(controllers, ","),
 		"--use-service-account-credentials",
 		// this can't be passed as two strings as the other parameters
@@ -279,7 +302,7 @@ func getFlags(data *resources.TemplateData, version *semverlib.Version) ([]strin
 	// New flag in v1.12 which gets used to perform permission checks for tokens
 	flags = append(flags, "--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig")
 	// New flag in v1.12 which gets used to perform permission checks for certs
-	flags = append(flags, "--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt")
+	flags = append(flags, "--client-ca-file", "/etc/kubernetes/pki/ca/trust-bundle.crt")
 
 	// With 1.13 we're using the secure port for scraping metrics as the insecure port got marked deprecated
 	flags = append(flags, "--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig")
@@ -299,6 +322,15 @@ func getFlags(data *resources.TemplateData, version *semverlib.Version) ([]strin
 	return flags, nil
 }
 
+// signsWithRotationCA returns true if certificate signing requests, most notably those of the
+// kubelets, must be signed by the new root CA. This is the case while the nodes are refreshed
+// during a root CA rotation, so that their certificates remain valid once the old CA is removed.
+func signsWithRotationCA(cluster *kubermaticv1.Cluster) bool {
+	rotation := cluster.Status.RootCARotation
+
+	return rotation != nil && rotation.Phase == kubermaticv1.ClusterRootCARotationPhaseRefreshingNodes
+}
+
 func getVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
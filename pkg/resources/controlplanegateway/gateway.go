@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanegateway
+
+import (
+	"fmt"
+	"sort"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// TLSListenerName is the name of the TLS passthrough listener that is shared
+	// by all user clusters for SNI-based routing.
+	TLSListenerName = "tls"
+
+	// MaxClusters is the number of user clusters that can be exposed by a single
+	// Gateway, as a Gateway supports at most 64 listeners and one is used by the
+	// shared TLS listener. The cluster validation webhook rejects clusters beyond
+	// this limit.
+	MaxClusters = 63
+)
+
+// APIServerListenerName returns the name of the TCP listener dedicated to the
+// API server of the given cluster.
+func APIServerListenerName(cluster *kubermaticv1.Cluster) gatewayapiv1.SectionName {
+	return gatewayapiv1.SectionName(fmt.Sprintf("apiserver-%s", cluster.Name))
+}
+
+// IsExposed returns true if the cluster can be exposed using the control plane Gateway.
+// The API server listener requires the port allocated for the API server.
+func IsExposed(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway &&
+		cluster.DeletionTimestamp == nil &&
+		cluster.Status.NamespaceName != "" &&
+		cluster.Status.Address.Port != 0
+}
+
+// ExposedClusters returns the clusters that get a listener on the control plane Gateway,
+// sorted by name. Clusters exceeding the listener limit of the Gateway are returned
+// separately; this only happens for clusters that were admitted before the limit was
+// enforced by the cluster validation webhook.
+func ExposedClusters(clusters []kubermaticv1.Cluster) (exposed, skipped []kubermaticv1.Cluster) {
+	for _, cluster := range clusters {
+		if IsExposed(&cluster) {
+			exposed = append(exposed, cluster)
+		}
+	}
+
+	sort.Slice(exposed, func(i, j int) bool {
+		return exposed[i].Name < exposed[j].Name
+	})
+
+	if len(exposed) > MaxClusters {
+		return exposed[:MaxClusters], exposed[MaxClusters:]
+	}
+
+	return exposed, nil
+}
+
+// GatewayReconciler returns the function to reconcile the Gateway that exposes all
+// control planes of a seed. The Gateway has one TLS passthrough listener for SNI-based
+// routing and one TCP listener per user cluster API server, as in-cluster clients
+// reach the API server by IP and therefore do not send SNI.
+func GatewayReconciler(seed *kubermaticv1.Seed, clusters []kubermaticv1.Cluster) reconciling.NamedGatewayReconcilerFactory {
+	return func() (string, reconciling.GatewayReconciler) {
+		return resources.ControlPlaneGatewayName, func(gw *gatewayapiv1.Gateway) (*gatewayapiv1.Gateway, error) {
+			config := seed.Spec.ControlPlaneGateway
+			if config == nil {
+				return nil, fmt.Errorf("seed %q has no control plane Gateway configured", seed.Name)
+			}
+
+			kubernetes.EnsureLabels(gw, map[string]string{
+				resources.AppLabelKey: resources.ControlPlaneGatewayName,
+			})
+			kubernetes.EnsureAnnotations(gw, config.Annotations)
+
+			gw.Spec.GatewayClassName = gatewayapiv1.ObjectName(config.GatewayClassName)
+			gw.Spec.Listeners = []gatewayapiv1.Listener{
+				{
+					Name:     TLSListenerName,
+					Port:     gatewayapiv1.PortNumber(config.GetTLSPort()),
+					Protocol: gatewayapiv1.TLSProtocolType,
+					TLS: &gatewayapiv1.GatewayTLSConfig{
+						Mode: ptr.To(gatewayapiv1.TLSModePassthrough),
+					},
+					AllowedRoutes: &gatewayapiv1.AllowedRoutes{
+						Namespaces: &gatewayapiv1.RouteNamespaces{
+							From: ptr.To(gatewayapiv1.NamespacesFromAll),
+						},
+						Kinds: []gatewayapiv1.RouteGroupKind{
+							{Kind: "TLSRoute"},
+						},
+					},
+				},
+			}
+
+			for _, cluster := range clusters {
+				gw.Spec.Listeners = append(gw.Spec.Listeners, gatewayapiv1.Listener{
+					Name:     APIServerListenerName(&cluster),
+					Port:     gatewayapiv1.PortNumber(cluster.Status.Address.Port),
+					Protocol: gatewayapiv1.TCPProtocolType,
+					AllowedRoutes: &gatewayapiv1.AllowedRoutes{
+						// only the cluster namespace may attach to the listener of its API server
+						Namespaces: &gatewayapiv1.RouteNamespaces{
+							From: ptr.To(gatewayapiv1.NamespacesFromSelector),
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									corev1.LabelMetadataName: cluster.Status.NamespaceName,
+								},
+							},
+						},
+						Kinds: []gatewayapiv1.RouteGroupKind{
+							{Kind: "TCPRoute"},
+						},
+					},
+				})
+			}
+
+			return gw, nil
+		}
+	}
+}
+
+func parentReference(seed *kubermaticv1.Seed, sectionName gatewayapiv1.SectionName) gatewayapiv1.ParentReference {
+	return gatewayapiv1.ParentReference{
+		Group:       ptr.To(gatewayapiv1.Group(gatewayapiv1.GroupName)),
+		Kind:        ptr.To(gatewayapiv1.Kind("Gateway")),
+		Namespace:   ptr.To(gatewayapiv1.Namespace(seed.Namespace)),
+		Name:        resources.ControlPlaneGatewayName,
+		SectionName: ptr.To(sectionName),
+	}
+}
+
+func serviceBackendRef(name string, port int32) gatewayapiv1.BackendRef {
+	return gatewayapiv1.BackendRef{
+		BackendObjectReference: gatewayapiv1.BackendObjectReference{
+			Kind: ptr.To(gatewayapiv1.Kind("Service")),
+			Name: gatewayapiv1.ObjectName(name),
+			Port: ptr.To(gatewayapiv1.PortNumber(port)),
+		},
+	}
+}
+
+// APIServerTCPRouteReconciler returns the function to reconcile the TCPRoute that attaches
+// the API server to its dedicated listener on the control plane Gateway.
+func APIServerTCPRouteReconciler(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) reconciling.NamedTCPRouteReconcilerFactory {
+	return func() (string, reconciling.TCPRouteReconciler) {
+		return resources.ApiserverTCPRouteName, func(route *gatewayapiv1alpha2.TCPRoute) (*gatewayapiv1alpha2.TCPRoute, error) {
+			route.Spec.ParentRefs = []gatewayapiv1.ParentReference{
+				parentReference(seed, APIServerListenerName(cluster)),
+			}
+			route.Spec.Rules = []gatewayapiv1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []gatewayapiv1.BackendRef{
+						serviceBackendRef(resources.ApiserverServiceName, 443),
+					},
+				},
+			}
+
+			return route, nil
+		}
+	}
+}
+
+// TLSRouteReconciler returns the function to reconcile a TLSRoute that routes the given
+// hostname from the shared TLS listener of the control plane Gateway to a service.
+func TLSRouteReconciler(name string, seed *kubermaticv1.Seed, hostname string, serviceName string, servicePort int32) reconciling.NamedTLSRouteReconcilerFactory {
+	return func() (string, reconciling.TLSRouteReconciler) {
+		return name, func(route *gatewayapiv1alpha2.TLSRoute) (*gatewayapiv1alpha2.TLSRoute, error) {
+			route.Spec.ParentRefs = []gatewayapiv1.ParentReference{
+				parentReference(seed, TLSListenerName),
+			}
+			route.Spec.Hostnames = []gatewayapiv1.Hostname{
+				gatewayapiv1.Hostname(hostname),
+			}
+			route.Spec.Rules = []gatewayapiv1alpha2.TLSRouteRule{
+				{
+					BackendRefs: []gatewayapiv1.BackendRef{
+						serviceBackendRef(serviceName, servicePort),
+					},
+				},
+			}
+
+			return route, nil
+		}
+	}
+}
+
+// KonnectivityTLSRouteReconciler returns the function to reconcile the TLSRoute for the
+// Konnectivity server, which is reached by the agents via SNI.
+func KonnectivityTLSRouteReconciler(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) reconciling.NamedTLSRouteReconcilerFactory {
+	hostname := fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, cluster.Status.Address.ExternalName)
+	return TLSRouteReconciler(resources.KonnectivityTLSRouteName, seed, hostname, resources.KonnectivityProxyServiceName, 443)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanegateway
+
+import (
+	"fmt"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func genCluster(name string) kubermaticv1.Cluster {
+	return kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-" + name,
+			Address: kubermaticv1.ClusterAddress{
+				ExternalName: name + ".europe-west3-c.dev.kubermatic.io",
+				Port:         30000,
+			},
+		},
+	}
+}
+
+func TestExposedClusters(t *testing.T) {
+	var clusters []kubermaticv1.Cluster
+	for i := MaxClusters + 1; i > 0; i-- {
+		clusters = append(clusters, genCluster(fmt.Sprintf("cluster-%03d", i)))
+	}
+
+	exposed, skipped := ExposedClusters(clusters)
+	if len(exposed) != MaxClusters {
+		t.Fatalf("Expected %d exposed clusters, got %d", MaxClusters, len(exposed))
+	}
+	if exposed[0].Name != "cluster-001" {
+		t.Errorf("Expected clusters to be sorted by name, but first cluster is %q", exposed[0].Name)
+	}
+	if len(skipped) != 1 || skipped[0].Name != fmt.Sprintf("cluster-%03d", MaxClusters+1) {
+		t.Errorf("Expected the last cluster to be skipped, got %v", skipped)
+	}
+}
+
+func TestKonnectivityTLSRouteReconciler(t *testing.T) {
+	cluster := genCluster("abcd")
+	seed := &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "seed",
+			Namespace: "kubermatic",
+		},
+	}
+
+	name, reconciler := KonnectivityTLSRouteReconciler(&cluster, seed)()
+	if name != resources.KonnectivityTLSRouteName {
+		t.Errorf("Expected TLSRoute %q, got %q", resources.KonnectivityTLSRouteName, name)
+	}
+
+	route, err := reconciler(&gatewayapiv1alpha2.TLSRoute{})
+	if err != nil {
+		t.Fatalf("Failed to reconcile TLSRoute: %v", err)
+	}
+
+	expectedHostname := gatewayapiv1.Hostname("konnectivity-server.abcd.europe-west3-c.dev.kubermatic.io")
+	if len(route.Spec.Hostnames) != 1 || route.Spec.Hostnames[0] != expectedHostname {
+		t.Errorf("Expected hostname %q, got %v", expectedHostname, route.Spec.Hostnames)
+	}
+
+	if len(route.Spec.ParentRefs) != 1 {
+		t.Fatalf("Expected exactly one parent, got %d", len(route.Spec.ParentRefs))
+	}
+
+	parent := route.Spec.ParentRefs[0]
+	if parent.Name != resources.ControlPlaneGatewayName || *parent.Namespace != "kubermatic" || *parent.SectionName != TLSListenerName {
+		t.Errorf("Expected route to attach to the TLS listener of the control plane Gateway, got %+v", parent)
+	}
+}
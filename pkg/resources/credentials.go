@@ -236,6 +236,45 @@ func GetCredentialsReference(cluster *kubermaticv1.Cluster) (*providerconfig.Glo
 	return nil, errors.New("cluster has no known cloud provider spec set")
 }
 
+// SetCredentialsReference sets the CredentialsReference for the cluster's chosen cloud
+// provider. An error is returned for providers that do not use credentials.
+func SetCredentialsReference(cluster *kubermaticv1.Cluster, ref *providerconfig.GlobalSecretKeySelector) error {
+	switch {
+	case cluster.Spec.Cloud.AWS != nil:
+		cluster.Spec.Cloud.AWS.CredentialsReference = ref
+	case cluster.Spec.Cloud.Azure != nil:
+		cluster.Spec.Cloud.Azure.CredentialsReference = ref
+	case cluster.Spec.Cloud.Baremetal != nil:
+		cluster.Spec.Cloud.Baremetal.CredentialsReference = ref
+	case cluster.Spec.Cloud.Digitalocean != nil:
+		cluster.Spec.Cloud.Digitalocean.CredentialsReference = ref
+	case cluster.Spec.Cloud.GCP != nil:
+		cluster.Spec.Cloud.GCP.CredentialsReference = ref
+	case cluster.Spec.Cloud.Hetzner != nil:
+		cluster.Spec.Cloud.Hetzner.CredentialsReference = ref
+	case cluster.Spec.Cloud.Openstack != nil:
+		cluster.Spec.Cloud.Openstack.CredentialsReference = ref
+	case cluster.Spec.Cloud.Packet != nil:
+		cluster.Spec.Cloud.Packet.CredentialsReference = ref
+	case cluster.Spec.Cloud.Kubevirt != nil:
+		cluster.Spec.Cloud.Kubevirt.CredentialsReference = ref
+	case cluster.Spec.Cloud.VSphere != nil:
+		cluster.Spec.Cloud.VSphere.CredentialsReference = ref
+	case cluster.Spec.Cloud.Alibaba != nil:
+		cluster.Spec.Cloud.Alibaba.CredentialsReference = ref
+	case cluster.Spec.Cloud.Anexia != nil:
+		cluster.Spec.Cloud.Anexia.CredentialsReference = ref
+	case cluster.Spec.Cloud.Nutanix != nil:
+		cluster.Spec.Cloud.Nutanix.CredentialsReference = ref
+	case cluster.Spec.Cloud.VMwareCloudDirector != nil:
+		cluster.Spec.Cloud.VMwareCloudDirector.CredentialsReference = ref
+	default:
+		return errors.New("cluster has no cloud provider spec with credentials set")
+	}
+
+	return nil
+}
+
 func GetCredentials(data CredentialsData) (Credentials, error) {
 	credentials := Credentials{}
 	var err error
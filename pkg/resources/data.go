@@ -434,6 +434,11 @@ func (d *TemplateData) GetRootCA() (*triple.KeyPair, error) {
 	return GetClusterRootCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
 }
 
+// GetRootCABundle returns all root CAs trusted by the cluster.
+func (d *TemplateData) GetRootCABundle() ([]*x509.Certificate, error) {
+	return GetClusterRootCABundle(d.ctx, d.cluster.Status.NamespaceName, d.client)
+}
+
 // GetFrontProxyCA returns the root CA for the front proxy.
 func (d *TemplateData) GetFrontProxyCA() (*triple.KeyPair, error) {
 	return GetClusterFrontProxyCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
@@ -517,6 +522,10 @@ func (d *TemplateData) GetKonnectivityServerPort() (int32, error) {
 	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
 		return d.Cluster().Status.Address.Port, nil
 	}
+	// When using gateway expose strategy the port is the one of the shared TLS listener
+	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		return d.Seed().Spec.ControlPlaneGateway.GetTLSPort(), nil
+	}
 	service := &corev1.Service{}
 	key := types.NamespacedName{Namespace: d.cluster.Status.NamespaceName, Name: KonnectivityProxyServiceName}
 	if err := d.client.Get(d.ctx, key, service); err != nil {
@@ -549,6 +558,10 @@ func (d *TemplateData) GetMLAGatewayPort() (int32, error) {
 	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
 		return d.Cluster().Status.Address.Port, nil
 	}
+	// When using gateway expose strategy the port is the one of the shared TLS listener
+	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+		return d.Seed().Spec.ControlPlaneGateway.GetTLSPort(), nil
+	}
 	service := &corev1.Service{}
 	key := types.NamespacedName{Namespace: d.cluster.Status.NamespaceName, Name: MLAGatewayExternalServiceName}
 	if err := d.client.Get(d.ctx, key, service); err != nil {
@@ -772,6 +785,24 @@ func (d *TemplateData) KubermaticConfiguration() *kubermaticv1.KubermaticConfigu
 	return d.config
 }
 
+// ControlPlaneSizingProfile returns the sizing profile that has been selected for the cluster,
+// or nil if no sizing profiles are configured.
+func (d *TemplateData) ControlPlaneSizingProfile() *kubermaticv1.ControlPlaneSizingProfile {
+	status := d.cluster.Status.ControlPlaneSizing
+	if status == nil || d.config == nil || d.config.Spec.UserCluster.ControlPlaneSizing == nil {
+		return nil
+	}
+
+	profiles := d.config.Spec.UserCluster.ControlPlaneSizing.Profiles
+	for i := range profiles {
+		if profiles[i].Name == status.Profile {
+			return &profiles[i]
+		}
+	}
+
+	return nil
+}
+
 func (data *TemplateData) GetEnvVars() ([]corev1.EnvVar, error) {
 	cluster := data.Cluster()
 	dc := data.DC()
@@ -83,7 +83,7 @@ func CronJobReconciler(data cronJobReconcilerData) reconciling.NamedCronJobRecon
 }
 
 func defraggerCommand(data cronJobReconcilerData) []string {
-	return []string{
+	command := []string{
 		"/etcd-launcher",
 		"defrag",
 		"--etcd-ca-file=/etc/etcd/pki/client/ca.crt",
@@ -91,4 +91,25 @@ func defraggerCommand(data cronJobReconcilerData) []string {
 		fmt.Sprintf("--etcd-client-key-file=%s", filepath.Join("/etc/etcd/pki/client", resources.ApiserverEtcdClientCertificateKeySecretKey)),
 		fmt.Sprintf("--cluster=%s", data.Cluster().Name),
 	}
+
+	threshold := int32(kubermaticv1.DefaultEtcdDefragmentationThreshold)
+	var minimumSize int64
+
+	if settings := data.Cluster().Spec.ComponentsOverride.Etcd.Defragmentation; settings != nil {
+		if settings.FragmentationThreshold != nil {
+			threshold = *settings.FragmentationThreshold
+		}
+
+		if settings.MinimumDatabaseSize != nil {
+			minimumSize = settings.MinimumDatabaseSize.Value()
+		}
+	}
+
+	command = append(command, fmt.Sprintf("--fragmentation-threshold=%d", threshold))
+
+	if minimumSize > 0 {
+		command = append(command, fmt.Sprintf("--minimum-db-size=%d", minimumSize))
+	}
+
+	return command
 }
@@ -34,7 +34,7 @@ type pdbData interface {
 func PodDisruptionBudgetReconciler(data pdbData) reconciling.NamedPodDisruptionBudgetReconcilerFactory {
 	return func() (string, reconciling.PodDisruptionBudgetReconciler) {
 		return resources.EtcdPodDisruptionBudgetName, func(pdb *policyv1.PodDisruptionBudget) (*policyv1.PodDisruptionBudget, error) {
-			minAvailable := intstr.FromInt((int(getClusterSize(data.Cluster().Spec.ComponentsOverride.Etcd)) / 2) + 1)
+			minAvailable := intstr.FromInt((int(GetClusterSize(data.Cluster().Spec.ComponentsOverride.Etcd)) / 2) + 1)
 			pdb.Spec = policyv1.PodDisruptionBudgetSpec{
 				Selector: &metav1.LabelSelector{
 					MatchLabels: GetBasePodLabels(data.Cluster()),
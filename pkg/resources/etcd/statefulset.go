@@ -67,6 +67,7 @@ type etcdStatefulSetReconcilerData interface {
 	EtcdLauncherTag() string
 	GetClusterRef() metav1.OwnerReference
 	SupportsFailureDomainZoneAntiAffinity() bool
+	ControlPlaneSizingProfile() *kubermaticv1.ControlPlaneSizingProfile
 }
 
 // StatefulSetReconciler returns the function to reconcile the etcd StatefulSet.
@@ -307,7 +308,7 @@ func StatefulSetReconciler(data etcdStatefulSetReconcilerData, enableDataCorrupt
 
 			set.Spec.Template.Spec.Tolerations = data.Cluster().Spec.ComponentsOverride.Etcd.Tolerations
 
-			err = resources.SetResourceRequirements(set.Spec.Template.Spec.Containers, defaultResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), set.Annotations)
+			err = resources.SetResourceRequirements(set.Spec.Template.Spec.Containers, resources.ApplyControlPlaneSizing(defaultResourceRequirements, data.ControlPlaneSizingProfile()), resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), set.Annotations)
 			if err != nil {
 				return nil, fmt.Errorf("failed to set resource requirements: %w", err)
 			}
@@ -321,6 +322,8 @@ func StatefulSetReconciler(data etcdStatefulSetReconcilerData, enableDataCorrupt
 				set.Spec.Template.Spec.Affinity = resources.MergeAffinities(set.Spec.Template.Spec.Affinity, failureDomainZoneAntiAffinity)
 			}
 
+			set.Spec.Template.Spec.TopologySpreadConstraints = resources.ZoneTopologySpreadConstraints(resources.EtcdStatefulSetName, data.Cluster().Spec.ControlPlaneTopology)
+
 			set.Spec.Template.Spec.NodeSelector = data.Cluster().Spec.ComponentsOverride.Etcd.NodeSelector
 
 			set.Spec.Template.Spec.Volumes = getVolumes()
@@ -377,6 +380,10 @@ func getVolumes() []corev1.Volume {
 							Path: resources.CACertSecretKey,
 							Key:  resources.CACertSecretKey,
 						},
+						{
+							Path: resources.CATrustBundleSecretKey,
+							Key:  resources.CATrustBundleSecretKey,
+						},
 					},
 				},
 			},
@@ -430,26 +437,19 @@ func computeReplicas(data etcdStatefulSetReconcilerData, set *appsv1.StatefulSet
 	if !data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] {
 		return kubermaticv1.DefaultEtcdClusterSize
 	}
-	etcdClusterSize := getClusterSize(data.Cluster().Spec.ComponentsOverride.Etcd)
 	if set.Spec.Replicas == nil { // new replicaset
-		return etcdClusterSize
+		return GetClusterSize(data.Cluster().Spec.ComponentsOverride.Etcd)
 	}
-	replicas := *set.Spec.Replicas
-	// at required size. do nothing
-	if etcdClusterSize == replicas {
-		return replicas
-	}
-	isEtcdHealthy := data.Cluster().Status.ExtendedHealth.Etcd == kubermaticv1.HealthStatusUp
-	if isEtcdHealthy { // no scaling until we are healthy
-		if etcdClusterSize > replicas {
-			return replicas + 1
-		}
-		return replicas - 1
+	// resizing an existing etcd cluster is driven one member at a time by the
+	// cluster controller, which records the next size in the cluster status
+	if status := data.Cluster().Status.Etcd; status != nil && status.Size > 0 {
+		return status.Size
 	}
-	return replicas
+	return *set.Spec.Replicas
 }
 
-func getClusterSize(settings kubermaticv1.EtcdStatefulSetSettings) int32 {
+// GetClusterSize returns the configured etcd cluster size, within the supported bounds.
+func GetClusterSize(settings kubermaticv1.EtcdStatefulSetSettings) int32 {
 	if settings.ClusterSize == nil {
 		return kubermaticv1.DefaultEtcdClusterSize
 	}
@@ -477,6 +477,10 @@ func getEtcdCommand(cluster *kubermaticv1.Cluster, enableCorruptionCheck, launch
 			command = append(command, "--enable-corruption-check")
 		}
 
+		if quota := cluster.Spec.ComponentsOverride.Etcd.QuotaBackendBytes; quota != nil {
+			command = append(command, "--quota-backend-bytes", fmt.Sprintf("%d", quota.Value()))
+		}
+
 		return command
 	}
 
@@ -505,7 +509,7 @@ func getEtcdCommand(cluster *kubermaticv1.Cluster, enableCorruptionCheck, launch
 		"--initial-advertise-peer-urls",
 		fmt.Sprintf("http://$(POD_NAME).%s.%s.svc.cluster.local:2380", resources.EtcdServiceName, cluster.Status.NamespaceName),
 		"--trusted-ca-file",
-		"/etc/etcd/pki/ca/ca.crt",
+		resources.EtcdTrustedCAFile,
 		"--client-cert-auth",
 		"--cert-file",
 		"/etc/etcd/pki/tls/etcd-tls.crt",
@@ -520,5 +524,9 @@ func getEtcdCommand(cluster *kubermaticv1.Cluster, enableCorruptionCheck, launch
 		command = append(command, "--experimental-corrupt-check-time", "240m")
 	}
 
+	if quota := cluster.Spec.ComponentsOverride.Etcd.QuotaBackendBytes; quota != nil {
+		command = append(command, "--quota-backend-bytes", fmt.Sprintf("%d", quota.Value()))
+	}
+
 	return command
 }
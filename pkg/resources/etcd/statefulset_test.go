@@ -25,6 +25,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	testhelper "k8c.io/kubermatic/v2/pkg/test"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -65,6 +66,26 @@ func TestGetEtcdCommand(t *testing.T) {
 			launcherEnabled:       false,
 			expectedArgs:          33,
 		},
+		{
+			name: "with-quota-backend-bytes",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ck7hj5s2zp",
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					ComponentsOverride: kubermaticv1.ComponentSettings{
+						Etcd: kubermaticv1.EtcdStatefulSetSettings{
+							QuotaBackendBytes: resource.NewQuantity(8*1024*1024*1024, resource.BinarySI),
+						},
+					},
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName: "cluster-ck7hj5s2zp",
+				},
+			},
+			launcherEnabled: true,
+			expectedArgs:    14,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1 @@
+/opt/bin/etcd-launcher run --cluster ck7hj5s2zp --pod-name $(POD_NAME) --pod-ip $(POD_IP) --api-version $(ETCDCTL_API) --token $(TOKEN) --quota-backend-bytes 8589934592
@@ -60,7 +60,7 @@ func TLSServingCertificateReconciler(data tlsServingCertReconcilerData) reconcil
 				DNSNames: []string{
 					// external address - nodeport / LB expose strategy
 					address.ExternalName,
-					// external address - tunneling expose strategy
+					// external address - tunneling / gateway expose strategy
 					fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, address.ExternalName),
 				},
 				IPs: []net.IP{
@@ -69,7 +69,9 @@ func TLSServingCertificateReconciler(data tlsServingCertReconcilerData) reconcil
 				},
 			}
 
-			if data.Cluster().Spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling {
+			// with the tunneling and gateway expose strategies, Konnectivity is only
+			// reachable via SNI and thus never by IP
+			if data.Cluster().Spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling && data.Cluster().Spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway {
 				externalIP := address.IP
 				if externalIP == "" {
 					return nil, errors.New("externalIP is unset")
@@ -40,7 +40,7 @@ const (
 
 type adminKubeconfigReconcilerData interface {
 	Cluster() *kubermaticv1.Cluster
-	GetRootCA() (*triple.KeyPair, error)
+	GetRootCABundle() ([]*x509.Certificate, error)
 }
 
 // AdminKubeconfigReconciler returns a function to create/update the secret with the admin kubeconfig.
@@ -51,13 +51,13 @@ func AdminKubeconfigReconciler(data adminKubeconfigReconcilerData) reconciling.N
 				se.Data = map[string][]byte{}
 			}
 
-			ca, err := data.GetRootCA()
+			caBundle, err := data.GetRootCABundle()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
+				return nil, fmt.Errorf("failed to get cluster ca bundle: %w", err)
 			}
 
 			address := data.Cluster().Status.Address
-			config := GetBaseKubeconfig(ca.Cert, address.URL, data.Cluster().Name)
+			config := GetBaseKubeconfigForCABundle(caBundle, address.URL, data.Cluster().Name)
 			config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
 				kubeconfigDefaultAuthInfoKey: {
 					Token: address.AdminToken,
@@ -84,12 +84,12 @@ func ViewerKubeconfigReconciler(data *TemplateData) reconciling.NamedSecretRecon
 				se.Data = map[string][]byte{}
 			}
 
-			ca, err := data.GetRootCA()
+			caBundle, err := data.GetRootCABundle()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
+				return nil, fmt.Errorf("failed to get cluster ca bundle: %w", err)
 			}
 
-			config := GetBaseKubeconfig(ca.Cert, data.Cluster().Status.Address.URL, data.Cluster().Name)
+			config := GetBaseKubeconfigForCABundle(caBundle, data.Cluster().Status.Address.URL, data.Cluster().Name)
 			token, err := data.GetViewerToken()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get token: %w", err)
@@ -114,6 +114,7 @@ func ViewerKubeconfigReconciler(data *TemplateData) reconciling.NamedSecretRecon
 
 type internalKubeconfigReconcilerData interface {
 	GetRootCA() (*triple.KeyPair, error)
+	GetRootCABundle() ([]*x509.Certificate, error)
 	Cluster() *kubermaticv1.Cluster
 }
 
@@ -130,9 +131,14 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
 			}
 
+			caBundle, err := data.GetRootCABundle()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster ca bundle: %w", err)
+			}
+
 			b := se.Data[KubeconfigSecretKey]
 			apiserverURL := fmt.Sprintf("https://%s", data.Cluster().Status.Address.InternalName)
-			valid, err := IsValidKubeconfig(b, ca.Cert, apiserverURL, commonName, organizations, data.Cluster().Name)
+			valid, err := isValidKubeconfig(b, ca.Cert, caBundle, apiserverURL, commonName, organizations, data.Cluster().Name)
 			if err != nil || !valid {
 				objLogger := log.With("namespace", namespace, "name", name)
 				if err != nil {
@@ -141,7 +147,7 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 					objLogger.Info("invalid/outdated kubeconfig found, regenerating")
 				}
 
-				se.Data[KubeconfigSecretKey], err = BuildNewKubeconfigAsByte(ca, apiserverURL, commonName, organizations, data.Cluster().Name)
+				se.Data[KubeconfigSecretKey], err = buildNewKubeconfigAsByte(ca, caBundle, apiserverURL, commonName, organizations, data.Cluster().Name)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create new kubeconfig: %w", err)
 				}
@@ -154,7 +160,11 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 }
 
 func BuildNewKubeconfigAsByte(ca *triple.KeyPair, server, commonName string, organizations []string, clusterName string) ([]byte, error) {
-	kubeconfig, err := buildNewKubeconfig(ca, server, commonName, organizations, clusterName)
+	return buildNewKubeconfigAsByte(ca, []*x509.Certificate{ca.Cert}, server, commonName, organizations, clusterName)
+}
+
+func buildNewKubeconfigAsByte(ca *triple.KeyPair, caBundle []*x509.Certificate, server, commonName string, organizations []string, clusterName string) ([]byte, error) {
+	kubeconfig, err := buildNewKubeconfig(ca, caBundle, server, commonName, organizations, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +172,8 @@ func BuildNewKubeconfigAsByte(ca *triple.KeyPair, server, commonName string, org
 	return clientcmd.Write(*kubeconfig)
 }
 
-func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizations []string, clusterName string) (*clientcmdapi.Config, error) {
-	baseKubconfig := GetBaseKubeconfig(ca.Cert, server, clusterName)
+func buildNewKubeconfig(ca *triple.KeyPair, caBundle []*x509.Certificate, server, commonName string, organizations []string, clusterName string) (*clientcmdapi.Config, error) {
+	baseKubconfig := GetBaseKubeconfigForCABundle(caBundle, server, clusterName)
 
 	kp, err := triple.NewClientKeyPair(ca, commonName, organizations)
 	if err != nil {
@@ -181,12 +191,23 @@ func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizat
 }
 
 func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *clientcmdapi.Config {
+	return GetBaseKubeconfigForCABundle([]*x509.Certificate{caCert}, server, clusterName)
+}
+
+// GetBaseKubeconfigForCABundle returns a kubeconfig without credentials that trusts all of the given CAs,
+// e.g. both the old and the new root CA while the root CA of the cluster is being rotated.
+func GetBaseKubeconfigForCABundle(caBundle []*x509.Certificate, server, clusterName string) *clientcmdapi.Config {
+	var caData []byte
+	for _, caCert := range caBundle {
+		caData = append(caData, triple.EncodeCertPEM(caCert)...)
+	}
+
 	return &clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
 			// We use the actual cluster name here. It is later used in encodeKubeconfig()
 			// to set the filename of the kubeconfig downloaded from API to `kubeconfig-clusterName`.
 			clusterName: {
-				CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+				CertificateAuthorityData: caData,
 				Server:                   server,
 			},
 		},
@@ -201,6 +222,10 @@ func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *cl
 }
 
 func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server, commonName string, organizations []string, clusterName string) (bool, error) {
+	return isValidKubeconfig(kubeconfigBytes, caCert, []*x509.Certificate{caCert}, server, commonName, organizations, clusterName)
+}
+
+func isValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, caBundle []*x509.Certificate, server, commonName string, organizations []string, clusterName string) (bool, error) {
 	if len(kubeconfigBytes) == 0 {
 		return false, nil
 	}
@@ -210,7 +235,7 @@ func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server,
 		return false, err
 	}
 
-	baseKubeconfig := GetBaseKubeconfig(caCert, server, clusterName)
+	baseKubeconfig := GetBaseKubeconfigForCABundle(caBundle, server, clusterName)
 
 	authInfo := existingKubeconfig.AuthInfos[kubeconfigDefaultAuthInfoKey]
 	if authInfo == nil {
@@ -30,6 +30,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func TestGetBaseKubeconfig(t *testing.T) {
@@ -93,7 +94,8 @@ func TestGetInternalKubeconfigReconcilerWithOrgs(t *testing.T) {
 
 // fakeDataProvider provides just enough for testing kubeconfig creation.
 type fakeDataProvider struct {
-	caPair *triple.KeyPair
+	caPair   *triple.KeyPair
+	caBundle []*x509.Certificate
 }
 
 func (fake *fakeDataProvider) Cluster() *kubermaticv1.Cluster { return &kubermaticv1.Cluster{} }
@@ -106,6 +108,13 @@ func (fake *fakeDataProvider) GetFrontProxyCA() (*triple.KeyPair, error) { retur
 
 func (fake *fakeDataProvider) GetRootCA() (*triple.KeyPair, error) { return fake.caPair, nil }
 
+func (fake *fakeDataProvider) GetRootCABundle() ([]*x509.Certificate, error) {
+	if fake.caBundle != nil {
+		return fake.caBundle, nil
+	}
+	return []*x509.Certificate{fake.caPair.Cert}, nil
+}
+
 func (fake *fakeDataProvider) GetOpenVPNCA() (*ECDSAKeyPair, error) { return &ECDSAKeyPair{}, nil }
 
 func (fake *fakeDataProvider) InClusterApiserverAddress() (string, error) { return "", nil }
@@ -134,3 +143,43 @@ func checkKubeConfigRegeneration(t *testing.T, orgs []string) {
 	// kubeconfig should be unmodified
 	assert.Equal(t, string(secret.Data[KubeconfigSecretKey]), string(secret2.Data[KubeconfigSecretKey]))
 }
+
+func TestGetInternalKubeconfigReconcilerWithCABundle(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("Failed to generate test root ca: %v", err)
+	}
+	nextCA, err := triple.NewCA("next-test-ca")
+	if err != nil {
+		t.Fatalf("Failed to generate next test root ca: %v", err)
+	}
+	data := &fakeDataProvider{caPair: ca}
+
+	_, create := GetInternalKubeconfigReconciler("some-namespace", "some-name", "test-creator-cn", nil, data, zap.NewNop().Sugar())()
+	secret, err := create(&corev1.Secret{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// adding the next CA to the bundle must regenerate the kubeconfig, so it trusts both CAs
+	data.caBundle = []*x509.Certificate{ca.Cert, nextCA.Cert}
+	secret2, err := create(secret.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, string(secret.Data[KubeconfigSecretKey]), string(secret2.Data[KubeconfigSecretKey]))
+
+	config, err := clientcmd.Load(secret2.Data[KubeconfigSecretKey])
+	if err != nil {
+		t.Fatal(err)
+	}
+	caData := config.Clusters[""].CertificateAuthorityData
+	assert.Equal(t, string(append(triple.EncodeCertPEM(ca.Cert), triple.EncodeCertPEM(nextCA.Cert)...)), string(caData))
+
+	// the kubeconfig is stable as long as the bundle does not change
+	secret3, err := create(secret2.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(secret2.Data[KubeconfigSecretKey]), string(secret3.Data[KubeconfigSecretKey]))
+}
@@ -52,6 +52,10 @@ const (
 	// exposed and the hostname, this is only used when the ExposeType is
 	// SNIType.
 	PortHostMappingAnnotationKey = "nodeport-proxy.k8s.io/port-mapping"
+	// PrivateAnnotationKey marks services of private clusters. Envoy still creates their
+	// listeners, but the lb-updater only exposes them on the private LoadBalancer instead
+	// of the public one.
+	PrivateAnnotationKey = "nodeport-proxy.k8s.io/private"
 
 	loadBalancerSourceRangesAnnotationKey = "service.beta.kubernetes.io/load-balancer-source-ranges"
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package openvpn
 
 import (
+	"errors"
 	"fmt"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -52,6 +53,8 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy) reconciling.N
 				se.Spec.Type = corev1.ServiceTypeClusterIP
 				se.Annotations[nodeportproxy.DefaultExposeAnnotationKey] = nodeportproxy.TunnelingType.String()
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				return nil, errors.New("the Gateway expose strategy requires Konnectivity")
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", exposeStrategy)
 			}
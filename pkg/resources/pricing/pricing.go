@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing implements the pricing catalogs used to estimate the costs of usercluster machines.
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// HoursPerMonth is the average number of hours in a month, used to extrapolate monthly costs.
+const HoursPerMonth = 730
+
+// EffectiveCatalog merges the inline prices of a datacenter with the prices from the
+// referenced Seed ConfigMap (which can be nil) for the given provider. Inline prices
+// take precedence. Nil is returned if the datacenter has no pricing configured.
+func EffectiveCatalog(dc *kubermaticv1.Datacenter, providerName string, cm *corev1.ConfigMap) *kubermaticv1.PricingCatalog {
+	if dc == nil || dc.Spec.Pricing == nil {
+		return nil
+	}
+
+	catalog := &kubermaticv1.PricingCatalog{
+		Currency:      dc.Spec.Pricing.Currency,
+		InstanceTypes: map[string]string{},
+	}
+
+	if cm != nil {
+		prefix := providerName + "."
+		for key, price := range cm.Data {
+			if instanceType, ok := strings.CutPrefix(key, prefix); ok && instanceType != "" {
+				catalog.InstanceTypes[instanceType] = strings.TrimSpace(price)
+			}
+		}
+	}
+
+	for instanceType, price := range dc.Spec.Pricing.InstanceTypes {
+		catalog.InstanceTypes[instanceType] = price
+	}
+
+	return catalog
+}
+
+// ConfigMapReconciler returns a reconciler for the ConfigMap holding the effective
+// pricing catalog inside the cluster namespace.
+func ConfigMapReconciler(catalog *kubermaticv1.PricingCatalog) reconciling.NamedConfigMapReconcilerFactory {
+	return func() (string, reconciling.ConfigMapReconciler) {
+		return resources.PricingCatalogConfigMapName, func(c *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			encoded, err := json.Marshal(catalog)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode pricing catalog: %w", err)
+			}
+
+			c.Data = map[string]string{
+				resources.PricingCatalogConfigMapKey: string(encoded),
+			}
+
+			return c, nil
+		}
+	}
+}
+
+// CatalogFromConfigMap decodes a pricing catalog as written by ConfigMapReconciler.
+func CatalogFromConfigMap(cm *corev1.ConfigMap) (*kubermaticv1.PricingCatalog, error) {
+	encoded, ok := cm.Data[resources.PricingCatalogConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap has no %q key", resources.PricingCatalogConfigMapKey)
+	}
+
+	catalog := &kubermaticv1.PricingCatalog{}
+	if err := json.Unmarshal([]byte(encoded), catalog); err != nil {
+		return nil, fmt.Errorf("failed to decode pricing catalog: %w", err)
+	}
+
+	return catalog, nil
+}
+
+// ParsePrice parses a decimal price and ensures it is not negative.
+func ParsePrice(price string) (resource.Quantity, error) {
+	q, err := resource.ParseQuantity(price)
+	if err != nil {
+		return q, fmt.Errorf("invalid price %q: %w", price, err)
+	}
+	if q.Sign() < 0 {
+		return q, fmt.Errorf("invalid price %q: must not be negative", price)
+	}
+
+	return q, nil
+}
+
+// Estimate calculates the cost of running one machine of each of the given instance
+// types. Instance types that are not part of the catalog (including empty ones, for
+// machines whose instance type could not be determined) are counted as unpriced.
+func Estimate(catalog *kubermaticv1.PricingCatalog, instanceTypes []string) (*kubermaticv1.ClusterCostEstimate, error) {
+	hourly := resource.Quantity{}
+	unpriced := 0
+
+	for _, instanceType := range instanceTypes {
+		price, ok := catalog.InstanceTypes[instanceType]
+		if !ok || instanceType == "" {
+			unpriced++
+			continue
+		}
+
+		q, err := ParsePrice(price)
+		if err != nil {
+			return nil, fmt.Errorf("instance type %q: %w", instanceType, err)
+		}
+
+		hourly.Add(q)
+	}
+
+	monthly := hourly.DeepCopy()
+	monthly.Mul(HoursPerMonth)
+
+	return &kubermaticv1.ClusterCostEstimate{
+		Currency:         catalog.Currency,
+		Hourly:           hourly.AsDec().String(),
+		Monthly:          monthly.AsDec().String(),
+		UnpricedMachines: unpriced,
+	}, nil
+}
+
+// EstimateMachineDeployment calculates the cost of running the given number of replicas
+// of an instance type. False is returned if the instance type is not part of the catalog.
+func EstimateMachineDeployment(catalog *kubermaticv1.PricingCatalog, instanceType string, replicas int32) (*kubermaticv1.MachineDeploymentCostEstimate, bool, error) {
+	price, ok := catalog.InstanceTypes[instanceType]
+	if !ok || instanceType == "" {
+		return nil, false, nil
+	}
+
+	hourly, err := ParsePrice(price)
+	if err != nil {
+		return nil, false, fmt.Errorf("instance type %q: %w", instanceType, err)
+	}
+
+	hourly.Mul(int64(replicas))
+
+	monthly := hourly.DeepCopy()
+	monthly.Mul(HoursPerMonth)
+
+	return &kubermaticv1.MachineDeploymentCostEstimate{
+		InstanceType: instanceType,
+		Replicas:     replicas,
+		Hourly:       hourly.AsDec().String(),
+		Monthly:      monthly.AsDec().String(),
+	}, true, nil
+}
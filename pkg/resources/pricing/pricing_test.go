@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEffectiveCatalog(t *testing.T) {
+	dc := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Pricing: &kubermaticv1.PricingCatalog{
+				Currency: "EUR",
+				InstanceTypes: map[string]string{
+					"t3.medium": "0.05",
+				},
+				ConfigMapName: "prices",
+			},
+		},
+	}
+
+	cm := &corev1.ConfigMap{
+		Data: map[string]string{
+			"aws.t3.medium": "0.04",
+			"aws.t3.large":  " 0.08 ",
+			"gcp.e2-small":  "0.02",
+			"aws.":          "1",
+		},
+	}
+
+	expected := &kubermaticv1.PricingCatalog{
+		Currency: "EUR",
+		InstanceTypes: map[string]string{
+			"t3.medium": "0.05",
+			"t3.large":  "0.08",
+		},
+	}
+
+	if catalog := EffectiveCatalog(dc, "aws", cm); !diff.SemanticallyEqual(expected, catalog) {
+		t.Fatalf("Unexpected catalog:\n%v", diff.ObjectDiff(expected, catalog))
+	}
+
+	if catalog := EffectiveCatalog(&kubermaticv1.Datacenter{}, "aws", cm); catalog != nil {
+		t.Fatalf("Expected no catalog for datacenter without pricing, got %v", catalog)
+	}
+}
+
+func TestCatalogConfigMapRoundtrip(t *testing.T) {
+	catalog := &kubermaticv1.PricingCatalog{
+		Currency: "USD",
+		InstanceTypes: map[string]string{
+			"Standard_D2s_v3": "0.096",
+		},
+	}
+
+	_, reconciler := ConfigMapReconciler(catalog)()
+	cm, err := reconciler(&corev1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("Failed to reconcile ConfigMap: %v", err)
+	}
+
+	decoded, err := CatalogFromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("Failed to decode ConfigMap: %v", err)
+	}
+
+	if !diff.SemanticallyEqual(catalog, decoded) {
+		t.Fatalf("Catalog changed during roundtrip:\n%v", diff.ObjectDiff(catalog, decoded))
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	catalog := &kubermaticv1.PricingCatalog{
+		Currency: "EUR",
+		InstanceTypes: map[string]string{
+			"small":  "0.0416",
+			"large":  "0.1664",
+			"broken": "cheap",
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		instanceTypes []string
+		expected      *kubermaticv1.ClusterCostEstimate
+		expectErr     bool
+	}{
+		{
+			name:          "no machines",
+			instanceTypes: nil,
+			expected:      &kubermaticv1.ClusterCostEstimate{Currency: "EUR", Hourly: "0", Monthly: "0"},
+		},
+		{
+			name:          "priced machines",
+			instanceTypes: []string{"small", "small", "large"},
+			expected:      &kubermaticv1.ClusterCostEstimate{Currency: "EUR", Hourly: "0.2496", Monthly: "182.2080"},
+		},
+		{
+			name:          "unpriced machines are counted",
+			instanceTypes: []string{"small", "unknown", ""},
+			expected:      &kubermaticv1.ClusterCostEstimate{Currency: "EUR", Hourly: "0.0416", Monthly: "30.3680", UnpricedMachines: 2},
+		},
+		{
+			name:          "invalid price",
+			instanceTypes: []string{"broken"},
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			estimate, err := Estimate(catalog, tc.instanceTypes)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("Expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !diff.SemanticallyEqual(tc.expected, estimate) {
+				t.Fatalf("Unexpected estimate:\n%v", diff.ObjectDiff(tc.expected, estimate))
+			}
+		})
+	}
+}
+
+func TestEstimateMachineDeployment(t *testing.T) {
+	catalog := &kubermaticv1.PricingCatalog{
+		Currency: "EUR",
+		InstanceTypes: map[string]string{
+			"small":  "0.0416",
+			"broken": "cheap",
+		},
+	}
+
+	estimate, priced, err := EstimateMachineDeployment(catalog, "small", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &kubermaticv1.MachineDeploymentCostEstimate{InstanceType: "small", Replicas: 3, Hourly: "0.1248", Monthly: "91.1040"}
+	if !priced || !diff.SemanticallyEqual(expected, estimate) {
+		t.Fatalf("Unexpected estimate:\n%v", diff.ObjectDiff(expected, estimate))
+	}
+
+	if _, priced, err := EstimateMachineDeployment(catalog, "unknown", 3); err != nil || priced {
+		t.Fatalf("Expected unknown instance type to be unpriced, got priced=%v, err=%v", priced, err)
+	}
+
+	if _, _, err := EstimateMachineDeployment(catalog, "broken", 1); err == nil {
+		t.Fatal("Expected error for invalid price, but got none")
+	}
+}
@@ -166,6 +166,21 @@ groups:
     labels:
       kubermatic: federate
 
+  - record: job:etcd_server_quota_backend_bytes:clone
+    expr: etcd_server_quota_backend_bytes
+    labels:
+      kubermatic: federate
+
+  - record: job:etcd_mvcc_db_quota_usage:percent
+    expr: (etcd_mvcc_db_total_size_in_bytes / etcd_server_quota_backend_bytes) * 100
+    labels:
+      kubermatic: federate
+
+  - record: job:etcd_mvcc_db_quota_usage_in_use:percent
+    expr: (etcd_mvcc_db_total_size_in_use_in_bytes / etcd_server_quota_backend_bytes) * 100
+    labels:
+      kubermatic: federate
+
   - record: job:etcd_network_client_grpc_received_bytes_total:rate5m
     expr: rate(etcd_network_client_grpc_received_bytes_total[5m])
     labels:
@@ -344,6 +359,19 @@ groups:
     labels:
       kubermatic: federate
 
+- name: kubermatic.apiserver
+  rules:
+  # all API server replicas report the same object counts
+  - record: job:apiserver_storage_objects:sum
+    expr: sum(max by (resource) (apiserver_storage_objects{job="apiserver"}))
+    labels:
+      kubermatic: federate
+
+  - record: job:apiserver_request_total:rate5msum
+    expr: sum(rate(apiserver_request_total{job="apiserver"}[5m]))
+    labels:
+      kubermatic: federate
+
 - name: kubernetes-absent
   rules:
   - alert: KubernetesApiserverDown
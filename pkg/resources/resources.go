@@ -111,10 +111,35 @@ const (
 	EtcdDefaultBackupConfigName = "default-backups"
 	// EtcdTLSEnabledAnnotation is the annotation assigned to etcd Pods that run with a TLS peer endpoint.
 	EtcdTLSEnabledAnnotation = "etcd.kubermatic.k8c.io/tls-peer-enabled"
+	// EtcdMemberStateAnnotation is the annotation etcd-launcher assigns to etcd Pods to report whether
+	// their member is still a learner, has failed to be promoted or is a voting member.
+	EtcdMemberStateAnnotation = "etcd.kubermatic.k8c.io/member-state"
+	// EtcdMemberStateLearner means the member has joined as a learner and is catching up with the leader.
+	EtcdMemberStateLearner = "Learner"
+	// EtcdMemberStatePromotionFailed means the learner did not catch up with the leader in time.
+	EtcdMemberStatePromotionFailed = "PromotionFailed"
+	// EtcdMemberStateVoter means the member is a voting member.
+	EtcdMemberStateVoter = "Voter"
+	// EtcdMemberStateRemoved means the member has removed itself from the etcd cluster because the
+	// cluster is being scaled down, and its Pod is about to be removed.
+	EtcdMemberStateRemoved = "Removed"
+	// EtcdNoSpaceRecoveryAnnotation is the annotation etcd-launcher assigns to the etcd Pod that
+	// recovered the cluster from a NOSPACE alarm, reporting whether the recovery succeeded.
+	EtcdNoSpaceRecoveryAnnotation = "etcd.kubermatic.k8c.io/nospace-recovery"
+	// EtcdNoSpaceRecoverySucceeded means the database has been compacted and defragmented and the
+	// NOSPACE alarm has been disarmed.
+	EtcdNoSpaceRecoverySucceeded = "Succeeded"
+	// EtcdNoSpaceRecoveryFailed means the NOSPACE alarm could not be resolved, e.g. because the
+	// database still exceeds the quota after defragmentation.
+	EtcdNoSpaceRecoveryFailed = "Failed"
 	// EncryptionConfigurationSecretName is the name of secret storing the API server's EncryptionConfiguration.
 	EncryptionConfigurationSecretName = "apiserver-encryption-configuration"
 	// EncryptionConfigurationKeyName is the name of the secret key that is used to store the configuration file for encryption-at-rest.
 	EncryptionConfigurationKeyName = "encryption-configuration.yaml"
+	// AuthenticationConfigurationConfigMapName is the name of the ConfigMap storing the API server's AuthenticationConfiguration.
+	AuthenticationConfigurationConfigMapName = "apiserver-authentication-configuration"
+	// AuthenticationConfigurationKeyName is the name of the ConfigMap key that is used to store the configuration file for structured authentication.
+	AuthenticationConfigurationKeyName = "authentication-configuration.yaml"
 	// NodePortProxyEnvoyDeploymentName is the name of the nodeport-proxy deployment in the user cluster.
 	NodePortProxyEnvoyDeploymentName = "nodeport-proxy-envoy"
 	// NodePortProxyEnvoyContainerName is the name of the envoy container in the nodeport-proxy deployment.
@@ -125,6 +150,15 @@ const (
 	// FrontLoadBalancerServiceName is the name of the LoadBalancer service that fronts everything
 	// when using exposeStrategy "LoadBalancer".
 	FrontLoadBalancerServiceName = "front-loadbalancer"
+	// ControlPlaneGatewayName is the name of the Gateway in the KKP namespace of a seed that exposes
+	// all user cluster control planes using the Gateway expose strategy.
+	ControlPlaneGatewayName = "kubermatic-control-planes"
+	// ApiserverTCPRouteName is the name of the TCPRoute that routes traffic from the control plane Gateway to the apiserver.
+	ApiserverTCPRouteName = "apiserver"
+	// KonnectivityTLSRouteName is the name of the TLSRoute that routes traffic from the control plane Gateway to Konnectivity.
+	KonnectivityTLSRouteName = "konnectivity-server"
+	// MLAGatewayTLSRouteName is the name of the TLSRoute that routes traffic from the control plane Gateway to the MLA Gateway.
+	MLAGatewayTLSRouteName = "mla-gateway"
 	// MetricsServerServiceName is the name for the metrics-server service.
 	MetricsServerServiceName = "metrics-server"
 	// MetricsServerExternalNameServiceName is the name for the metrics-server service inside the user cluster.
@@ -195,6 +229,9 @@ const (
 	FrontProxyCASecretName = "front-proxy-ca"
 	// CASecretName is the name for the secret containing the root ca.
 	CASecretName = "ca"
+	// RootCARotationSecretName is the name for the secret containing the new root ca while
+	// the root ca of a cluster is being rotated.
+	RootCARotationSecretName = "ca-rotation"
 	// ApiserverTLSSecretName is the name for the secrets required for the apiserver tls.
 	ApiserverTLSSecretName = "apiserver-tls"
 	// KubeletClientCertificatesSecretName is the name for the secret containing the kubelet client certificates.
@@ -239,6 +276,12 @@ const (
 	// CABundleConfigMapKey is the key under which a ConfigMap must contain a PEM-encoded collection of certificates.
 	CABundleConfigMapKey = "ca-bundle.pem"
 
+	// PricingCatalogConfigMapName is the name for the configmap that contains the effective pricing catalog
+	// for a usercluster, as configured in its datacenter.
+	PricingCatalogConfigMapName = "pricing-catalog"
+	// PricingCatalogConfigMapKey is the key under which the JSON-encoded pricing catalog can be found.
+	PricingCatalogConfigMapKey = "catalog.json"
+
 	// CloudConfigSeedSecretName is the name for the secret containing the cloud-config inside the usercluster namespace
 	// on the seed cluster. Not to be confused with CloudConfigSecretName, which is the copy of this Secret inside the
 	// usercluster.
@@ -366,6 +409,10 @@ const (
 	EtcdPodDisruptionBudgetName = "etcd"
 	// ApiserverPodDisruptionBudgetName is the name of the PDB for the apiserver deployment.
 	ApiserverPodDisruptionBudgetName = "apiserver"
+	// ControllerManagerPodDisruptionBudgetName is the name of the PDB for the controller-manager deployment.
+	ControllerManagerPodDisruptionBudgetName = "controller-manager"
+	// SchedulerPodDisruptionBudgetName is the name of the PDB for the scheduler deployment.
+	SchedulerPodDisruptionBudgetName = "scheduler"
 	// MetricsServerPodDisruptionBudgetName is the name of the PDB for the metrics-server deployment.
 	MetricsServerPodDisruptionBudgetName = "metrics-server"
 
@@ -527,6 +574,11 @@ const (
 	// a UNIX timestamp (or similar) value to trigger cluster control plane restarts. The value of this
 	// annotation is copied into control plane components.
 	ClusterLastRestartAnnotation = "kubermatic.k8c.io/last-restart"
+
+	// PrivateEndpointIPAnnotation is set on private Cluster objects by the cloud provider and
+	// contains the IP of the private endpoint through which the control plane is reachable from
+	// within the cluster network.
+	PrivateEndpointIPAnnotation = "kubermatic.k8c.io/private-endpoint-ip"
 )
 
 const (
@@ -534,6 +586,9 @@ const (
 	CAKeySecretKey = "ca.key"
 	// CACertSecretKey ca.crt.
 	CACertSecretKey = "ca.crt"
+	// CATrustBundleSecretKey trust-bundle.crt. It contains all CAs that are trusted in addition
+	// to the signing CA in ca.crt, e.g. the next root CA while it is being rotated.
+	CATrustBundleSecretKey = "trust-bundle.crt"
 	// ApiserverTLSKeySecretKey apiserver-tls.key.
 	ApiserverTLSKeySecretKey = "apiserver-tls.key"
 	// ApiserverTLSCertSecretKey apiserver-tls.crt.
@@ -734,6 +789,23 @@ const (
 	ServiceAccountTokenAnnotation = "kubernetes.io/service-account.name"
 
 	UserSSHKeys = "usersshkeys"
+	// UserSSHKeyCASecretName is the name of the Secret in the cluster namespace holding the SSH
+	// certificate authority that signs the UserSSHKeys of clusters with the SSH CA mode enabled.
+	UserSSHKeyCASecretName = "user-ssh-key-ca"
+	// UserSSHKeyCAPrivateKeySecretKey is the key for the OpenSSH-encoded CA private key.
+	UserSSHKeyCAPrivateKeySecretKey = "ca"
+	// UserSSHKeyCAPublicKeySecretKey is the key for the CA public key in authorized_keys format.
+	UserSSHKeyCAPublicKeySecretKey = "ca.pub"
+	// UserSSHKeyCertificateRequestsSecretName is the name of the Secret in the cluster namespace listing
+	// the public keys that the seed should issue SSH certificates for. It is written by the master.
+	UserSSHKeyCertificateRequestsSecretName = "user-ssh-key-certificate-requests"
+	// UserSSHKeyCertificatesSecretName is the name of the Secret in the cluster namespace holding the SSH
+	// certificates issued by the seed for the requested public keys.
+	UserSSHKeyCertificatesSecretName = "user-ssh-key-certificates"
+	// UserSSHKeyCAEntrySecretKey is the key for the CA public key in both the certificates Secret
+	// and the usersshkeys Secret of clusters with the SSH CA mode enabled. The user-ssh-keys-agent
+	// configures it as sshd's TrustedUserCAKeys instead of writing it to the authorized_keys files.
+	UserSSHKeyCAEntrySecretKey = "kubermatic-user-ssh-ca"
 
 	// This Constant is used in GetBaremetalCredentials() to get the Tinkerbell kubeconfig.
 	TinkerbellKubeconfig = "kubeConfig"
@@ -869,7 +941,7 @@ const (
 )
 
 const (
-	EtcdTrustedCAFile = "/etc/etcd/pki/ca/ca.crt"
+	EtcdTrustedCAFile = "/etc/etcd/pki/ca/trust-bundle.crt"
 	EtcdCertFile      = "/etc/etcd/pki/tls/etcd-tls.crt"
 	EtcdKeyFile       = "/etc/etcd/pki/tls/etcd-tls.key"
 
@@ -930,6 +1002,13 @@ const (
 	MLAMonitoringAgentClusterRoleBindingName = "system:mla:mla-monitoring-agent"
 	MLAMonitoringAgentDeploymentName         = "mla-monitoring-agent"
 
+	MLATracingAgentConfigMapName          = "mla-tracing-agent"
+	MLATracingAgentServiceAccountName     = "mla-tracing-agent"
+	MLATracingAgentClusterRoleName        = "system:mla:mla-tracing-agent"
+	MLATracingAgentClusterRoleBindingName = "system:mla:mla-tracing-agent"
+	MLATracingAgentDeploymentName         = "mla-tracing-agent"
+	MLATracingAgentServiceName            = "mla-tracing-agent"
+
 	// MLAGatewayExternalServiceName is the name for the MLA Gateway external service.
 	MLAGatewayExternalServiceName = "mla-gateway-ext"
 	// MLAGatewaySNIPrefix is the URL prefix which identifies the MLA Gateway endpoint in the external URL if SNI expose strategy is used.
@@ -959,6 +1038,13 @@ const (
 	MLALoggingAgentClientCertSecretKey    = "client.crt"
 	MLALoggingAgentClientCertMountPath    = "/etc/ssl/mla"
 
+	// MLATracingAgentCertificatesSecretName is the name for the secret containing the Tracing Agent (OpenTelemetry collector) client certificates.
+	MLATracingAgentCertificatesSecretName = "tracing-agent-certificates"
+	MLATracingAgentCertificateCommonName  = "tracing-agent"
+	MLATracingAgentClientKeySecretKey     = "client.key"
+	MLATracingAgentClientCertSecretKey    = "client.crt"
+	MLATracingAgentClientCertMountPath    = "/etc/ssl/mla"
+
 	AlertmanagerName                    = "alertmanager"
 	DefaultAlertmanagerConfigSecretName = "alertmanager"
 	AlertmanagerConfigSecretKey         = "alertmanager.yaml"
@@ -1401,6 +1487,28 @@ func GetClusterRootCA(ctx context.Context, namespace string, client ctrlruntimec
 	return getRSAClusterCAFromLister(ctx, namespace, CASecretName, client)
 }
 
+// GetClusterRootCABundle returns all root CAs trusted by the cluster. Outside of a root CA
+// rotation, this is only the root CA that signs the cluster's certificates.
+func GetClusterRootCABundle(ctx context.Context, namespace string, client ctrlruntimeclient.Client) ([]*x509.Certificate, error) {
+	caSecret := &corev1.Secret{}
+	caSecretKey := types.NamespacedName{Namespace: namespace, Name: CASecretName}
+	if err := client.Get(ctx, caSecretKey, caSecret); err != nil {
+		return nil, fmt.Errorf("unable to get the CA secret: %w", err)
+	}
+
+	bundle, exists := caSecret.Data[CATrustBundleSecretKey]
+	if !exists {
+		bundle = caSecret.Data[CACertSecretKey]
+	}
+
+	certs, err := certutil.ParseCertsPEM(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("got an invalid CA bundle from the CA secret %s: %w", caSecretKey, err)
+	}
+
+	return certs, nil
+}
+
 // GetClusterFrontProxyCA returns the frontproxy CA of the cluster from the lister.
 func GetClusterFrontProxyCA(ctx context.Context, namespace string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
 	return getRSAClusterCAFromLister(ctx, namespace, FrontProxyCASecretName, client)
@@ -1559,6 +1667,33 @@ func GetOverrides(componentSettings kubermaticv1.ComponentSettings) map[string]*
 	return r
 }
 
+// ApplyControlPlaneSizing returns the given default resource requirements with the resources of
+// the sizing profile applied on top. As the profile only replaces the defaults, resources that have
+// been configured by the VPA or via the componentsOverride of a cluster still take precedence.
+func ApplyControlPlaneSizing(defaultRequirements map[string]*corev1.ResourceRequirements, profile *kubermaticv1.ControlPlaneSizingProfile) map[string]*corev1.ResourceRequirements {
+	if profile == nil {
+		return defaultRequirements
+	}
+
+	requirements := map[string]*corev1.ResourceRequirements{}
+	for k, v := range defaultRequirements {
+		requirements[k] = v
+	}
+
+	for name, r := range map[string]*corev1.ResourceRequirements{
+		ApiserverDeploymentName:         profile.Apiserver,
+		ControllerManagerDeploymentName: profile.ControllerManager,
+		SchedulerDeploymentName:         profile.Scheduler,
+		EtcdStatefulSetName:             profile.Etcd,
+	} {
+		if r != nil {
+			requirements[name] = r.DeepCopy()
+		}
+	}
+
+	return requirements
+}
+
 // SupportsFailureDomainZoneAntiAffinity checks if there are any nodes with the
 // TopologyKeyZone label.
 func SupportsFailureDomainZoneAntiAffinity(ctx context.Context, client ctrlruntimeclient.Client) (bool, error) {
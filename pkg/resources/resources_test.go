@@ -576,6 +576,55 @@ func TestSetResourceRequirementsDoesNotChangeDefaults(t *testing.T) {
 	}
 }
 
+func TestApplyControlPlaneSizing(t *testing.T) {
+	defaults := map[string]*corev1.ResourceRequirements{
+		ApiserverDeploymentName: {
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+		"sidecar": {
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		},
+	}
+
+	profile := &kubermaticv1.ControlPlaneSizingProfile{
+		Name: "large",
+		Apiserver: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+
+	containers := []corev1.Container{{Name: ApiserverDeploymentName}, {Name: "sidecar"}}
+	overrides := map[string]*corev1.ResourceRequirements{
+		"sidecar": {
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+	}
+
+	if err := SetResourceRequirements(containers, ApplyControlPlaneSizing(defaults, profile), overrides, nil); err != nil {
+		t.Fatalf("function should not have returned an error, but got: %v", err)
+	}
+
+	if memory := containers[0].Resources.Requests[corev1.ResourceMemory]; memory.String() != "4Gi" {
+		t.Errorf("Expected the profile to replace the default, but got %s", memory.String())
+	}
+
+	if memory := containers[1].Resources.Requests[corev1.ResourceMemory]; memory.String() != "64Mi" {
+		t.Errorf("Expected overrides to take precedence, but got %s", memory.String())
+	}
+
+	if memory := defaults[ApiserverDeploymentName].Requests[corev1.ResourceMemory]; memory.String() != "256Mi" {
+		t.Errorf("The defaults have changed: %s", memory.String())
+	}
+}
+
 func TestGetNodePortsAllowedIPRanges(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -66,7 +66,7 @@ func DeploymentReconciler(data *resources.TemplateData) reconciling.NamedDeploym
 				"--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 				"--authorization-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 				// This is used to validate certs
-				"--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+				"--client-ca-file", "/etc/kubernetes/pki/ca/trust-bundle.crt",
 				// this can't be passed as two strings as the other parameters
 				"--profiling=false",
 			}
@@ -165,12 +165,13 @@ func DeploymentReconciler(data *resources.TemplateData) reconciling.NamedDeploym
 
 			dep.Spec.Template.Spec.Volumes = getVolumes(data.IsKonnectivityEnabled())
 
-			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
+			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, resources.ApplyControlPlaneSizing(defResourceRequirements, data.ControlPlaneSizingProfile()), resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
 			if err != nil {
 				return nil, fmt.Errorf("failed to set resource requirements: %w", err)
 			}
 
 			dep.Spec.Template.Spec.Affinity = resources.HostnameAntiAffinity(name, kubermaticv1.AntiAffinityTypePreferred)
+			dep.Spec.Template.Spec.TopologySpreadConstraints = resources.ZoneTopologySpreadConstraints(name, data.Cluster().Spec.ControlPlaneTopology)
 
 			dep.Spec.Template, err = apiserver.IsRunningWrapper(data, dep.Spec.Template, sets.New(name))
 			if err != nil {
@@ -214,6 +215,10 @@ func getVolumes(isKonnectivityEnabled bool) []corev1.Volume {
 							Path: resources.CACertSecretKey,
 							Key:  resources.CACertSecretKey,
 						},
+						{
+							Path: resources.CATrustBundleSecretKey,
+							Key:  resources.CATrustBundleSecretKey,
+						},
 					},
 				},
 			},
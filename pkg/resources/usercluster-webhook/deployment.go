@@ -21,6 +21,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
@@ -103,6 +104,7 @@ func DeploymentReconciler(data webhookData) reconciling.NamedDeploymentReconcile
 				fmt.Sprintf("-ca-bundle=/opt/ca-bundle/%s", resources.CABundleConfigMapKey),
 				fmt.Sprintf("-project-id=%s", projectID),
 				fmt.Sprintf("-cluster-name=%s", data.Cluster().Name),
+				fmt.Sprintf("-allow-spot-instances=%t", spot.Allowed(data.DC())),
 			}
 
 			if data.Cluster().Spec.DebugLog {
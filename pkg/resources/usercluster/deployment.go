@@ -74,6 +74,7 @@ type userclusterControllerData interface {
 	UserClusterMLAEnabled() bool
 	IsKonnectivityEnabled() bool
 	DC() *kubermaticv1.Datacenter
+	Seed() *kubermaticv1.Seed
 	GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
 	GetEnvVars() ([]corev1.EnvVar, error)
 }
@@ -161,7 +162,7 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 				args = append(args, "-konnectivity-enabled=true")
 
 				kHost := address.ExternalName
-				if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+				if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 					kHost = fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, kHost)
 				}
 				kPort, err := data.GetKonnectivityServerPort()
@@ -219,16 +220,20 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 			}
 
 			if data.UserClusterMLAEnabled() && data.Cluster().Spec.MLA != nil {
+				// the tracing agent has nowhere to send traces to unless the seed runs a tracing backend
+				tracingEnabled := data.Cluster().Spec.MLA.TracingEnabled && data.Seed().IsUserClusterTracingEnabled()
+
 				args = append(args, fmt.Sprintf("-user-cluster-monitoring=%t", data.Cluster().Spec.MLA.MonitoringEnabled))
 				args = append(args, fmt.Sprintf("-user-cluster-logging=%t", data.Cluster().Spec.MLA.LoggingEnabled))
+				args = append(args, fmt.Sprintf("-user-cluster-tracing=%t", tracingEnabled))
 
-				if data.Cluster().Spec.MLA.MonitoringEnabled || data.Cluster().Spec.MLA.LoggingEnabled {
+				if data.Cluster().Spec.MLA.MonitoringEnabled || data.Cluster().Spec.MLA.LoggingEnabled || tracingEnabled {
 					mlaGatewayPort, err := data.GetMLAGatewayPort()
 					if err != nil {
 						return nil, err
 					}
 					mlaEndpoint := net.JoinHostPort(address.ExternalName, fmt.Sprintf("%d", mlaGatewayPort))
-					if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+					if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling || data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
 						mlaEndpoint = resources.MLAGatewaySNIPrefix + mlaEndpoint
 					}
 					args = append(args, "-mla-gateway-url", "https://"+mlaEndpoint)
@@ -445,10 +445,11 @@ func isClusterEtcdHealthy(ctx context.Context, client ctrlruntimeclient.Client,
 		clusterSize = *size
 	}
 
-	// we are healthy if the cluster controller is happy and the sts has ready replicas
-	// matching the cluster's expected etcd cluster size
+	// we are healthy if the cluster controller is happy, the sts has ready replicas
+	// matching the cluster's expected etcd cluster size and no resize is in progress
 	return cluster.Status.ExtendedHealth.Etcd == kubermaticv1.HealthStatusUp &&
-		clusterSize == sts.Status.ReadyReplicas, nil
+		clusterSize == sts.Status.ReadyReplicas &&
+		(cluster.Status.Etcd == nil || cluster.Status.Etcd.ResizePhase == ""), nil
 }
 
 func isStrictTLSEnabled(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (bool, error) {
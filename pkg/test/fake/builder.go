@@ -52,11 +52,13 @@ func NewClientBuilder() *fakectrlruntimeclient.ClientBuilder {
 			&kubermaticv1.Addon{},
 			&kubermaticv1.Alertmanager{},
 			&kubermaticv1.Cluster{},
+			&kubermaticv1.CredentialRotation{},
 			&kubermaticv1.Seed{},
 			&kubermaticv1.EtcdBackupConfig{},
 			&kubermaticv1.EtcdRestore{},
 			&kubermaticv1.Project{},
 			&kubermaticv1.ResourceQuota{},
 			&kubermaticv1.User{},
+			&kubermaticv1.UserSSHKey{},
 		)
 }
@@ -19,9 +19,11 @@ package validation
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"regexp"
 	"strings"
 	"time"
 
@@ -35,16 +37,22 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/gcp"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	"k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/version"
 	clusterversion "k8c.io/kubermatic/v2/pkg/version/cluster"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	kubenetutil "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	apiserverconfig "k8s.io/apiserver/pkg/apis/apiserver"
+	apiserverconfigv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	apiserverconfigvalidation "k8s.io/apiserver/pkg/apis/apiserver/validation"
 )
 
 var (
@@ -53,6 +61,9 @@ var (
 	azureLoadBalancerSKUTypes = sets.New("", string(kubermaticv1.AzureStandardLBSKU), string(kubermaticv1.AzureBasicLBSKU))
 
 	errPodSecurityPolicyAdmissionPluginWithVersionGte125 = errors.New("admission plugin \"PodSecurityPolicy\" is not supported in Kubernetes v1.25 and later")
+
+	// maxEtcdQuotaBackendBytes is the largest etcd database size recommended by etcd.
+	maxEtcdQuotaBackendBytes = resource.MustParse("8Gi")
 )
 
 const (
@@ -112,6 +123,10 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("TunnelingAgentIP"), "Tunneling agent IP can be configured only for Tunneling Expose strategy"))
 	}
 
+	if err := validateGatewayExposeStrategy(spec, parentFieldPath.Child("exposeStrategy")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	// External CCM is not supported for all providers and all Kubernetes versions.
 	if spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider] {
 		if !resources.ExternalCloudControllerFeatureSupported(dc, &spec.Cloud, spec.Version, versionManager.GetIncompatibilities()...) {
@@ -138,6 +153,7 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 
 	allErrs = append(allErrs, ValidateLeaderElectionSettings(&spec.ComponentsOverride.ControllerManager.LeaderElectionSettings, parentFieldPath.Child("componentsOverride", "controllerManager", "leaderElection"))...)
 	allErrs = append(allErrs, ValidateLeaderElectionSettings(&spec.ComponentsOverride.Scheduler.LeaderElectionSettings, parentFieldPath.Child("componentsOverride", "scheduler", "leaderElection"))...)
+	allErrs = append(allErrs, validateEtcdSettings(&spec.ComponentsOverride.Etcd, parentFieldPath.Child("componentsOverride", "etcd"))...)
 
 	externalCCM := false
 	if val, ok := spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]; ok {
@@ -166,6 +182,10 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := validateAuthenticationConfiguration(spec, parentFieldPath.Child("authenticationConfiguration")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	// KubeLB can only be enabled on the cluster if it's either enforced or enabled at the datacenter level.
 	if spec.IsKubeLBEnabled() && (dc.Spec.KubeLB == nil || !(dc.Spec.KubeLB.Enabled || dc.Spec.KubeLB.Enforced)) {
 		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("kubeLB"), "KubeLB is not enabled on this datacenter"))
@@ -175,6 +195,165 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, err)
 	}
 
+	if errs := validateUserSSHKeyCertificateAuthority(spec, parentFieldPath.Child("userSSHKeyCertificateAuthority")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	// an invalid cloud spec is reported by the cloud spec validation already
+	tagProvider, _ := kubermaticv1helper.ClusterCloudProviderName(spec.Cloud)
+	if errs := ValidateResourceTags(kubermaticv1.ProviderType(tagProvider), spec.ResourceTags, parentFieldPath.Child("resourceTags")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validatePrivateCluster(spec, dc, parentFieldPath.Child("privateCluster")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateControlPlaneTopology(spec.ControlPlaneTopology, parentFieldPath.Child("controlPlaneTopology")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
+}
+
+const (
+	// AWS allows tag keys of up to 128 and values of up to 256 characters.
+	maxAWSResourceTagKeyLength   = 128
+	maxAWSResourceTagValueLength = 256
+
+	// GCP and Hetzner labels are limited to 63 characters for both keys and values.
+	maxLabelResourceTagLength = 63
+)
+
+var (
+	// resourceTagLabelKeyRegex and resourceTagLabelValueRegex match the label format
+	// accepted by GCP and Hetzner: lowercase letters, digits, '_' and '-'. GCP
+	// additionally requires keys to start with a lowercase letter.
+	resourceTagLabelKeyRegex   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	resourceTagLabelValueRegex = regexp.MustCompile(`^[a-z0-9_-]*$`)
+
+	// resourceTagProviders are the providers that apply resource tags to cloud resources.
+	resourceTagProviders = []kubermaticv1.ProviderType{
+		kubermaticv1.AWSCloudProvider,
+		kubermaticv1.AzureCloudProvider,
+		kubermaticv1.GCPCloudProvider,
+		kubermaticv1.HetznerCloudProvider,
+		kubermaticv1.OpenstackCloudProvider,
+	}
+)
+
+// ValidateResourceTags validates the resource tags configured on a cluster, project or
+// datacenter against the restrictions of the given cloud provider. If no provider is
+// given (e.g. for projects, whose clusters can run on any provider), the tags must be
+// valid for all providers that apply resource tags.
+func ValidateResourceTags(providerName kubermaticv1.ProviderType, tags map[string]string, fldPath *field.Path) field.ErrorList {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	providers := resourceTagProviders
+	if providerName != "" {
+		providers = []kubermaticv1.ProviderType{providerName}
+	}
+
+	allErrs := field.ErrorList{}
+
+	for key, value := range tags {
+		if key == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, key, "tag keys must not be empty"))
+			continue
+		}
+
+		for _, p := range providers {
+			if err := validateResourceTag(p, key, value, fldPath.Key(key)); err != nil {
+				allErrs = append(allErrs, err)
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateResourceTag(providerName kubermaticv1.ProviderType, key, value string, fldPath *field.Path) *field.Error {
+	switch providerName {
+	case kubermaticv1.AWSCloudProvider:
+		if len(key) > maxAWSResourceTagKeyLength {
+			return field.TooLong(fldPath, key, maxAWSResourceTagKeyLength)
+		}
+		if len(value) > maxAWSResourceTagValueLength {
+			return field.TooLong(fldPath, value, maxAWSResourceTagValueLength)
+		}
+
+	case kubermaticv1.GCPCloudProvider, kubermaticv1.HetznerCloudProvider:
+		if len(key) > maxLabelResourceTagLength {
+			return field.TooLong(fldPath, key, maxLabelResourceTagLength)
+		}
+		if !resourceTagLabelKeyRegex.MatchString(key) {
+			return field.Invalid(fldPath, key, fmt.Sprintf("%s labels must start with a lowercase letter and consist only of lowercase letters, digits, '_' and '-'", providerName))
+		}
+		if len(value) > maxLabelResourceTagLength {
+			return field.TooLong(fldPath, value, maxLabelResourceTagLength)
+		}
+		if !resourceTagLabelValueRegex.MatchString(value) {
+			return field.Invalid(fldPath, value, fmt.Sprintf("%s label values must consist only of lowercase letters, digits, '_' and '-'", providerName))
+		}
+
+	case kubermaticv1.OpenstackCloudProvider:
+		// OpenStack only supports plain string tags, which are encoded as "key=value"
+		if strings.Contains(key, "=") {
+			return field.Invalid(fldPath, key, "tag keys must not contain '='")
+		}
+	}
+
+	return nil
+}
+
+func validatePrivateCluster(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datacenter, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !spec.IsPrivateCluster() {
+		return allErrs
+	}
+
+	// The private endpoint forwards to the seed's NodePorts, which rules out the
+	// SNI-based routing of the Tunneling and the front LoadBalancer of the LoadBalancer strategy.
+	if spec.ExposeStrategy != kubermaticv1.ExposeStrategyNodePort {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "private clusters require the NodePort expose strategy"))
+	}
+
+	switch {
+	case spec.Cloud.Azure != nil:
+		if dc != nil && (dc.Spec.Azure == nil || dc.Spec.Azure.PrivateLinkServiceID == "") {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "the datacenter has no private link service configured"))
+		}
+	case spec.Cloud.GCP != nil:
+		if dc != nil && (dc.Spec.GCP == nil || dc.Spec.GCP.PrivateServiceAttachment == "") {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "the datacenter has no private service attachment configured"))
+		}
+	default:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "private clusters are only supported on Azure and GCP"))
+	}
+
+	return allErrs
+}
+
+func validateUserSSHKeyCertificateAuthority(spec *kubermaticv1.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	settings := spec.UserSSHKeyCertificateAuthority
+	if settings == nil || !settings.Enabled {
+		return allErrs
+	}
+
+	if spec.EnableUserSSHKeyAgent != nil && !*spec.EnableUserSSHKeyAgent {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enabled"), "the SSH certificate authority requires the UserSSHKey agent to be enabled"))
+	}
+
+	if settings.CertificateValidity != nil && settings.CertificateValidity.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("certificateValidity"), settings.CertificateValidity.Duration.String(), "certificate validity must be positive"))
+	}
+
 	return allErrs
 }
 
@@ -202,6 +381,53 @@ func ValidateNewClusterSpec(ctx context.Context, spec *kubermaticv1.ClusterSpec,
 	return allErrs
 }
 
+// capacityCheckTimeout bounds the cloud calls made by ValidateClusterCapacity, which
+// runs inside the admission webhook.
+var capacityCheckTimeout = 5 * time.Second
+
+// ValidateClusterCapacity checks whether the cloud account of a new cluster has enough quota left
+// for the cluster's cloud resources and the machines requested by its initial MachineDeployment.
+// Only insufficient quotas are reported as errors. If the quotas cannot be determined in time
+// (e.g. because of missing permissions or a slow API), a warning is returned instead, as this
+// must not prevent the cluster from being created; the initial-machinedeployment-controller
+// checks the capacity again before creating the machines.
+func ValidateClusterCapacity(ctx context.Context, cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, cloudProvider provider.CloudProvider) (string, *field.Error) {
+	checker, ok := cloudProvider.(provider.CapacityChecker)
+	if !ok || validateDatacenterMatchesProvider(cluster.Spec.Cloud, dc) != nil {
+		return "", nil
+	}
+
+	request := provider.CapacityRequest{}
+
+	annotationPath := field.NewPath("metadata", "annotations").Key(kubermaticv1.InitialMachineDeploymentRequestAnnotation)
+	if encoded := cluster.Annotations[kubermaticv1.InitialMachineDeploymentRequestAnnotation]; encoded != "" {
+		md := &clusterv1alpha1.MachineDeployment{}
+		if err := json.Unmarshal([]byte(encoded), md); err != nil {
+			return "", field.Invalid(annotationPath, "<redacted>", fmt.Sprintf("failed to decode MachineDeployment: %v", err))
+		}
+
+		// the user cluster does not exist yet, so fields referencing
+		// Secrets or ConfigMaps in it cannot be checked
+		var err error
+		if request, err = provider.NewCapacityRequest(ctx, md, nil); err != nil {
+			return "", field.Invalid(annotationPath, "<redacted>", err.Error())
+		}
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, capacityCheckTimeout)
+	defer cancel()
+
+	err := checker.CheckCapacity(checkCtx, cluster.Spec.Cloud, request)
+	switch {
+	case err == nil:
+		return "", nil
+	case provider.IsInsufficientQuotaError(err):
+		return "", field.Forbidden(field.NewPath("spec", "cloud"), fmt.Sprintf("the cloud account does not have enough capacity left for the cluster: %v", err))
+	default:
+		return fmt.Sprintf("could not verify that the cloud account has enough capacity left for the cluster: %v", err), nil
+	}
+}
+
 // ValidateClusterUpdate validates the new cluster and if no forbidden changes were attempted.
 func ValidateClusterUpdate(ctx context.Context, newCluster, oldCluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, cloudProvider provider.CloudProvider, versionManager *version.Manager, features features.FeatureGate) field.ErrorList {
 	specPath := field.NewPath("spec")
@@ -268,6 +494,12 @@ func ValidateClusterUpdate(ctx context.Context, newCluster, oldCluster *kubermat
 		}
 	}
 
+	allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
+		newCluster.Spec.IsPrivateCluster(),
+		oldCluster.Spec.IsPrivateCluster(),
+		specPath.Child("privateCluster", "enabled"),
+	)...)
+
 	if oldCluster.Spec.ComponentsOverride.Apiserver.NodePortRange != "" {
 		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(
 			newCluster.Spec.ComponentsOverride.Apiserver.NodePortRange,
@@ -512,6 +744,47 @@ func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *
 	return allErrs
 }
 
+func validateAuthenticationConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.AuthenticationConfiguration == nil {
+		return allErrs
+	}
+
+	if !checkVersionConstraint(spec.Version.Semver(), ">= 1.30") {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "structured authentication requires Kubernetes 1.30 or newer"))
+	}
+
+	if spec.OIDC.IssuerURL != "" || spec.OIDC.ClientID != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "cannot be combined with the 'oidc' settings, configure the issuer as a JWT authenticator instead"))
+	}
+
+	if len(spec.AuthenticationConfiguration.JWT) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("jwt"), "at least one JWT authenticator must be configured"))
+		return allErrs
+	}
+
+	// convert the configuration into the internal kube-apiserver types and let
+	// the upstream validation check the issuers, audiences and CEL expressions
+	versioned := apiserver.AuthenticationConfiguration(spec.AuthenticationConfiguration)
+	internal := &apiserverconfig.AuthenticationConfiguration{}
+	if err := apiserverconfigv1beta1.Convert_v1beta1_AuthenticationConfiguration_To_apiserver_AuthenticationConfiguration(versioned, internal, nil); err != nil {
+		return append(allErrs, field.Invalid(fieldPath, spec.AuthenticationConfiguration, err.Error()))
+	}
+
+	var disallowedIssuers []string
+	if spec.ServiceAccount != nil && spec.ServiceAccount.Issuer != "" {
+		disallowedIssuers = append(disallowedIssuers, spec.ServiceAccount.Issuer)
+	}
+
+	for _, err := range apiserverconfigvalidation.ValidateAuthenticationConfiguration(internal, disallowedIssuers) {
+		err.Field = fieldPath.String() + "." + err.Field
+		allErrs = append(allErrs, err)
+	}
+
+	return allErrs
+}
+
 // validateKeyLength base64 decodes key and checks length.
 func validateKeyLength(key string) error {
 	data, err := base64.StdEncoding.DecodeString(key)
@@ -907,7 +1180,7 @@ func validateHetznerCloudSpec(spec *kubermaticv1.HetznerCloudSpec) error {
 		}
 	}
 
-	return nil
+	return spec.NodePortsAllowedIPRanges.Validate()
 }
 
 func validatePacketCloudSpec(spec *kubermaticv1.PacketCloudSpec) error {
@@ -1036,7 +1309,7 @@ func validateDigitaloceanCloudSpec(spec *kubermaticv1.DigitaloceanCloudSpec) err
 		}
 	}
 
-	return nil
+	return spec.NodePortsAllowedIPRanges.Validate()
 }
 
 func validateFakeCloudSpec(spec *kubermaticv1.FakeCloudSpec) error {
@@ -1199,6 +1472,54 @@ func ValidateLeaderElectionSettings(l *kubermaticv1.LeaderElectionSettings, fldP
 	return allErrs
 }
 
+// validateGatewayExposeStrategy ensures that clusters using the Gateway expose strategy
+// use Konnectivity, as the control plane Gateway has no route for OpenVPN.
+func validateGatewayExposeStrategy(spec *kubermaticv1.ClusterSpec, fldPath *field.Path) *field.Error {
+	if spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway {
+		return nil
+	}
+
+	if spec.ClusterNetwork.KonnectivityEnabled == nil || !*spec.ClusterNetwork.KonnectivityEnabled { //nolint:staticcheck
+		return field.Forbidden(fldPath, "the Gateway expose strategy requires Konnectivity to be enabled")
+	}
+
+	return nil
+}
+
+func validateEtcdSettings(etcd *kubermaticv1.EtcdStatefulSetSettings, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if quota := etcd.QuotaBackendBytes; quota != nil {
+		if quota.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("quotaBackendBytes"), quota.String(), "quota must not be negative"))
+		} else if quota.Cmp(maxEtcdQuotaBackendBytes) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("quotaBackendBytes"), quota.String(), fmt.Sprintf("quota must not exceed %s", maxEtcdQuotaBackendBytes.String())))
+		}
+	}
+
+	return allErrs
+}
+
+func validateControlPlaneTopology(topology *kubermaticv1.ControlPlaneTopologySettings, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if topology == nil {
+		return allErrs
+	}
+
+	switch topology.ZoneSpread {
+	case "", kubermaticv1.AntiAffinityTypePreferred, kubermaticv1.AntiAffinityTypeRequired:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("zoneSpread"), topology.ZoneSpread, []string{kubermaticv1.AntiAffinityTypePreferred, kubermaticv1.AntiAffinityTypeRequired}))
+	}
+
+	if topology.MaxSkew != nil && *topology.MaxSkew < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSkew"), *topology.MaxSkew, "max skew must be at least 1"))
+	}
+
+	return allErrs
+}
+
 func ValidateNodePortRange(nodePortRange string, fldPath *field.Path) *field.Error {
 	if nodePortRange == "" {
 		return field.Required(fldPath, "node port range is required")
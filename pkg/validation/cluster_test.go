@@ -23,6 +23,7 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	semverlib "github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
@@ -30,9 +31,12 @@ import (
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/fake"
 	"k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/version"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 )
@@ -1411,3 +1415,548 @@ func TestValidateCoreDNSReplicas(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateClusterCapacity(t *testing.T) {
+	fakeDC := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Fake: &kubermaticv1.DatacenterSpecFake{},
+		},
+	}
+
+	genCluster := func(replicas int) *kubermaticv1.Cluster {
+		cluster := &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				Cloud: kubermaticv1.CloudSpec{
+					ProviderName: string(kubermaticv1.FakeCloudProvider),
+					Fake:         &kubermaticv1.FakeCloudSpec{},
+				},
+			},
+		}
+
+		if replicas > 0 {
+			cluster.Annotations = map[string]string{
+				kubermaticv1.InitialMachineDeploymentRequestAnnotation: fmt.Sprintf(`{"spec":{"replicas":%d,"template":{"spec":{"providerSpec":{"value":{"cloudProvider":"fake"}}}}}}`, replicas),
+			}
+		}
+
+		return cluster
+	}
+
+	testCases := []struct {
+		name          string
+		cluster       *kubermaticv1.Cluster
+		cloudProvider provider.CloudProvider
+		valid         bool
+		warning       bool
+	}{
+		{
+			name:          "no initial MachineDeployment",
+			cluster:       genCluster(0),
+			cloudProvider: fake.NewCloudProviderWithQuota(0),
+			valid:         true,
+		},
+		{
+			name:          "initial MachineDeployment fits into the quota",
+			cluster:       genCluster(3),
+			cloudProvider: fake.NewCloudProviderWithQuota(3),
+			valid:         true,
+		},
+		{
+			name:          "initial MachineDeployment exceeds the quota",
+			cluster:       genCluster(4),
+			cloudProvider: fake.NewCloudProviderWithQuota(3),
+			valid:         false,
+		},
+		{
+			name:          "unresponsive provider only causes a warning",
+			cluster:       genCluster(3),
+			cloudProvider: fake.NewUnresponsiveCloudProvider(),
+			valid:         true,
+			warning:       true,
+		},
+	}
+
+	capacityCheckTimeout = 10 * time.Millisecond
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			warning, err := ValidateClusterCapacity(context.Background(), test.cluster, fakeDC, test.cloudProvider)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got error %v", test.valid, err)
+			}
+			if (warning != "") != test.warning {
+				t.Errorf("Expected warning=%v, got %q", test.warning, warning)
+			}
+		})
+	}
+}
+
+func TestValidateResourceTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider kubermaticv1.ProviderType
+		tags     map[string]string
+		valid    bool
+	}{
+		{
+			name:  "no tags",
+			valid: true,
+		},
+		{
+			name:  "valid tags for all providers",
+			tags:  map[string]string{"env": "prod", "cost-center": ""},
+			valid: true,
+		},
+		{
+			name:  "empty key",
+			tags:  map[string]string{"": "value"},
+			valid: false,
+		},
+		{
+			name:     "AWS: key too long",
+			provider: kubermaticv1.AWSCloudProvider,
+			tags:     map[string]string{strings.Repeat("k", 129): "value"},
+			valid:    false,
+		},
+		{
+			name:     "AWS: value too long",
+			provider: kubermaticv1.AWSCloudProvider,
+			tags:     map[string]string{"key": strings.Repeat("v", 257)},
+			valid:    false,
+		},
+		{
+			name:     "AWS: uppercase and long values are allowed",
+			provider: kubermaticv1.AWSCloudProvider,
+			tags:     map[string]string{"CostCenter": strings.Repeat("V", 100)},
+			valid:    true,
+		},
+		{
+			name:     "GCP: uppercase key",
+			provider: kubermaticv1.GCPCloudProvider,
+			tags:     map[string]string{"CostCenter": "abc"},
+			valid:    false,
+		},
+		{
+			name:     "GCP: key starting with a digit",
+			provider: kubermaticv1.GCPCloudProvider,
+			tags:     map[string]string{"1env": "prod"},
+			valid:    false,
+		},
+		{
+			name:     "GCP: value too long",
+			provider: kubermaticv1.GCPCloudProvider,
+			tags:     map[string]string{"env": strings.Repeat("v", 64)},
+			valid:    false,
+		},
+		{
+			name:     "Hetzner: value with invalid characters",
+			provider: kubermaticv1.HetznerCloudProvider,
+			tags:     map[string]string{"owner": "jane.doe@example.com"},
+			valid:    false,
+		},
+		{
+			name:     "Hetzner: valid labels",
+			provider: kubermaticv1.HetznerCloudProvider,
+			tags:     map[string]string{"cost_center": "team-a"},
+			valid:    true,
+		},
+		{
+			name:     "OpenStack: key containing a '='",
+			provider: kubermaticv1.OpenstackCloudProvider,
+			tags:     map[string]string{"a=b": "value"},
+			valid:    false,
+		},
+		{
+			name:  "no provider: tags must be valid for all providers",
+			tags:  map[string]string{"CostCenter": "abc"},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateResourceTags(test.provider, test.tags, field.NewPath("resourceTags"))
+
+			if (len(errs) == 0) != test.valid {
+				t.Errorf("Expected valid=%v, got %v", test.valid, errs)
+			}
+		})
+	}
+}
+
+func TestValidatePrivateCluster(t *testing.T) {
+	privateDC := &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			Azure: &kubermaticv1.DatacenterSpecAzure{
+				PrivateLinkServiceID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateLinkServices/seed",
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		spec  *kubermaticv1.ClusterSpec
+		dc    *kubermaticv1.Datacenter
+		valid bool
+	}{
+		{
+			name: "public cluster",
+			spec: &kubermaticv1.ClusterSpec{
+				ExposeStrategy: kubermaticv1.ExposeStrategyTunneling,
+				Cloud:          kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+			},
+			dc:    &kubermaticv1.Datacenter{},
+			valid: true,
+		},
+		{
+			name: "private Azure cluster",
+			spec: &kubermaticv1.ClusterSpec{
+				ExposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+				Cloud:          kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+				PrivateCluster: &kubermaticv1.PrivateClusterSettings{Enabled: true},
+			},
+			dc:    privateDC,
+			valid: true,
+		},
+		{
+			name: "private cluster with Tunneling expose strategy",
+			spec: &kubermaticv1.ClusterSpec{
+				ExposeStrategy: kubermaticv1.ExposeStrategyTunneling,
+				Cloud:          kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+				PrivateCluster: &kubermaticv1.PrivateClusterSettings{Enabled: true},
+			},
+			dc:    privateDC,
+			valid: false,
+		},
+		{
+			name: "private cluster in datacenter without private link service",
+			spec: &kubermaticv1.ClusterSpec{
+				ExposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+				Cloud:          kubermaticv1.CloudSpec{Azure: &kubermaticv1.AzureCloudSpec{}},
+				PrivateCluster: &kubermaticv1.PrivateClusterSettings{Enabled: true},
+			},
+			dc: &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{Azure: &kubermaticv1.DatacenterSpecAzure{}},
+			},
+			valid: false,
+		},
+		{
+			name: "private cluster on unsupported provider",
+			spec: &kubermaticv1.ClusterSpec{
+				ExposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+				Cloud:          kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+				PrivateCluster: &kubermaticv1.PrivateClusterSettings{Enabled: true},
+			},
+			dc:    &kubermaticv1.Datacenter{},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validatePrivateCluster(test.spec, test.dc, field.NewPath("privateCluster"))
+
+			if (len(errs) == 0) != test.valid {
+				t.Errorf("Expected valid=%v, got %v", test.valid, errs)
+			}
+		})
+	}
+}
+
+func TestValidateAuthenticationConfiguration(t *testing.T) {
+	corporateIdP := kubermaticv1.JWTAuthenticator{
+		Issuer: kubermaticv1.JWTIssuer{
+			URL:       "https://idp.example.com",
+			Audiences: []string{"kubernetes"},
+		},
+		ClaimMappings: kubermaticv1.JWTClaimMappings{
+			Username: kubermaticv1.JWTPrefixedClaimOrExpression{Claim: "sub", Prefix: ptr.To("idp:")},
+			Groups:   kubermaticv1.JWTPrefixedClaimOrExpression{Claim: "groups", Prefix: ptr.To("idp:")},
+		},
+	}
+
+	ciIssuer := kubermaticv1.JWTAuthenticator{
+		Issuer: kubermaticv1.JWTIssuer{
+			URL:       "https://token.ci.example.com",
+			Audiences: []string{"kubernetes", "ci"},
+
+			AudienceMatchPolicy: "MatchAny",
+		},
+		ClaimValidationRules: []kubermaticv1.JWTClaimValidationRule{
+			{Expression: "claims.repository_owner == 'example'", Message: "only example repositories are allowed"},
+		},
+		ClaimMappings: kubermaticv1.JWTClaimMappings{
+			Username: kubermaticv1.JWTPrefixedClaimOrExpression{Expression: "'ci:' + claims.sub"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		spec  *kubermaticv1.ClusterSpec
+		valid bool
+	}{
+		{
+			name: "no authentication configuration",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.29.4"),
+			},
+			valid: true,
+		},
+		{
+			name: "multiple issuers",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.30.5"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{corporateIdP, ciIssuer},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "unsupported Kubernetes version",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.29.4"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{corporateIdP},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "combined with OIDC settings",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.30.5"),
+				OIDC: kubermaticv1.OIDCSettings{
+					IssuerURL: "https://dex.example.com",
+					ClientID:  "kubernetes",
+				},
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{corporateIdP},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "no authenticators",
+			spec: &kubermaticv1.ClusterSpec{
+				Version:                     *semver.NewSemverOrDie("1.30.5"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{},
+			},
+			valid: false,
+		},
+		{
+			name: "duplicate issuers",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.30.5"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{corporateIdP, corporateIdP},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "insecure issuer URL",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.30.5"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{
+						{
+							Issuer: kubermaticv1.JWTIssuer{
+								URL:       "http://idp.example.com",
+								Audiences: []string{"kubernetes"},
+							},
+							ClaimMappings: corporateIdP.ClaimMappings,
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "claim and expression for the username",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.30.5"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{
+						{
+							Issuer: corporateIdP.Issuer,
+							ClaimMappings: kubermaticv1.JWTClaimMappings{
+								Username: kubermaticv1.JWTPrefixedClaimOrExpression{Claim: "sub", Expression: "claims.sub"},
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid CEL expression",
+			spec: &kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.30.5"),
+				AuthenticationConfiguration: &kubermaticv1.AuthenticationConfiguration{
+					JWT: []kubermaticv1.JWTAuthenticator{
+						{
+							Issuer:        corporateIdP.Issuer,
+							ClaimMappings: corporateIdP.ClaimMappings,
+							UserValidationRules: []kubermaticv1.JWTUserValidationRule{
+								{Expression: "!user.username.startsWith("},
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateAuthenticationConfiguration(test.spec, field.NewPath("spec", "authenticationConfiguration"))
+
+			if (len(errs) == 0) != test.valid {
+				t.Errorf("Expected valid=%v, got %v", test.valid, errs)
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneTopology(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology *kubermaticv1.ControlPlaneTopologySettings
+		wantErr  bool
+	}{
+		{
+			name:     "no topology",
+			topology: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "defaulted topology",
+			topology: &kubermaticv1.ControlPlaneTopologySettings{},
+			wantErr:  false,
+		},
+		{
+			name: "required zone spread",
+			topology: &kubermaticv1.ControlPlaneTopologySettings{
+				ZoneSpread: kubermaticv1.AntiAffinityTypeRequired,
+				MaxSkew:    ptr.To[int32](2),
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown zone spread",
+			topology: &kubermaticv1.ControlPlaneTopologySettings{
+				ZoneSpread: "always",
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero max skew",
+			topology: &kubermaticv1.ControlPlaneTopologySettings{
+				MaxSkew: ptr.To[int32](0),
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateControlPlaneTopology(test.topology, field.NewPath("spec", "controlPlaneTopology"))
+
+			if test.wantErr == (len(errs) == 0) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateEtcdSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		quota   *resource.Quantity
+		wantErr bool
+	}{
+		{
+			name:    "no quota",
+			quota:   nil,
+			wantErr: false,
+		},
+		{
+			name:    "valid quota",
+			quota:   ptr.To(resource.MustParse("4Gi")),
+			wantErr: false,
+		},
+		{
+			name:    "maximum quota",
+			quota:   ptr.To(resource.MustParse("8Gi")),
+			wantErr: false,
+		},
+		{
+			name:    "quota too large",
+			quota:   ptr.To(resource.MustParse("9Gi")),
+			wantErr: true,
+		},
+		{
+			name:    "negative quota",
+			quota:   ptr.To(resource.MustParse("-1Gi")),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := &kubermaticv1.EtcdStatefulSetSettings{QuotaBackendBytes: test.quota}
+			errs := validateEtcdSettings(settings, field.NewPath("spec", "componentsOverride", "etcd"))
+
+			if test.wantErr == (len(errs) == 0) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateGatewayExposeStrategy(t *testing.T) {
+	tests := []struct {
+		name                string
+		exposeStrategy      kubermaticv1.ExposeStrategy
+		konnectivityEnabled *bool
+		wantErr             bool
+	}{
+		{
+			name:           "NodePort without Konnectivity",
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			wantErr:        false,
+		},
+		{
+			name:                "Gateway with Konnectivity",
+			exposeStrategy:      kubermaticv1.ExposeStrategyGateway,
+			konnectivityEnabled: ptr.To(true),
+			wantErr:             false,
+		},
+		{
+			name:                "Gateway with OpenVPN",
+			exposeStrategy:      kubermaticv1.ExposeStrategyGateway,
+			konnectivityEnabled: ptr.To(false),
+			wantErr:             true,
+		},
+		{
+			name:           "Gateway without network configuration",
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			wantErr:        true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := &kubermaticv1.ClusterSpec{
+				ExposeStrategy: test.exposeStrategy,
+				ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+					KonnectivityEnabled: test.konnectivityEnabled, //nolint:staticcheck
+				},
+			}
+
+			err := validateGatewayExposeStrategy(spec, field.NewPath("spec", "exposeStrategy"))
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	semverlib "github.com/Masterminds/semver/v3"
 
@@ -39,6 +40,40 @@ func ValidateKubermaticConfigurationSpec(spec *kubermaticv1.KubermaticConfigurat
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateControlPlaneSizingConfiguration(spec.UserCluster.ControlPlaneSizing, field.NewPath("spec", "userCluster", "controlPlaneSizing")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
+}
+
+func ValidateControlPlaneSizingConfiguration(config *kubermaticv1.ControlPlaneSizingConfiguration, parentFieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config == nil {
+		return allErrs
+	}
+
+	if len(config.Profiles) == 0 {
+		allErrs = append(allErrs, field.Required(parentFieldPath.Child("profiles"), "at least one profile must be configured"))
+	}
+
+	if config.Interval != nil && config.Interval.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(parentFieldPath.Child("interval"), config.Interval.Duration.String(), "interval must be at least 1m"))
+	}
+
+	names := sets.New[string]()
+	for i, profile := range config.Profiles {
+		fieldPath := parentFieldPath.Child("profiles").Index(i)
+
+		if profile.Name == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("name"), "profile name must be set"))
+		} else if names.Has(profile.Name) {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Child("name"), profile.Name))
+		}
+		names.Insert(profile.Name)
+	}
+
 	return allErrs
 }
 
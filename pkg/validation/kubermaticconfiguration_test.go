@@ -18,10 +18,13 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/semver"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 )
 
@@ -212,3 +215,61 @@ func TestValidateKubermaticConfigurationVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateControlPlaneSizingConfiguration(t *testing.T) {
+	testcases := []struct {
+		name   string
+		config *kubermaticv1.ControlPlaneSizingConfiguration
+		valid  bool
+	}{
+		{
+			name:  "no sizing configured",
+			valid: true,
+		},
+		{
+			name: "valid profiles",
+			config: &kubermaticv1.ControlPlaneSizingConfiguration{
+				Profiles: []kubermaticv1.ControlPlaneSizingProfile{
+					{Name: "small"},
+					{Name: "large", MinNodes: 50},
+				},
+			},
+			valid: true,
+		},
+		{
+			name:   "no profiles",
+			config: &kubermaticv1.ControlPlaneSizingConfiguration{},
+			valid:  false,
+		},
+		{
+			name: "duplicate profile names",
+			config: &kubermaticv1.ControlPlaneSizingConfiguration{
+				Profiles: []kubermaticv1.ControlPlaneSizingProfile{
+					{Name: "small"},
+					{Name: "small", MinNodes: 50},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "interval too short",
+			config: &kubermaticv1.ControlPlaneSizingConfiguration{
+				Interval: &metav1.Duration{Duration: 10 * time.Second},
+				Profiles: []kubermaticv1.ControlPlaneSizingProfile{
+					{Name: "small"},
+				},
+			},
+			valid: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateControlPlaneSizingConfiguration(tc.config, field.NewPath("spec"))
+
+			if tc.valid != (len(errs) == 0) {
+				t.Fatalf("Expected valid=%v, but got errors: %v", tc.valid, errs)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package validation
 
 import (
+	"time"
+
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -41,7 +43,13 @@ func ValidateUserSSHKey(key *kubermaticv1.UserSSHKey) field.ErrorList {
 }
 
 func ValidateUserSSHKeyCreate(key *kubermaticv1.UserSSHKey) field.ErrorList {
-	return ValidateUserSSHKey(key)
+	allErrs := ValidateUserSSHKey(key)
+
+	if err := validateUserSSHKeyExpiry(key, time.Now()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	return allErrs
 }
 
 func ValidateUserSSHKeyUpdate(oldKey, newKey *kubermaticv1.UserSSHKey) field.ErrorList {
@@ -51,5 +59,21 @@ func ValidateUserSSHKeyUpdate(oldKey, newKey *kubermaticv1.UserSSHKey) field.Err
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "project"), newKey.Spec.Project, "this field is immutable"))
 	}
 
+	// Keys that have already expired must remain updatable (e.g. when a cluster is removed
+	// from them), so the expiry date is only checked when it is being changed.
+	if !oldKey.Spec.ExpiresAt.Equal(newKey.Spec.ExpiresAt) {
+		if err := validateUserSSHKeyExpiry(newKey, time.Now()); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
 	return allErrs
 }
+
+func validateUserSSHKeyExpiry(key *kubermaticv1.UserSSHKey, now time.Time) *field.Error {
+	if key.IsExpired(now) {
+		return field.Invalid(field.NewPath("spec", "expiresAt"), key.Spec.ExpiresAt.Format(time.RFC3339), "expiry date must be in the future")
+	}
+
+	return nil
+}
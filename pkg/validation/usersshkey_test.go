@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateUserSSHKeyExpiry(t *testing.T) {
+	past := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	future := &metav1.Time{Time: time.Now().Add(time.Hour)}
+
+	newKey := func(expiresAt *metav1.Time) *kubermaticv1.UserSSHKey {
+		return &kubermaticv1.UserSSHKey{
+			Spec: kubermaticv1.SSHKeySpec{
+				Project:     "my-project",
+				Fingerprint: "fingerprint",
+				PublicKey:   "ssh-ed25519 AAAA",
+				ExpiresAt:   expiresAt,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name   string
+		oldKey *kubermaticv1.UserSSHKey
+		newKey *kubermaticv1.UserSSHKey
+		valid  bool
+	}{
+		{
+			name:   "create without expiry",
+			newKey: newKey(nil),
+			valid:  true,
+		},
+		{
+			name:   "create with future expiry",
+			newKey: newKey(future),
+			valid:  true,
+		},
+		{
+			name:   "create with past expiry",
+			newKey: newKey(past),
+			valid:  false,
+		},
+		{
+			name:   "update an already expired key",
+			oldKey: newKey(past),
+			newKey: newKey(past),
+			valid:  true,
+		},
+		{
+			name:   "extend an expired key",
+			oldKey: newKey(past),
+			newKey: newKey(future),
+			valid:  true,
+		},
+		{
+			name:   "set expiry to the past",
+			oldKey: newKey(future),
+			newKey: newKey(past),
+			valid:  false,
+		},
+		{
+			name:   "remove expiry",
+			oldKey: newKey(past),
+			newKey: newKey(nil),
+			valid:  true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs field.ErrorList
+			if tc.oldKey == nil {
+				errs = ValidateUserSSHKeyCreate(tc.newKey)
+			} else {
+				errs = ValidateUserSSHKeyUpdate(tc.oldKey, tc.newKey)
+			}
+
+			if tc.valid && len(errs) > 0 {
+				t.Fatalf("Expected key to be valid, but got errors: %v", errs)
+			}
+
+			if !tc.valid && len(errs) == 0 {
+				t.Fatal("Expected key to be invalid, but got no errors.")
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud"
+	"k8c.io/kubermatic/v2/pkg/resources/controlplanegateway"
 	"k8c.io/kubermatic/v2/pkg/validation"
 	"k8c.io/kubermatic/v2/pkg/version"
 
@@ -81,7 +82,7 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, fmt.Errorf("cluster name exceeds maximum allowed length of %d characters", validation.MaxClusterNameLength)
 	}
 
-	datacenter, cloudProvider, err := v.buildValidationDependencies(ctx, cluster)
+	seed, datacenter, cloudProvider, err := v.buildValidationDependencies(ctx, cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -95,11 +96,29 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 
 	errs := validation.ValidateNewClusterSpec(ctx, &cluster.Spec, datacenter, cloudProvider, versionManager, v.features, nil)
 
+	// only spend the time to query the provider's quotas if the cluster is valid otherwise
+	var warnings admission.Warnings
+	if len(errs) == 0 {
+		warning, err := validation.ValidateClusterCapacity(ctx, cluster, datacenter, cloudProvider)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	errs = append(errs, validateSeedFeatures(seed, cluster, nil)...)
+
+	if err := v.validateControlPlaneGatewayCapacity(ctx, cluster, nil); err != nil {
+		errs = append(errs, err)
+	}
+
 	if err := v.validateProjectRelation(ctx, cluster, nil); err != nil {
 		errs = append(errs, err)
 	}
 
-	return nil, errs.ToAggregate()
+	return warnings, errs.ToAggregate()
 }
 
 func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -113,7 +132,7 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 		return nil, errors.New("new object is not a Cluster")
 	}
 
-	datacenter, cloudProvider, err := v.buildValidationDependencies(ctx, newCluster)
+	seed, datacenter, cloudProvider, err := v.buildValidationDependencies(ctx, newCluster)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +146,12 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 
 	errs := validation.ValidateClusterUpdate(ctx, newCluster, oldCluster, datacenter, cloudProvider, updateManager, v.features)
 
+	errs = append(errs, validateSeedFeatures(seed, newCluster, oldCluster)...)
+
+	if err := v.validateControlPlaneGatewayCapacity(ctx, newCluster, oldCluster); err != nil {
+		errs = append(errs, err)
+	}
+
 	if err := v.validateProjectRelation(ctx, newCluster, oldCluster); err != nil {
 		errs = append(errs, err)
 	}
@@ -138,31 +163,91 @@ func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (adm
 	return nil, nil
 }
 
-func (v *validator) buildValidationDependencies(ctx context.Context, c *kubermaticv1.Cluster) (*kubermaticv1.Datacenter, provider.CloudProvider, *field.Error) {
+func (v *validator) buildValidationDependencies(ctx context.Context, c *kubermaticv1.Cluster) (*kubermaticv1.Seed, *kubermaticv1.Datacenter, provider.CloudProvider, *field.Error) {
 	seed, err := v.seedGetter()
 	if err != nil {
-		return nil, nil, field.InternalError(nil, err)
+		return nil, nil, nil, field.InternalError(nil, err)
 	}
 	if seed == nil {
-		return nil, nil, field.InternalError(nil, errors.New("webhook is not configured with -seed-name, cannot validate Clusters"))
+		return nil, nil, nil, field.InternalError(nil, errors.New("webhook is not configured with -seed-name, cannot validate Clusters"))
 	}
 
 	datacenter, fieldErr := defaulting.DatacenterForClusterSpec(&c.Spec, seed)
 	if fieldErr != nil {
-		return nil, nil, fieldErr
+		return nil, nil, nil, fieldErr
 	}
 
 	if v.disableProviderValidation {
-		return datacenter, nil, nil
+		return seed, datacenter, nil, nil
 	}
 
 	secretKeySelectorFunc := provider.SecretKeySelectorValueFuncFactory(ctx, v.client)
 	cloudProvider, err := cloud.Provider(datacenter, secretKeySelectorFunc, v.caBundle)
 	if err != nil {
-		return nil, nil, field.InternalError(nil, err)
+		return nil, nil, nil, field.InternalError(nil, err)
 	}
 
-	return datacenter, cloudProvider, nil
+	return seed, datacenter, cloudProvider, nil
+}
+
+// validateSeedFeatures ensures that a cluster only enables features that the seed it
+// is running on supports.
+func validateSeedFeatures(seed *kubermaticv1.Seed, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// only forbid enabling tracing, clusters that already have it enabled must remain updatable
+	tracingEnabled := cluster.Spec.MLA != nil && cluster.Spec.MLA.TracingEnabled
+	tracingWasEnabled := oldCluster != nil && oldCluster.Spec.MLA != nil && oldCluster.Spec.MLA.TracingEnabled
+	if tracingEnabled && !tracingWasEnabled && !seed.IsUserClusterTracingEnabled() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "mla", "tracingEnabled"), "the seed does not provide a tracing backend"))
+	}
+
+	// private clusters are only reachable via the private nodeport-proxy LoadBalancer
+	if cluster.Spec.IsPrivateCluster() && oldCluster == nil && seed.Spec.NodeportProxy.PrivateLoadBalancerService == nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "privateCluster", "enabled"), "the seed has no private nodeport-proxy LoadBalancer configured"))
+	}
+
+	// only forbid switching to the Gateway expose strategy, existing clusters must remain updatable
+	if isNewGatewayCluster(cluster, oldCluster) && seed.Spec.ControlPlaneGateway == nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "exposeStrategy"), "the seed has no control plane Gateway configured"))
+	}
+
+	return allErrs
+}
+
+// isNewGatewayCluster returns true if the cluster is created with or switched to the
+// Gateway expose strategy.
+func isNewGatewayCluster(cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway &&
+		(oldCluster == nil || oldCluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway)
+}
+
+// validateControlPlaneGatewayCapacity ensures that no more clusters use the Gateway
+// expose strategy than the control plane Gateway has listeners for.
+func (v *validator) validateControlPlaneGatewayCapacity(ctx context.Context, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) *field.Error {
+	if !isNewGatewayCluster(cluster, oldCluster) {
+		return nil
+	}
+
+	fieldPath := field.NewPath("spec", "exposeStrategy")
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := v.client.List(ctx, clusters); err != nil {
+		return field.InternalError(fieldPath, fmt.Errorf("failed to list clusters: %w", err))
+	}
+
+	gatewayClusters := 0
+	for _, c := range clusters.Items {
+		if c.Name != cluster.Name && c.DeletionTimestamp == nil && c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+			gatewayClusters++
+		}
+	}
+
+	if gatewayClusters >= controlplanegateway.MaxClusters {
+		return field.Forbidden(fieldPath, fmt.Sprintf("the control plane Gateway already exposes the maximum of %d clusters", controlplanegateway.MaxClusters))
+	}
+
+	return nil
 }
 
 func (v *validator) validateProjectRelation(ctx context.Context, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) *field.Error {
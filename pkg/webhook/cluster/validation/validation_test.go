@@ -19,12 +19,14 @@ package validation
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/defaulting"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/controlplanegateway"
 	"k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/test"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
@@ -34,6 +36,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -1774,3 +1777,211 @@ func (r rawClusterGen) Do() []byte {
 	_ = s.Encode(&c, buff)
 	return buff.Bytes()
 }
+
+func TestValidateSeedFeatures(t *testing.T) {
+	tracingCluster := func(enabled bool) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				MLA: &kubermaticv1.MLASettings{TracingEnabled: enabled},
+			},
+		}
+	}
+
+	tracingSeed := func(enabled bool) *kubermaticv1.Seed {
+		return &kubermaticv1.Seed{
+			Spec: kubermaticv1.SeedSpec{
+				MLA: &kubermaticv1.SeedMLASettings{
+					UserClusterMLAEnabled:     true,
+					UserClusterTracingEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	privateCluster := func() *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				PrivateCluster: &kubermaticv1.PrivateClusterSettings{Enabled: true},
+			},
+		}
+	}
+
+	privateSeed := func(withLoadBalancer bool) *kubermaticv1.Seed {
+		seed := &kubermaticv1.Seed{}
+		if withLoadBalancer {
+			seed.Spec.NodeportProxy.PrivateLoadBalancerService = &kubermaticv1.EnvoyLoadBalancerService{}
+		}
+		return seed
+	}
+
+	exposeStrategyCluster := func(exposeStrategy kubermaticv1.ExposeStrategy) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				ExposeStrategy: exposeStrategy,
+			},
+		}
+	}
+
+	gatewaySeed := func(withGateway bool) *kubermaticv1.Seed {
+		seed := &kubermaticv1.Seed{}
+		if withGateway {
+			seed.Spec.ControlPlaneGateway = &kubermaticv1.ControlPlaneGatewayConfig{}
+		}
+		return seed
+	}
+
+	tests := []struct {
+		name       string
+		seed       *kubermaticv1.Seed
+		cluster    *kubermaticv1.Cluster
+		oldCluster *kubermaticv1.Cluster
+		wantErr    bool
+	}{
+		{
+			name:    "tracing disabled",
+			seed:    tracingSeed(false),
+			cluster: tracingCluster(false),
+		},
+		{
+			name:    "tracing enabled with a tracing backend",
+			seed:    tracingSeed(true),
+			cluster: tracingCluster(true),
+		},
+		{
+			name:    "tracing enabled without a tracing backend",
+			seed:    tracingSeed(false),
+			cluster: tracingCluster(true),
+			wantErr: true,
+		},
+		{
+			name:       "tracing newly enabled without a tracing backend",
+			seed:       tracingSeed(false),
+			cluster:    tracingCluster(true),
+			oldCluster: tracingCluster(false),
+			wantErr:    true,
+		},
+		{
+			name:       "tracing was already enabled before the backend was removed",
+			seed:       tracingSeed(false),
+			cluster:    tracingCluster(true),
+			oldCluster: tracingCluster(true),
+		},
+		{
+			name:    "private cluster on a seed with a private nodeport-proxy LoadBalancer",
+			seed:    privateSeed(true),
+			cluster: privateCluster(),
+		},
+		{
+			name:    "private cluster on a seed without a private nodeport-proxy LoadBalancer",
+			seed:    privateSeed(false),
+			cluster: privateCluster(),
+			wantErr: true,
+		},
+		{
+			name:    "Gateway cluster on a seed with a control plane Gateway",
+			seed:    gatewaySeed(true),
+			cluster: exposeStrategyCluster(kubermaticv1.ExposeStrategyGateway),
+		},
+		{
+			name:    "Gateway cluster on a seed without a control plane Gateway",
+			seed:    gatewaySeed(false),
+			cluster: exposeStrategyCluster(kubermaticv1.ExposeStrategyGateway),
+			wantErr: true,
+		},
+		{
+			name:       "switching to Gateway on a seed without a control plane Gateway",
+			seed:       gatewaySeed(false),
+			cluster:    exposeStrategyCluster(kubermaticv1.ExposeStrategyGateway),
+			oldCluster: exposeStrategyCluster(kubermaticv1.ExposeStrategyNodePort),
+			wantErr:    true,
+		},
+		{
+			name:       "Gateway cluster after the control plane Gateway was removed",
+			seed:       gatewaySeed(false),
+			cluster:    exposeStrategyCluster(kubermaticv1.ExposeStrategyGateway),
+			oldCluster: exposeStrategyCluster(kubermaticv1.ExposeStrategyGateway),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateSeedFeatures(tc.seed, tc.cluster, tc.oldCluster)
+			if tc.wantErr != (len(errs) > 0) {
+				t.Errorf("Want error: %t, but got: %v", tc.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneGatewayCapacity(t *testing.T) {
+	gatewayCluster := func(name string) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubermaticv1.ClusterSpec{
+				ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			},
+		}
+	}
+
+	gatewayClusters := func(count int) []ctrlruntimeclient.Object {
+		clusters := []ctrlruntimeclient.Object{}
+		for i := range count {
+			clusters = append(clusters, gatewayCluster(fmt.Sprintf("existing-%d", i)))
+		}
+		return clusters
+	}
+
+	nodePortCluster := gatewayCluster("new")
+	nodePortCluster.Spec.ExposeStrategy = kubermaticv1.ExposeStrategyNodePort
+
+	tests := []struct {
+		name       string
+		existing   []ctrlruntimeclient.Object
+		cluster    *kubermaticv1.Cluster
+		oldCluster *kubermaticv1.Cluster
+		wantErr    bool
+	}{
+		{
+			name:     "Gateway with free listeners",
+			existing: gatewayClusters(controlplanegateway.MaxClusters - 1),
+			cluster:  gatewayCluster("new"),
+		},
+		{
+			name:     "Gateway without free listeners",
+			existing: gatewayClusters(controlplanegateway.MaxClusters),
+			cluster:  gatewayCluster("new"),
+			wantErr:  true,
+		},
+		{
+			name:       "switching to Gateway without free listeners",
+			existing:   gatewayClusters(controlplanegateway.MaxClusters),
+			cluster:    gatewayCluster("new"),
+			oldCluster: nodePortCluster,
+			wantErr:    true,
+		},
+		{
+			name:       "updating an existing Gateway cluster",
+			existing:   gatewayClusters(controlplanegateway.MaxClusters),
+			cluster:    gatewayCluster("existing-0"),
+			oldCluster: gatewayCluster("existing-0"),
+		},
+		{
+			name:     "NodePort cluster",
+			existing: gatewayClusters(controlplanegateway.MaxClusters),
+			cluster:  nodePortCluster,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterValidator := validator{
+				client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tc.existing...).Build(),
+			}
+
+			err := clusterValidator.validateControlPlaneGatewayCapacity(context.Background(), tc.cluster, tc.oldCluster)
+			if tc.wantErr != (err != nil) {
+				t.Errorf("Want error: %t, but got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
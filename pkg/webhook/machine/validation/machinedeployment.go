@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// machineDeploymentValidator for validating MachineDeployments.
+type machineDeploymentValidator struct {
+	log *zap.SugaredLogger
+
+	// allowSpotInstances is false if the datacenter of the cluster does not allow spot instances.
+	allowSpotInstances bool
+}
+
+// NewMachineDeploymentValidator returns a new MachineDeployment validator.
+func NewMachineDeploymentValidator(log *zap.SugaredLogger, allowSpotInstances bool) *machineDeploymentValidator {
+	return &machineDeploymentValidator{
+		log:                log,
+		allowSpotInstances: allowSpotInstances,
+	}
+}
+
+var _ admission.CustomValidator = &machineDeploymentValidator{}
+
+func (v *machineDeploymentValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+func (v *machineDeploymentValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+func (v *machineDeploymentValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *machineDeploymentValidator) validate(obj runtime.Object) error {
+	md, ok := obj.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return errors.New("object is not a MachineDeployment")
+	}
+
+	v.log.Debugw("validating", "machinedeployment", md.Name)
+
+	return spot.ValidateProviderSpecAllowed(md.Spec.Template.Spec.ProviderSpec, v.allowSpotInstances)
+}
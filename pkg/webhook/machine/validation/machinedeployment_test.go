@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
+	aws "k8c.io/machine-controller/pkg/cloudprovider/provider/aws/types"
+	providerconfig "k8c.io/machine-controller/pkg/providerconfig/types"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func getMachineDeployment(t *testing.T, spotInstance bool) *clusterv1alpha1.MachineDeployment {
+	cloudProviderSpec, err := json.Marshal(&aws.RawConfig{IsSpotInstance: ptr.To(spotInstance)})
+	if err != nil {
+		t.Fatalf("Failed to encode cloud provider spec: %v", err)
+	}
+
+	config, err := json.Marshal(&providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderAWS,
+		CloudProviderSpec: runtime.RawExtension{Raw: cloudProviderSpec},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode provider config: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{}
+	md.Spec.Template.Spec.ProviderSpec = clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: config}}
+
+	return md
+}
+
+func TestMachineDeploymentValidator(t *testing.T) {
+	testCases := []struct {
+		name               string
+		spotInstance       bool
+		allowSpotInstances bool
+		errExpected        bool
+	}{
+		{
+			name:               "spot instances are accepted if the datacenter allows them",
+			spotInstance:       true,
+			allowSpotInstances: true,
+		},
+		{
+			name:               "spot instances are rejected if the datacenter forbids them",
+			spotInstance:       true,
+			allowSpotInstances: false,
+			errExpected:        true,
+		},
+		{
+			name:               "on-demand instances are always accepted",
+			spotInstance:       false,
+			allowSpotInstances: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			validator := NewMachineDeploymentValidator(zap.NewNop().Sugar(), tc.allowSpotInstances)
+			md := getMachineDeployment(t, tc.spotInstance)
+
+			if _, err := validator.ValidateCreate(ctx, md); (err != nil) != tc.errExpected {
+				t.Errorf("Expected create err=%v, got err=%v", tc.errExpected, err)
+			}
+
+			if _, err := validator.ValidateUpdate(ctx, md, md); (err != nil) != tc.errExpected {
+				t.Errorf("Expected update err=%v, got err=%v", tc.errExpected, err)
+			}
+		})
+	}
+}
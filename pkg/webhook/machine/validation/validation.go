@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/machine/spot"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	clusterv1alpha1 "k8c.io/machine-controller/pkg/apis/cluster/v1alpha1"
 
@@ -41,11 +42,14 @@ type validator struct {
 	userClient      ctrlruntimeclient.Client
 	caBundle        *certificates.CABundle
 	subjectSelector labels.Selector
+
+	// allowSpotInstances is false if the datacenter of the cluster does not allow spot instances.
+	allowSpotInstances bool
 }
 
 // NewValidator returns a new Machine validator.
 func NewValidator(seedClient, userClient ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *certificates.CABundle,
-	projectID string) (*validator, error) {
+	projectID string, allowSpotInstances bool) (*validator, error) {
 	subjectNameReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectNameLabelKey, selection.Equals, []string{projectID})
 	if err != nil {
 		return nil, fmt.Errorf("error creating resource quota subject name requirement: %w", err)
@@ -62,6 +66,8 @@ func NewValidator(seedClient, userClient ctrlruntimeclient.Client, log *zap.Suga
 		userClient:      userClient,
 		caBundle:        caBundle,
 		subjectSelector: subjectSelector,
+
+		allowSpotInstances: allowSpotInstances,
 	}, nil
 }
 
@@ -76,6 +82,10 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 	log := v.log.With("machine", machine.Name)
 	log.Debug("validating create")
 
+	if err := spot.ValidateProviderSpecAllowed(machine.Spec.ProviderSpec, v.allowSpotInstances); err != nil {
+		return nil, err
+	}
+
 	quota, err := getResourceQuota(ctx, v.seedClient, v.subjectSelector)
 	if err != nil {
 		return nil, err
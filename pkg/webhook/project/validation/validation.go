@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/validation"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validator for validating Kubermatic Project CRD.
+type validator struct{}
+
+// NewValidator returns a new project validator.
+func NewValidator() *validator {
+	return &validator{}
+}
+
+var _ admission.CustomValidator = &validator{}
+
+func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	project, ok := obj.(*kubermaticv1.Project)
+	if !ok {
+		return nil, errors.New("object is not a Project")
+	}
+
+	return nil, validateProject(project).ToAggregate()
+}
+
+func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	project, ok := newObj.(*kubermaticv1.Project)
+	if !ok {
+		return nil, errors.New("new object is not a Project")
+	}
+
+	return nil, validateProject(project).ToAggregate()
+}
+
+func (v *validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateProject(project *kubermaticv1.Project) field.ErrorList {
+	// clusters of a project can run on any provider, so the tags must be valid everywhere
+	return validation.ValidateResourceTags("", project.Spec.ResourceTags, field.NewPath("spec", "resourceTags"))
+}
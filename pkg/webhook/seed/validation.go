@@ -27,6 +27,7 @@ import (
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources/pricing"
 	"k8c.io/kubermatic/v2/pkg/validation"
 
 	corev1 "k8s.io/api/core/v1"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -165,6 +167,15 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object, isDelete b
 			}
 		}
 
+		if err := validatePricingCatalog(dc.Spec.Pricing); err != nil {
+			return fmt.Errorf("datacenter %q has an invalid pricing catalog: %w", dcName, err)
+		}
+
+		tagProvider, _ := kubermaticv1helper.DatacenterCloudProviderName(&dc.Spec)
+		if errs := validation.ValidateResourceTags(kubermaticv1.ProviderType(tagProvider), dc.Spec.ResourceTags, field.NewPath("spec", "datacenters").Key(dcName).Child("spec", "resourceTags")); len(errs) > 0 {
+			return fmt.Errorf("datacenter %q has invalid resource tags: %w", dcName, errs.ToAggregate())
+		}
+
 		if existingSeed == nil {
 			continue
 		}
@@ -192,6 +203,26 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object, isDelete b
 		return err
 	}
 
+	if err := validateControlPlaneGateway(subject); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateControlPlaneGateway(subject *kubermaticv1.Seed) error {
+	config := subject.Spec.ControlPlaneGateway
+	if config == nil {
+		if subject.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+			return fmt.Errorf("expose strategy %q requires a control plane Gateway to be configured", kubermaticv1.ExposeStrategyGateway)
+		}
+		return nil
+	}
+
+	if config.GatewayClassName == "" {
+		return errors.New("invalid control plane Gateway configuration: gatewayClassName must be set")
+	}
+
 	return nil
 }
 
@@ -271,6 +302,24 @@ func validateKubeVirtSupportedOS(datacenterSpec *kubermaticv1.DatacenterSpecKube
 	return nil
 }
 
+func validatePricingCatalog(catalog *kubermaticv1.PricingCatalog) error {
+	if catalog == nil {
+		return nil
+	}
+
+	if catalog.Currency == "" {
+		return errors.New("currency must be set")
+	}
+
+	for instanceType, price := range catalog.InstanceTypes {
+		if _, err := pricing.ParsePrice(price); err != nil {
+			return fmt.Errorf("instance type %q: %w", instanceType, err)
+		}
+	}
+
+	return nil
+}
+
 func validateTinkerbellSupportedOS(datacenterSpec *kubermaticv1.DatacenterSpecTinkerbell) error {
 	if datacenterSpec != nil && datacenterSpec.Images.HTTP != nil {
 		for os := range datacenterSpec.Images.HTTP.OperatingSystems {
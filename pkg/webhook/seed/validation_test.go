@@ -270,6 +270,70 @@ func TestValidate(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "Datacenters can have a pricing catalog",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "myseed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"a": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake: &kubermaticv1.DatacenterSpecFake{},
+								Pricing: &kubermaticv1.PricingCatalog{
+									Currency:      "EUR",
+									InstanceTypes: map[string]string{"small": "0.0416"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Pricing catalogs must have a currency",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "myseed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"a": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake: &kubermaticv1.DatacenterSpecFake{},
+								Pricing: &kubermaticv1.PricingCatalog{
+									InstanceTypes: map[string]string{"small": "0.0416"},
+								},
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Pricing catalogs must not contain negative prices",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "myseed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"a": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake: &kubermaticv1.DatacenterSpecFake{},
+								Pricing: &kubermaticv1.PricingCatalog{
+									Currency:      "EUR",
+									InstanceTypes: map[string]string{"small": "-1"},
+								},
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
 		{
 			name: "It should not be possible to change a datacenter's provider",
 			existingSeeds: []*kubermaticv1.Seed{
@@ -442,6 +506,32 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Adding a seed with Gateway ExposeStrategy should succeed",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+					ControlPlaneGateway: &kubermaticv1.ControlPlaneGatewayConfig{
+						GatewayClassName: "envoy",
+					},
+				},
+			},
+		},
+		{
+			name: "Adding a seed with Gateway ExposeStrategy but without Gateway configuration should fail",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+				},
+			},
+			errExpected: true,
+		},
 		{
 			name: "Adding a seed with invalid cron expression",
 			seedToValidate: &kubermaticv1.Seed{